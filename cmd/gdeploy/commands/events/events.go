@@ -0,0 +1,36 @@
+package events
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/cfaws"
+	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/urfave/cli/v2"
+)
+
+var Command = cli.Command{
+	Name:        "events",
+	Description: "Inspect and replay events which the Granted Approvals event handler failed to process.",
+	Usage:       "Inspect and replay failed events",
+	Subcommands: []*cli.Command{&ListCommand, &ReplayCommand},
+	Action:      cli.ShowSubcommandHelp,
+}
+
+// getDB returns a client for the deployment's DynamoDB table.
+func getDB(ctx context.Context) (*ddb.Client, error) {
+	dc, err := deploy.ConfigFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	o, err := dc.LoadOutput(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := cfaws.ConfigFromContextOrDefault(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ddb.New(ctx, o.DynamoDBTable, ddb.WithDynamoDBClient(dynamodb.NewFromConfig(cfg)))
+}
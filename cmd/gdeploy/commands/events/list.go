@@ -0,0 +1,45 @@
+package events
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/common-fate/granted-approvals/pkg/clio"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli/v2"
+)
+
+var ListCommand = cli.Command{
+	Name:        "list",
+	Description: "List events which the event handler failed to process",
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+
+		db, err := getDB(ctx)
+		if err != nil {
+			return err
+		}
+
+		q := storage.ListDeadLetters{}
+		_, err = db.Query(ctx, &q)
+		if err != nil {
+			return err
+		}
+		if len(q.Result) == 0 {
+			clio.Success("There are no failed events")
+			return nil
+		}
+
+		table := tablewriter.NewWriter(os.Stderr)
+		table.SetHeader([]string{"ID", "Type", "Attempts", "Last Failed", "Error"})
+		for _, e := range q.Result {
+			table.Append([]string{e.ID, e.DetailType, strconv.Itoa(e.Attempts), e.LastFailedAt.Format(time.RFC3339), e.Error})
+		}
+		table.Render()
+
+		clio.Info("Run 'gdeploy events replay --id <event ID>' to replay a failed event once the underlying issue has been resolved")
+		return nil
+	},
+}
@@ -0,0 +1,70 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/clio"
+	"github.com/common-fate/granted-approvals/pkg/eventhandler"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/urfave/cli/v2"
+)
+
+var ReplayCommand = cli.Command{
+	Name:        "replay",
+	Description: "Replay failed events through the event handler. Events which have already been applied are skipped.",
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{Name: "id", Usage: "The ID of the failed event to replay"},
+		&cli.BoolFlag{Name: "all", Usage: "Replay all failed events"},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+
+		ids := c.StringSlice("id")
+		if len(ids) == 0 && !c.Bool("all") {
+			return clio.NewCLIError("Provide the events to replay with --id, or use --all to replay all failed events.")
+		}
+
+		db, err := getDB(ctx)
+		if err != nil {
+			return err
+		}
+
+		if c.Bool("all") {
+			q := storage.ListDeadLetters{}
+			_, err = db.Query(ctx, &q)
+			if err != nil {
+				return err
+			}
+			ids = []string{}
+			for _, e := range q.Result {
+				ids = append(ids, e.ID)
+			}
+		}
+
+		eh, err := eventhandler.New(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		var failed int
+		for _, id := range ids {
+			err = eh.Replay(ctx, id)
+			if err == ddb.ErrNoItems {
+				clio.Warn("No failed event found with ID %s", id)
+				failed++
+				continue
+			}
+			if err != nil {
+				clio.Error("Failed to replay event %s: %s", id, err)
+				failed++
+				continue
+			}
+			clio.Success("Replayed event %s", id)
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d events could not be replayed", failed, len(ids))
+		}
+		return nil
+	},
+}
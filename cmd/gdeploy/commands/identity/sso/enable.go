@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/common-fate/granted-approvals/pkg/clio"
@@ -187,6 +188,11 @@ func updateOrAddSSO(c *cli.Context, idpType string) error {
 		return err
 	}
 
+	// users and groups are pushed by SCIM providers, so the groups can't be listed yet.
+	if p, ok := idp.IdentityProvider.(identitysync.PushIdentityProvider); ok && p.PushesChanges() {
+		return enablePushProvider(c, dc)
+	}
+
 	grps, err := idp.IdentityProvider.ListGroups(ctx)
 	if err != nil {
 		return err
@@ -231,3 +237,35 @@ func updateOrAddSSO(c *cli.Context, idpType string) error {
 	`)
 	return nil
 }
+
+// enablePushProvider finishes setting up an identity provider which provisions users and groups via SCIM.
+func enablePushProvider(c *cli.Context, dc deploy.Config) error {
+	err := survey.AskOne(&survey.Input{
+		Message: "The external ID of the Granted Administrators group in your identity provider:",
+		Default: dc.Deployment.Parameters.AdministratorGroupID,
+	}, &dc.Deployment.Parameters.AdministratorGroupID, survey.WithValidator(survey.Required))
+	if err != nil {
+		return err
+	}
+
+	clio.Info("Updating your deployment config")
+
+	f := c.Path("file")
+	err = dc.Save(f)
+	if err != nil {
+		return err
+	}
+	clio.Success("Successfully completed SSO configuration")
+
+	scimURL := "<your API URL>/scim/v2"
+	o, err := dc.LoadOutput(c.Context)
+	if err == nil && o.APIURL != "" {
+		scimURL = strings.TrimSuffix(o.APIURL, "/") + "/scim/v2"
+	}
+	clio.Warn(`Users and groups will be provisioned by your identity provider via SCIM. To finish enabling SSO, follow these steps:
+
+	  1) Run 'gdeploy update' to apply the changes to your CloudFormation deployment.
+	  2) Configure SCIM provisioning in your identity provider with the base URL %s and the bearer token you entered.
+	`, scimURL)
+	return nil
+}
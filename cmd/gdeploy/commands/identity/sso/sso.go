@@ -6,6 +6,6 @@ import (
 
 var SSOCommand = cli.Command{
 	Name:        "sso",
-	Subcommands: []*cli.Command{&enableCommand, &disableCommand, &updateCommand},
+	Subcommands: []*cli.Command{&enableCommand, &disableCommand, &updateCommand, &testCommand},
 	Action:      cli.ShowSubcommandHelp,
 }
@@ -0,0 +1,85 @@
+package sso
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/common-fate/granted-approvals/accesshandler/pkg/diagnostics"
+	"github.com/common-fate/granted-approvals/accesshandler/pkg/providers"
+	"github.com/common-fate/granted-approvals/pkg/clio"
+	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/common-fate/granted-approvals/pkg/gconfig"
+	"github.com/common-fate/granted-approvals/pkg/identity/identitysync"
+
+	"github.com/urfave/cli/v2"
+)
+
+var testCommand = cli.Command{
+	Name:        "test",
+	Description: "Test the SSO configuration for a deployment by connecting to the identity provider",
+	Usage:       "Test SSO configuration",
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+		dc, err := deploy.ConfigFromContext(ctx)
+		if err != nil {
+			return err
+		}
+		idpType := dc.Deployment.Parameters.IdentityProviderType
+		if idpType == "" || idpType == identitysync.IDPTypeCognito {
+			clio.Info("You are currently using cognito as your identity provider, there is no SSO configuration to test.")
+			return nil
+		}
+		idp, ok := identitysync.Registry().IdentityProviders[idpType]
+		if !ok {
+			return fmt.Errorf("no matching identity provider found for %s", idpType)
+		}
+
+		cfg := idp.IdentityProvider.Config()
+		err = cfg.Load(ctx, &gconfig.MapLoader{Values: dc.Deployment.Parameters.IdentityConfiguration[idpType]})
+		if err != nil {
+			return err
+		}
+
+		cv, ok := idp.IdentityProvider.(providers.ConfigValidator)
+		if !ok {
+			return deploy.RunConfigTest(ctx, idp.IdentityProvider)
+		}
+
+		err = idp.IdentityProvider.Init(ctx)
+		if err != nil {
+			return err
+		}
+
+		validations := cv.ValidateConfig()
+		keys := make([]string, 0, len(validations))
+		for k := range validations {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		failed := false
+		for _, k := range keys {
+			v := validations[k]
+			logs := v.Run(ctx)
+			if logs.HasSucceeded() {
+				clio.Success(v.Name)
+			} else {
+				failed = true
+				clio.Error(v.Name)
+			}
+			for _, l := range logs {
+				if l.Level == diagnostics.ErrorLevel {
+					clio.Error("  %s", l.Msg)
+				} else {
+					clio.Info("  %s", l.Msg)
+				}
+			}
+		}
+		if failed {
+			return errors.New("one or more configuration tests failed")
+		}
+		clio.Success("Configuration tests passed!")
+		return nil
+	},
+}
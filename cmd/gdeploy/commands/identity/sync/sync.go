@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
@@ -12,12 +13,18 @@ import (
 	"github.com/common-fate/granted-approvals/pkg/cfaws"
 	"github.com/common-fate/granted-approvals/pkg/clio"
 	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/common-fate/granted-approvals/pkg/identity"
 	"github.com/common-fate/granted-approvals/pkg/identity/identitysync"
+	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli/v2"
 )
 
 var SyncCommand = cli.Command{
 	Name: "sync",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{Name: "dry-run", Usage: "Show the changes which the sync would make without making them"},
+		&cli.BoolFlag{Name: "force", Usage: "Run the sync even if it would archive more than the archive threshold of active users"},
+	},
 	Action: func(c *cli.Context) error {
 		ctx := c.Context
 
@@ -43,11 +50,15 @@ var SyncCommand = cli.Command{
 		si.Writer = os.Stderr
 		si.Start()
 
+		payload, err := json.Marshal(identitysync.RunOpts{DryRun: c.Bool("dry-run"), Force: c.Bool("force")})
+		if err != nil {
+			return err
+		}
 		lambdaClient := lambda.NewFromConfig(cfg)
 		res, err := lambdaClient.Invoke(ctx, &lambda.InvokeInput{
 			FunctionName:   &o.IdpSyncFunctionName,
 			InvocationType: types.InvocationTypeRequestResponse,
-			Payload:        []byte("{}"),
+			Payload:        payload,
 		})
 		si.Stop()
 		if err != nil {
@@ -59,15 +70,106 @@ var SyncCommand = cli.Command{
 		}
 		clio.Debug("idp sync lamda invoke response: %s", string(b))
 		if res.FunctionError != nil {
+			// the payload contains the error returned by the sync, such as the archive threshold being exceeded.
+			var lambdaErr struct {
+				ErrorMessage string `json:"errorMessage"`
+			}
+			if json.Unmarshal(res.Payload, &lambdaErr) == nil && lambdaErr.ErrorMessage != "" {
+				return fmt.Errorf("user and group sync failed: %s", lambdaErr.ErrorMessage)
+			}
 			return fmt.Errorf("user and group sync failed with lambda execution error: %s", *res.FunctionError)
 		} else if res.StatusCode == 200 {
 			idp := dc.Deployment.Parameters.IdentityProviderType
 			if idp == "" {
 				idp = identitysync.IDPTypeCognito
 			}
-			clio.Success("Successfully synced users and groups using %s", idp)
+			var result identitysync.SyncResult
+			err = json.Unmarshal(res.Payload, &result)
+			if err != nil {
+				return err
+			}
+			printMappedGroups(result.MappedGroups)
+			printDiff(result.Diff)
+			if result.ArchiveThresholdExceeded {
+				clio.Warn("%d of %d active users are archived by this sync, which is more than the archive threshold", len(result.Diff.UsersArchived), result.Diff.ActiveUsers)
+			}
+			printOffboarded(result.Offboarded)
+			for _, f := range result.OffboardingFailures {
+				clio.Warn("%s was kept active because offboarding them failed, it will be retried by the next sync: %s", f.Email, f.Error)
+			}
+			if result.DryRun {
+				clio.Info("Dry run: no changes were made")
+			} else {
+				clio.Success("Successfully synced users and groups using %s", idp)
+			}
 		} else {
 			return fmt.Errorf("user and group sync failed with lambda invoke status code: %d", res.StatusCode)
 		}
 		return nil
 	}}
+
+// printMappedGroups prints the identity provider groups matched by each group mapping.
+func printMappedGroups(mapped []identitysync.MappedGroup) {
+	if len(mapped) == 0 {
+		return
+	}
+	clio.Info("Identity provider groups are mapped onto the following groups")
+	table := tablewriter.NewWriter(os.Stderr)
+	table.SetHeader([]string{"Group", "Identity Provider Groups"})
+	for _, m := range mapped {
+		table.Append([]string{m.ID, strings.Join(m.IdentityProviderGroups, ", ")})
+	}
+	table.Render()
+	for _, m := range mapped {
+		if len(m.IdentityProviderGroups) == 0 {
+			clio.Warn("The group mapping %s doesn't match any identity provider groups", m.ID)
+		}
+	}
+}
+
+// printDiff prints the changes made by the sync.
+func printDiff(d identitysync.Diff) {
+	if d.IsEmpty() {
+		clio.Info("No users or groups were changed")
+		return
+	}
+	table := tablewriter.NewWriter(os.Stderr)
+	table.SetHeader([]string{"Change", "User/Group", "Details"})
+	for _, u := range d.UsersAdded {
+		table.Append([]string{"user added", u, ""})
+	}
+	for _, u := range d.UsersArchived {
+		table.Append([]string{"user archived", u, ""})
+	}
+	for _, u := range d.UsersChanged {
+		var details []string
+		if len(u.GroupsAdded) > 0 {
+			details = append(details, "added to "+strings.Join(u.GroupsAdded, ", "))
+		}
+		if len(u.GroupsRemoved) > 0 {
+			details = append(details, "removed from "+strings.Join(u.GroupsRemoved, ", "))
+		}
+		table.Append([]string{"user groups changed", u.Email, strings.Join(details, "; ")})
+	}
+	for _, g := range d.GroupsAdded {
+		table.Append([]string{"group added", g, ""})
+	}
+	for _, g := range d.GroupsRemoved {
+		table.Append([]string{"group removed", g, ""})
+	}
+	table.Render()
+}
+
+// printOffboarded prints the users who were archived by the sync, along with the requests and grants affected.
+func printOffboarded(offboarded []identity.Offboarding) {
+	if len(offboarded) == 0 {
+		return
+	}
+	clio.Info("The following users were removed from the identity provider")
+	table := tablewriter.NewWriter(os.Stderr)
+	table.SetHeader([]string{"Email", "Cancelled Requests", "Revoked Grants", "Removed Reviews"})
+	for _, o := range offboarded {
+		table.Append([]string{o.Email, strings.Join(o.CancelledRequests, ", "), strings.Join(o.RevokedGrants, ", "), strings.Join(o.RemovedReviews, ", ")})
+	}
+	table.Render()
+}
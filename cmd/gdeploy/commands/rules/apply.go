@@ -0,0 +1,146 @@
+package rules
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/benbjohnson/clock"
+	"github.com/common-fate/granted-approvals/internal"
+	"github.com/common-fate/granted-approvals/pkg/clio"
+	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/common-fate/granted-approvals/pkg/gevent"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/rule/rulesync"
+	"github.com/common-fate/granted-approvals/pkg/service/cachesvc"
+	"github.com/common-fate/granted-approvals/pkg/service/rulesvc"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli/v2"
+)
+
+// actorID is recorded as the creator or updater of rules which are changed by applying a rules file.
+const actorID = "gdeploy"
+
+var ApplyCommand = cli.Command{
+	Name:        "apply",
+	Description: "Create, update and archive Access Rules so that they match a rules file. Active rules which aren't in the file are archived.",
+	Flags: []cli.Flag{
+		&cli.PathFlag{Name: "rules-file", Aliases: []string{"r"}, Usage: "The YAML or JSON rules file", Required: true},
+		&cli.BoolFlag{Name: "dry-run", Usage: "Show the changes which would be made without making them"},
+		&cli.BoolFlag{Name: "yes", Aliases: []string{"y"}, Usage: "Apply the changes without asking for confirmation"},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+
+		data, err := os.ReadFile(c.Path("rules-file"))
+		if err != nil {
+			return err
+		}
+		f, err := rulesync.Parse(data)
+		if err != nil {
+			return err
+		}
+
+		dc, err := deploy.ConfigFromContext(ctx)
+		if err != nil {
+			return err
+		}
+		o, err := dc.LoadOutput(ctx)
+		if err != nil {
+			return err
+		}
+		db, err := getDB(ctx, o)
+		if err != nil {
+			return err
+		}
+		current, err := listCurrentRules(ctx, db)
+		if err != nil {
+			return err
+		}
+		plan, err := rulesync.ComputePlan(f, current)
+		if err != nil {
+			return err
+		}
+		if plan.IsEmpty() {
+			clio.Success("Access Rules are up to date")
+			return nil
+		}
+		printPlan(plan)
+		if c.Bool("dry-run") {
+			clio.Info("Dry run: no changes were made")
+			return nil
+		}
+		if !c.Bool("yes") {
+			confirm := false
+			err = survey.AskOne(&survey.Confirm{Message: "Apply these changes?"}, &confirm)
+			if err != nil {
+				return err
+			}
+			if !confirm {
+				return nil
+			}
+		}
+
+		if o.AccessHandlerAPIURL == "" {
+			return clio.NewCLIError("The Access Handler URL is not yet available. You may need to update your deployment to use this feature.")
+		}
+		ahc, err := internal.NewAccessHandlerClient(ctx, o.AccessHandlerAPIURL, o.Region)
+		if err != nil {
+			return err
+		}
+		writer, err := getWriter(ctx, o)
+		if err != nil {
+			return err
+		}
+		eventBus, err := gevent.NewSender(ctx, gevent.SenderOpts{EventBusARN: o.EventBusArn})
+		if err != nil {
+			return err
+		}
+		rs := &rulesvc.Service{
+			Clock:       clock.New(),
+			DB:          db,
+			AHClient:    ahc,
+			Cache:       &cachesvc.Service{DB: db, AccessHandlerClient: ahc},
+			Writer:      writer,
+			EventPutter: eventBus,
+		}
+		created, applyErr := plan.Apply(ctx, rs, &identity.User{ID: actorID})
+		// the IDs are written even if a later change failed, so that the rules which were created aren't created again.
+		if len(created) > 0 {
+			var indexes []int
+			for i := range created {
+				indexes = append(indexes, i)
+			}
+			sort.Ints(indexes)
+			for _, i := range indexes {
+				clio.Info("Created rule %s with ID %s", f.Rules[i].Name, created[i])
+			}
+			out, err := rulesync.SetIDs(data, created)
+			if err != nil {
+				return err
+			}
+			err = os.WriteFile(c.Path("rules-file"), out, 0644)
+			if err != nil {
+				return err
+			}
+			clio.Info("Wrote the IDs of the created rules to %s, commit the file so that they aren't created again", c.Path("rules-file"))
+		}
+		if applyErr != nil {
+			return applyErr
+		}
+		clio.Success("Applied %d changes to Access Rules", len(plan.Changes))
+		return nil
+	},
+}
+
+// printPlan prints the changes in the plan to stdout, so that the plan can be saved and reviewed.
+func printPlan(p rulesync.Plan) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"Change", "ID", "Name", "Fields"})
+	for _, c := range p.Changes {
+		table.Append([]string{string(c.Type), c.RuleID, c.Name, strings.Join(c.Fields, ", ")})
+	}
+	table.Render()
+}
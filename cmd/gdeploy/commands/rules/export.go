@@ -0,0 +1,55 @@
+package rules
+
+import (
+	"os"
+
+	"github.com/common-fate/granted-approvals/pkg/clio"
+	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/common-fate/granted-approvals/pkg/rule/rulesync"
+	"github.com/urfave/cli/v2"
+)
+
+var ExportCommand = cli.Command{
+	Name:        "export",
+	Description: "Export the active Access Rules as YAML",
+	Flags: []cli.Flag{
+		&cli.PathFlag{Name: "output", Aliases: []string{"o"}, Usage: "The file to write the rules to. The rules are written to stdout if this isn't set"},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+
+		dc, err := deploy.ConfigFromContext(ctx)
+		if err != nil {
+			return err
+		}
+		o, err := dc.LoadOutput(ctx)
+		if err != nil {
+			return err
+		}
+		db, err := getDB(ctx, o)
+		if err != nil {
+			return err
+		}
+		rules, err := listCurrentRules(ctx, db)
+		if err != nil {
+			return err
+		}
+		f := rulesync.Export(rules)
+		b, err := f.Marshal()
+		if err != nil {
+			return err
+		}
+
+		out := c.Path("output")
+		if out == "" {
+			_, err = os.Stdout.Write(b)
+			return err
+		}
+		err = os.WriteFile(out, b, 0644)
+		if err != nil {
+			return err
+		}
+		clio.Success("Exported %d Access Rules to %s", len(f.Rules), out)
+		return nil
+	},
+}
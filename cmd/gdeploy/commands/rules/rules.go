@@ -0,0 +1,50 @@
+package rules
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/cfaws"
+	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/storage/dbcond"
+	"github.com/urfave/cli/v2"
+)
+
+var Command = cli.Command{
+	Name:        "rules",
+	Description: "Manage Access Rules as code. Export the current rules to a YAML file, review changes to the file in git, then apply them to your deployment.",
+	Usage:       "Manage Access Rules as code",
+	Subcommands: []*cli.Command{&ExportCommand, &ApplyCommand, &SimulateCommand},
+	Action:      cli.ShowSubcommandHelp,
+}
+
+// getDB returns a client for the deployment's DynamoDB table.
+func getDB(ctx context.Context, o deploy.Output) (*ddb.Client, error) {
+	cfg, err := cfaws.ConfigFromContextOrDefault(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ddb.New(ctx, o.DynamoDBTable, ddb.WithDynamoDBClient(dynamodb.NewFromConfig(cfg)))
+}
+
+// getWriter returns a client for conditional writes to the deployment's DynamoDB table.
+func getWriter(ctx context.Context, o deploy.Output) (*dbcond.Client, error) {
+	cfg, err := cfaws.ConfigFromContextOrDefault(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &dbcond.Client{DB: dynamodb.NewFromConfig(cfg), Table: o.DynamoDBTable}, nil
+}
+
+// listCurrentRules returns the current version of every access rule.
+func listCurrentRules(ctx context.Context, db ddb.Storage) ([]rule.AccessRule, error) {
+	q := storage.ListCurrentAccessRules{}
+	_, err := db.Query(ctx, &q)
+	if err != nil && err != ddb.ErrNoItems {
+		return nil, err
+	}
+	return q.Result, nil
+}
@@ -0,0 +1,147 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/clio"
+	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/service/rulesvc"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli/v2"
+)
+
+var SimulateCommand = cli.Command{
+	Name:        "simulate",
+	Description: "Show who can request and approve the active Access Rules, based on the users and groups synced from the identity provider. Pass --user to show what a user can request.",
+	Usage:       "Show who can request and approve access",
+	Flags: []cli.Flag{
+		&cli.StringFlag{Name: "provider", Usage: "Only include rules for this provider ID"},
+		&cli.StringSliceFlag{Name: "with", Usage: "Only include rules which grant this argument value, such as --with accountId=123456789012 --with permissionSetArn=AdministratorAccess. The value may be an option's label"},
+		&cli.StringFlag{Name: "user", Usage: "Only include rules which this user can request. Takes a user ID or email address"},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+
+		with := map[string]string{}
+		for _, w := range c.StringSlice("with") {
+			arg, value, ok := strings.Cut(w, "=")
+			if !ok || arg == "" || value == "" {
+				return fmt.Errorf("invalid --with %q, it must be in the format argId=value", w)
+			}
+			with[arg] = value
+		}
+
+		dc, err := deploy.ConfigFromContext(ctx)
+		if err != nil {
+			return err
+		}
+		o, err := dc.LoadOutput(ctx)
+		if err != nil {
+			return err
+		}
+		db, err := getDB(ctx, o)
+		if err != nil {
+			return err
+		}
+
+		opts := rulesvc.SimulateOpts{ProviderID: c.String("provider"), With: with}
+		if user := c.String("user"); user != "" {
+			opts.UserID, err = userID(ctx, db, user)
+			if err != nil {
+				return err
+			}
+		}
+
+		sims, err := rulesvc.Simulate(ctx, db, opts)
+		if err != nil {
+			return err
+		}
+		if len(sims) == 0 {
+			clio.Info("No active Access Rules match")
+			return nil
+		}
+		emails, err := userEmails(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		table := tablewriter.NewWriter(os.Stderr)
+		table.SetAutoWrapText(false)
+		if opts.UserID != "" {
+			table.SetHeader([]string{"Rule", "Target", "Approval", "Approvers"})
+			for _, s := range sims {
+				table.Append([]string{s.Rule.Name, target(s.Rule.Target), string(s.Approval), lookup(emails, s.Approvers)})
+			}
+		} else {
+			table.SetHeader([]string{"Rule", "Target", "Approval", "Requesters", "Approvers"})
+			for _, s := range sims {
+				table.Append([]string{s.Rule.Name, target(s.Rule.Target), string(s.Approval), lookup(emails, s.RequestableUsers), lookup(emails, s.Approvers)})
+			}
+		}
+		table.Render()
+		return nil
+	},
+}
+
+// userID returns the ID of the user, looking the user up by their email address if it contains an @.
+func userID(ctx context.Context, db ddb.Storage, user string) (string, error) {
+	if !strings.Contains(user, "@") {
+		return user, nil
+	}
+	q := storage.GetUserByEmail{Email: user}
+	_, err := db.Query(ctx, &q)
+	if err == ddb.ErrNoItems {
+		return "", fmt.Errorf("user %s does not exist", user)
+	}
+	if err != nil {
+		return "", err
+	}
+	return q.Result.ID, nil
+}
+
+// userEmails returns the email addresses of every user, keyed by the user ID.
+func userEmails(ctx context.Context, db ddb.Storage) (map[string]string, error) {
+	q := storage.ListUsers{}
+	_, err := db.Query(ctx, &q)
+	if err != nil && err != ddb.ErrNoItems {
+		return nil, err
+	}
+	res := make(map[string]string)
+	for _, u := range q.Result {
+		res[u.ID] = u.Email
+	}
+	return res, nil
+}
+
+// lookup returns the email addresses of the users on separate lines, falling back to the ID for users which don't exist.
+func lookup(emails map[string]string, ids []string) string {
+	var res []string
+	for _, id := range ids {
+		if e, ok := emails[id]; ok {
+			res = append(res, e)
+		} else {
+			res = append(res, id)
+		}
+	}
+	return strings.Join(res, "\n")
+}
+
+// target formats the provider and arguments of the target, such as "aws-sso accountId=123456789012".
+func target(t rule.Target) string {
+	var args []string
+	for arg, v := range t.With {
+		args = append(args, arg+"="+v)
+	}
+	for arg, v := range t.WithSelectable {
+		args = append(args, arg+"="+strings.Join(v, ","))
+	}
+	sort.Strings(args)
+	return strings.Join(append([]string{t.ProviderID}, args...), "\n")
+}
@@ -0,0 +1,68 @@
+package tickets
+
+import (
+	"github.com/common-fate/granted-approvals/pkg/clio"
+	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/common-fate/granted-approvals/pkg/gconfig"
+	"github.com/common-fate/granted-approvals/pkg/tickets"
+	"github.com/urfave/cli/v2"
+)
+
+var configureCommand = cli.Command{
+	Name:        "configure",
+	Description: "configure and enable a ticket system",
+	Flags: []cli.Flag{
+		&cli.StringFlag{Name: "system", Usage: "the ticket system to configure (jira or servicenow)", Required: true},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+		f := c.Path("file")
+		system := c.String("system")
+
+		dc, err := deploy.ConfigFromContext(ctx)
+		if err != nil {
+			return err
+		}
+
+		r, err := tickets.Registry().Lookup(system)
+		if err != nil {
+			return err
+		}
+		tv := r.TicketValidator
+		cfg := tv.Config()
+		currentConfig := dc.Deployment.Parameters.TicketConfiguration[system]
+		if currentConfig != nil {
+			err = cfg.Load(ctx, &gconfig.MapLoader{Values: currentConfig})
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, v := range cfg {
+			err := deploy.CLIPrompt(v)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = deploy.RunConfigTest(ctx, tv)
+		if err != nil {
+			return err
+		}
+
+		// if tests pass, dump the config and update in the deployment config
+		newConfig, err := cfg.Dump(ctx, gconfig.SSMDumper{Suffix: dc.Deployment.Parameters.DeploymentSuffix})
+		if err != nil {
+			return err
+		}
+		dc.Deployment.Parameters.TicketConfiguration.Upsert(system, newConfig)
+		err = dc.Save(f)
+		if err != nil {
+			return err
+		}
+
+		clio.Success("Successfully configured %s", r.Description)
+		clio.Warn("Your changes won't be applied until you redeploy. Run 'gdeploy update' to apply the changes to your CloudFormation deployment.")
+		return nil
+	},
+}
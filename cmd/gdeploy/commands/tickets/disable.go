@@ -0,0 +1,40 @@
+package tickets
+
+import (
+	"github.com/common-fate/granted-approvals/pkg/clio"
+	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/common-fate/granted-approvals/pkg/tickets"
+	"github.com/urfave/cli/v2"
+)
+
+var disableCommand = cli.Command{
+	Name:        "disable",
+	Description: "disable a ticket system",
+	Flags: []cli.Flag{
+		&cli.StringFlag{Name: "system", Usage: "the ticket system to disable (jira or servicenow)", Required: true},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+		f := c.Path("file")
+		system := c.String("system")
+
+		dc, err := deploy.ConfigFromContext(ctx)
+		if err != nil {
+			return err
+		}
+		r, err := tickets.Registry().Lookup(system)
+		if err != nil {
+			return err
+		}
+
+		dc.Deployment.Parameters.TicketConfiguration.Remove(system)
+		err = dc.Save(f)
+		if err != nil {
+			return err
+		}
+		clio.Success("Successfully disabled %s", r.Description)
+		clio.Warn("Access rules which require a %s ticket will reject new requests until it is configured again.", r.Description)
+		clio.Warn("Your changes won't be applied until you redeploy. Run 'gdeploy update' to apply the changes to your CloudFormation deployment.")
+		return nil
+	},
+}
@@ -0,0 +1,14 @@
+package tickets
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+var Command = cli.Command{
+	Name:        "tickets",
+	Aliases:     []string{"ticket"},
+	Description: "Manage the ticket systems like Jira and ServiceNow which access rules can require requests to reference",
+	Usage:       "Manage the ticket systems like Jira and ServiceNow which access rules can require requests to reference",
+	Action:      cli.ShowSubcommandHelp,
+	Subcommands: []*cli.Command{&configureCommand, &disableCommand},
+}
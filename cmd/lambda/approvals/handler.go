@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-lambda-go/lambdacontext"
 	"github.com/awslabs/aws-lambda-go-api-proxy/handlerfunc"
 	"github.com/common-fate/apikit/logger"
+	"github.com/common-fate/ddb"
 	"github.com/common-fate/granted-approvals/accesshandler/pkg/psetup"
 	"github.com/common-fate/granted-approvals/internal"
 	"github.com/common-fate/granted-approvals/pkg/api"
@@ -18,6 +19,7 @@ import (
 	"github.com/common-fate/granted-approvals/pkg/gevent"
 	"github.com/common-fate/granted-approvals/pkg/identity/identitysync"
 	"github.com/common-fate/granted-approvals/pkg/server"
+	"github.com/common-fate/granted-approvals/pkg/tickets"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/sethvargo/go-envconfig"
 	"go.uber.org/zap"
@@ -74,15 +76,43 @@ func buildHandler() (*Lambda, error) {
 		panic(err)
 	}
 
+	db, err := ddb.New(ctx, cfg.DynamoTable)
+	if err != nil {
+		return nil, err
+	}
+	var offboarder identitysync.Offboarder
+	if ahc != nil {
+		offboarder = internal.BuildOffboarder(db, ahc, eventBus)
+	}
+
+	gm, err := deploy.UnmarshalIdentityGroupMappings(cfg.IdentityGroupMappings)
+	if err != nil {
+		return nil, err
+	}
 	idsync, err := identitysync.NewIdentitySyncer(ctx, identitysync.SyncOpts{
-		TableName:      cfg.DynamoTable,
-		UserPoolId:     cfg.CognitoUserPoolID,
-		IdpType:        cfg.IdpProvider,
-		IdentityConfig: ic,
+		TableName:        cfg.DynamoTable,
+		UserPoolId:       cfg.CognitoUserPoolID,
+		IdpType:          cfg.IdpProvider,
+		IdentityConfig:   ic,
+		Offboarder:       offboarder,
+		ArchiveThreshold: cfg.IdentitySyncArchiveThreshold,
+		GroupMappings:    gm,
 	})
 	if err != nil {
 		return nil, err
 	}
+	adminRoles, err := deploy.UnmarshalAdminRoles(cfg.AdminRoles)
+	if err != nil {
+		return nil, err
+	}
+	tc, err := deploy.UnmarshalFeatureMap(cfg.TicketSettings)
+	if err != nil {
+		return nil, err
+	}
+	tv, err := tickets.Load(ctx, tc)
+	if err != nil {
+		return nil, err
+	}
 	api, err := api.New(ctx, api.Opts{
 		Log:                 log,
 		DynamoTable:         cfg.DynamoTable,
@@ -90,6 +120,7 @@ func buildHandler() (*Lambda, error) {
 		AccessHandlerClient: ahc,
 		EventSender:         eventBus,
 		AdminGroup:          cfg.AdminGroup,
+		AdminRoles:          adminRoles,
 		TemplateData:        td,
 		DeploymentSuffix:    cfg.DeploymentSuffix,
 		IdentitySyncer:      idsync,
@@ -97,6 +128,7 @@ func buildHandler() (*Lambda, error) {
 		IDPType:             cfg.IdpProvider,
 		AdminGroupID:        cfg.AdminGroup,
 		DeploymentConfig:    dc,
+		Tickets:             tv,
 	})
 	if err != nil {
 		return nil, err
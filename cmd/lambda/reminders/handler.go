@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/benbjohnson/clock"
+	"github.com/common-fate/apikit/logger"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/config"
+	"github.com/common-fate/granted-approvals/pkg/gevent"
+	"github.com/common-fate/granted-approvals/pkg/service/remindersvc"
+	"github.com/common-fate/granted-approvals/pkg/storage/dbcond"
+	"github.com/joho/godotenv"
+	"github.com/sethvargo/go-envconfig"
+	"go.uber.org/zap"
+)
+
+func main() {
+	var cfg config.RemindersConfig
+	ctx := context.Background()
+	_ = godotenv.Load()
+
+	err := envconfig.Process(ctx, &cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	log, err := logger.Build(cfg.LogLevel)
+	if err != nil {
+		panic(err)
+	}
+	zap.ReplaceGlobals(log.Desugar())
+
+	db, err := ddb.New(ctx, cfg.DynamoTable)
+	if err != nil {
+		panic(err)
+	}
+	writer, err := dbcond.New(ctx, cfg.DynamoTable)
+	if err != nil {
+		panic(err)
+	}
+	eventBus, err := gevent.NewSender(ctx, gevent.SenderOpts{
+		EventBusARN: cfg.EventBusArn,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	s := remindersvc.Service{
+		Clock:              clock.New(),
+		DB:                 db,
+		EventPutter:        eventBus,
+		Writer:             writer,
+		ReminderInterval:   cfg.ReminderInterval,
+		EscalationInterval: cfg.EscalationInterval,
+		GrantExpiryWarning: cfg.GrantExpiryWarning,
+	}
+
+	zap.S().Infow("starting reminders", "reminderInterval", cfg.ReminderInterval, "escalationInterval", cfg.EscalationInterval, "grantExpiryWarning", cfg.GrantExpiryWarning)
+	lambda.Start(func(ctx context.Context) error {
+		err := s.SendReminders(ctx)
+		if err != nil {
+			return err
+		}
+		return s.SendGrantExpiryNotifications(ctx)
+	})
+}
@@ -5,8 +5,11 @@ import (
 
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/common-fate/apikit/logger"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/internal"
 	"github.com/common-fate/granted-approvals/pkg/config"
 	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/common-fate/granted-approvals/pkg/gevent"
 	"github.com/common-fate/granted-approvals/pkg/identity/identitysync"
 	"github.com/joho/godotenv"
 	"github.com/sethvargo/go-envconfig"
@@ -28,12 +31,39 @@ func main() {
 		panic(err)
 	}
 
+	var offboarder identitysync.Offboarder
+	if cfg.AccessHandlerURL != "" {
+		db, err := ddb.New(ctx, cfg.TableName)
+		if err != nil {
+			panic(err)
+		}
+		ahc, err := internal.NewAccessHandlerClient(ctx, cfg.AccessHandlerURL, cfg.Region)
+		if err != nil {
+			panic(err)
+		}
+		eventBus, err := gevent.NewSender(ctx, gevent.SenderOpts{
+			EventBusARN: cfg.EventBusArn,
+		})
+		if err != nil {
+			panic(err)
+		}
+		offboarder = internal.BuildOffboarder(db, ahc, eventBus)
+	}
+
+	gm, err := deploy.UnmarshalIdentityGroupMappings(cfg.IdentityGroupMappings)
+	if err != nil {
+		panic(err)
+	}
+
 	//set up the sync handler
 	syncer, err := identitysync.NewIdentitySyncer(ctx, identitysync.SyncOpts{
-		TableName:      cfg.TableName,
-		IdpType:        cfg.IdpProvider,
-		UserPoolId:     cfg.UserPoolId,
-		IdentityConfig: ic,
+		TableName:        cfg.TableName,
+		IdpType:          cfg.IdpProvider,
+		UserPoolId:       cfg.UserPoolId,
+		IdentityConfig:   ic,
+		Offboarder:       offboarder,
+		ArchiveThreshold: cfg.ArchiveThreshold,
+		GroupMappings:    gm,
 	})
 	if err != nil {
 		panic(err)
@@ -44,5 +74,6 @@ func main() {
 	}
 	zap.ReplaceGlobals(log.Desugar())
 	zap.S().Infow("starting sync", "config", ic, "idp.type", cfg.IdpProvider)
-	lambda.Start(syncer.Sync)
+	// the sync is invoked on a schedule, or by 'gdeploy identity sync' with a RunOpts payload.
+	lambda.Start(syncer.SyncWithOpts)
 }
@@ -8,10 +8,17 @@ import (
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/awslabs/aws-lambda-go-api-proxy/handlerfunc"
+	"github.com/benbjohnson/clock"
 	"github.com/common-fate/apikit/apio"
 	"github.com/common-fate/apikit/logger"
 	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/internal"
 	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/common-fate/granted-approvals/pkg/gconfig"
+	"github.com/common-fate/granted-approvals/pkg/gevent"
+	"github.com/common-fate/granted-approvals/pkg/identity/identitysync"
+	"github.com/common-fate/granted-approvals/pkg/scim"
 	"github.com/common-fate/granted-approvals/pkg/storage"
 	"github.com/go-chi/chi/v5"
 	"github.com/pkg/errors"
@@ -55,10 +62,29 @@ func buildHandler() (*Lambda, error) {
 type Config struct {
 	LogLevel    string `env:"LOG_LEVEL,default=info"`
 	DynamoTable string `env:"APPROVALS_TABLE_NAME,required"`
+	IdpProvider string `env:"IDENTITY_PROVIDER"`
+	UserPoolId  string `env:"APPROVALS_COGNITO_USER_POOL_ID"`
+	// This should be an instance of deploy.FeatureMap which is a specific json format for this
+	// Use deploy.UnmarshalFeatureMap to unmarshal this data into a FeatureMap
+	IdentitySettings string `env:"IDENTITY_SETTINGS,default={}"`
+	// AccessHandlerURL and EventBusArn are used to offboard users who are removed from the identity provider.
+	// Offboarding is disabled if AccessHandlerURL is empty.
+	AccessHandlerURL string `env:"ACCESS_HANDLER_URL"`
+	EventBusArn      string `env:"EVENT_BUS_ARN"`
+	Region           string `env:"AWS_REGION"`
+	// ArchiveThreshold is the percentage of active users which a full sync may archive before it is aborted.
+	ArchiveThreshold int `env:"IDENTITY_SYNC_ARCHIVE_THRESHOLD"`
+	// This should be an instance of deploy.IdentityGroupMappings
+	// Use deploy.UnmarshalIdentityGroupMappings to unmarshal this data
+	IdentityGroupMappings string `env:"IDENTITY_GROUP_MAPPINGS,default=[]"`
 }
 
 type Server struct {
 	db *ddb.Client
+	// syncer is nil if the deployment uses Cognito or SCIM, which don't send webhooks.
+	syncer *identitysync.IdentitySyncer
+	// scim is set if users and groups are provisioned via SCIM.
+	scim *scim.Server
 }
 
 func NewServer(ctx context.Context, cfg Config) (*Server, error) {
@@ -69,6 +95,58 @@ func NewServer(ctx context.Context, cfg Config) (*Server, error) {
 	s := Server{
 		db: db,
 	}
+
+	var offboarder identitysync.Offboarder
+	if cfg.AccessHandlerURL != "" {
+		ahc, err := internal.NewAccessHandlerClient(ctx, cfg.AccessHandlerURL, cfg.Region)
+		if err != nil {
+			return nil, err
+		}
+		eventBus, err := gevent.NewSender(ctx, gevent.SenderOpts{
+			EventBusARN: cfg.EventBusArn,
+		})
+		if err != nil {
+			return nil, err
+		}
+		offboarder = internal.BuildOffboarder(db, ahc, eventBus)
+	}
+
+	if cfg.IdpProvider == identitysync.IDPTypeSCIM {
+		ic, err := deploy.UnmarshalFeatureMap(cfg.IdentitySettings)
+		if err != nil {
+			return nil, err
+		}
+		var sc identitysync.SCIMSync
+		err = sc.Config().Load(ctx, &gconfig.MapLoader{Values: ic[identitysync.IDPTypeSCIM]})
+		if err != nil {
+			return nil, err
+		}
+		s.scim = &scim.Server{DB: db, Clock: clock.New(), Token: sc.BearerToken()}
+		if offboarder != nil {
+			s.scim.Offboarder = offboarder
+		}
+	} else if cfg.IdpProvider != "" && cfg.IdpProvider != identitysync.IDPTypeCognito {
+		ic, err := deploy.UnmarshalFeatureMap(cfg.IdentitySettings)
+		if err != nil {
+			return nil, err
+		}
+		gm, err := deploy.UnmarshalIdentityGroupMappings(cfg.IdentityGroupMappings)
+		if err != nil {
+			return nil, err
+		}
+		s.syncer, err = identitysync.NewIdentitySyncer(ctx, identitysync.SyncOpts{
+			TableName:        cfg.DynamoTable,
+			IdpType:          cfg.IdpProvider,
+			UserPoolId:       cfg.UserPoolId,
+			IdentityConfig:   ic,
+			Offboarder:       offboarder,
+			ArchiveThreshold: cfg.ArchiveThreshold,
+			GroupMappings:    gm,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &s, nil
 }
 
@@ -112,6 +190,21 @@ func (s *Server) Routes() http.Handler {
 		w.WriteHeader(http.StatusOK)
 	})
 
+	// receives change notifications from the identity provider, such as Okta Event Hooks,
+	// so that users and groups are synced as soon as they change.
+	r.HandleFunc("/webhook/v1/identity", func(w http.ResponseWriter, r *http.Request) {
+		if s.syncer == nil {
+			apio.ErrorString(r.Context(), w, "webhooks are not supported by this identity provider", http.StatusNotFound)
+			return
+		}
+		s.syncer.HandleWebhook(w, r)
+	})
+
+	// users and groups are provisioned by the identity provider via SCIM.
+	if s.scim != nil {
+		r.Mount("/scim/v2", s.scim.Handler())
+	}
+
 	r.Post("/webhook/v1/health", func(w http.ResponseWriter, r *http.Request) {
 		//successful connection to webhook url return OK
 		w.WriteHeader(http.StatusOK)
@@ -8,6 +8,7 @@ import (
 	"github.com/common-fate/granted-approvals/accesshandler/pkg/psetup"
 
 	"github.com/common-fate/apikit/logger"
+	"github.com/common-fate/ddb"
 	ahServer "github.com/common-fate/granted-approvals/accesshandler/pkg/server"
 	"github.com/common-fate/granted-approvals/internal"
 	"github.com/common-fate/granted-approvals/pkg/api"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/common-fate/granted-approvals/pkg/config"
 	"github.com/common-fate/granted-approvals/pkg/server"
+	"github.com/common-fate/granted-approvals/pkg/tickets"
 	"github.com/getsentry/sentry-go"
 	"github.com/joho/godotenv"
 	"github.com/sethvargo/go-envconfig"
@@ -94,17 +96,45 @@ func run() error {
 		panic(err)
 	}
 
+	db, err := ddb.New(ctx, cfg.DynamoTable)
+	if err != nil {
+		return err
+	}
+	var offboarder identitysync.Offboarder
+	if ahc != nil {
+		offboarder = internal.BuildOffboarder(db, ahc, eventBus)
+	}
+
+	gm, err := deploy.UnmarshalIdentityGroupMappings(cfg.IdentityGroupMappings)
+	if err != nil {
+		return err
+	}
 	idsync, err := identitysync.NewIdentitySyncer(ctx, identitysync.SyncOpts{
-		TableName:      cfg.DynamoTable,
-		UserPoolId:     cfg.CognitoUserPoolID,
-		IdpType:        cfg.IdpProvider,
-		IdentityConfig: ic,
+		TableName:        cfg.DynamoTable,
+		UserPoolId:       cfg.CognitoUserPoolID,
+		IdpType:          cfg.IdpProvider,
+		IdentityConfig:   ic,
+		Offboarder:       offboarder,
+		ArchiveThreshold: cfg.IdentitySyncArchiveThreshold,
+		GroupMappings:    gm,
 	})
 
 	if err != nil {
 		return err
 	}
 
+	adminRoles, err := deploy.UnmarshalAdminRoles(cfg.AdminRoles)
+	if err != nil {
+		return err
+	}
+	tc, err := deploy.UnmarshalFeatureMap(cfg.TicketSettings)
+	if err != nil {
+		return err
+	}
+	tv, err := tickets.Load(ctx, tc)
+	if err != nil {
+		return err
+	}
 	api, err := api.New(ctx, api.Opts{
 		Log:                 log,
 		DynamoTable:         cfg.DynamoTable,
@@ -112,12 +142,14 @@ func run() error {
 		AccessHandlerClient: ahc,
 		EventSender:         eventBus,
 		AdminGroup:          cfg.AdminGroup,
+		AdminRoles:          adminRoles,
 		DeploymentSuffix:    cfg.DeploymentSuffix,
 		IdentitySyncer:      idsync,
 		CognitoUserPoolID:   cfg.CognitoUserPoolID,
 		IDPType:             cfg.IdpProvider,
 		AdminGroupID:        cfg.AdminGroup,
 		DeploymentConfig:    dc,
+		Tickets:             tv,
 		TemplateData:        td,
 	})
 	if err != nil {
@@ -16,8 +16,14 @@ const idpType = app.node.tryGetContext("idpType");
 const samlMetadataUrl = app.node.tryGetContext("samlMetadataUrl");
 const samlMetadata = app.node.tryGetContext("samlMetadata");
 const adminGroupId = app.node.tryGetContext("adminGroupId");
+const adminRoles = app.node.tryGetContext("adminRoles");
+const ticketConfiguration = app.node.tryGetContext("ticketConfiguration");
 const providerConfig = app.node.tryGetContext("providerConfiguration");
 const identityConfig = app.node.tryGetContext("identityConfiguration");
+const identityGroupMappings = app.node.tryGetContext("identityGroupMappings");
+const identitySyncArchiveThreshold = app.node.tryGetContext(
+  "identitySyncArchiveThreshold"
+);
 const notificationsConfiguration = app.node.tryGetContext(
   "notificationsConfiguration"
 );
@@ -59,9 +65,13 @@ if (stackTarget === "dev") {
     samlMetadataUrl: samlMetadataUrl || "",
     devConfig,
     adminGroupId: adminGroupId || "granted_administrators",
+    adminRoles: adminRoles || "[]",
     samlMetadata: samlMetadata || "",
     notificationsConfiguration: notificationsConfiguration || "{}",
+    ticketConfiguration: ticketConfiguration || "{}",
     identityProviderSyncConfiguration: identityConfig || "{}",
+    identityGroupMappings: identityGroupMappings || "[]",
+    identitySyncArchiveThreshold: identitySyncArchiveThreshold || "0",
   });
 } else if (stackTarget === "prod") {
   new CustomerGrantedStack(app, "Granted", {
@@ -13,6 +13,7 @@ import { WebUserPool } from "./app-user-pool";
 import { EventHandler } from "./event-handler";
 import { IdpSync } from "./idp-sync";
 import { Notifiers } from "./notifiers";
+import { Reminders } from "./reminders";
 import { AccessHandler } from "./access-handler";
 
 interface Props {
@@ -23,9 +24,13 @@ interface Props {
   eventBusSourceName: string;
   eventBus: EventBus;
   adminGroupId: string;
+  adminRoles: string;
   providerConfig: string;
   notificationsConfiguration: string;
+  ticketConfiguration: string;
   identityProviderSyncConfiguration: string;
+  identityGroupMappings: string;
+  identitySyncArchiveThreshold: string;
   deploymentSuffix: string;
   dynamoTable: dynamodb.Table;
 }
@@ -38,6 +43,7 @@ export class AppBackend extends Construct {
   private _notifiers: Notifiers;
   private _eventHandler: EventHandler;
   private _idpSync: IdpSync;
+  private _reminders: Reminders;
   private _KMSkey: cdk.aws_kms.Key;
   private _webhook: apigateway.Resource;
   private _webhookLambda: lambda.Function;
@@ -66,11 +72,39 @@ export class AppBackend extends Construct {
       handler: "webhook",
       environment: {
         APPROVALS_TABLE_NAME: this._dynamoTable.tableName,
+        APPROVALS_COGNITO_USER_POOL_ID: props.userPool.getUserPoolId(),
+        IDENTITY_PROVIDER: props.userPool.getIdpType(),
+        IDENTITY_SETTINGS: props.identityProviderSyncConfiguration,
+        IDENTITY_GROUP_MAPPINGS: props.identityGroupMappings,
+        IDENTITY_SYNC_ARCHIVE_THRESHOLD: props.identitySyncArchiveThreshold,
+        ACCESS_HANDLER_URL: props.accessHandler.getApiGateway().url,
+        EVENT_BUS_ARN: props.eventBus.eventBusArn,
       },
     });
 
     this._dynamoTable.grantReadWriteData(this._webhookLambda);
 
+    // users who are removed from the identity provider are offboarded, which revokes their grants via the access handler.
+    this._webhookLambda.addToRolePolicy(
+      new PolicyStatement({
+        resources: [props.accessHandler.getApiGateway().arnForExecuteApi()],
+        actions: ["execute-api:Invoke"],
+      })
+    );
+    props.eventBus.grantPutEventsTo(this._webhookLambda);
+
+    // the webhook handler syncs users and groups when it receives change notifications from the identity provider.
+    this._webhookLambda.addToRolePolicy(
+      new iam.PolicyStatement({
+        actions: ["ssm:GetParameter"],
+        resources: [
+          `arn:aws:ssm:${Stack.of(this).region}:${
+            Stack.of(this).account
+          }:parameter/granted/secrets/identity/*`,
+        ],
+      })
+    );
+
     this._apigateway = new apigateway.RestApi(this, "RestAPI", {
       restApiName: this._appName,
     });
@@ -88,6 +122,17 @@ export class AppBackend extends Construct {
 
     this._webhook = webhookv1;
 
+    // the SCIM API is served by the webhook handler, for identity providers which provision users and groups via SCIM.
+    const scim = this._apigateway.root.addResource("scim");
+    const scimv2 = scim.addResource("v2");
+    const scimProxy = scimv2.addResource("{proxy+}");
+    scimProxy.addMethod(
+      "ANY",
+      new apigateway.LambdaIntegration(this._webhookLambda, {
+        allowTestInvoke: false,
+      })
+    );
+
     const code = lambda.Code.fromAsset(
       path.join(__dirname, "..", "..", "..", "..", "bin", "approvals.zip")
     );
@@ -101,6 +146,7 @@ export class AppBackend extends Construct {
         APPROVALS_COGNITO_USER_POOL_ID: props.userPool.getUserPoolId(),
         IDENTITY_PROVIDER: props.userPool.getIdpType(),
         APPROVALS_ADMIN_GROUP: props.adminGroupId,
+        ADMIN_ROLES: props.adminRoles,
         MOCK_ACCESS_HANDLER: "false",
         ACCESS_HANDLER_URL: props.accessHandler.getApiGateway().url,
         PROVIDER_CONFIG: props.providerConfig,
@@ -108,6 +154,9 @@ export class AppBackend extends Construct {
         EVENT_BUS_ARN: props.eventBus.eventBusArn,
         EVENT_BUS_SOURCE: props.eventBusSourceName,
         IDENTITY_SETTINGS: props.identityProviderSyncConfiguration,
+        IDENTITY_GROUP_MAPPINGS: props.identityGroupMappings,
+        IDENTITY_SYNC_ARCHIVE_THRESHOLD: props.identitySyncArchiveThreshold,
+        TICKET_SETTINGS: props.ticketConfiguration,
         PAGINATION_KMS_KEY_ARN: this._KMSkey.keyArn,
         ACCESS_HANDLER_EXECUTION_ROLE_ARN: props.accessHandler.getAccessHandlerExecutionRoleArn(),
         DEPLOYMENT_SUFFIX: props.deploymentSuffix,
@@ -148,6 +197,18 @@ export class AppBackend extends Construct {
       })
     );
 
+    // allow the Approvals API to read the secrets for the ticket systems which requests are validated against.
+    this._lambda.addToRolePolicy(
+      new iam.PolicyStatement({
+        actions: ["ssm:GetParameter"],
+        resources: [
+          `arn:aws:ssm:${Stack.of(this).region}:${
+            Stack.of(this).account
+          }:parameter/granted/secrets/tickets/*`,
+        ],
+      })
+    );
+
     // allow the Approvals API to write SSM parameters as part of the guided setup workflow.
     this._lambda.addToRolePolicy(
       new iam.PolicyStatement({
@@ -181,12 +242,30 @@ export class AppBackend extends Construct {
       }
     );
 
+    // API keys are verified by the Approvals API rather than by Cognito, so requests
+    // made with an API key use a separate path which doesn't have an authorizer.
+    const serviceProxy = this._apigateway.root
+      .addResource("service")
+      .addResource("api")
+      .addResource("v1")
+      .addResource("{proxy+}");
+    serviceProxy.addMethod(
+      "ANY",
+      new apigateway.LambdaIntegration(this._lambda, {
+        allowTestInvoke: false,
+      }),
+      {
+        authorizationType: apigateway.AuthorizationType.NONE,
+      }
+    );
+
     const ALLOWED_HEADERS = [
       "Content-Type",
       "X-Amz-Date",
       "X-Amz-Security-Token",
       "Authorization",
       "X-Api-Key",
+      "X-Granted-On-Behalf-Of",
       "X-Requested-With",
       "Accept",
       "Access-Control-Allow-Methods",
@@ -264,6 +343,15 @@ export class AppBackend extends Construct {
       userPool: props.userPool,
       identityProviderSyncConfiguration:
         props.identityProviderSyncConfiguration,
+      identityGroupMappings: props.identityGroupMappings,
+      identitySyncArchiveThreshold: props.identitySyncArchiveThreshold,
+      accessHandler: props.accessHandler,
+      eventBus: props.eventBus,
+    });
+
+    this._reminders = new Reminders(this, "Reminders", {
+      dynamoTable: this._dynamoTable,
+      eventBus: props.eventBus,
     });
   }
 
@@ -300,6 +388,9 @@ export class AppBackend extends Construct {
   getIdpSync(): IdpSync {
     return this._idpSync;
   }
+  getReminders(): Reminders {
+    return this._reminders;
+  }
 
   getKmsKeyArn(): string {
     return this._KMSkey.keyArn;
@@ -7,11 +7,16 @@ import * as events from "aws-cdk-lib/aws-events";
 import * as targets from "aws-cdk-lib/aws-events-targets";
 import { Table } from "aws-cdk-lib/aws-dynamodb";
 import { WebUserPool } from "./app-user-pool";
+import { AccessHandler } from "./access-handler";
 
 interface Props {
   dynamoTable: Table;
   userPool: WebUserPool;
   identityProviderSyncConfiguration: string;
+  identityGroupMappings: string;
+  identitySyncArchiveThreshold: string;
+  accessHandler: AccessHandler;
+  eventBus: events.EventBus;
 }
 
 export class IdpSync extends Construct {
@@ -26,12 +31,16 @@ export class IdpSync extends Construct {
 
     this._lambda = new lambda.Function(this, "HandlerFunction", {
       code,
-      timeout: Duration.seconds(20),
+      timeout: Duration.seconds(60),
       environment: {
         APPROVALS_TABLE_NAME: props.dynamoTable.tableName,
         IDENTITY_PROVIDER: props.userPool.getIdpType(),
         APPROVALS_COGNITO_USER_POOL_ID: props.userPool.getUserPoolId(),
         IDENTITY_SETTINGS: props.identityProviderSyncConfiguration,
+        IDENTITY_GROUP_MAPPINGS: props.identityGroupMappings,
+        IDENTITY_SYNC_ARCHIVE_THRESHOLD: props.identitySyncArchiveThreshold,
+        ACCESS_HANDLER_URL: props.accessHandler.getApiGateway().url,
+        EVENT_BUS_ARN: props.eventBus.eventBusArn,
       },
       runtime: lambda.Runtime.GO_1_X,
       handler: "syncer",
@@ -75,6 +84,14 @@ export class IdpSync extends Construct {
         ],
       })
     );
+    // users who are removed from the identity provider are offboarded, which revokes their grants via the access handler.
+    this._lambda.addToRolePolicy(
+      new PolicyStatement({
+        resources: [props.accessHandler.getApiGateway().arnForExecuteApi()],
+        actions: ["execute-api:Invoke"],
+      })
+    );
+    props.eventBus.grantPutEventsTo(this._lambda);
     //allow the lambda to write to the table
     props.dynamoTable.grantWriteData(this._lambda);
   }
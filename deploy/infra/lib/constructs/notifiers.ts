@@ -1,6 +1,6 @@
 import { CfnCondition, Duration, Fn, Stack } from "aws-cdk-lib";
 import { Table } from "aws-cdk-lib/aws-dynamodb";
-import { CfnRule, EventBus, Rule } from "aws-cdk-lib/aws-events";
+import { CfnRule, EventBus, Rule, Schedule } from "aws-cdk-lib/aws-events";
 import { LambdaFunction } from "aws-cdk-lib/aws-events-targets";
 import * as iam from "aws-cdk-lib/aws-iam";
 import { PolicyStatement } from "aws-cdk-lib/aws-iam";
@@ -20,6 +20,7 @@ interface Props {
 export class Notifiers extends Construct {
   private _slackLambda: lambda.Function;
   private _slackRule: Rule;
+  private _slackDigestRule: Rule;
   constructor(scope: Construct, id: string, props: Props) {
     super(scope, id);
 
@@ -58,8 +59,12 @@ export class Notifiers extends Construct {
         }),
       ],
     });
-   
-    
+
+    // send notification digests to users who prefer them once a day
+    this._slackDigestRule = new Rule(this, "SlackNotifierDigestRule", {
+      schedule: Schedule.cron({ minute: "0", hour: "9" }),
+      targets: [new LambdaFunction(this._slackLambda)],
+    });
 
     props.dynamoTable.grantReadWriteData(this._slackLambda);
     this._slackLambda.addToRolePolicy(
@@ -98,11 +98,44 @@ export class CustomerGrantedStack extends cdk.Stack {
         default: "{}",
       }
     );
+    const ticketConfiguration = new CfnParameter(this, "TicketConfiguration", {
+      type: "String",
+      description: "The Ticket System configuration in JSON format",
+      default: "{}",
+    });
     const identityConfig = new CfnParameter(this, "IdentityConfiguration", {
       type: "String",
       description: "The Identity Provider Sync configuration in JSON format",
       default: "{}",
     });
+    const adminRoles = new CfnParameter(this, "AdminRoles", {
+      type: "String",
+      description: "Admin roles assigned to groups in JSON format",
+      default: "[]",
+    });
+    const identityGroupMappings = new CfnParameter(
+      this,
+      "IdentityGroupMappings",
+      {
+        type: "String",
+        description:
+          "Rules mapping Identity Provider groups onto Granted groups in JSON format",
+        default: "[]",
+      }
+    );
+
+    const identitySyncArchiveThreshold = new CfnParameter(
+      this,
+      "IdentitySyncArchiveThreshold",
+      {
+        type: "Number",
+        description:
+          "The percentage of active users which an identity sync may archive before it is aborted. If 0, the default threshold of 50% is used",
+        default: 0,
+        minValue: 0,
+        maxValue: 100,
+      }
+    );
 
     const appName = this.stackName + suffix.valueAsString;
 
@@ -150,8 +183,12 @@ export class CustomerGrantedStack extends cdk.Stack {
       eventBus: events.getEventBus(),
       eventBusSourceName: events.getEventBusSourceName(),
       adminGroupId: grantedAdminGroupId.valueAsString,
+      adminRoles: adminRoles.valueAsString,
       identityProviderSyncConfiguration: identityConfig.valueAsString,
+      identityGroupMappings: identityGroupMappings.valueAsString,
+      identitySyncArchiveThreshold: identitySyncArchiveThreshold.valueAsString,
       notificationsConfiguration: notificationsConfiguration.valueAsString,
+      ticketConfiguration: ticketConfiguration.valueAsString,
       providerConfig: providerConfig.valueAsString,
       deploymentSuffix: suffix.valueAsString,
       dynamoTable: db.getTable(),
@@ -199,6 +236,7 @@ export class CustomerGrantedStack extends cdk.Stack {
       Region: this.region,
       PaginationKMSKeyARN: appBackend.getKmsKeyArn(),
       AccessHandlerExecutionRoleARN: accessHandler.getAccessHandlerExecutionRoleArn(),
+      AccessHandlerAPIURL: accessHandler.getApiGateway().url,
     });
   }
 }
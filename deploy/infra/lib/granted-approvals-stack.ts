@@ -20,8 +20,12 @@ interface Props extends cdk.StackProps {
   samlMetadata: string;
   devConfig: DevEnvironmentConfig | null;
   notificationsConfiguration: string;
+  ticketConfiguration: string;
   identityProviderSyncConfiguration: string;
+  identityGroupMappings: string;
+  identitySyncArchiveThreshold: string;
   adminGroupId: string;
+  adminRoles: string;
 }
 export class DevGrantedStack extends cdk.Stack {
   constructor(scope: Construct, id: string, props: Props) {
@@ -35,8 +39,12 @@ export class DevGrantedStack extends cdk.Stack {
       samlMetadata,
       devConfig,
       adminGroupId,
+      adminRoles,
       notificationsConfiguration,
+      ticketConfiguration,
       identityProviderSyncConfiguration,
+      identityGroupMappings,
+      identitySyncArchiveThreshold,
     } = props;
     const appName = `granted-approvals-${stage}`;
 
@@ -79,9 +87,13 @@ export class DevGrantedStack extends cdk.Stack {
       eventBus: events.getEventBus(),
       eventBusSourceName: events.getEventBusSourceName(),
       adminGroupId,
+      adminRoles,
       providerConfig: props.providerConfig,
       identityProviderSyncConfiguration: identityProviderSyncConfiguration,
+      identityGroupMappings: identityGroupMappings,
+      identitySyncArchiveThreshold: identitySyncArchiveThreshold,
       notificationsConfiguration: notificationsConfiguration,
+      ticketConfiguration: ticketConfiguration,
       deploymentSuffix: stage,
       dynamoTable: db.getTable(),
     });
@@ -114,6 +126,7 @@ export class DevGrantedStack extends cdk.Stack {
       Region: this.region,
       PaginationKMSKeyARN: approvals.getKmsKeyArn(),
       AccessHandlerExecutionRoleARN: accessHandler.getAccessHandlerExecutionRoleArn(),
+      AccessHandlerAPIURL: accessHandler.getApiGateway().url,
     });
   }
 }
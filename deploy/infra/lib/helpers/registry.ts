@@ -7,7 +7,9 @@ export const IdentityProviderRegistry = {
     Cognito : "cognito",
     Okta    : "okta",
     AzureAD : "azure",
-    Google  : "google"
+    Google  : "google",
+    SCIM    : "scim",
+    LDAP    : "ldap"
 } as const 
 
 export type IdentityProviderTypes = typeof IdentityProviderRegistry[keyof typeof IdentityProviderRegistry]
\ No newline at end of file
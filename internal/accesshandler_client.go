@@ -23,6 +23,11 @@ func BuildAccessHandlerClient(ctx context.Context, cfg config.Config) (types.Cli
 	if cfg.MockAccessHandler {
 		return nil, nil
 	}
+	return NewAccessHandlerClient(ctx, cfg.AccessHandlerURL, cfg.Region)
+}
+
+// NewAccessHandlerClient builds an Access Handler client which signs requests with the AWS credentials from the context.
+func NewAccessHandlerClient(ctx context.Context, url string, region string) (types.ClientWithResponsesInterface, error) {
 	awsCfg, err := cfaws.ConfigFromContextOrDefault(ctx)
 	if err != nil {
 		return nil, err
@@ -31,7 +36,7 @@ func BuildAccessHandlerClient(ctx context.Context, cfg config.Config) (types.Cli
 	if err != nil {
 		return nil, err
 	}
-	return types.NewClientWithResponses(cfg.AccessHandlerURL, types.WithRequestEditorFn(apiGatewayRequestSigner(creds, cfg.Region)))
+	return types.NewClientWithResponses(url, types.WithRequestEditorFn(apiGatewayRequestSigner(creds, region)))
 }
 
 // apiGatewayRequestSigner uses the AWS SDK to sign the request with sigv4
@@ -0,0 +1,28 @@
+package internal
+
+import (
+	"github.com/benbjohnson/clock"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/accesshandler/pkg/types"
+	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/common-fate/granted-approvals/pkg/gevent"
+	"github.com/common-fate/granted-approvals/pkg/service/grantsvc"
+	"github.com/common-fate/granted-approvals/pkg/service/offboardsvc"
+)
+
+// BuildOffboarder builds the service which offboards users who are archived by identity sync.
+func BuildOffboarder(db ddb.Storage, ahc types.ClientWithResponsesInterface, eventBus *gevent.Sender) *offboardsvc.Service {
+	clk := clock.New()
+	return &offboardsvc.Service{
+		Clock: clk,
+		DB:    db,
+		Granter: grantsvc.New(grantsvc.GranterOpts{
+			AHClient:         ahc,
+			DB:               db,
+			Clock:            clk,
+			EventBus:         eventBus,
+			DeploymentConfig: &deploy.EnvDeploymentConfig{},
+		}),
+		EventPutter: eventBus,
+	}
+}
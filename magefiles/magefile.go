@@ -94,6 +94,14 @@ func (Build) Syncer() error {
 	return sh.RunWith(env, "go", "build", "-o", "bin/syncer", "cmd/lambda/syncer/handler.go")
 }
 
+func (Build) Reminders() error {
+	env := map[string]string{
+		"GOOS":   "linux",
+		"GOARCH": "amd64",
+	}
+	return sh.RunWith(env, "go", "build", "-o", "bin/reminders", "cmd/lambda/reminders/handler.go")
+}
+
 func (Build) SlackNotifier() error {
 	env := map[string]string{
 		"GOOS":   "linux",
@@ -167,7 +175,7 @@ func PackageBackend() error {
 }
 
 func Package() {
-	mg.Deps(PackageBackend, PackageGranter, PackageAccessHandler, PackageSlackNotifier, PackageEventHandler, PackageSyncer, PackageWebhook, PackageFrontendDeployer)
+	mg.Deps(PackageBackend, PackageGranter, PackageAccessHandler, PackageSlackNotifier, PackageEventHandler, PackageSyncer, PackageReminders, PackageWebhook, PackageFrontendDeployer)
 }
 
 // PackageGranter zips the Go granter so that it can be deployed to Lambda.
@@ -194,6 +202,12 @@ func PackageSyncer() error {
 	return sh.Run("zip", "--junk-paths", "bin/syncer.zip", "bin/syncer")
 }
 
+// PackageReminders zips the Go reminders function handler so that it can be deployed to Lambda.
+func PackageReminders() error {
+	mg.Deps(Build.Reminders)
+	return sh.Run("zip", "--junk-paths", "bin/reminders.zip", "bin/reminders")
+}
+
 // PackageNotifier zips the Go notifier so that it can be deployed to Lambda.
 func PackageSlackNotifier() error {
 	mg.Deps(Build.SlackNotifier)
@@ -265,6 +279,14 @@ func Dotenv() error {
 		}
 		idConf = string(b)
 	}
+	ticketConf := "{}"
+	if cfg.Deployment.Parameters.TicketConfiguration != nil {
+		b, err := json.Marshal(cfg.Deployment.Parameters.TicketConfiguration)
+		if err != nil {
+			return err
+		}
+		ticketConf = string(b)
+	}
 	providerConf := "{}"
 	if cfg.Deployment.Parameters.ProviderConfiguration != nil {
 		b, err := json.Marshal(cfg.Deployment.Parameters.ProviderConfiguration)
@@ -285,6 +307,7 @@ func Dotenv() error {
 	myEnv["EVENT_BUS_SOURCE"] = o.EventBusSource
 	myEnv["IDENTITY_SETTINGS"] = idConf
 	myEnv["PROVIDER_CONFIG"] = providerConf
+	myEnv["TICKET_SETTINGS"] = ticketConf
 	myEnv["STATE_MACHINE_ARN"] = o.GranterStateMachineArn
 	myEnv["IDENTITY_PROVIDER"] = idpType
 	myEnv["APPROVALS_ADMIN_GROUP"] = cfg.Deployment.Parameters.AdministratorGroupID
@@ -0,0 +1,26 @@
+package access
+
+import (
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/storage/keys"
+)
+
+// ChannelMessage is a notification for a Request which was posted to a shared channel,
+// such as a Slack channel configured on the Access Rule.
+// It is stored so that the message can be updated in place as the Request moves through its lifecycle.
+type ChannelMessage struct {
+	RequestID string `json:"requestId" dynamodbav:"requestId"`
+	// Channel is the channel as it is configured on the Access Rule.
+	Channel       string        `json:"channel" dynamodbav:"channel"`
+	Notifications Notifications `json:"notifications" dynamodbav:"notifications"`
+}
+
+// DDBKeys provides the keys for storing the object in DynamoDB
+func (c *ChannelMessage) DDBKeys() (ddb.Keys, error) {
+	keys := ddb.Keys{
+		PK: keys.RequestChannelMessage.PK1,
+		SK: keys.RequestChannelMessage.SK1(c.RequestID, c.Channel),
+	}
+
+	return keys, nil
+}
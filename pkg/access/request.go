@@ -33,6 +33,8 @@ type Grant struct {
 	Status    ac_types.GrantStatus `json:"status" dynamodbav:"status"`
 	CreatedAt time.Time            `json:"createdAt" dynamodbav:"createdAt"`
 	UpdatedAt time.Time            `json:"updatedAt" dynamodbav:"updatedAt"`
+	// ExpiryNotificationSentAt is set when the user has been warned that the grant is about to expire.
+	ExpiryNotificationSentAt *time.Time `json:"expiryNotificationSentAt,omitempty" dynamodbav:"expiryNotificationSentAt,omitempty"`
 }
 
 func (g *Grant) ToAHGrant(requestID string) ac_types.Grant {
@@ -147,6 +149,9 @@ func (r *Request) ToAPI() types.Request {
 		req.Grant = &g
 	}
 
+	if len(r.Data.Fields) > 0 {
+		req.Fields = &r.Data.Fields
+	}
 	// show the updated timing rather than the requested timing if it's been overridden by an approver.
 	if r.OverrideTiming != nil {
 		req.Timing = r.OverrideTiming.ToAPI()
@@ -182,6 +187,9 @@ func (r *Request) ToAPIDetail(accessRule rule.AccessRule, canReview bool) types.
 		g := r.Grant.ToAPI()
 		req.Grant = &g
 	}
+	if len(r.Data.Fields) > 0 {
+		req.Fields = &r.Data.Fields
+	}
 	// show the updated timing rather than the requested timing if it's been overridden by an approver.
 	if r.OverrideTiming != nil {
 		req.Timing = r.OverrideTiming.ToAPI()
@@ -280,4 +288,6 @@ func (t *Timing) GetInterval(opts ...func(o *GetIntervalOpts)) (start time.Time,
 // through filling in form fields in the web application.
 type RequestData struct {
 	Reason *string `json:"reason,omitempty" dynamodbav:"reason,omitempty"`
+	// Fields are the values for the request fields of the access rule, keyed by the field ID.
+	Fields map[string]string `json:"fields,omitempty" dynamodbav:"fields,omitempty"`
 }
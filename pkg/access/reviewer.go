@@ -1,6 +1,8 @@
 package access
 
 import (
+	"time"
+
 	"github.com/common-fate/ddb"
 	"github.com/common-fate/granted-approvals/pkg/storage/keys"
 )
@@ -18,6 +20,15 @@ type Reviewer struct {
 type Notifications struct {
 	// if slack is in use, slack message ID should be populated when this has been notified
 	SlackMessageID *string `json:"slackMessageId" dynamodbav:"slackMessageId"`
+	// SlackChannelID is the ID of the Slack conversation the message was posted to.
+	// It is only populated for messages posted to channels, as DMs are looked up by the user's email.
+	SlackChannelID *string `json:"slackChannelId,omitempty" dynamodbav:"slackChannelId,omitempty"`
+	// ReminderSentAt is set when the reviewer has been reminded about a pending request.
+	// Reviewers are only reminded once per request.
+	ReminderSentAt *time.Time `json:"reminderSentAt,omitempty" dynamodbav:"reminderSentAt,omitempty"`
+	// EscalatedAt is set when the request has been escalated to the reviewer
+	// because it wasn't reviewed within the escalation interval.
+	EscalatedAt *time.Time `json:"escalatedAt,omitempty" dynamodbav:"escalatedAt,omitempty"`
 }
 
 // DDBKeys provides the keys for storing the object in DynamoDB
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"sync"
 
@@ -9,6 +11,8 @@ import (
 	"github.com/common-fate/ddb"
 	"github.com/common-fate/granted-approvals/pkg/auth"
 	"github.com/common-fate/granted-approvals/pkg/cache"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/policy"
 	"github.com/common-fate/granted-approvals/pkg/rule"
 	"github.com/common-fate/granted-approvals/pkg/service/rulesvc"
 	"github.com/common-fate/granted-approvals/pkg/storage"
@@ -31,7 +35,12 @@ func (a *API) AdminArchiveAccessRule(w http.ResponseWriter, r *http.Request, rul
 		return
 	}
 
-	c, err := a.Rules.ArchiveAccessRule(ctx, u, *q.Result)
+	// rule owners can archive the rule even if they aren't an admin.
+	isAdmin := auth.CanManageRulesForProvider(ctx, q.Result.Target.ProviderID)
+	c, err := a.Rules.ArchiveAccessRule(ctx, u, *q.Result, isAdmin)
+	if err == rulesvc.ErrUserNotAuthorized {
+		err = errRuleNotPermitted
+	}
 	if err != nil {
 		apio.Error(ctx, w, err)
 		return
@@ -68,10 +77,13 @@ func (a *API) AdminListAccessRules(w http.ResponseWriter, r *http.Request, param
 	}
 
 	res := types.ListAccessRulesDetailResponse{
-		AccessRules: make([]types.AccessRuleDetail, len(rules)),
+		AccessRules: []types.AccessRuleDetail{},
 	}
-	for i, r := range rules {
-		res.AccessRules[i] = r.ToAPIDetail()
+	for _, r := range rules {
+		// rule managers which are scoped to providers and rule owners only see the rules they can manage.
+		if canManageRule(ctx, r) {
+			res.AccessRules = append(res.AccessRules, r.ToAPIDetail())
+		}
 	}
 
 	apio.JSON(ctx, w, res, http.StatusOK)
@@ -87,11 +99,28 @@ func (a *API) AdminCreateAccessRule(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	u := auth.UserFromContext(ctx)
+	if !auth.CanManageRulesForProvider(ctx, createRequest.Target.ProviderId) {
+		// rule owners can create rules for the providers of the rules which they own.
+		owns, err := a.ownsRuleForProvider(ctx, u, createRequest.Target.ProviderId)
+		if err != nil {
+			apio.Error(ctx, w, err)
+			return
+		}
+		if !owns {
+			apio.Error(ctx, w, errProviderNotPermitted(createRequest.Target.ProviderId))
+			return
+		}
+		// the owner must own the new rule, otherwise they can't manage it after creating it.
+		createRequest.Owners = withOwner(createRequest.Owners, u.ID)
+	}
 	c, err := a.Rules.CreateAccessRule(ctx, u, createRequest)
 	if err == rulesvc.ErrRuleIdAlreadyExists {
 		// the user supplied id already exists
 		err = apio.NewRequestError(err, http.StatusBadRequest)
 	}
+	if errors.Is(err, rulesvc.ErrInvalidTimeConstraints) || errors.Is(err, rulesvc.ErrInvalidRequestFields) || errors.Is(err, rulesvc.ErrInvalidTicketRequirement) || errors.Is(err, policy.ErrInvalidPolicy) {
+		err = apio.NewRequestError(err, http.StatusBadRequest)
+	}
 	if err != nil {
 		apio.Error(ctx, w, err)
 		return
@@ -116,6 +145,10 @@ func (a *API) AdminGetAccessRule(w http.ResponseWriter, r *http.Request, ruleId
 		apio.Error(ctx, w, err)
 		return
 	}
+	if !canManageRule(ctx, *rule) {
+		apio.Error(ctx, w, errRuleNotPermitted)
+		return
+	}
 	apio.JSON(ctx, w, rule.ToAPIDetail(), http.StatusOK)
 }
 
@@ -129,7 +162,7 @@ func (a *API) AdminUpdateAccessRule(w http.ResponseWriter, r *http.Request, rule
 		apio.Error(ctx, w, apio.NewRequestError(err, http.StatusBadRequest))
 		return
 	}
-	uid := auth.UserIDFromContext(ctx)
+	u := auth.UserFromContext(ctx)
 
 	var rule *rule.AccessRule
 	ruleq := storage.GetAccessRuleCurrent{ID: ruleId}
@@ -140,11 +173,28 @@ func (a *API) AdminUpdateAccessRule(w http.ResponseWriter, r *http.Request, rule
 	}
 	rule = ruleq.Result
 
+	// moving the rule to another provider requires permission to manage rules for that provider.
+	if updateRequest.Target != nil && updateRequest.Target.ProviderId != rule.Target.ProviderID && !auth.CanManageRulesForProvider(ctx, updateRequest.Target.ProviderId) {
+		apio.Error(ctx, w, errProviderNotPermitted(updateRequest.Target.ProviderId))
+		return
+	}
+
 	updatedRule, err := a.Rules.UpdateRule(ctx, &rulesvc.UpdateOpts{
-		UpdaterID:     uid,
+		Updater:       u,
 		Rule:          *rule,
 		UpdateRequest: updateRequest,
+		// rule owners can update the rule even if they aren't an admin.
+		IsAdmin: auth.CanManageRulesForProvider(ctx, rule.Target.ProviderID),
 	})
+	if err == rulesvc.ErrUserNotAuthorized {
+		err = errRuleNotPermitted
+	}
+	if err == rulesvc.ErrVersionConflict {
+		err = apio.NewRequestError(err, http.StatusConflict)
+	}
+	if err == rulesvc.ErrProviderNotFound || err == rulesvc.ErrTooManyDeclinedRequests || errors.Is(err, rulesvc.ErrInvalidTargetOption) || errors.Is(err, rulesvc.ErrInvalidTimeConstraints) || errors.Is(err, rulesvc.ErrInvalidRequestFields) || errors.Is(err, rulesvc.ErrInvalidTicketRequirement) || errors.Is(err, policy.ErrInvalidPolicy) {
+		err = apio.NewRequestError(err, http.StatusBadRequest)
+	}
 	if err != nil {
 		apio.Error(ctx, w, err)
 		return
@@ -153,6 +203,64 @@ func (a *API) AdminUpdateAccessRule(w http.ResponseWriter, r *http.Request, rule
 	apio.JSON(ctx, w, updatedRule.ToAPIDetail(), http.StatusAccepted)
 }
 
+// Upsert Access Rule
+// (PUT /api/v1/admin/access-rule-slugs/{slug})
+func (a *API) AdminUpsertAccessRule(w http.ResponseWriter, r *http.Request, slug string) {
+	ctx := r.Context()
+	var upsertRequest types.UpsertAccessRuleRequest
+	err := apio.DecodeJSONBody(w, r, &upsertRequest)
+	if err != nil {
+		apio.Error(ctx, w, apio.NewRequestError(err, http.StatusBadRequest))
+		return
+	}
+	u := auth.UserFromContext(ctx)
+
+	// permission to manage an existing rule depends on its current provider, rather than the provider in the request.
+	providerID := upsertRequest.Target.ProviderId
+	q := storage.GetAccessRuleCurrent{ID: slug}
+	_, err = a.DB.Query(ctx, &q)
+	if err != nil && err != ddb.ErrNoItems {
+		apio.Error(ctx, w, err)
+		return
+	}
+	if err == nil {
+		providerID = q.Result.Target.ProviderID
+		// moving the rule to another provider requires permission to manage rules for that provider.
+		if upsertRequest.Target.ProviderId != providerID && !auth.CanManageRulesForProvider(ctx, upsertRequest.Target.ProviderId) {
+			apio.Error(ctx, w, errProviderNotPermitted(upsertRequest.Target.ProviderId))
+			return
+		}
+	}
+
+	c, result, err := a.Rules.UpsertAccessRule(ctx, rulesvc.UpsertOpts{
+		Slug:     slug,
+		Upserter: u,
+		Request:  upsertRequest,
+		// rule owners can update the rule even if they aren't an admin.
+		IsAdmin: auth.CanManageRulesForProvider(ctx, providerID),
+	})
+	switch err {
+	case rulesvc.ErrUserNotAuthorized:
+		err = errRuleNotPermitted
+	case rulesvc.ErrVersionConflict:
+		err = apio.NewRequestError(err, http.StatusConflict)
+	case rulesvc.ErrInvalidSlug, rulesvc.ErrAccessRuleArchived, rulesvc.ErrProviderNotFound, rulesvc.ErrTooManyDeclinedRequests:
+		err = apio.NewRequestError(err, http.StatusBadRequest)
+	}
+	if errors.Is(err, rulesvc.ErrInvalidTargetOption) || errors.Is(err, rulesvc.ErrInvalidTimeConstraints) || errors.Is(err, rulesvc.ErrInvalidRequestFields) || errors.Is(err, rulesvc.ErrInvalidTicketRequirement) || errors.Is(err, policy.ErrInvalidPolicy) {
+		err = apio.NewRequestError(err, http.StatusBadRequest)
+	}
+	if err != nil {
+		apio.Error(ctx, w, err)
+		return
+	}
+	code := http.StatusOK
+	if result == rulesvc.UpsertCreated {
+		code = http.StatusCreated
+	}
+	apio.JSON(ctx, w, c.ToAPIDetail(), code)
+}
+
 func (a *API) AdminGetAccessRuleVersions(w http.ResponseWriter, r *http.Request, ruleId string) {
 	ctx := r.Context()
 	q := storage.ListAccessRuleVersions{ID: ruleId}
@@ -162,6 +270,12 @@ func (a *API) AdminGetAccessRuleVersions(w http.ResponseWriter, r *http.Request,
 		return
 	}
 	versions := q.Result
+	for _, v := range versions {
+		if v.Current && !canManageRule(ctx, v) {
+			apio.Error(ctx, w, errRuleNotPermitted)
+			return
+		}
+	}
 	res := types.ListAccessRulesDetailResponse{
 		AccessRules: make([]types.AccessRuleDetail, len(versions)),
 	}
@@ -186,15 +300,231 @@ func (a *API) AdminGetAccessRuleVersion(w http.ResponseWriter, r *http.Request,
 		apio.Error(ctx, w, err)
 		return
 	}
+	if !auth.CanManageRulesForProvider(ctx, q.Result.Target.ProviderID) {
+		// owners can view every version of the rules which they currently own.
+		current := storage.GetAccessRuleCurrent{ID: ruleId}
+		_, err = a.DB.Query(ctx, &current)
+		if err != nil {
+			apio.Error(ctx, w, err)
+			return
+		}
+		if !canManageRule(ctx, *current.Result) {
+			apio.Error(ctx, w, errRuleNotPermitted)
+			return
+		}
+	}
 	apio.JSON(ctx, w, q.Result.ToAPIDetail(), http.StatusOK)
 }
 
+// Returns the changes between two versions of a rule
+// (GET /api/v1/admin/access-rules/{ruleId}/diff)
+func (a *API) AdminDiffAccessRuleVersions(w http.ResponseWriter, r *http.Request, ruleId string, params types.AdminDiffAccessRuleVersionsParams) {
+	ctx := r.Context()
+	current := storage.GetAccessRuleCurrent{ID: ruleId}
+	_, err := a.DB.Query(ctx, &current)
+	if err == ddb.ErrNoItems {
+		apio.Error(ctx, w, &apio.APIError{Err: errors.New("this rule does not exist"), Status: http.StatusNotFound})
+		return
+	}
+	if err != nil {
+		apio.Error(ctx, w, err)
+		return
+	}
+	if !canManageRule(ctx, *current.Result) {
+		apio.Error(ctx, w, errRuleNotPermitted)
+		return
+	}
+	from, err := a.getRuleVersion(ctx, ruleId, params.From)
+	if err != nil {
+		apio.Error(ctx, w, err)
+		return
+	}
+	to, err := a.getRuleVersion(ctx, ruleId, params.To)
+	if err != nil {
+		apio.Error(ctx, w, err)
+		return
+	}
+
+	res := types.AccessRuleDiff{
+		From:    params.From,
+		To:      params.To,
+		Changes: []types.AccessRuleFieldChange{},
+	}
+	for _, c := range rule.Diff(*from, *to) {
+		res.Changes = append(res.Changes, c.ToAPI())
+	}
+	apio.JSON(ctx, w, res, http.StatusOK)
+}
+
+// Creates a new current version of a rule from an earlier version
+// (POST /api/v1/admin/access-rules/{ruleId}/versions/{version}/rollback)
+func (a *API) AdminRollbackAccessRule(w http.ResponseWriter, r *http.Request, ruleId string, version string) {
+	ctx := r.Context()
+	u := auth.UserFromContext(ctx)
+	current := storage.GetAccessRuleCurrent{ID: ruleId}
+	_, err := a.DB.Query(ctx, &current)
+	if err == ddb.ErrNoItems {
+		apio.Error(ctx, w, &apio.APIError{Err: errors.New("this rule does not exist"), Status: http.StatusNotFound})
+		return
+	}
+	if err != nil {
+		apio.Error(ctx, w, err)
+		return
+	}
+	v, err := a.getRuleVersion(ctx, ruleId, version)
+	if err != nil {
+		apio.Error(ctx, w, err)
+		return
+	}
+	// restoring a target for a different provider requires permission to manage rules for that provider.
+	if v.Target.ProviderID != current.Result.Target.ProviderID && !auth.CanManageRulesForProvider(ctx, v.Target.ProviderID) {
+		apio.Error(ctx, w, errProviderNotPermitted(v.Target.ProviderID))
+		return
+	}
+
+	res, err := a.Rules.RollbackAccessRule(ctx, rulesvc.RollbackOpts{
+		User:    u,
+		Current: *current.Result,
+		Version: *v,
+		// rule owners can roll back the rule even if they aren't an admin.
+		IsAdmin: auth.CanManageRulesForProvider(ctx, current.Result.Target.ProviderID),
+	})
+	switch err {
+	case rulesvc.ErrUserNotAuthorized:
+		err = errRuleNotPermitted
+	case rulesvc.ErrAccessRuleArchived, rulesvc.ErrVersionIsCurrent, rulesvc.ErrProviderNotFound, rulesvc.ErrTooManyDeclinedRequests:
+		err = apio.NewRequestError(err, http.StatusBadRequest)
+	case rulesvc.ErrVersionConflict:
+		err = apio.NewRequestError(err, http.StatusConflict)
+	}
+	if errors.Is(err, rulesvc.ErrInvalidTargetOption) {
+		err = apio.NewRequestError(err, http.StatusBadRequest)
+	}
+	if err != nil {
+		apio.Error(ctx, w, err)
+		return
+	}
+	apio.JSON(ctx, w, res.ToAPIDetail(), http.StatusOK)
+}
+
+// Returns who can request and approve the current access rules
+// (POST /api/v1/admin/access-simulation)
+func (a *API) AdminSimulateAccess(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var b types.AdminSimulateAccessJSONRequestBody
+	err := apio.DecodeJSONBody(w, r, &b)
+	if err != nil {
+		apio.Error(ctx, w, apio.NewRequestError(err, http.StatusBadRequest))
+		return
+	}
+	opts := rulesvc.SimulateOpts{}
+	if b.ProviderId != nil {
+		opts.ProviderID = *b.ProviderId
+	}
+	if b.With != nil {
+		opts.With = b.With.AdditionalProperties
+	}
+	if b.UserId != nil {
+		opts.UserID = *b.UserId
+	}
+
+	sims, err := rulesvc.Simulate(ctx, a.DB, opts)
+	if err == ddb.ErrNoItems {
+		apio.Error(ctx, w, &apio.APIError{Err: errors.New("user does not exist"), Status: http.StatusNotFound})
+		return
+	}
+	if err != nil {
+		apio.Error(ctx, w, err)
+		return
+	}
+
+	res := types.AccessSimulation{Results: []types.AccessSimulationResult{}}
+	for _, s := range sims {
+		// rule managers which are scoped to providers and rule owners only see the rules they can manage.
+		if !canManageRule(ctx, s.Rule) {
+			continue
+		}
+		res.Results = append(res.Results, s.ToAPI())
+	}
+	apio.JSON(ctx, w, res, http.StatusOK)
+}
+
+// getRuleVersion returns a 404 error if the version of the rule doesn't exist.
+func (a *API) getRuleVersion(ctx context.Context, ruleID string, version string) (*rule.AccessRule, error) {
+	q := storage.GetAccessRuleVersion{ID: ruleID, VersionID: version}
+	_, err := a.DB.Query(ctx, &q)
+	if err == ddb.ErrNoItems {
+		return nil, &apio.APIError{Err: fmt.Errorf("version %s of this rule does not exist", version), Status: http.StatusNotFound}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return q.Result, nil
+}
+
+// errProviderNotPermitted is returned when a user tries to manage a rule for a provider which they aren't assigned to.
+func errProviderNotPermitted(providerID string) error {
+	return apio.NewRequestError(fmt.Errorf("you don't have permission to manage access rules for provider %s", providerID), http.StatusUnauthorized)
+}
+
+// errRuleNotPermitted is returned when a user tries to manage a rule which they don't own.
+var errRuleNotPermitted = apio.NewRequestError(errors.New("you don't have permission to manage this access rule"), http.StatusUnauthorized)
+
+// canManageRule returns true if the user is an admin, a rule manager for the rule's provider, or an owner of the rule.
+func canManageRule(ctx context.Context, r rule.AccessRule) bool {
+	return auth.CanManageRulesForProvider(ctx, r.Target.ProviderID) || r.IsOwner(auth.UserFromContext(ctx))
+}
+
+// ownedRules returns the current access rules which the user owns.
+func (a *API) ownedRules(ctx context.Context, u *identity.User) ([]rule.AccessRule, error) {
+	q := storage.ListCurrentAccessRules{}
+	_, err := a.DB.Query(ctx, &q)
+	if err != nil && err != ddb.ErrNoItems {
+		return nil, err
+	}
+	var owned []rule.AccessRule
+	for _, r := range q.Result {
+		if r.IsOwner(u) {
+			owned = append(owned, r)
+		}
+	}
+	return owned, nil
+}
+
+// ownsRuleForProvider returns true if the user owns a rule for the provider.
+func (a *API) ownsRuleForProvider(ctx context.Context, u *identity.User, providerID string) (bool, error) {
+	owned, err := a.ownedRules(ctx, u)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range owned {
+		if r.Target.ProviderID == providerID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// withOwner adds the user to the owners if they aren't already an owner.
+func withOwner(owners *types.AccessRuleOwners, userID string) *types.AccessRuleOwners {
+	if owners == nil {
+		owners = &types.AccessRuleOwners{Users: []string{}, Groups: []string{}}
+	}
+	for _, u := range owners.Users {
+		if u == userID {
+			return owners
+		}
+	}
+	owners.Users = append(owners.Users, userID)
+	return owners
+}
+
 // List Access Rules
 // (GET /api/v1/access-rules)
 func (a *API) ListUserAccessRules(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	u := auth.UserFromContext(ctx)
-	q := storage.ListAccessRulesForGroupsAndStatus{Groups: u.Groups, Status: rule.ACTIVE}
+	q := storage.ListAccessRulesForGroupsAndStatus{Groups: u.EffectiveGroups(), Status: rule.ACTIVE}
 	_, err := a.DB.Query(ctx, &q)
 	if err != nil && err != ddb.ErrNoItems {
 		apio.Error(ctx, w, err)
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +13,7 @@ import (
 	"github.com/common-fate/ddb"
 	"github.com/common-fate/ddb/ddbmock"
 	"github.com/common-fate/granted-approvals/pkg/api/mocks"
+	"github.com/common-fate/granted-approvals/pkg/deploy"
 	"github.com/common-fate/granted-approvals/pkg/identity"
 	"github.com/common-fate/granted-approvals/pkg/rule"
 	"github.com/common-fate/granted-approvals/pkg/service/rulesvc"
@@ -26,6 +29,12 @@ func TestAdminCreateAccessRule(t *testing.T) {
 		give          string
 		mockCreate    *rule.AccessRule
 		mockCreateErr error
+		// adminRoles makes the request as a user with admin roles rather than as an admin.
+		adminRoles deploy.AdminRoleAssignments
+		// currentRules are the existing rules, which are checked if the user isn't an admin.
+		currentRules []rule.AccessRule
+		// wantOwners are the owners which the rule is created with.
+		wantOwners *types.AccessRuleOwners
 
 		//idpUser  *types.User
 		wantCode int
@@ -69,6 +78,60 @@ func TestAdminCreateAccessRule(t *testing.T) {
 			wantCode: http.StatusBadRequest,
 			wantBody: `{"error":"request body has an error: doesn't match the schema: Error at \"/timeConstraints/maxDurationSeconds\": number must be at least 60"}`,
 		},
+		{
+			name:       "rule manager for the provider",
+			give:       `{"target":{"providerId":"string","with":{}},"timeConstraints":{"maxDurationSeconds": 60},"groups":["string"],"name":"string","description":"string","approval":{"groups":[],"users":[]}}`,
+			adminRoles: deploy.AdminRoleAssignments{{Group: "rule-managers", Role: deploy.AdminRoleRuleManager, Providers: []string{"string"}}},
+			mockCreate: &rule.AccessRule{
+				ID:          "rule1",
+				Status:      rule.ACTIVE,
+				Description: "string",
+				Name:        "string",
+				Groups:      []string{"string"},
+				Target: rule.Target{
+					ProviderID: "string",
+					With:       map[string]string{},
+				},
+			},
+			wantCode: http.StatusCreated,
+			wantBody: `{"approval":{"groups":[],"users":[]},"description":"string","groups":["string"],"id":"rule1","isCurrent":false,"metadata":{"createdAt":"0001-01-01T00:00:00Z","createdBy":"","updatedAt":"0001-01-01T00:00:00Z","updatedBy":""},"name":"string","status":"ACTIVE","target":{"provider":{"id":"string","type":""},"with":{},"withSelectable":{}},"timeConstraints":{"maxDurationSeconds":0},"version":""}`,
+		},
+		{
+			name:       "rule manager for a different provider",
+			give:       `{"target":{"providerId":"string","with":{}},"timeConstraints":{"maxDurationSeconds": 60},"groups":["string"],"name":"string","description":"string","approval":{"groups":[],"users":[]}}`,
+			adminRoles: deploy.AdminRoleAssignments{{Group: "rule-managers", Role: deploy.AdminRoleRuleManager, Providers: []string{"other"}}},
+			wantCode:   http.StatusUnauthorized,
+			wantBody:   `{"error":"you don't have permission to manage access rules for provider string"}`,
+		},
+		{
+			name:         "owner of a rule for the provider",
+			give:         `{"target":{"providerId":"string","with":{}},"timeConstraints":{"maxDurationSeconds": 60},"groups":["string"],"name":"string","description":"string","approval":{"groups":[],"users":[]}}`,
+			adminRoles:   deploy.AdminRoleAssignments{},
+			currentRules: []rule.AccessRule{{ID: "rule0", Target: rule.Target{ProviderID: "string"}, Owners: rule.Owners{Users: []string{"usr_owner"}}}},
+			wantOwners:   &types.AccessRuleOwners{Users: []string{"usr_owner"}, Groups: []string{}},
+			mockCreate: &rule.AccessRule{
+				ID:          "rule1",
+				Status:      rule.ACTIVE,
+				Description: "string",
+				Name:        "string",
+				Groups:      []string{"string"},
+				Target: rule.Target{
+					ProviderID: "string",
+					With:       map[string]string{},
+				},
+				Owners: rule.Owners{Users: []string{"usr_owner"}},
+			},
+			wantCode: http.StatusCreated,
+			wantBody: `{"approval":{"groups":[],"users":[]},"description":"string","groups":["string"],"id":"rule1","isCurrent":false,"metadata":{"createdAt":"0001-01-01T00:00:00Z","createdBy":"","updatedAt":"0001-01-01T00:00:00Z","updatedBy":""},"name":"string","owners":{"groups":[],"users":["usr_owner"]},"status":"ACTIVE","target":{"provider":{"id":"string","type":""},"with":{},"withSelectable":{}},"timeConstraints":{"maxDurationSeconds":0},"version":""}`,
+		},
+		{
+			name:         "owner of a rule for a different provider",
+			give:         `{"target":{"providerId":"string","with":{}},"timeConstraints":{"maxDurationSeconds": 60},"groups":["string"],"name":"string","description":"string","approval":{"groups":[],"users":[]}}`,
+			adminRoles:   deploy.AdminRoleAssignments{},
+			currentRules: []rule.AccessRule{{ID: "rule0", Target: rule.Target{ProviderID: "other"}, Owners: rule.Owners{Users: []string{"usr_owner"}}}},
+			wantCode:     http.StatusUnauthorized,
+			wantBody:     `{"error":"you don't have permission to manage access rules for provider string"}`,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -78,11 +141,20 @@ func TestAdminCreateAccessRule(t *testing.T) {
 
 			m := mocks.NewMockAccessRuleService(ctrl)
 			if (tc.mockCreate != nil) || (tc.mockCreateErr != nil) {
-				m.EXPECT().CreateAccessRule(gomock.Any(), gomock.Any(), gomock.Any()).Return(tc.mockCreate, tc.mockCreateErr)
+				m.EXPECT().CreateAccessRule(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *identity.User, in types.CreateAccessRuleRequest) (*rule.AccessRule, error) {
+					assert.Equal(t, tc.wantOwners, in.Owners)
+					return tc.mockCreate, tc.mockCreateErr
+				})
 			}
+			db := ddbmock.New(t)
+			db.MockQuery(&storage.ListCurrentAccessRules{Result: tc.currentRules})
 
-			a := API{Rules: m}
-			handler := newTestServer(t, &a)
+			a := API{Rules: m, DB: db}
+			opts := []func(*testOptions){withIsAdmin(true)}
+			if tc.adminRoles != nil {
+				opts = []func(*testOptions){withAdminRoles(tc.adminRoles), withRequestUser(identity.User{ID: "usr_owner"})}
+			}
+			handler := newTestServer(t, &a, opts...)
 
 			req, err := http.NewRequest("POST", "/api/v1/admin/access-rules", strings.NewReader(tc.give))
 			if err != nil {
@@ -167,7 +239,7 @@ func TestAdminUpdateAccessRule(t *testing.T) {
 			db := ddbmock.New(t)
 			db.MockQuery(&storage.GetAccessRuleCurrent{Result: tc.mockCreate})
 			a := API{Rules: m, DB: db}
-			handler := newTestServer(t, &a)
+			handler := newTestServer(t, &a, withIsAdmin(true))
 
 			req, err := http.NewRequest("PUT", "/api/v1/admin/access-rules/"+"rule1", strings.NewReader(tc.give))
 			if err != nil {
@@ -196,6 +268,407 @@ func TestAdminUpdateAccessRule(t *testing.T) {
 	}
 
 }
+
+func TestAdminUpsertAccessRule(t *testing.T) {
+	type testcase struct {
+		name       string
+		slug       string
+		give       string
+		current    *rule.AccessRule
+		roles      deploy.AdminRoleAssignments
+		notAdmin   bool
+		mockResult rulesvc.UpsertResult
+		mockErr    error
+		wantCode   int
+		wantBody   string
+	}
+
+	body := `{"approval":{"users":[],"groups":[]},"name":"Production","description":"","timeConstraints":{"maxDurationSeconds":3600},"groups":["granted_administrators"],"target":{"providerId":"aws","with":{"accountId":["123"]}}}`
+	upserted := rule.AccessRule{
+		ID:              "production",
+		Status:          rule.ACTIVE,
+		Name:            "Production",
+		Groups:          []string{"granted_administrators"},
+		Target:          rule.Target{ProviderID: "aws", With: map[string]string{"accountId": "123"}},
+		TimeConstraints: types.TimeConstraints{MaxDurationSeconds: 3600},
+		Version:         "ver_1",
+		Current:         true,
+	}
+	onOkta := rule.AccessRule{ID: "production", Status: rule.ACTIVE, Target: rule.Target{ProviderID: "okta"}, Version: "ver_1", Current: true}
+	wantRule := `{"approval":{"groups":[],"users":[]},"description":"","groups":["granted_administrators"],"id":"production","isCurrent":true,"metadata":{"createdAt":"0001-01-01T00:00:00Z","createdBy":"","updatedAt":"0001-01-01T00:00:00Z","updatedBy":""},"name":"Production","status":"ACTIVE","target":{"provider":{"id":"aws","type":""},"with":{"accountId":"123"},"withSelectable":{}},"timeConstraints":{"maxDurationSeconds":3600},"version":"ver_1"}`
+
+	testcases := []testcase{
+		{
+			name:       "created",
+			slug:       "production",
+			give:       body,
+			mockResult: rulesvc.UpsertCreated,
+			wantCode:   http.StatusCreated,
+			wantBody:   wantRule,
+		},
+		{
+			name:       "unchanged",
+			slug:       "production",
+			give:       body,
+			current:    &upserted,
+			mockResult: rulesvc.UpsertUnchanged,
+			wantCode:   http.StatusOK,
+			wantBody:   wantRule,
+		},
+		{
+			name:     "version conflict",
+			slug:     "production",
+			give:     body,
+			current:  &upserted,
+			mockErr:  rulesvc.ErrVersionConflict,
+			wantCode: http.StatusConflict,
+			wantBody: `{"error":"the access rule has been modified since the expected version"}`,
+		},
+		{
+			name:     "invalid target option",
+			slug:     "production",
+			give:     body,
+			current:  &upserted,
+			mockErr:  fmt.Errorf("%w: 123 is not an option for accountId", rulesvc.ErrInvalidTargetOption),
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"error":"invalid target option: 123 is not an option for accountId"}`,
+		},
+		{
+			name:     "not an owner",
+			slug:     "production",
+			give:     body,
+			current:  &upserted,
+			mockErr:  rulesvc.ErrUserNotAuthorized,
+			wantCode: http.StatusUnauthorized,
+			wantBody: `{"error":"you don't have permission to manage this access rule"}`,
+		},
+		{
+			// the manager can manage rules for the provider in the request, but not for the rule's current provider.
+			name:     "scoped rule manager can't take over a rule for another provider",
+			slug:     "production",
+			give:     body,
+			current:  &onOkta,
+			roles:    deploy.AdminRoleAssignments{{Role: deploy.AdminRoleRuleManager, Providers: []string{"aws"}}},
+			notAdmin: true,
+			mockErr:  rulesvc.ErrUserNotAuthorized,
+			wantCode: http.StatusUnauthorized,
+			wantBody: `{"error":"you don't have permission to manage this access rule"}`,
+		},
+		{
+			name:     "owner can't move a rule to another provider",
+			slug:     "production",
+			give:     body,
+			current:  &onOkta,
+			notAdmin: true,
+			wantCode: http.StatusUnauthorized,
+			wantBody: `{"error":"you don't have permission to manage access rules for provider aws"}`,
+		},
+		{
+			name:     "invalid slug",
+			slug:     "rul_123",
+			give:     body,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocks.NewMockAccessRuleService(ctrl)
+			if tc.mockResult != "" || tc.mockErr != nil {
+				var res *rule.AccessRule
+				if tc.mockErr == nil {
+					res = &upserted
+				}
+				m.EXPECT().UpsertAccessRule(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, in rulesvc.UpsertOpts) (*rule.AccessRule, rulesvc.UpsertResult, error) {
+					assert.Equal(t, tc.slug, in.Slug)
+					assert.Equal(t, !tc.notAdmin, in.IsAdmin)
+					return res, tc.mockResult, tc.mockErr
+				})
+			}
+			db := ddbmock.New(t)
+			var getErr error
+			if tc.current == nil {
+				getErr = ddb.ErrNoItems
+			}
+			db.MockQueryWithErr(&storage.GetAccessRuleCurrent{Result: tc.current}, getErr)
+			a := API{Rules: m, DB: db}
+			handler := newTestServer(t, &a, withIsAdmin(!tc.notAdmin), withAdminRoles(tc.roles))
+
+			req, err := http.NewRequest("PUT", "/api/v1/admin/access-rule-slugs/"+tc.slug, strings.NewReader(tc.give))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Add("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+
+			data, err := io.ReadAll(rr.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.wantBody != "" {
+				assert.Equal(t, tc.wantBody, string(data))
+			}
+		})
+	}
+}
+
+func TestAdminDiffAccessRuleVersions(t *testing.T) {
+	type testcase struct {
+		name         string
+		notAdmin     bool
+		givenCurrent rule.AccessRule
+		givenVersion *rule.AccessRule
+		wantCode     int
+		wantBody     string
+	}
+
+	current := rule.AccessRule{ID: "rule1", Version: "ver_2", Current: true, Target: rule.Target{ProviderID: "aws"}}
+
+	testcases := []testcase{
+		{
+			name:         "ok",
+			givenCurrent: current,
+			givenVersion: &rule.AccessRule{ID: "rule1", Version: "ver_1", Target: rule.Target{ProviderID: "aws"}},
+			wantCode:     http.StatusOK,
+			wantBody:     `{"changes":[],"from":"ver_1","to":"ver_2"}`,
+		},
+		{
+			name:         "version not found",
+			givenCurrent: current,
+			wantCode:     http.StatusNotFound,
+			wantBody:     `{"error":"version ver_1 of this rule does not exist"}`,
+		},
+		{
+			name:         "user who doesn't own the rule",
+			notAdmin:     true,
+			givenCurrent: current,
+			wantCode:     http.StatusUnauthorized,
+			wantBody:     `{"error":"you don't have permission to manage this access rule"}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			db.MockQuery(&storage.GetAccessRuleCurrent{Result: &tc.givenCurrent})
+			if tc.givenVersion != nil {
+				db.MockQuery(&storage.GetAccessRuleVersion{Result: tc.givenVersion})
+			} else {
+				db.MockQueryWithErr(&storage.GetAccessRuleVersion{}, ddb.ErrNoItems)
+			}
+			a := API{DB: db}
+			handler := newTestServer(t, &a, withIsAdmin(!tc.notAdmin))
+
+			req, err := http.NewRequest("GET", "/api/v1/admin/access-rules/rule1/diff?from=ver_1&to=ver_2", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+			data, err := io.ReadAll(rr.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.wantBody, string(data))
+		})
+	}
+}
+
+func TestAdminRollbackAccessRule(t *testing.T) {
+	type testcase struct {
+		name         string
+		roles        deploy.AdminRoleAssignments
+		givenVersion rule.AccessRule
+		mockRollback *rule.AccessRule
+		mockErr      error
+		wantIsAdmin  bool
+		wantCode     int
+		wantBody     string
+	}
+
+	current := rule.AccessRule{ID: "rule1", Version: "ver_2", Current: true, Target: rule.Target{ProviderID: "aws"}}
+	old := rule.AccessRule{ID: "rule1", Version: "ver_1", Target: rule.Target{ProviderID: "aws"}}
+
+	testcases := []testcase{
+		{
+			name:         "ok",
+			roles:        deploy.AdminRoleAssignments{{Role: deploy.AdminRoleRuleManager}},
+			givenVersion: old,
+			mockRollback: &rule.AccessRule{ID: "rule1", Version: "ver_3", Current: true, Status: rule.ACTIVE, Target: rule.Target{ProviderID: "aws"}},
+			wantIsAdmin:  true,
+			wantCode:     http.StatusOK,
+			wantBody:     `{"approval":{"groups":[],"users":[]},"description":"","groups":null,"id":"rule1","isCurrent":true,"metadata":{"createdAt":"0001-01-01T00:00:00Z","createdBy":"","updatedAt":"0001-01-01T00:00:00Z","updatedBy":""},"name":"","status":"ACTIVE","target":{"provider":{"id":"aws","type":""},"with":{},"withSelectable":{}},"timeConstraints":{"maxDurationSeconds":0},"version":"ver_3"}`,
+		},
+		{
+			name:         "version is current",
+			roles:        deploy.AdminRoleAssignments{{Role: deploy.AdminRoleRuleManager}},
+			givenVersion: current,
+			mockErr:      rulesvc.ErrVersionIsCurrent,
+			wantIsAdmin:  true,
+			wantCode:     http.StatusBadRequest,
+			wantBody:     `{"error":"the version is already the current version of the access rule"}`,
+		},
+		{
+			name:         "earlier target has options which the provider no longer has",
+			roles:        deploy.AdminRoleAssignments{{Role: deploy.AdminRoleRuleManager}},
+			givenVersion: old,
+			mockErr:      fmt.Errorf("%w: permissionSetArn b", rulesvc.ErrInvalidTargetOption),
+			wantIsAdmin:  true,
+			wantCode:     http.StatusBadRequest,
+			wantBody:     `{"error":"invalid target option: permissionSetArn b"}`,
+		},
+		{
+			name:         "rule updated during the rollback",
+			roles:        deploy.AdminRoleAssignments{{Role: deploy.AdminRoleRuleManager}},
+			givenVersion: old,
+			mockErr:      rulesvc.ErrVersionConflict,
+			wantIsAdmin:  true,
+			wantCode:     http.StatusConflict,
+			wantBody:     `{"error":"the access rule has been modified since the expected version"}`,
+		},
+		{
+			name:         "restoring a target for another provider",
+			roles:        deploy.AdminRoleAssignments{{Role: deploy.AdminRoleRuleManager, Providers: []string{"aws"}}},
+			givenVersion: rule.AccessRule{ID: "rule1", Version: "ver_1", Target: rule.Target{ProviderID: "okta"}},
+			wantCode:     http.StatusUnauthorized,
+			wantBody:     `{"error":"you don't have permission to manage access rules for provider okta"}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocks.NewMockAccessRuleService(ctrl)
+			if tc.mockRollback != nil || tc.mockErr != nil {
+				m.EXPECT().RollbackAccessRule(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, in rulesvc.RollbackOpts) (*rule.AccessRule, error) {
+					assert.Equal(t, current, in.Current)
+					assert.Equal(t, tc.givenVersion, in.Version)
+					assert.Equal(t, tc.wantIsAdmin, in.IsAdmin)
+					return tc.mockRollback, tc.mockErr
+				})
+			}
+			db := ddbmock.New(t)
+			db.MockQuery(&storage.GetAccessRuleCurrent{Result: &current})
+			db.MockQuery(&storage.GetAccessRuleVersion{Result: &tc.givenVersion})
+			a := API{Rules: m, DB: db}
+			handler := newTestServer(t, &a, withIsAdmin(false), withAdminRoles(tc.roles))
+
+			req, err := http.NewRequest("POST", "/api/v1/admin/access-rules/rule1/versions/"+tc.givenVersion.Version+"/rollback", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+			data, err := io.ReadAll(rr.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.wantBody, string(data))
+		})
+	}
+}
+
+func TestAdminSimulateAccess(t *testing.T) {
+	type testcase struct {
+		name     string
+		notAdmin bool
+		body     string
+		mockUser *identity.User
+		wantCode int
+		wantBody string
+	}
+
+	r := rule.AccessRule{
+		ID:       "rule1",
+		Version:  "ver_1",
+		Name:     "Admin",
+		Current:  true,
+		Status:   rule.ACTIVE,
+		Groups:   []string{"grp_1"},
+		Target:   rule.Target{ProviderID: "aws", With: map[string]string{"accountId": "123456789012"}},
+		Approval: rule.Approval{Users: []string{"usr_2"}},
+	}
+	users := []identity.User{
+		{ID: "usr_1", Status: types.IdpStatusACTIVE, Groups: []string{"grp_1"}},
+		{ID: "usr_2", Status: types.IdpStatusACTIVE},
+	}
+
+	testcases := []testcase{
+		{
+			name:     "ok",
+			body:     `{"with":{"accountId":"123456789012"}}`,
+			wantCode: http.StatusOK,
+			wantBody: `{"results":[{"accessRule":{"description":"","id":"rule1","isCurrent":true,"name":"Admin","target":{"provider":{"id":"aws","type":""},"with":{"accountId":"123456789012"},"withSelectable":{}},"timeConstraints":{"maxDurationSeconds":0},"version":"ver_1"},"approval":"REVIEW_REQUIRED","approvers":["usr_2"],"requestableUsers":["usr_1"]}]}`,
+		},
+		{
+			name:     "user who can't request the rule",
+			body:     `{"userId":"usr_2"}`,
+			mockUser: &users[1],
+			wantCode: http.StatusOK,
+			wantBody: `{"results":[]}`,
+		},
+		{
+			name:     "user not found",
+			body:     `{"userId":"usr_3"}`,
+			wantCode: http.StatusNotFound,
+			wantBody: `{"error":"user does not exist"}`,
+		},
+		{
+			name:     "user who doesn't own the rule",
+			notAdmin: true,
+			body:     `{}`,
+			wantCode: http.StatusOK,
+			wantBody: `{"results":[]}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			db.MockQuery(&storage.ListAccessRulesForStatus{Result: []rule.AccessRule{r}})
+			db.MockQuery(&storage.ListUsersForStatus{Result: users})
+			if tc.mockUser != nil {
+				db.MockQuery(&storage.GetUser{Result: tc.mockUser})
+			} else {
+				db.MockQueryWithErr(&storage.GetUser{}, ddb.ErrNoItems)
+			}
+			a := API{DB: db}
+			handler := newTestServer(t, &a, withIsAdmin(!tc.notAdmin))
+
+			req, err := http.NewRequest("POST", "/api/v1/admin/access-simulation", strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Add("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+			data, err := io.ReadAll(rr.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.wantBody, string(data))
+		})
+	}
+}
+
 func TestAdminListAccessRules(t *testing.T) {
 	type testcase struct {
 		name string
@@ -269,7 +742,7 @@ func TestAdminListAccessRules(t *testing.T) {
 			db.MockQueryWithErr(&storage.ListCurrentAccessRules{Result: tc.rules}, tc.mockListErr)
 
 			a := API{DB: db}
-			handler := newTestServer(t, &a)
+			handler := newTestServer(t, &a, withIsAdmin(true))
 
 			req, err := http.NewRequest("GET", "/api/v1/admin/access-rules", nil)
 			if err != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/common-fate/granted-approvals/pkg/auth"
 	"github.com/common-fate/granted-approvals/pkg/cache"
 	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/common-fate/granted-approvals/pkg/eventhandler"
 	"github.com/common-fate/granted-approvals/pkg/gconfig"
 	"github.com/common-fate/granted-approvals/pkg/gevent"
 	"github.com/common-fate/granted-approvals/pkg/identity"
@@ -26,6 +27,7 @@ import (
 	"github.com/common-fate/granted-approvals/pkg/service/grantsvc"
 	"github.com/common-fate/granted-approvals/pkg/service/psetupsvc"
 	"github.com/common-fate/granted-approvals/pkg/service/rulesvc"
+	"github.com/common-fate/granted-approvals/pkg/storage/dbcond"
 
 	"github.com/common-fate/ddb"
 	"github.com/common-fate/granted-approvals/pkg/types"
@@ -60,12 +62,15 @@ type API struct {
 	ProviderSetup       ProviderSetupService
 	AccessHandlerClient ahtypes.ClientWithResponsesInterface
 	AdminGroup          string
+	AdminRoles          deploy.AdminRoleAssignments
 	IdentityProvider    string
 	Granter             accesssvc.Granter
 	Cache               CacheService
-	IdentitySyncer      auth.IdentitySyncer
+	IdentitySyncer      IdentitySyncer
 	// Set this to nil if cognito is not configured as the IDP for the deployment
 	Cognito CognitoService
+	// Events re-drives events which the event handler failed to process.
+	Events EventReplayer
 }
 
 //go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_cognito_service.go -package=mocks . CognitoService
@@ -87,7 +92,7 @@ type ProviderSetupService interface {
 
 // RequestServices can create Access Requests.
 type AccessService interface {
-	CreateRequest(ctx context.Context, user *identity.User, in types.CreateRequestRequest) (*accesssvc.CreateRequestResult, error)
+	CreateRequest(ctx context.Context, opts accesssvc.CreateRequestOpts) (*accesssvc.CreateRequestResult, error)
 	AddReviewAndGrantAccess(ctx context.Context, opts accesssvc.AddReviewOpts) (*accesssvc.AddReviewResult, error)
 	CancelRequest(ctx context.Context, opts accesssvc.CancelRequestOpts) error
 }
@@ -96,11 +101,29 @@ type AccessService interface {
 
 // AccessRuleService can create and get rules
 type AccessRuleService interface {
-	ArchiveAccessRule(ctx context.Context, user *identity.User, in rule.AccessRule) (*rule.AccessRule, error)
+	ArchiveAccessRule(ctx context.Context, user *identity.User, in rule.AccessRule, isAdmin bool) (*rule.AccessRule, error)
 	CreateAccessRule(ctx context.Context, user *identity.User, in types.CreateAccessRuleRequest) (*rule.AccessRule, error)
 	GetRule(ctx context.Context, ID string, user *identity.User, isAdmin bool) (*rule.AccessRule, error)
 	UpdateRule(ctx context.Context, in *rulesvc.UpdateOpts) (*rule.AccessRule, error)
+	UpsertAccessRule(ctx context.Context, in rulesvc.UpsertOpts) (*rule.AccessRule, rulesvc.UpsertResult, error)
+	RollbackAccessRule(ctx context.Context, in rulesvc.RollbackOpts) (*rule.AccessRule, error)
 }
+
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_event_replayer.go -package=mocks . EventReplayer
+
+// EventReplayer can replay events which the event handler failed to process.
+type EventReplayer interface {
+	Replay(ctx context.Context, eventID string) error
+}
+
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/mock_identity_syncer.go -package=mocks . IdentitySyncer
+
+// IdentitySyncer syncs users and groups from the identity provider.
+type IdentitySyncer interface {
+	auth.IdentitySyncer
+	SyncWithOpts(ctx context.Context, opts identitysync.RunOpts) (*identitysync.SyncResult, error)
+}
+
 type CacheService interface {
 	RefreshCachedProviderArgOptions(ctx context.Context, providerId string, argId string) (bool, []cache.ProviderOption, error)
 	LoadCachedProviderArgOptions(ctx context.Context, providerId string, argId string) (bool, []cache.ProviderOption, error)
@@ -113,16 +136,19 @@ type Opts struct {
 	Log                 *zap.SugaredLogger
 	AccessHandlerClient ahtypes.ClientWithResponsesInterface
 	EventSender         *gevent.Sender
-	IdentitySyncer      auth.IdentitySyncer
+	IdentitySyncer      IdentitySyncer
 	DeploymentConfig    deploy.DeployConfigReader
 	DynamoTable         string
 	PaginationKMSKeyARN string
 	AdminGroup          string
+	AdminRoles          deploy.AdminRoleAssignments
 	TemplateData        psetup.TemplateData
 	DeploymentSuffix    string
 	CognitoUserPoolID   string
 	IDPType             string
 	AdminGroupID        string
+	// Tickets looks up the tickets which access rules can require.
+	Tickets accesssvc.TicketGetter
 }
 
 // New creates a new API.
@@ -142,9 +168,18 @@ func New(ctx context.Context, opts Opts) (*API, error) {
 	if err != nil {
 		return nil, err
 	}
+	writer, err := dbcond.New(ctx, opts.DynamoTable)
+	if err != nil {
+		return nil, err
+	}
 
 	clk := clock.New()
 
+	eh, err := eventhandler.New(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
 	granter := grantsvc.New(grantsvc.GranterOpts{
 		AHClient:         opts.AccessHandlerClient,
 		DB:               db,
@@ -156,11 +191,13 @@ func New(ctx context.Context, opts Opts) (*API, error) {
 	a := API{
 		DeploymentConfig: opts.DeploymentConfig,
 		AdminGroup:       opts.AdminGroup,
+		AdminRoles:       opts.AdminRoles,
 		Access: &accesssvc.Service{
 			Clock:       clk,
 			DB:          db,
 			Granter:     granter,
 			EventPutter: opts.EventSender,
+			Tickets:     opts.Tickets,
 			Cache: &cachesvc.Service{
 				DB:                  db,
 				AccessHandlerClient: opts.AccessHandlerClient,
@@ -174,6 +211,12 @@ func New(ctx context.Context, opts Opts) (*API, error) {
 			Clock:    clk,
 			DB:       db,
 			AHClient: opts.AccessHandlerClient,
+			Cache: &cachesvc.Service{
+				DB:                  db,
+				AccessHandlerClient: opts.AccessHandlerClient,
+			},
+			Writer:      writer,
+			EventPutter: opts.EventSender,
 		},
 		ProviderSetup: &psetupsvc.Service{
 			DB:               db,
@@ -185,6 +228,7 @@ func New(ctx context.Context, opts Opts) (*API, error) {
 		Granter:             granter,
 		IdentitySyncer:      opts.IdentitySyncer,
 		IdentityProvider:    opts.IDPType,
+		Events:              eh,
 	}
 
 	// only initialise this if cognito is the IDP
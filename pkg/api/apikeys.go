@@ -0,0 +1,114 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/common-fate/apikit/apio"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/auth"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+// List API keys
+// (GET /api/v1/admin/api-keys)
+func (a *API) AdminListApiKeys(w http.ResponseWriter, r *http.Request, params types.AdminListApiKeysParams) {
+	ctx := r.Context()
+
+	queryOpts := []func(*ddb.QueryOpts){ddb.Limit(50)}
+	if params.NextToken != nil {
+		queryOpts = append(queryOpts, ddb.Page(*params.NextToken))
+	}
+
+	q := storage.ListAPIKeys{}
+	qr, err := a.DB.Query(ctx, &q, queryOpts...)
+	if err != nil {
+		apio.Error(ctx, w, err)
+		return
+	}
+
+	res := types.ListAPIKeysResponse{
+		ApiKeys: make([]types.APIKey, len(q.Result)),
+	}
+	if qr != nil && qr.NextPage != "" {
+		res.Next = &qr.NextPage
+	}
+
+	for i, k := range q.Result {
+		res.ApiKeys[i] = k.ToAPI()
+	}
+
+	apio.JSON(ctx, w, res, http.StatusOK)
+}
+
+// Create an API key
+// (POST /api/v1/admin/api-keys)
+func (a *API) AdminCreateApiKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var b types.AdminCreateApiKeyJSONRequestBody
+	err := apio.DecodeJSONBody(w, r, &b)
+	if err != nil {
+		apio.Error(ctx, w, apio.NewRequestError(err, http.StatusBadRequest))
+		return
+	}
+	// API keys can't create other API keys, so that a leaked key can't be used to keep access after it's revoked.
+	if auth.APIKeyFromContext(ctx) != nil {
+		apio.Error(ctx, w, apio.NewRequestError(errors.New("API keys can't be created with an API key"), http.StatusUnauthorized))
+		return
+	}
+
+	var description string
+	if b.Description != nil {
+		description = *b.Description
+	}
+	k, secret, err := identity.NewAPIKey(b.Name, description, b.Scope, auth.UserIDFromContext(ctx), time.Now())
+	if err != nil {
+		apio.Error(ctx, w, err)
+		return
+	}
+	err = a.DB.Put(ctx, &k)
+	if err != nil {
+		apio.Error(ctx, w, err)
+		return
+	}
+
+	res := types.CreateAPIKeyResponse{
+		ApiKey: k.ToAPI(),
+		Key:    secret,
+	}
+	apio.JSON(ctx, w, res, http.StatusCreated)
+}
+
+// Revoke an API key
+// (POST /api/v1/admin/api-keys/{keyId}/revoke)
+func (a *API) AdminRevokeApiKey(w http.ResponseWriter, r *http.Request, keyId string) {
+	ctx := r.Context()
+
+	q := storage.GetAPIKey{ID: keyId}
+	_, err := a.DB.Query(ctx, &q)
+	if err == ddb.ErrNoItems {
+		apio.Error(ctx, w, apio.NewRequestError(errors.New("API key not found"), http.StatusNotFound))
+		return
+	}
+	if err != nil {
+		apio.Error(ctx, w, err)
+		return
+	}
+
+	k := q.Result
+	if k.Status != types.APIKeyStatusREVOKED {
+		k.Status = types.APIKeyStatusREVOKED
+		k.UpdatedAt = time.Now()
+		err = a.DB.Put(ctx, k)
+		if err != nil {
+			apio.Error(ctx, w, err)
+			return
+		}
+	}
+
+	apio.JSON(ctx, w, k.ToAPI(), http.StatusOK)
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/ddb/ddbmock"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminListApiKeys(t *testing.T) {
+	now := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	key := identity.APIKey{ID: "key_123", Name: "ci", Scope: types.READONLY, Status: types.APIKeyStatusACTIVE, Prefix: "gak_abcdef", CreatedBy: "usr_123", CreatedAt: now}
+
+	db := ddbmock.New(t)
+	db.MockQuery(&storage.ListAPIKeys{Result: []identity.APIKey{key}})
+
+	a := API{DB: db}
+	handler := newTestServer(t, &a)
+
+	req, err := http.NewRequest("GET", "/api/v1/admin/api-keys", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	data, err := io.ReadAll(rr.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the hash of the key is never returned.
+	assert.Equal(t, `{"apiKeys":[{"createdAt":"2022-01-01T10:00:00Z","createdBy":"usr_123","description":"","id":"key_123","name":"ci","prefix":"gak_abcdef","scope":"READ_ONLY","status":"ACTIVE"}],"next":null}`, string(data))
+}
+
+func TestAdminCreateApiKey(t *testing.T) {
+	type testcase struct {
+		name     string
+		give     string
+		wantCode int
+		wantBody string
+	}
+
+	testcases := []testcase{
+		{
+			name:     "ok",
+			give:     `{"name":"ci","scope":"REQUEST_ON_BEHALF"}`,
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:     "invalid scope",
+			give:     `{"name":"ci","scope":"OWNER"}`,
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"error":"request body has an error: doesn't match the schema: Error at \"/scope\": value is not one of the allowed values"}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+
+			a := API{DB: db}
+			handler := newTestServer(t, &a, withRequestUser(identity.User{ID: "usr_123"}))
+
+			req, err := http.NewRequest("POST", "/api/v1/admin/api-keys", strings.NewReader(tc.give))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Add("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+			data, err := io.ReadAll(rr.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.wantBody != "" {
+				assert.Equal(t, tc.wantBody, string(data))
+				return
+			}
+
+			var res types.CreateAPIKeyResponse
+			err = json.Unmarshal(data, &res)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.True(t, identity.IsAPIKey(res.Key))
+			assert.True(t, strings.HasPrefix(res.Key, res.ApiKey.Prefix))
+			assert.Equal(t, types.REQUESTONBEHALF, res.ApiKey.Scope)
+			assert.Equal(t, "usr_123", res.ApiKey.CreatedBy)
+		})
+	}
+}
+
+func TestAdminRevokeApiKey(t *testing.T) {
+	type testcase struct {
+		name      string
+		key       *identity.APIKey
+		getKeyErr error
+		wantCode  int
+		wantBody  string
+	}
+
+	now := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	testcases := []testcase{
+		{
+			name:     "ok",
+			key:      &identity.APIKey{ID: "key_123", Name: "ci", Scope: types.ADMIN, Status: types.APIKeyStatusACTIVE, Prefix: "gak_abcdef", CreatedBy: "usr_123", CreatedAt: now},
+			wantCode: http.StatusOK,
+			wantBody: `{"createdAt":"2022-01-01T10:00:00Z","createdBy":"usr_123","description":"","id":"key_123","name":"ci","prefix":"gak_abcdef","scope":"ADMIN","status":"REVOKED"}`,
+		},
+		{
+			name:      "not found",
+			getKeyErr: ddb.ErrNoItems,
+			wantCode:  http.StatusNotFound,
+			wantBody:  `{"error":"API key not found"}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			db.MockQueryWithErr(&storage.GetAPIKey{ID: "key_123", Result: tc.key}, tc.getKeyErr)
+
+			a := API{DB: db}
+			handler := newTestServer(t, &a)
+
+			req, err := http.NewRequest("POST", "/api/v1/admin/api-keys/key_123/revoke", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+			data, err := io.ReadAll(rr.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.wantBody, string(data))
+		})
+	}
+}
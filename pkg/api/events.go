@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/common-fate/apikit/apio"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+// List failed events
+// (GET /api/v1/admin/events/failed)
+func (a *API) AdminListFailedEvents(w http.ResponseWriter, r *http.Request, params types.AdminListFailedEventsParams) {
+	ctx := r.Context()
+
+	queryOpts := []func(*ddb.QueryOpts){ddb.Limit(50)}
+	if params.NextToken != nil {
+		queryOpts = append(queryOpts, ddb.Page(*params.NextToken))
+	}
+
+	q := storage.ListDeadLetters{}
+	qr, err := a.DB.Query(ctx, &q, queryOpts...)
+	if err != nil {
+		apio.Error(ctx, w, err)
+		return
+	}
+
+	res := types.ListFailedEventsResponse{
+		Events: make([]types.FailedEvent, len(q.Result)),
+	}
+	if qr != nil && qr.NextPage != "" {
+		res.Next = &qr.NextPage
+	}
+
+	for i, e := range q.Result {
+		res.Events[i] = e.ToAPI()
+	}
+
+	apio.JSON(ctx, w, res, http.StatusOK)
+}
+
+// Replay a failed event
+// (POST /api/v1/admin/events/failed/{eventId}/replay)
+func (a *API) AdminReplayFailedEvent(w http.ResponseWriter, r *http.Request, eventId string) {
+	ctx := r.Context()
+
+	err := a.Events.Replay(ctx, eventId)
+	if err == ddb.ErrNoItems {
+		apio.Error(ctx, w, &apio.APIError{Err: err, Status: http.StatusNotFound})
+		return
+	}
+	if err != nil {
+		apio.Error(ctx, w, err)
+		return
+	}
+	apio.JSON(ctx, w, nil, http.StatusOK)
+}
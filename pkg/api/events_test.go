@@ -0,0 +1,141 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/ddb/ddbmock"
+	"github.com/common-fate/granted-approvals/pkg/api/mocks"
+	"github.com/common-fate/granted-approvals/pkg/eventlog"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminListFailedEvents(t *testing.T) {
+	type testcase struct {
+		name        string
+		deadLetters []eventlog.DeadLetter
+		mockErr     error
+		wantCode    int
+		wantBody    string
+	}
+
+	now := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	dl := eventlog.NewDeadLetter(events.CloudWatchEvent{ID: "evt_123", DetailType: "grant.activated", Source: "commonfate.io/granted"}, errors.New("request: req_123 does not have a grant"), now)
+
+	testcases := []testcase{
+		{
+			name:        "ok",
+			deadLetters: []eventlog.DeadLetter{dl},
+			wantCode:    http.StatusOK,
+			wantBody:    `{"events":[{"attempts":1,"detailType":"grant.activated","error":"request: req_123 does not have a grant","firstFailedAt":"2022-01-01T10:00:00Z","id":"evt_123","lastFailedAt":"2022-01-01T10:00:00Z","source":"commonfate.io/granted"}],"next":null}`,
+		},
+		{
+			name:     "no failed events",
+			wantCode: http.StatusOK,
+			wantBody: `{"events":[],"next":null}`,
+		},
+		{
+			name:     "internal error",
+			mockErr:  errors.New("internal error"),
+			wantCode: http.StatusInternalServerError,
+			wantBody: `{"error":"Internal Server Error"}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			db.MockQueryWithErr(&storage.ListDeadLetters{Result: tc.deadLetters}, tc.mockErr)
+
+			a := API{DB: db}
+			handler := newTestServer(t, &a)
+
+			req, err := http.NewRequest("GET", "/api/v1/admin/events/failed", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Add("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+
+			data, err := io.ReadAll(rr.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tc.wantBody, string(data))
+		})
+	}
+}
+
+func TestAdminReplayFailedEvent(t *testing.T) {
+	type testcase struct {
+		name      string
+		replayErr error
+		wantCode  int
+		wantBody  string
+	}
+
+	testcases := []testcase{
+		{
+			name:     "ok",
+			wantCode: http.StatusOK,
+			wantBody: `null`,
+		},
+		{
+			name:      "not found",
+			replayErr: ddb.ErrNoItems,
+			wantCode:  http.StatusNotFound,
+			wantBody:  `{"error":"item query returned no items"}`,
+		},
+		{
+			name:      "event failed again",
+			replayErr: errors.New("request: req_123 does not have a grant"),
+			wantCode:  http.StatusInternalServerError,
+			wantBody:  `{"error":"Internal Server Error"}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := mocks.NewMockEventReplayer(ctrl)
+			m.EXPECT().Replay(gomock.Any(), "evt_123").Return(tc.replayErr).Times(1)
+
+			a := API{Events: m}
+			handler := newTestServer(t, &a)
+
+			req, err := http.NewRequest("POST", "/api/v1/admin/events/failed/evt_123/replay", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Add("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+
+			data, err := io.ReadAll(rr.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tc.wantBody, string(data))
+		})
+	}
+}
@@ -1,21 +1,32 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/common-fate/apikit/apio"
+	"github.com/common-fate/granted-approvals/pkg/identity/identitysync"
 	"github.com/common-fate/granted-approvals/pkg/types"
 )
 
 // (POST /api/v1/admin/identity/sync)
-func (a *API) IdentitySync(w http.ResponseWriter, r *http.Request) {
+func (a *API) IdentitySync(w http.ResponseWriter, r *http.Request, params types.IdentitySyncParams) {
 	ctx := r.Context()
-	err := a.IdentitySyncer.Sync(ctx)
+	opts := identitysync.RunOpts{
+		DryRun: params.DryRun != nil && *params.DryRun,
+		Force:  params.Force != nil && *params.Force,
+	}
+	res, err := a.IdentitySyncer.SyncWithOpts(ctx, opts)
+	var te *identitysync.ArchiveThresholdError
+	if errors.As(err, &te) {
+		apio.Error(ctx, w, apio.NewRequestError(err, http.StatusBadRequest))
+		return
+	}
 	if err != nil {
 		apio.Error(ctx, w, err)
 		return
 	}
-	apio.JSON(ctx, w, nil, http.StatusOK)
+	apio.JSON(ctx, w, res.ToAPI(), http.StatusOK)
 }
 
 // Get identity configuration
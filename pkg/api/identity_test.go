@@ -0,0 +1,113 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/common-fate/granted-approvals/pkg/api/mocks"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/identity/identitysync"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentitySync(t *testing.T) {
+	type testcase struct {
+		name     string
+		query    string
+		wantOpts identitysync.RunOpts
+		result   *identitysync.SyncResult
+		mockErr  error
+		wantCode int
+		wantBody string
+	}
+
+	diff := identitysync.Diff{
+		UsersAdded:    []string{"alice@example.com"},
+		UsersArchived: []string{"bob@example.com"},
+		UsersChanged:  []identitysync.UserGroupsChange{{Email: "carol@example.com", GroupsAdded: []string{"admins"}, GroupsRemoved: []string{}}},
+		GroupsAdded:   []string{"admins"},
+		GroupsRemoved: []string{},
+		ActiveUsers:   2,
+	}
+
+	testcases := []testcase{
+		{
+			name:     "ok",
+			result:   &identitysync.SyncResult{Diff: diff, Offboarded: []identity.Offboarding{}},
+			wantCode: http.StatusOK,
+			wantBody: `{"archiveThresholdExceeded":false,"diff":{"activeUsers":2,"groupsAdded":["admins"],"groupsRemoved":[],"usersAdded":["alice@example.com"],"usersArchived":["bob@example.com"],"usersChanged":[{"email":"carol@example.com","groupsAdded":["admins"],"groupsRemoved":[]}]},"dryRun":false,"offboarded":[]}`,
+		},
+		{
+			name:     "dry run",
+			query:    "?dryRun=true",
+			wantOpts: identitysync.RunOpts{DryRun: true},
+			result:   &identitysync.SyncResult{DryRun: true, Diff: diff, ArchiveThresholdExceeded: true, Offboarded: []identity.Offboarding{}},
+			wantCode: http.StatusOK,
+			wantBody: `{"archiveThresholdExceeded":true,"diff":{"activeUsers":2,"groupsAdded":["admins"],"groupsRemoved":[],"usersAdded":["alice@example.com"],"usersArchived":["bob@example.com"],"usersChanged":[{"email":"carol@example.com","groupsAdded":["admins"],"groupsRemoved":[]}]},"dryRun":true,"offboarded":[]}`,
+		},
+		{
+			name:     "threshold exceeded",
+			mockErr:  &identitysync.ArchiveThresholdError{Archived: 2, Active: 3, Threshold: 50},
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"error":"sync aborted: 2 of 3 active users would be archived, which is more than the threshold of 50%. Run the sync with force enabled if this is expected"}`,
+		},
+		{
+			name:     "force",
+			query:    "?force=true",
+			wantOpts: identitysync.RunOpts{Force: true},
+			result:   &identitysync.SyncResult{Diff: diff, ArchiveThresholdExceeded: true, Offboarded: []identity.Offboarding{}},
+			wantCode: http.StatusOK,
+			wantBody: `{"archiveThresholdExceeded":true,"diff":{"activeUsers":2,"groupsAdded":["admins"],"groupsRemoved":[],"usersAdded":["alice@example.com"],"usersArchived":["bob@example.com"],"usersChanged":[{"email":"carol@example.com","groupsAdded":["admins"],"groupsRemoved":[]}]},"dryRun":false,"offboarded":[]}`,
+		},
+		{
+			name: "offboarded users",
+			result: &identitysync.SyncResult{
+				Diff:                diff,
+				Offboarded:          []identity.Offboarding{{UserID: "usr_bob", Email: "bob@example.com", RevokedGrants: []string{"req_1"}}},
+				OffboardingFailures: []identitysync.OffboardingFailure{{UserID: "usr_dave", Email: "dave@example.com", Error: "revoking grant"}},
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"archiveThresholdExceeded":false,"diff":{"activeUsers":2,"groupsAdded":["admins"],"groupsRemoved":[],"usersAdded":["alice@example.com"],"usersArchived":["bob@example.com"],"usersChanged":[{"email":"carol@example.com","groupsAdded":["admins"],"groupsRemoved":[]}]},"dryRun":false,"offboarded":[{"cancelledRequests":[],"email":"bob@example.com","removedReviews":[],"revokedGrants":["req_1"],"userId":"usr_bob"}],"offboardingFailures":[{"email":"dave@example.com","error":"revoking grant","userId":"usr_dave"}]}`,
+		},
+		{
+			name:     "internal error",
+			mockErr:  errors.New("internal error"),
+			wantCode: http.StatusInternalServerError,
+			wantBody: `{"error":"Internal Server Error"}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			syncer := mocks.NewMockIdentitySyncer(ctrl)
+			syncer.EXPECT().SyncWithOpts(gomock.Any(), tc.wantOpts).Return(tc.result, tc.mockErr)
+
+			a := API{IdentitySyncer: syncer}
+			handler := newTestServer(t, &a)
+
+			req, err := http.NewRequest("POST", "/api/v1/admin/identity/sync"+tc.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Add("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+
+			data, err := io.ReadAll(rr.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tc.wantBody, string(data))
+		})
+	}
+}
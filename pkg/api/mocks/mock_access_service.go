@@ -8,9 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
-	identity "github.com/common-fate/granted-approvals/pkg/identity"
 	accesssvc "github.com/common-fate/granted-approvals/pkg/service/accesssvc"
-	types "github.com/common-fate/granted-approvals/pkg/types"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -67,16 +65,16 @@ func (mr *MockAccessServiceMockRecorder) CancelRequest(arg0, arg1 interface{}) *
 }
 
 // CreateRequest mocks base method.
-func (m *MockAccessService) CreateRequest(arg0 context.Context, arg1 *identity.User, arg2 types.CreateRequestRequest) (*accesssvc.CreateRequestResult, error) {
+func (m *MockAccessService) CreateRequest(arg0 context.Context, arg1 accesssvc.CreateRequestOpts) (*accesssvc.CreateRequestResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRequest", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "CreateRequest", arg0, arg1)
 	ret0, _ := ret[0].(*accesssvc.CreateRequestResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateRequest indicates an expected call of CreateRequest.
-func (mr *MockAccessServiceMockRecorder) CreateRequest(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockAccessServiceMockRecorder) CreateRequest(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRequest", reflect.TypeOf((*MockAccessService)(nil).CreateRequest), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRequest", reflect.TypeOf((*MockAccessService)(nil).CreateRequest), arg0, arg1)
 }
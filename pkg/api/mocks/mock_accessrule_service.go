@@ -39,18 +39,18 @@ func (m *MockAccessRuleService) EXPECT() *MockAccessRuleServiceMockRecorder {
 }
 
 // ArchiveAccessRule mocks base method.
-func (m *MockAccessRuleService) ArchiveAccessRule(arg0 context.Context, arg1 *identity.User, arg2 rule.AccessRule) (*rule.AccessRule, error) {
+func (m *MockAccessRuleService) ArchiveAccessRule(arg0 context.Context, arg1 *identity.User, arg2 rule.AccessRule, arg3 bool) (*rule.AccessRule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ArchiveAccessRule", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "ArchiveAccessRule", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(*rule.AccessRule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ArchiveAccessRule indicates an expected call of ArchiveAccessRule.
-func (mr *MockAccessRuleServiceMockRecorder) ArchiveAccessRule(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockAccessRuleServiceMockRecorder) ArchiveAccessRule(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveAccessRule", reflect.TypeOf((*MockAccessRuleService)(nil).ArchiveAccessRule), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveAccessRule", reflect.TypeOf((*MockAccessRuleService)(nil).ArchiveAccessRule), arg0, arg1, arg2, arg3)
 }
 
 // CreateAccessRule mocks base method.
@@ -83,6 +83,21 @@ func (mr *MockAccessRuleServiceMockRecorder) GetRule(arg0, arg1, arg2, arg3 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRule", reflect.TypeOf((*MockAccessRuleService)(nil).GetRule), arg0, arg1, arg2, arg3)
 }
 
+// RollbackAccessRule mocks base method.
+func (m *MockAccessRuleService) RollbackAccessRule(arg0 context.Context, arg1 rulesvc.RollbackOpts) (*rule.AccessRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackAccessRule", arg0, arg1)
+	ret0, _ := ret[0].(*rule.AccessRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RollbackAccessRule indicates an expected call of RollbackAccessRule.
+func (mr *MockAccessRuleServiceMockRecorder) RollbackAccessRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackAccessRule", reflect.TypeOf((*MockAccessRuleService)(nil).RollbackAccessRule), arg0, arg1)
+}
+
 // UpdateRule mocks base method.
 func (m *MockAccessRuleService) UpdateRule(arg0 context.Context, arg1 *rulesvc.UpdateOpts) (*rule.AccessRule, error) {
 	m.ctrl.T.Helper()
@@ -97,3 +112,19 @@ func (mr *MockAccessRuleServiceMockRecorder) UpdateRule(arg0, arg1 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockAccessRuleService)(nil).UpdateRule), arg0, arg1)
 }
+
+// UpsertAccessRule mocks base method.
+func (m *MockAccessRuleService) UpsertAccessRule(arg0 context.Context, arg1 rulesvc.UpsertOpts) (*rule.AccessRule, rulesvc.UpsertResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertAccessRule", arg0, arg1)
+	ret0, _ := ret[0].(*rule.AccessRule)
+	ret1, _ := ret[1].(rulesvc.UpsertResult)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpsertAccessRule indicates an expected call of UpsertAccessRule.
+func (mr *MockAccessRuleServiceMockRecorder) UpsertAccessRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertAccessRule", reflect.TypeOf((*MockAccessRuleService)(nil).UpsertAccessRule), arg0, arg1)
+}
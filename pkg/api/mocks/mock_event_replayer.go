@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/common-fate/granted-approvals/pkg/api (interfaces: EventReplayer)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEventReplayer is a mock of EventReplayer interface.
+type MockEventReplayer struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventReplayerMockRecorder
+}
+
+// MockEventReplayerMockRecorder is the mock recorder for MockEventReplayer.
+type MockEventReplayerMockRecorder struct {
+	mock *MockEventReplayer
+}
+
+// NewMockEventReplayer creates a new mock instance.
+func NewMockEventReplayer(ctrl *gomock.Controller) *MockEventReplayer {
+	mock := &MockEventReplayer{ctrl: ctrl}
+	mock.recorder = &MockEventReplayerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventReplayer) EXPECT() *MockEventReplayerMockRecorder {
+	return m.recorder
+}
+
+// Replay mocks base method.
+func (m *MockEventReplayer) Replay(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replay", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replay indicates an expected call of Replay.
+func (mr *MockEventReplayerMockRecorder) Replay(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockEventReplayer)(nil).Replay), arg0, arg1)
+}
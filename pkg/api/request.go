@@ -212,12 +212,23 @@ func (a *API) UserCreateRequest(w http.ResponseWriter, r *http.Request) {
 
 	// create the request. The RequestCreator handles the validation
 	// and saving the request to the database.
-	result, err := a.Access.CreateRequest(ctx, u, incomingRequest)
+	result, err := a.Access.CreateRequest(ctx, accesssvc.CreateRequestOpts{
+		User:    u,
+		Request: incomingRequest,
+		// API keys can create requests on behalf of users, in which case the key is recorded as the actor.
+		ActorID: auth.ActorIDFromContext(ctx),
+	})
 	if err == accesssvc.ErrNoMatchingGroup {
 		// the user isn't authorized to make requests on this rule.
 		err = apio.NewRequestError(err, http.StatusUnauthorized)
 	} else if err == accesssvc.ErrRuleNotFound {
 		err = apio.NewRequestError(fmt.Errorf("access rule %s not found", incomingRequest.AccessRuleId), http.StatusNotFound)
+	} else if errors.Is(err, accesssvc.ErrRequestDeniedByPolicy) {
+		// the denied request is saved, so the user can see it in their requests.
+		err = apio.NewRequestError(err, http.StatusForbidden)
+	} else if err == accesssvc.ErrNoReviewers {
+		// the declined request is saved, so the user can see it in their requests.
+		err = apio.NewRequestError(err, http.StatusBadRequest)
 	}
 
 	if err != nil {
@@ -370,7 +381,8 @@ func (a *API) GetAccessInstructions(w http.ResponseWriter, r *http.Request, requ
 func (a *API) ListRequestEvents(w http.ResponseWriter, r *http.Request, requestId string) {
 	ctx := r.Context()
 	u := auth.UserFromContext(ctx)
-	canView := auth.IsAdmin(ctx)
+	// auditors can view the events of every request.
+	canView := auth.HasPermission(ctx, auth.PermissionReadRequests)
 	q := storage.GetRequest{ID: requestId}
 	_, err := a.DB.Query(ctx, &q)
 	if err == ddb.ErrNoItems {
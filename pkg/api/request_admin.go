@@ -1,8 +1,10 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/common-fate/apikit/apio"
 	"github.com/common-fate/ddb"
@@ -12,65 +14,91 @@ import (
 	"github.com/common-fate/granted-approvals/pkg/types"
 )
 
+// adminRequestsPageSize is the number of requests fetched for each page of AdminListRequests.
+const adminRequestsPageSize = 50
+
+// adminRequestsMaxQueries is the most pages of requests which are fetched for each page of AdminListRequests.
+const adminRequestsMaxQueries = 10
+
 // "/api/v1/admin/requests"
+//
+// When the requests are filtered, further pages are fetched until the page has at least adminRequestsPageSize requests or there are no more requests,
+// so that the next token is returned only if there may be more matching requests.
+// At most adminRequestsMaxQueries pages are fetched, so a page may have fewer requests when few requests match,
+// in which case the next token continues from where the page stopped.
 func (a *API) AdminListRequests(w http.ResponseWriter, r *http.Request, params types.AdminListRequestsParams) {
 	ctx := r.Context()
 
-	var err error
-	var dbRes []access.Request
-	var qR *ddb.QueryResult
-	var next *string
-
-	queryOpts := []func(*ddb.QueryOpts){ddb.Limit(50)}
-	if params.NextToken != nil {
-		queryOpts = append(queryOpts, ddb.Page(*params.NextToken))
-	}
-	if params.Status != nil {
-		q := storage.ListRequestsForStatus{Status: access.Status(*params.Status)}
-		qR, err := a.DB.Query(ctx, &q, queryOpts...)
-		if err == ddb.ErrNoItems {
-			apio.Error(ctx, w, apio.NewRequestError(err, http.StatusNotFound))
-			return
-		}
+	var filters []func([]access.Request) []access.Request
+	if !auth.HasPermission(ctx, auth.PermissionReadRequests) {
+		// rule owners only see the requests for the rules which they own.
+		owned, err := a.ownedRuleRequestsFilter(ctx)
 		if err != nil {
 			apio.Error(ctx, w, err)
 			return
 		}
-		if qR.NextPage != "" {
-			next = &qR.NextPage
-		}
-		dbRes = q.Result
-	} else {
-		q := storage.ListRequests{}
-		qR, err = a.DB.Query(ctx, &q, queryOpts...)
+		filters = append(filters, owned)
+	}
+	if params.FieldValue != nil && *params.FieldValue != "" {
+		filters = append(filters, func(requests []access.Request) []access.Request {
+			return filterRequestsByField(requests, params.FieldId, *params.FieldValue)
+		})
+	}
 
-		if err == ddb.ErrNoItems {
+	dbRes := []access.Request{}
+	next := params.NextToken
+	for i := 0; i < adminRequestsMaxQueries; i++ {
+		queryOpts := []func(*ddb.QueryOpts){ddb.Limit(adminRequestsPageSize)}
+		if next != nil {
+			queryOpts = append(queryOpts, ddb.Page(*next))
+		}
+		var page []access.Request
+		var qR *ddb.QueryResult
+		var err error
+		if params.Status != nil {
+			q := storage.ListRequestsForStatus{Status: access.Status(*params.Status)}
+			qR, err = a.DB.Query(ctx, &q, queryOpts...)
+			page = q.Result
+		} else {
+			q := storage.ListRequests{}
+			qR, err = a.DB.Query(ctx, &q, queryOpts...)
+			page = q.Result
+		}
+		if err == ddb.ErrNoItems && i == 0 {
 			apio.Error(ctx, w, apio.NewRequestError(err, http.StatusNotFound))
 			return
 		}
+		if err == ddb.ErrNoItems {
+			next = nil
+			break
+		}
 		if err != nil {
 			apio.Error(ctx, w, err)
 			return
 		}
 
+		for _, f := range filters {
+			page = f(page)
+		}
+		dbRes = append(dbRes, page...)
+
+		next = nil
 		if qR.NextPage != "" {
 			next = &qR.NextPage
 		}
-
-		dbRes = q.Result
+		if next == nil || len(dbRes) >= adminRequestsPageSize {
+			break
+		}
 	}
 
-	// var endToken int
 	res := types.ListRequestsResponse{
 		Requests: make([]types.Request, len(dbRes)),
+		Next:     next,
 	}
-
 	for i, r := range dbRes {
 		res.Requests[i] = r.ToAPI()
 	}
 
-	res.Next = next
-
 	apio.JSON(ctx, w, res, http.StatusOK)
 }
 
@@ -88,6 +116,19 @@ func (a *API) AdminGetRequest(w http.ResponseWriter, r *http.Request, requestId
 		apio.Error(ctx, w, err)
 		return
 	}
+	if !auth.HasPermission(ctx, auth.PermissionReadRequests) {
+		// rule owners can view the requests for the rules which they own.
+		current := storage.GetAccessRuleCurrent{ID: q.Result.Rule}
+		_, err = a.DB.Query(ctx, &current)
+		if err != nil && err != ddb.ErrNoItems {
+			apio.Error(ctx, w, err)
+			return
+		}
+		if current.Result == nil || !current.Result.IsOwner(u) {
+			apio.ErrorString(ctx, w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+	}
 	qr := storage.GetAccessRuleVersion{ID: q.Result.Rule, VersionID: q.Result.RuleVersion}
 	_, err = a.DB.Query(ctx, &qr)
 	// Any error fetching the access rule is an internal server error because it should exist if the request exists
@@ -101,3 +142,43 @@ func (a *API) AdminGetRequest(w http.ResponseWriter, r *http.Request, requestId
 	}
 	apio.JSON(ctx, w, q.Result.ToAPIDetail(*qr.Result, q.Result.RequestedBy != u.ID), http.StatusOK)
 }
+
+// ownedRuleRequestsFilter returns a filter which keeps the requests for the access rules which the current user owns.
+func (a *API) ownedRuleRequestsFilter(ctx context.Context) (func([]access.Request) []access.Request, error) {
+	owned, err := a.ownedRules(ctx, auth.UserFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	ruleIDs := make(map[string]bool)
+	for _, r := range owned {
+		ruleIDs[r.ID] = true
+	}
+	return func(requests []access.Request) []access.Request {
+		res := []access.Request{}
+		for _, r := range requests {
+			if ruleIDs[r.Rule] {
+				res = append(res, r)
+			}
+		}
+		return res
+	}, nil
+}
+
+// filterRequestsByField returns the requests with a request field containing the value, ignoring case.
+// If fieldID is nil, every request field is searched.
+func filterRequestsByField(requests []access.Request, fieldID *string, value string) []access.Request {
+	value = strings.ToLower(value)
+	res := []access.Request{}
+	for _, r := range requests {
+		for id, v := range r.Data.Fields {
+			if fieldID != nil && *fieldID != id {
+				continue
+			}
+			if strings.Contains(strings.ToLower(v), value) {
+				res = append(res, r)
+				break
+			}
+		}
+	}
+	return res
+}
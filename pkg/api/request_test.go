@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -18,6 +20,7 @@ import (
 	"github.com/common-fate/granted-approvals/pkg/rule"
 	"github.com/common-fate/granted-approvals/pkg/service/accesssvc"
 	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/types"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
@@ -78,13 +81,20 @@ func TestUserCreateRequest(t *testing.T) {
 			wantCode:      http.StatusUnauthorized,
 			wantBody:      `{"error":"user was not in a matching group for the access rule"}`,
 		},
+		{
+			name:          "denied by policy",
+			give:          `{"timing":{"durationSeconds": 10}, "accessRuleId": "rul_123"}`,
+			mockCreateErr: fmt.Errorf("%w: prod access is not allowed", accesssvc.ErrRequestDeniedByPolicy),
+			wantCode:      http.StatusForbidden,
+			wantBody:      `{"error":"the request was denied by the policy of the access rule: prod access is not allowed"}`,
+		},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockAccess := mocks.NewMockAccessService(ctrl)
-			mockAccess.EXPECT().CreateRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(tc.mockCreate, tc.mockCreateErr).AnyTimes()
+			mockAccess.EXPECT().CreateRequest(gomock.Any(), gomock.Any()).Return(tc.mockCreate, tc.mockCreateErr).AnyTimes()
 			a := API{Access: mockAccess}
 			handler := newTestServer(t, &a)
 
@@ -633,3 +643,178 @@ func TestUserListRequestEvents(t *testing.T) {
 	}
 
 }
+
+func TestAdminListRequests(t *testing.T) {
+	type testcase struct {
+		name         string
+		isAdmin      bool
+		query        string
+		currentRules []rule.AccessRule
+		wantCode     int
+		wantBody     string
+	}
+
+	requests := []access.Request{
+		{ID: "req_1", Status: access.PENDING, Rule: "owned", RuleVersion: "v1"},
+		{ID: "req_2", Status: access.PENDING, Rule: "other", RuleVersion: "v1", Data: access.RequestData{Fields: map[string]string{"ticketId": "OPS-123"}}},
+	}
+
+	testcases := []testcase{
+		{
+			name:     "admins see every request",
+			isAdmin:  true,
+			wantCode: http.StatusOK,
+			wantBody: `{"next":null,"requests":[{"accessRule":{"id":"owned","version":"v1"},"id":"req_1","requestedAt":"0001-01-01T00:00:00Z","requestor":"","selectedWith":{},"status":"PENDING","timing":{"durationSeconds":0},"updatedAt":"0001-01-01T00:00:00Z"},{"accessRule":{"id":"other","version":"v1"},"fields":{"ticketId":"OPS-123"},"id":"req_2","requestedAt":"0001-01-01T00:00:00Z","requestor":"","selectedWith":{},"status":"PENDING","timing":{"durationSeconds":0},"updatedAt":"0001-01-01T00:00:00Z"}]}`,
+		},
+		{
+			name: "owners see the requests for their rules",
+			currentRules: []rule.AccessRule{
+				{ID: "owned", Owners: rule.Owners{Groups: []string{"platform"}}},
+				{ID: "other", Owners: rule.Owners{Users: []string{"usr_other"}}},
+			},
+			wantCode: http.StatusOK,
+			wantBody: `{"next":null,"requests":[{"accessRule":{"id":"owned","version":"v1"},"id":"req_1","requestedAt":"0001-01-01T00:00:00Z","requestor":"","selectedWith":{},"status":"PENDING","timing":{"durationSeconds":0},"updatedAt":"0001-01-01T00:00:00Z"}]}`,
+		},
+		{
+			name:     "filter by request field",
+			isAdmin:  true,
+			query:    "?fieldId=ticketId&fieldValue=ops-1",
+			wantCode: http.StatusOK,
+			wantBody: `{"next":null,"requests":[{"accessRule":{"id":"other","version":"v1"},"fields":{"ticketId":"OPS-123"},"id":"req_2","requestedAt":"0001-01-01T00:00:00Z","requestor":"","selectedWith":{},"status":"PENDING","timing":{"durationSeconds":0},"updatedAt":"0001-01-01T00:00:00Z"}]}`,
+		},
+		{
+			name:     "filter by a request field which doesn't match",
+			isAdmin:  true,
+			query:    "?fieldId=incident&fieldValue=ops-1",
+			wantCode: http.StatusOK,
+			wantBody: `{"next":null,"requests":[]}`,
+		},
+		{
+			name:     "users who don't own rules see no requests",
+			wantCode: http.StatusOK,
+			wantBody: `{"next":null,"requests":[]}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			db.MockQueryWithErrWithResult(&storage.ListRequests{Result: requests}, &ddb.QueryResult{}, nil)
+			db.MockQuery(&storage.ListCurrentAccessRules{Result: tc.currentRules})
+			a := API{DB: db}
+			handler := newTestServer(t, &a, withRequestUser(identity.User{ID: "usr_123", Groups: []string{"platform"}}), withIsAdmin(tc.isAdmin))
+
+			req, err := http.NewRequest("GET", "/api/v1/admin/requests"+tc.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Add("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+
+			data, err := io.ReadAll(rr.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.wantBody, string(data))
+		})
+	}
+}
+
+func TestAdminListRequestsFilterPages(t *testing.T) {
+	// the second page fills the response, so the third page isn't fetched
+	var page2 []access.Request
+	for i := 0; i < adminRequestsPageSize; i++ {
+		page2 = append(page2, access.Request{ID: fmt.Sprintf("req_%d", i+2), Status: access.PENDING, Rule: "rule1", Data: access.RequestData{Fields: map[string]string{"ticketId": "OPS-123"}}})
+	}
+	pages := map[string]pagedRequests{
+		// the first page has no requests which match the filter
+		"":      {requests: []access.Request{{ID: "req_1", Status: access.PENDING, Rule: "rule1"}}, next: "page2"},
+		"page2": {requests: page2, next: "page3"},
+	}
+
+	db := pagedRequestsDB{Client: ddbmock.New(t), pages: pages}
+	a := API{DB: &db}
+	handler := newTestServer(t, &a, withIsAdmin(true))
+
+	req, err := http.NewRequest("GET", "/api/v1/admin/requests?fieldValue=ops-", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var got types.ListRequestsResponse
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, got.Requests, adminRequestsPageSize)
+	assert.Equal(t, "req_2", got.Requests[0].ID)
+	assert.Equal(t, "page3", *got.Next)
+	assert.Equal(t, []string{"", "page2"}, db.fetched)
+}
+
+func TestAdminListRequestsMaxQueries(t *testing.T) {
+	// each page has a single request, so pages are fetched until the limit is reached.
+	pages := map[string]pagedRequests{}
+	for i := 0; i < adminRequestsMaxQueries+5; i++ {
+		token := ""
+		if i > 0 {
+			token = fmt.Sprintf("page%d", i)
+		}
+		pages[token] = pagedRequests{requests: []access.Request{{ID: fmt.Sprintf("req_%d", i), Status: access.PENDING, Rule: "rule1"}}, next: fmt.Sprintf("page%d", i+1)}
+	}
+
+	db := pagedRequestsDB{Client: ddbmock.New(t), pages: pages}
+	a := API{DB: &db}
+	handler := newTestServer(t, &a, withIsAdmin(true))
+
+	req, err := http.NewRequest("GET", "/api/v1/admin/requests", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var got types.ListRequestsResponse
+	err = json.NewDecoder(rr.Body).Decode(&got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, got.Requests, adminRequestsMaxQueries)
+	assert.Equal(t, fmt.Sprintf("page%d", adminRequestsMaxQueries), *got.Next)
+	assert.Len(t, db.fetched, adminRequestsMaxQueries)
+}
+
+type pagedRequests struct {
+	requests []access.Request
+	next     string
+}
+
+// pagedRequestsDB returns a page of requests for each page token.
+type pagedRequestsDB struct {
+	*ddbmock.Client
+	pages   map[string]pagedRequests
+	fetched []string
+}
+
+func (d *pagedRequestsDB) Query(ctx context.Context, qb ddb.QueryBuilder, opts ...func(*ddb.QueryOpts)) (*ddb.QueryResult, error) {
+	q, ok := qb.(*storage.ListRequests)
+	if !ok {
+		return d.Client.Query(ctx, qb, opts...)
+	}
+	var o ddb.QueryOpts
+	for _, opt := range opts {
+		opt(&o)
+	}
+	d.fetched = append(d.fetched, o.PageToken)
+	p := d.pages[o.PageToken]
+	q.Result = p.requests
+	return &ddb.QueryResult{NextPage: p.next}, nil
+}
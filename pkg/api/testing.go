@@ -7,6 +7,7 @@ import (
 	"github.com/common-fate/apikit/logger"
 	"github.com/common-fate/apikit/openapi"
 	"github.com/common-fate/granted-approvals/pkg/auth"
+	"github.com/common-fate/granted-approvals/pkg/deploy"
 	"github.com/common-fate/granted-approvals/pkg/identity"
 	"github.com/common-fate/granted-approvals/pkg/types"
 	"github.com/go-chi/chi/v5"
@@ -18,6 +19,7 @@ type testOptions struct {
 	// comes from this user.
 	RequestUser identity.User
 	IsAdmin     bool
+	AdminRoles  deploy.AdminRoleAssignments
 }
 
 func withRequestUser(user identity.User) func(*testOptions) {
@@ -32,6 +34,12 @@ func withIsAdmin(isAdmin bool) func(*testOptions) {
 	}
 }
 
+func withAdminRoles(roles deploy.AdminRoleAssignments) func(*testOptions) {
+	return func(to *testOptions) {
+		to.AdminRoles = roles
+	}
+}
+
 // newTestServer creates a configured API server for use in Go tests.
 // The default time of the server is 1st Jan 2022, 10:00am UTC.
 // This can be overriden by providing a custom clock with the withClock() option.
@@ -55,17 +63,18 @@ func newTestServer(t *testing.T, a *API, opts ...func(*testOptions)) http.Handle
 	r := chi.NewRouter()
 	r.Use(logger.Middleware(log))
 	r.Use(openapi.Validator(swagger))
-	r.Use(testAuthMiddleware(to.RequestUser, to.IsAdmin))
+	r.Use(testAuthMiddleware(to.RequestUser, to.IsAdmin, to.AdminRoles))
 
 	return a.Handler(r)
 }
 
-func testAuthMiddleware(user identity.User, isAdmin bool) func(next http.Handler) http.Handler {
+func testAuthMiddleware(user identity.User, isAdmin bool, adminRoles deploy.AdminRoleAssignments) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := auth.TestingSetUserID(r.Context(), user.ID)
 			ctx = auth.TestingSetUser(ctx, user)
 			ctx = auth.TestingSetIsAdmin(ctx, isAdmin)
+			ctx = auth.TestingSetAdminRoles(ctx, adminRoles)
 			r = r.WithContext(ctx)
 			next.ServeHTTP(w, r)
 		})
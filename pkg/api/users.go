@@ -8,6 +8,8 @@ import (
 	"github.com/common-fate/ddb"
 	"github.com/common-fate/granted-approvals/pkg/auth"
 	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/notifications"
+	"github.com/common-fate/granted-approvals/pkg/notifiers"
 	"github.com/common-fate/granted-approvals/pkg/service/cognitosvc"
 	"github.com/common-fate/granted-approvals/pkg/storage"
 	"github.com/common-fate/granted-approvals/pkg/types"
@@ -70,13 +72,50 @@ func (a *API) GetMe(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	u := auth.UserFromContext(ctx)
 	admin := auth.IsAdmin(ctx)
+	prefs, err := notifiers.GetPreferences(ctx, a.DB, u.ID)
+	if err != nil {
+		apio.Error(ctx, w, err)
+		return
+	}
 	res := types.AuthUserResponse{
-		User:    u.ToAPI(),
-		IsAdmin: admin,
+		User:                    u.ToAPI(),
+		IsAdmin:                 admin,
+		NotificationPreferences: prefs.ToAPI(),
+	}
+	if roles := auth.AdminRolesFromContext(ctx); len(roles) > 0 {
+		adminRoles := make([]types.AdminRole, len(roles))
+		for i, ra := range roles {
+			adminRoles[i] = types.AdminRole{Role: types.AdminRoleRole(ra.Role), Group: ra.Group}
+			if len(ra.Providers) > 0 {
+				providers := ra.Providers
+				adminRoles[i].Providers = &providers
+			}
+		}
+		res.AdminRoles = &adminRoles
 	}
 	apio.JSON(ctx, w, res, http.StatusOK)
 }
 
+// Update notification preferences for the current user
+// (PUT /api/v1/users/me/notification-preferences)
+func (a *API) UpdateMyNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	u := auth.UserFromContext(ctx)
+	var b types.UpdateMyNotificationPreferencesJSONRequestBody
+	err := apio.DecodeJSONBody(w, r, &b)
+	if err != nil {
+		apio.Error(ctx, w, apio.NewRequestError(err, http.StatusBadRequest))
+		return
+	}
+	prefs := notifications.PreferencesFromAPI(u.ID, b)
+	err = a.DB.Put(ctx, &prefs)
+	if err != nil {
+		apio.Error(ctx, w, err)
+		return
+	}
+	apio.JSON(ctx, w, prefs.ToAPI(), http.StatusOK)
+}
+
 // Create User
 // (POST /api/v1/admin/users)
 func (a *API) CreateUser(w http.ResponseWriter, r *http.Request) {
@@ -91,6 +130,10 @@ func (a *API) CreateUser(w http.ResponseWriter, r *http.Request) {
 		apio.Error(ctx, w, apio.NewRequestError(err, http.StatusBadRequest))
 		return
 	}
+	if createUserRequest.IsAdmin && !auth.IsAdmin(ctx) {
+		apio.Error(ctx, w, errAdminGroupNotPermitted)
+		return
+	}
 	user, err := a.Cognito.CreateUser(ctx, cognitosvc.CreateUserOpts{
 		FirstName: createUserRequest.FirstName,
 		LastName:  createUserRequest.LastName,
@@ -120,6 +163,22 @@ func (a *API) UpdateUser(w http.ResponseWriter, r *http.Request, userId string)
 		apio.Error(ctx, w, apio.NewRequestError(err, http.StatusBadRequest))
 		return
 	}
+	if !auth.IsAdmin(ctx) {
+		q := storage.GetUser{ID: userId}
+		_, err = a.DB.Query(ctx, &q)
+		if err == ddb.ErrNoItems {
+			apio.Error(ctx, w, &apio.APIError{Err: errors.New("user does not exist"), Status: http.StatusNotFound})
+			return
+		}
+		if err != nil {
+			apio.Error(ctx, w, err)
+			return
+		}
+		if a.changesPrivilegedGroups(q.Result.Groups, updateUserRequest.Groups) {
+			apio.Error(ctx, w, errAdminGroupNotPermitted)
+			return
+		}
+	}
 	user, err := a.Cognito.UpdateUserGroups(ctx, cognitosvc.UpdateUserGroupsOpts{
 		Groups: updateUserRequest.Groups,
 		UserID: userId,
@@ -130,3 +189,44 @@ func (a *API) UpdateUser(w http.ResponseWriter, r *http.Request, userId string)
 	}
 	apio.JSON(ctx, w, user.ToAPI(), http.StatusOK)
 }
+
+// errAdminGroupNotPermitted is returned when a user who isn't an admin, such as an identity manager,
+// tries to add users to or remove users from a group which grants admin access.
+var errAdminGroupNotPermitted = apio.NewRequestError(errors.New("only admins can change the membership of the admin group or of groups with admin roles"), http.StatusUnauthorized)
+
+// changesPrivilegedGroups returns true if changing a user's groups from before to after
+// adds or removes the admin group or a group which has an admin role.
+func (a *API) changesPrivilegedGroups(before, after []string) bool {
+	for _, g := range before {
+		if !contains(after, g) && a.isPrivilegedGroup(g) {
+			return true
+		}
+	}
+	for _, g := range after {
+		if !contains(before, g) && a.isPrivilegedGroup(g) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *API) isPrivilegedGroup(group string) bool {
+	if group == a.AdminGroup {
+		return true
+	}
+	for _, ra := range a.AdminRoles {
+		if ra.Group == group {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(set []string, str string) bool {
+	for _, s := range set {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
@@ -8,9 +8,12 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/common-fate/ddb"
 	"github.com/common-fate/ddb/ddbmock"
 	"github.com/common-fate/granted-approvals/pkg/api/mocks"
+	"github.com/common-fate/granted-approvals/pkg/deploy"
 	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/notifications"
 	"github.com/common-fate/granted-approvals/pkg/service/cognitosvc"
 	"github.com/common-fate/granted-approvals/pkg/storage"
 	"github.com/common-fate/granted-approvals/pkg/types"
@@ -146,6 +149,7 @@ func TestPostApiV1AdminUsers(t *testing.T) {
 		wantCode              int
 		wantBody              string
 		notEnabled            bool
+		notAdmin              bool
 		expectCreateUserOpts  *cognitosvc.CreateUserOpts
 		withCreatedUser       *identity.User
 		expectCreateUserError error
@@ -175,6 +179,12 @@ func TestPostApiV1AdminUsers(t *testing.T) {
 			expectCreateUserError: errors.New("random error"),
 			wantBody:              `{"error":"Internal Server Error"}`,
 		},
+		{name: "identity manager can't create admins",
+			body:     `{"firstName":"test","lastName":"user","email":"test@test.com","isAdmin":true}`,
+			notAdmin: true,
+			wantCode: http.StatusUnauthorized,
+			wantBody: `{"error":"only admins can change the membership of the admin group or of groups with admin roles"}`,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -185,12 +195,12 @@ func TestPostApiV1AdminUsers(t *testing.T) {
 				ctrl := gomock.NewController(t)
 				defer ctrl.Finish()
 				m := mocks.NewMockCognitoService(ctrl)
+				a.Cognito = m
 				if tc.expectCreateUserOpts != nil {
-					a.Cognito = m
 					m.EXPECT().CreateUser(gomock.Any(), gomock.Eq(*tc.expectCreateUserOpts)).Times(1).Return(tc.withCreatedUser, tc.expectCreateUserError)
 				}
 			}
-			handler := newTestServer(t, &a)
+			handler := newTestServer(t, &a, withIsAdmin(!tc.notAdmin))
 
 			req, err := http.NewRequest("POST", "/api/v1/admin/users", strings.NewReader(tc.body))
 			if err != nil {
@@ -213,3 +223,214 @@ func TestPostApiV1AdminUsers(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateUser(t *testing.T) {
+	type testcase struct {
+		name               string
+		body               string
+		notAdmin           bool
+		givenUser          *identity.User
+		expectUpdateGroups []string
+		wantCode           int
+		wantBody           string
+	}
+
+	adminGroup := "test_admins"
+	roles := deploy.AdminRoleAssignments{{Group: "rule_managers", Role: deploy.AdminRoleRuleManager}}
+	user := identity.User{ID: "1234", Email: "test@test.com", Groups: []string{"everyone"}, Status: types.IdpStatusACTIVE}
+	updated := identity.User{ID: "1234", Email: "test@test.com", Groups: []string{"everyone", "developers"}, Status: types.IdpStatusACTIVE}
+
+	testcases := []testcase{
+		{
+			name:               "identity manager adds a group",
+			body:               `{"groups":["everyone","developers"]}`,
+			notAdmin:           true,
+			givenUser:          &user,
+			expectUpdateGroups: []string{"everyone", "developers"},
+			wantCode:           http.StatusOK,
+			wantBody:           `{"email":"test@test.com","firstName":"","groups":["everyone","developers"],"id":"1234","lastName":"","picture":"","status":"ACTIVE","updatedAt":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:      "identity manager adds the admin group",
+			body:      `{"groups":["everyone","test_admins"]}`,
+			notAdmin:  true,
+			givenUser: &user,
+			wantCode:  http.StatusUnauthorized,
+			wantBody:  `{"error":"only admins can change the membership of the admin group or of groups with admin roles"}`,
+		},
+		{
+			name:      "identity manager adds a group with an admin role",
+			body:      `{"groups":["everyone","rule_managers"]}`,
+			notAdmin:  true,
+			givenUser: &user,
+			wantCode:  http.StatusUnauthorized,
+			wantBody:  `{"error":"only admins can change the membership of the admin group or of groups with admin roles"}`,
+		},
+		{
+			name:      "identity manager removes the admin group",
+			body:      `{"groups":["everyone"]}`,
+			notAdmin:  true,
+			givenUser: &identity.User{ID: "1234", Groups: []string{"everyone", "test_admins"}},
+			wantCode:  http.StatusUnauthorized,
+			wantBody:  `{"error":"only admins can change the membership of the admin group or of groups with admin roles"}`,
+		},
+		{
+			name:     "identity manager updates a user who doesn't exist",
+			body:     `{"groups":["everyone"]}`,
+			notAdmin: true,
+			wantCode: http.StatusNotFound,
+			wantBody: `{"error":"user does not exist"}`,
+		},
+		{
+			name:               "admin adds the admin group",
+			body:               `{"groups":["everyone","test_admins"]}`,
+			expectUpdateGroups: []string{"everyone", "test_admins"},
+			wantCode:           http.StatusOK,
+			wantBody:           `{"email":"test@test.com","firstName":"","groups":["everyone","developers"],"id":"1234","lastName":"","picture":"","status":"ACTIVE","updatedAt":"0001-01-01T00:00:00Z"}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := mocks.NewMockCognitoService(ctrl)
+			if tc.expectUpdateGroups != nil {
+				m.EXPECT().UpdateUserGroups(gomock.Any(), gomock.Eq(cognitosvc.UpdateUserGroupsOpts{UserID: "1234", Groups: tc.expectUpdateGroups})).Times(1).Return(&updated, nil)
+			}
+			db := ddbmock.New(t)
+			if tc.givenUser != nil {
+				db.MockQuery(&storage.GetUser{Result: tc.givenUser})
+			} else {
+				db.MockQueryWithErr(&storage.GetUser{}, ddb.ErrNoItems)
+			}
+
+			a := API{AdminGroup: adminGroup, AdminRoles: roles, Cognito: m, DB: db}
+			handler := newTestServer(t, &a, withIsAdmin(!tc.notAdmin))
+
+			req, err := http.NewRequest("POST", "/api/v1/admin/users/1234", strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Add("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+			data, err := io.ReadAll(rr.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.wantBody, string(data))
+		})
+	}
+}
+
+func TestGetMe(t *testing.T) {
+	type testcase struct {
+		name      string
+		prefs     *notifications.Preferences
+		prefsErr  error
+		wantCode  int
+		wantBody  string
+		giveAdmin bool
+	}
+
+	testcases := []testcase{
+		{
+			name:     "default preferences",
+			prefsErr: ddb.ErrNoItems,
+			wantCode: http.StatusOK,
+			wantBody: `{"isAdmin":false,"notificationPreferences":{"channel":"SLACK","delivery":"IMMEDIATE","mutedEvents":[]},"user":{"email":"test@acme.com","firstName":"Test","groups":[],"id":"123","lastName":"User","picture":"","status":"","updatedAt":"0001-01-01T00:00:00Z"}}`,
+		},
+		{
+			name:      "saved preferences",
+			prefs:     &notifications.Preferences{UserID: "123", Channel: notifications.ChannelSlack, Delivery: notifications.DeliveryDigest, MutedEvents: []string{"request.approved"}},
+			giveAdmin: true,
+			wantCode:  http.StatusOK,
+			wantBody:  `{"isAdmin":true,"notificationPreferences":{"channel":"SLACK","delivery":"DIGEST","mutedEvents":["request.approved"]},"user":{"email":"test@acme.com","firstName":"Test","groups":[],"id":"123","lastName":"User","picture":"","status":"","updatedAt":"0001-01-01T00:00:00Z"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			db.MockQueryWithErr(&storage.GetNotificationPreferences{Result: tc.prefs}, tc.prefsErr)
+
+			a := API{DB: db}
+			handler := newTestServer(t, &a, withRequestUser(identity.User{ID: "123", Email: "test@acme.com", FirstName: "Test", LastName: "User"}), withIsAdmin(tc.giveAdmin))
+
+			req, err := http.NewRequest("GET", "/api/v1/users/me", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Add("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+
+			data, err := io.ReadAll(rr.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tc.wantBody, string(data))
+		})
+	}
+}
+
+func TestUpdateMyNotificationPreferences(t *testing.T) {
+	type testcase struct {
+		name     string
+		body     string
+		wantCode int
+		wantBody string
+	}
+
+	testcases := []testcase{
+		{
+			name:     "ok",
+			body:     `{"channel":"SLACK","delivery":"DIGEST","mutedEvents":["grant.expiring"]}`,
+			wantCode: http.StatusOK,
+			wantBody: `{"channel":"SLACK","delivery":"DIGEST","mutedEvents":["grant.expiring"]}`,
+		},
+		{
+			name:     "invalid channel",
+			body:     `{"channel":"CARRIER_PIGEON","delivery":"DIGEST","mutedEvents":[]}`,
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"error":"request body has an error: doesn't match the schema: Error at \"/channel\": value is not one of the allowed values"}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+
+			a := API{DB: db}
+			handler := newTestServer(t, &a, withRequestUser(identity.User{ID: "123"}))
+
+			req, err := http.NewRequest("PUT", "/api/v1/users/me/notification-preferences", strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Add("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+
+			data, err := io.ReadAll(rr.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tc.wantBody, string(data))
+		})
+	}
+}
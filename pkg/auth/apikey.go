@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/common-fate/apikit/apio"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+// OnBehalfOfHeader contains the email of the user which a REQUEST_ON_BEHALF API key creates a request for.
+const OnBehalfOfHeader = "X-Granted-On-Behalf-Of"
+
+var servicePathContext = contextKey{name: "servicePathContext"}
+
+// WithServicePath marks a request as made to a service path. Service paths aren't authenticated before
+// they reach the API, so APIKeyAuthenticator only accepts API keys for them.
+func WithServicePath(ctx context.Context) context.Context {
+	return context.WithValue(ctx, servicePathContext, true)
+}
+
+// isServicePath returns true if the request was made to a service path.
+func isServicePath(ctx context.Context) bool {
+	ok, _ := ctx.Value(servicePathContext).(bool)
+	return ok
+}
+
+// APIKeyAuthenticator authenticates requests which have an API key in the Authorization header,
+// in the format "Bearer <key>". Requests without an API key are passed to Authenticator,
+// unless they were made to a service path.
+type APIKeyAuthenticator struct {
+	DB ddb.Storage
+	// Authenticator authenticates users, for example with their Cognito identity token.
+	Authenticator Authenticator
+}
+
+func (a *APIKeyAuthenticator) Authenticate(r *http.Request) (*Claims, error) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !identity.IsAPIKey(token) {
+		// Authenticator may rely on the token having been verified before the request reached the API,
+		// which isn't the case for service paths.
+		if isServicePath(r.Context()) {
+			return nil, errors.New("service paths require an API key")
+		}
+		return a.Authenticator.Authenticate(r)
+	}
+
+	q := storage.GetAPIKeyByHash{Hash: identity.HashAPIKey(token)}
+	_, err := a.DB.Query(r.Context(), &q)
+	if err == ddb.ErrNoItems {
+		return nil, errors.New("invalid API key")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if q.Result.Status != types.APIKeyStatusACTIVE {
+		return nil, errors.New("API key has been revoked")
+	}
+	return &Claims{Sub: q.Result.ID, APIKey: q.Result}, nil
+}
+
+// apiKeyUser returns the user which a request authenticated with an API key acts as.
+// This is the key's service account, unless the request is made on behalf of a user.
+// Errors are returned as apio request errors.
+func apiKeyUser(r *http.Request, db ddb.Storage, key *identity.APIKey) (*identity.User, error) {
+	if key.Scope == types.READONLY && r.Method != http.MethodGet {
+		return nil, apio.NewRequestError(errors.New("API key is read only"), http.StatusUnauthorized)
+	}
+
+	email := r.Header.Get(OnBehalfOfHeader)
+	if email == "" {
+		usr := key.Principal()
+		return &usr, nil
+	}
+	if key.Scope != types.REQUESTONBEHALF {
+		return nil, apio.NewRequestError(errors.New("API key can't make requests on behalf of users"), http.StatusUnauthorized)
+	}
+	// keys can only create requests on behalf of users, so that the key is always recorded as the actor.
+	if r.Method != http.MethodPost || r.URL.Path != "/api/v1/requests" {
+		return nil, apio.NewRequestError(errors.New(OnBehalfOfHeader+" is only supported when creating requests"), http.StatusBadRequest)
+	}
+	q := storage.GetUserByEmail{Email: email}
+	_, err := db.Query(r.Context(), &q)
+	if err == ddb.ErrNoItems {
+		return nil, apio.NewRequestError(errors.New("user not found"), http.StatusBadRequest)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if q.Result.Status != types.IdpStatusACTIVE {
+		return nil, apio.NewRequestError(errors.New("user is archived"), http.StatusBadRequest)
+	}
+	return q.Result, nil
+}
@@ -0,0 +1,237 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/common-fate/apikit/logger"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/ddb/ddbmock"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	type testcase struct {
+		name       string
+		header     string
+		service    bool
+		key        *identity.APIKey
+		getKeyErr  error
+		wantClaims *Claims
+		wantErr    error
+	}
+
+	active := identity.APIKey{ID: "key_123", Scope: types.READONLY, Status: types.APIKeyStatusACTIVE}
+	revoked := identity.APIKey{ID: "key_123", Scope: types.READONLY, Status: types.APIKeyStatusREVOKED}
+
+	testcases := []testcase{
+		{
+			name:       "ok",
+			header:     "Bearer gak_secret",
+			key:        &active,
+			wantClaims: &Claims{Sub: "key_123", APIKey: &active},
+		},
+		{
+			name:      "invalid key",
+			header:    "Bearer gak_secret",
+			getKeyErr: ddb.ErrNoItems,
+			wantErr:   errors.New("invalid API key"),
+		},
+		{
+			name:    "revoked key",
+			header:  "Bearer gak_secret",
+			key:     &revoked,
+			wantErr: errors.New("API key has been revoked"),
+		},
+		{
+			name:       "users are passed to the next authenticator",
+			header:     "eyJraWQ",
+			wantClaims: &Claims{Sub: "usr_123", Email: "test@test.com"},
+		},
+		{
+			name:       "API keys are accepted on service paths",
+			header:     "Bearer gak_secret",
+			service:    true,
+			key:        &active,
+			wantClaims: &Claims{Sub: "key_123", APIKey: &active},
+		},
+		{
+			name:    "users are rejected on service paths",
+			header:  "eyJraWQ",
+			service: true,
+			wantErr: errors.New("service paths require an API key"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			db.MockQueryWithErr(&storage.GetAPIKeyByHash{Hash: identity.HashAPIKey("gak_secret"), Result: tc.key}, tc.getKeyErr)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := NewMockAuthenticator(ctrl)
+			m.EXPECT().Authenticate(gomock.Any()).Return(&Claims{Sub: "usr_123", Email: "test@test.com"}, nil).AnyTimes()
+
+			a := APIKeyAuthenticator{DB: db, Authenticator: m}
+
+			req, err := http.NewRequest("GET", "/api/v1/requests", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Add("Authorization", tc.header)
+			if tc.service {
+				req = req.WithContext(WithServicePath(req.Context()))
+			}
+
+			got, err := a.Authenticate(req)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantClaims, got)
+		})
+	}
+}
+
+func TestMiddlewareAPIKey(t *testing.T) {
+	type testcase struct {
+		name       string
+		scope      types.APIKeyScope
+		method     string
+		path       string
+		onBehalfOf string
+		user       *identity.User
+		getUserErr error
+		wantBody   string
+		wantCode   int
+	}
+
+	testcases := []testcase{
+		{
+			name:     "service account",
+			scope:    types.ADMIN,
+			method:   "POST",
+			path:     "/api/v1/requests",
+			wantBody: "user=key_123 actor=key_123 admin=true",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "read only key can make GET requests",
+			scope:    types.READONLY,
+			method:   "GET",
+			path:     "/api/v1/admin/requests",
+			wantBody: "user=key_123 actor=key_123 admin=true",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "read only key can't make POST requests",
+			scope:    types.READONLY,
+			method:   "POST",
+			path:     "/api/v1/requests",
+			wantBody: `{"error":"API key is read only"}`,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "request on behalf of a user",
+			scope:      types.REQUESTONBEHALF,
+			method:     "POST",
+			path:       "/api/v1/requests",
+			onBehalfOf: "test@test.com",
+			user:       &identity.User{ID: "usr_123", Status: types.IdpStatusACTIVE},
+			wantBody:   "user=usr_123 actor=key_123 admin=false",
+			wantCode:   http.StatusOK,
+		},
+		{
+			name:       "request on behalf of an unknown user",
+			scope:      types.REQUESTONBEHALF,
+			method:     "POST",
+			path:       "/api/v1/requests",
+			onBehalfOf: "test@test.com",
+			getUserErr: ddb.ErrNoItems,
+			wantBody:   `{"error":"user not found"}`,
+			wantCode:   http.StatusBadRequest,
+		},
+		{
+			name:       "on behalf of is only supported when creating requests",
+			scope:      types.REQUESTONBEHALF,
+			method:     "POST",
+			path:       "/api/v1/requests/req_123/cancel",
+			onBehalfOf: "test@test.com",
+			wantBody:   `{"error":"X-Granted-On-Behalf-Of is only supported when creating requests"}`,
+			wantCode:   http.StatusBadRequest,
+		},
+		{
+			name:       "admin key can't make requests on behalf of users",
+			scope:      types.ADMIN,
+			method:     "POST",
+			path:       "/api/v1/requests",
+			onBehalfOf: "test@test.com",
+			wantBody:   `{"error":"API key can't make requests on behalf of users"}`,
+			wantCode:   http.StatusUnauthorized,
+		},
+		{
+			name:     "request on behalf key isn't an admin",
+			scope:    types.REQUESTONBEHALF,
+			method:   "GET",
+			path:     "/api/v1/admin/requests",
+			wantBody: `{"error":"Unauthorized"}`,
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := chi.NewRouter()
+			c := ddbmock.New(t)
+			c.MockQueryWithErr(&storage.GetUserByEmail{Email: "test@test.com", Result: tc.user}, tc.getUserErr)
+
+			log := zaptest.NewLogger(t)
+			r.Use(logger.Middleware(log))
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			key := identity.APIKey{ID: "key_123", Name: "ci", Scope: tc.scope, Status: types.APIKeyStatusACTIVE}
+			m := NewMockAuthenticator(ctrl)
+			m.EXPECT().Authenticate(gomock.Any()).Return(&Claims{Sub: key.ID, APIKey: &key}, nil)
+
+			mis := NewMockIdentitySyncer(ctrl)
+
+			r.Use(Middleware(m, c, mis))
+			r.Use(AdminAuthorizer("admins", nil, nil))
+			r.HandleFunc("/*", func(w http.ResponseWriter, r *http.Request) {
+				ctx := r.Context()
+				_, _ = fmt.Fprintf(w, "user=%s actor=%s admin=%t", UserIDFromContext(ctx), ActorIDFromContext(ctx), IsAdmin(ctx))
+			})
+
+			req, err := http.NewRequest(tc.method, tc.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Add("Content-Type", "application/json")
+			if tc.onBehalfOf != "" {
+				req.Header.Add(OnBehalfOfHeader, tc.onBehalfOf)
+			}
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+
+			data, err := io.ReadAll(rr.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tc.wantBody, string(data))
+		})
+	}
+}
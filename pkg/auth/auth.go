@@ -11,8 +11,12 @@ import (
 	"github.com/common-fate/apikit/logger"
 	"github.com/common-fate/apikit/userid"
 	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/deploy"
 	"github.com/common-fate/granted-approvals/pkg/identity"
 	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
 	"go.uber.org/zap"
 )
 
@@ -23,12 +27,15 @@ type contextKey struct {
 var userIDContext = contextKey{name: "userIDContext"}
 var userContext = contextKey{name: "userContext"}
 var adminContext = contextKey{name: "adminContext"}
+var apiKeyContext = contextKey{name: "apiKeyContext"}
 
 // Claims stores the relevant claims from a user's provided auth token.
 // The identity token contains more claims, but we only parse the ones that we need.
 type Claims struct {
 	Sub   string `json:"sub"`
 	Email string `json:"email"`
+	// APIKey is set if the request was authenticated with an API key rather than as a user.
+	APIKey *identity.APIKey `json:"-"`
 }
 
 //go:generate go run github.com/golang/mock/mockgen -destination=mock_authenticator.go -package=auth . Authenticator
@@ -62,6 +69,25 @@ func Middleware(authenticator Authenticator, db ddb.Storage, idp IdentitySyncer)
 				return
 			}
 
+			// requests made with an API key act as the key's service account, or as the user they're made on behalf of.
+			if claims.APIKey != nil {
+				usr, err := apiKeyUser(r, db, claims.APIKey)
+				if err != nil {
+					log.Infow("API key authorization error", "apiKey.id", claims.APIKey.ID, zap.Error(err))
+					apio.Error(ctx, w, err)
+					return
+				}
+				ctx = context.WithValue(ctx, apiKeyContext, claims.APIKey)
+				ctx = context.WithValue(ctx, userContext, usr)
+				ctx = context.WithValue(ctx, userIDContext, usr.ID)
+				ctx = userid.Set(ctx, usr.ID)
+
+				log.Debugw("API key is authenticated", "apiKey.id", claims.APIKey.ID, "user.id", usr.ID)
+				r = r.WithContext(ctx)
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			// get email from claims
 			// lookup user by email
 			// add user to context
@@ -79,8 +105,8 @@ func Middleware(authenticator Authenticator, db ddb.Storage, idp IdentitySyncer)
 
 			// if we get ddb.ErrNoItems, the user may not have been synced yet from the IDP.
 			// try and sync them now.
-			// Note: this approach isn't very performant. In future this can be replaced with an incremental sync,
-			// which we can use for handling IDP webhook notifications rather than polling.
+			// Note: this approach isn't very performant. If webhooks are configured for the IDP, new users are
+			// synced incrementally as soon as they are created, so this should only happen if a webhook was missed.
 			if err == ddb.ErrNoItems {
 				log.Info("user does not exist in database - running an IDP sync and trying again", "user", claims)
 				err = idp.Sync(ctx)
@@ -110,15 +136,27 @@ func Middleware(authenticator Authenticator, db ddb.Storage, idp IdentitySyncer)
 	}
 }
 
-// AdminAuthorizer only allows users belonging to adminGroup to access administrative endpoints.
-// The middleware currently gates all endpoints in the format /api/v1/admin/*
-func AdminAuthorizer(adminGroup string) func(next http.Handler) http.Handler {
+// AdminAuthorizer gates all endpoints in the format /api/v1/admin/*.
+// Users belonging to adminGroup can access every administrative endpoint.
+// Users belonging to a group with an admin role can access the endpoints for the OpenAPI operations
+// which require a permission granted by the role. The swagger spec is used to look up the operation of
+// a request, and must be provided if any roles are configured.
+func AdminAuthorizer(adminGroup string, roles deploy.AdminRoleAssignments, swagger *openapi3.T) func(next http.Handler) http.Handler {
 	// the admin group should always be provided.
 	// if it's not it's a serious misconfiguration so we panic to avoid
 	// actually running the server.
 	if adminGroup == "" {
 		panic("AdminAuthorizer: adminGroup was empty")
 	}
+	if len(roles) > 0 && swagger == nil {
+		panic("AdminAuthorizer: swagger must be provided if admin roles are configured")
+	}
+	// the router finds the operation for requests made to admin routes by users who aren't admins,
+	// who may be able to call the operation through their admin roles or by owning access rules.
+	var router routers.Router
+	if swagger != nil {
+		router = newOperationRouter(swagger)
+	}
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -141,10 +179,28 @@ func AdminAuthorizer(adminGroup string) func(next http.Handler) http.Handler {
 
 			// a user is an admin if they belong to the adminGroup.
 			// The user's groups are set in their identity provider such as Okta or Google Workspace.
-			isAdmin := contains(usr.Groups, adminGroup)
+			isAdmin := usr.BelongsToGroup(adminGroup)
+			if key := APIKeyFromContext(ctx); key != nil {
+				// API keys are admins based on their scope rather than group membership.
+				isAdmin = key.Scope == types.ADMIN || (key.Scope == types.READONLY && r.Method == http.MethodGet)
+			}
 			ctx = context.WithValue(ctx, adminContext, isAdmin)
+
+			// API keys are authorized by their scope rather than by roles.
+			var userRoles deploy.AdminRoleAssignments
+			if !isAdmin && APIKeyFromContext(ctx) == nil {
+				userRoles = userAdminRoles(roles, usr.BelongsToGroup)
+			}
+			ctx = context.WithValue(ctx, adminRolesContext, userRoles)
 			r = r.WithContext(ctx)
 
+			if isAdminRoute && !isAdmin && router != nil && APIKeyFromContext(ctx) == nil {
+				if id, ok := operationID(router, r); ok && canCallOperation(userRoles, id) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
 			if isAdminRoute && !isAdmin {
 				// the user is trying to access an admin route, but they're not authorized.
 				// return a HTTP401 unauthorized response.
@@ -169,20 +225,26 @@ func IsAdmin(ctx context.Context) bool {
 	return ctx.Value(adminContext).(bool)
 }
 
+// APIKeyFromContext returns the API key which authenticated the request, or nil if the request was made by a user.
+// It requires that auth.Middleware has run.
+func APIKeyFromContext(ctx context.Context) *identity.APIKey {
+	key, _ := ctx.Value(apiKeyContext).(*identity.APIKey)
+	return key
+}
+
+// ActorIDFromContext returns the ID of the API key which made the request, or the current user's ID if
+// the request wasn't made with an API key. It's recorded as the actor in request events.
+// It requires that auth.Middleware has run.
+func ActorIDFromContext(ctx context.Context) string {
+	if key := APIKeyFromContext(ctx); key != nil {
+		return key.ID
+	}
+	return UserIDFromContext(ctx)
+}
+
 // UserIDFromContext returns the current user's ID.
 // It requires that auth.Middleware has run.
 func UserFromContext(ctx context.Context) *identity.User {
 	usr := ctx.Value(userContext)
 	return usr.(*identity.User)
 }
-
-// contains is a helper function to check if a string slice
-// contains a particular string.
-func contains(s []string, e string) bool {
-	for _, a := range s {
-		if a == e {
-			return true
-		}
-	}
-	return false
-}
@@ -114,7 +114,7 @@ func TestAdminAuthorizer(t *testing.T) {
 
 			}
 
-			r.Use(AdminAuthorizer(tc.adminGroup))
+			r.Use(AdminAuthorizer(tc.adminGroup, nil, nil))
 			r.HandleFunc("/*", func(w http.ResponseWriter, r *http.Request) {
 				_, _ = w.Write([]byte("ok"))
 				w.WriteHeader(http.StatusOK)
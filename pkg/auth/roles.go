@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+var adminRolesContext = contextKey{name: "adminRolesContext"}
+
+// Permission is required to call an administrative API operation.
+type Permission string
+
+const (
+	PermissionManageRules     Permission = "manage_rules"
+	PermissionReadRequests    Permission = "read_requests"
+	PermissionReadEvents      Permission = "read_events"
+	PermissionReadIdentity    Permission = "read_identity"
+	PermissionManageIdentity  Permission = "manage_identity"
+	PermissionReadProviders   Permission = "read_providers"
+	PermissionManageProviders Permission = "manage_providers"
+)
+
+// rolePermissions contains the permissions granted by each admin role.
+var rolePermissions = map[string][]Permission{
+	// rule managers need to read providers and groups to set the target and approvers of rules.
+	deploy.AdminRoleRuleManager:     {PermissionManageRules, PermissionReadProviders, PermissionReadIdentity},
+	deploy.AdminRoleAuditor:         {PermissionReadRequests, PermissionReadEvents},
+	deploy.AdminRoleIdentityManager: {PermissionManageIdentity, PermissionReadIdentity},
+	deploy.AdminRoleProviderAdmin:   {PermissionManageProviders, PermissionReadProviders},
+}
+
+// operationPermissions maps the ID of each administrative OpenAPI operation to the permission it requires.
+// The IDs are the generated handler names, as oapi-codegen rewrites the operation IDs in the embedded spec.
+// Operations which aren't listed can only be called by members of the admin group.
+var operationPermissions = map[string]Permission{
+	"AdminListAccessRules":         PermissionManageRules,
+	"AdminCreateAccessRule":        PermissionManageRules,
+	"AdminGetAccessRule":           PermissionManageRules,
+	"AdminUpdateAccessRule":        PermissionManageRules,
+	"AdminArchiveAccessRule":       PermissionManageRules,
+	"AdminGetAccessRuleVersions":   PermissionManageRules,
+	"AdminGetAccessRuleVersion":    PermissionManageRules,
+	"AdminUpsertAccessRule":        PermissionManageRules,
+	"AdminDiffAccessRuleVersions":  PermissionManageRules,
+	"AdminRollbackAccessRule":      PermissionManageRules,
+	"AdminSimulateAccess":          PermissionManageRules,
+	"AdminListRequests":            PermissionReadRequests,
+	"AdminGetRequest":              PermissionReadRequests,
+	"AdminListFailedEvents":        PermissionReadEvents,
+	"GetUsers":                     PermissionReadIdentity,
+	"GetGroups":                    PermissionReadIdentity,
+	"GetGroup":                     PermissionReadIdentity,
+	"CreateUser":                   PermissionManageIdentity,
+	"UpdateUser":                   PermissionManageIdentity,
+	"CreateGroup":                  PermissionManageIdentity,
+	"IdentitySync":                 PermissionManageIdentity,
+	"IdentityConfiguration":        PermissionManageIdentity,
+	"ListProviders":                PermissionReadProviders,
+	"GetProvider":                  PermissionReadProviders,
+	"GetProviderArgs":              PermissionReadProviders,
+	"ListProviderArgOptions":       PermissionReadProviders,
+	"ListProvidersetups":           PermissionManageProviders,
+	"CreateProvidersetup":          PermissionManageProviders,
+	"GetProvidersetup":             PermissionManageProviders,
+	"DeleteProvidersetup":          PermissionManageProviders,
+	"GetProvidersetupInstructions": PermissionManageProviders,
+	"ValidateProvidersetup":        PermissionManageProviders,
+	"CompleteProvidersetup":        PermissionManageProviders,
+	"SubmitProvidersetupStep":      PermissionManageProviders,
+}
+
+// ruleOwnerOperations are administrative operations which users who aren't admins can call to manage the rules which they own.
+// The handlers for these operations check that the user owns the rule.
+var ruleOwnerOperations = map[string]bool{
+	"AdminListAccessRules":        true,
+	"AdminCreateAccessRule":       true,
+	"AdminGetAccessRule":          true,
+	"AdminUpdateAccessRule":       true,
+	"AdminArchiveAccessRule":      true,
+	"AdminGetAccessRuleVersions":  true,
+	"AdminGetAccessRuleVersion":   true,
+	"AdminUpsertAccessRule":       true,
+	"AdminDiffAccessRuleVersions": true,
+	"AdminRollbackAccessRule":     true,
+	"AdminSimulateAccess":         true,
+	"AdminListRequests":           true,
+	"AdminGetRequest":             true,
+}
+
+// operationID returns the ID of the OpenAPI operation which handles the request.
+func operationID(router routers.Router, r *http.Request) (string, bool) {
+	route, _, err := router.FindRoute(r)
+	if err != nil || route.Operation == nil {
+		return "", false
+	}
+	return route.Operation.OperationID, true
+}
+
+// canCallOperation returns true if a user who isn't an admin can call the operation,
+// either because one of their roles grants the permission it requires or because rule owners can call it.
+func canCallOperation(roles deploy.AdminRoleAssignments, id string) bool {
+	if ruleOwnerOperations[id] {
+		return true
+	}
+	p, ok := operationPermissions[id]
+	return ok && hasPermission(roles, p)
+}
+
+// newOperationRouter returns a router which finds the OpenAPI operation for a request.
+func newOperationRouter(swagger *openapi3.T) routers.Router {
+	router, err := gorillamux.NewRouter(swagger)
+	if err != nil {
+		panic(err)
+	}
+	return router
+}
+
+// userAdminRoles returns the admin roles assigned to groups which the user belongs to.
+func userAdminRoles(roles deploy.AdminRoleAssignments, belongsToGroup func(string) bool) deploy.AdminRoleAssignments {
+	var res deploy.AdminRoleAssignments
+	for _, ra := range roles {
+		if belongsToGroup(ra.Group) {
+			res = append(res, ra)
+		}
+	}
+	return res
+}
+
+func hasPermission(roles deploy.AdminRoleAssignments, p Permission) bool {
+	for _, ra := range roles {
+		for _, rp := range rolePermissions[ra.Role] {
+			if rp == p {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AdminRolesFromContext returns the admin roles of the current user.
+// Members of the admin group have every permission, so use HasPermission to check permissions rather than the roles.
+// It requires that the AdminAuthorizer middleware has run.
+func AdminRolesFromContext(ctx context.Context) deploy.AdminRoleAssignments {
+	roles, _ := ctx.Value(adminRolesContext).(deploy.AdminRoleAssignments)
+	return roles
+}
+
+// HasPermission returns true if the current user is an admin, or has an admin role with the permission.
+// It requires that the AdminAuthorizer middleware has run.
+func HasPermission(ctx context.Context, p Permission) bool {
+	return IsAdmin(ctx) || hasPermission(AdminRolesFromContext(ctx), p)
+}
+
+// CanManageRulesForProvider returns true if the current user is an admin, or is a rule manager for the provider.
+// It requires that the AdminAuthorizer middleware has run.
+func CanManageRulesForProvider(ctx context.Context, providerID string) bool {
+	if IsAdmin(ctx) {
+		return true
+	}
+	for _, ra := range AdminRolesFromContext(ctx) {
+		if ra.Role != deploy.AdminRoleRuleManager {
+			continue
+		}
+		if len(ra.Providers) == 0 {
+			return true
+		}
+		for _, p := range ra.Providers {
+			if p == providerID {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/common-fate/apikit/logger"
+	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestAdminAuthorizerRoles(t *testing.T) {
+	type testcase struct {
+		name     string
+		groups   []string
+		method   string
+		path     string
+		wantCode int
+		wantBody string
+	}
+
+	roles := deploy.AdminRoleAssignments{
+		{Group: "rule-managers", Role: deploy.AdminRoleRuleManager, Providers: []string{"aws"}},
+		{Group: "auditors", Role: deploy.AdminRoleAuditor},
+		{Group: "identity-managers", Role: deploy.AdminRoleIdentityManager},
+		{Group: "provider-admins", Role: deploy.AdminRoleProviderAdmin},
+	}
+
+	testcases := []testcase{
+		{
+			name:     "rule manager can list access rules",
+			groups:   []string{"rule-managers"},
+			method:   "GET",
+			path:     "/api/v1/admin/access-rules",
+			wantCode: http.StatusOK,
+			wantBody: "admin=false roles=1",
+		},
+		{
+			name:     "rule manager can update access rules",
+			groups:   []string{"rule-managers"},
+			method:   "PUT",
+			path:     "/api/v1/admin/access-rules/rul_123",
+			wantCode: http.StatusOK,
+			wantBody: "admin=false roles=1",
+		},
+		{
+			name:     "rule manager can simulate access",
+			groups:   []string{"rule-managers"},
+			method:   "POST",
+			path:     "/api/v1/admin/access-simulation",
+			wantCode: http.StatusOK,
+			wantBody: "admin=false roles=1",
+		},
+		{
+			name:     "rule manager can't set up providers",
+			groups:   []string{"rule-managers"},
+			method:   "GET",
+			path:     "/api/v1/admin/providersetups",
+			wantCode: http.StatusUnauthorized,
+			wantBody: `{"error":"Unauthorized"}`,
+		},
+		{
+			name:     "auditor can list requests",
+			groups:   []string{"auditors"},
+			method:   "GET",
+			path:     "/api/v1/admin/requests",
+			wantCode: http.StatusOK,
+			wantBody: "admin=false roles=1",
+		},
+		{
+			name:     "auditor can't sync identities",
+			groups:   []string{"auditors"},
+			method:   "POST",
+			path:     "/api/v1/admin/identity/sync",
+			wantCode: http.StatusUnauthorized,
+			wantBody: `{"error":"Unauthorized"}`,
+		},
+		{
+			name:     "identity manager can create groups",
+			groups:   []string{"identity-managers"},
+			method:   "POST",
+			path:     "/api/v1/admin/groups",
+			wantCode: http.StatusOK,
+			wantBody: "admin=false roles=1",
+		},
+		{
+			name:     "roles don't grant access to API keys",
+			groups:   []string{"rule-managers", "auditors", "identity-managers", "provider-admins"},
+			method:   "GET",
+			path:     "/api/v1/admin/api-keys",
+			wantCode: http.StatusUnauthorized,
+			wantBody: `{"error":"Unauthorized"}`,
+		},
+		{
+			name:     "admins don't have roles",
+			groups:   []string{"admins", "auditors"},
+			method:   "GET",
+			path:     "/api/v1/admin/api-keys",
+			wantCode: http.StatusOK,
+			wantBody: "admin=true roles=0",
+		},
+		{
+			name:     "users without roles can't access admin routes",
+			groups:   []string{"everyone"},
+			method:   "GET",
+			path:     "/api/v1/admin/providers",
+			wantCode: http.StatusUnauthorized,
+			wantBody: `{"error":"Unauthorized"}`,
+		},
+		{
+			name:     "users without roles can manage the rules they own",
+			groups:   []string{"everyone"},
+			method:   "POST",
+			path:     "/api/v1/admin/access-rules/rul_123/archive",
+			wantCode: http.StatusOK,
+			wantBody: "admin=false roles=0",
+		},
+	}
+
+	swagger, err := types.GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	swagger.Servers = nil
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := chi.NewRouter()
+
+			log := zaptest.NewLogger(t)
+			r.Use(logger.Middleware(log))
+			user := identity.User{ID: "usr_123", Groups: tc.groups}
+			r.Use(func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					ctx := context.WithValue(r.Context(), userContext, &user)
+					next.ServeHTTP(w, r.WithContext(ctx))
+				})
+			})
+			r.Use(AdminAuthorizer("admins", roles, swagger))
+			r.HandleFunc("/*", func(w http.ResponseWriter, r *http.Request) {
+				ctx := r.Context()
+				_, _ = fmt.Fprintf(w, "admin=%t roles=%d", IsAdmin(ctx), len(AdminRolesFromContext(ctx)))
+			})
+
+			req, err := http.NewRequest(tc.method, tc.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Add("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+
+			data, err := io.ReadAll(rr.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tc.wantBody, string(data))
+		})
+	}
+}
+
+// TestOperationPermissionsExist ensures that operations aren't silently restricted to admins
+// when an operation ID in the OpenAPI spec is renamed.
+func TestOperationPermissionsExist(t *testing.T) {
+	swagger, err := types.GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make(map[string]bool)
+	for _, item := range swagger.Paths {
+		for _, op := range item.Operations() {
+			ids[op.OperationID] = true
+		}
+	}
+	for id := range operationPermissions {
+		assert.True(t, ids[id], "operation %s doesn't exist in the OpenAPI spec", id)
+	}
+	for id := range ruleOwnerOperations {
+		assert.True(t, ids[id], "operation %s doesn't exist in the OpenAPI spec", id)
+	}
+}
+
+func TestCanManageRulesForProvider(t *testing.T) {
+	testcases := []struct {
+		name     string
+		isAdmin  bool
+		roles    deploy.AdminRoleAssignments
+		provider string
+		want     bool
+	}{
+		{name: "admin", isAdmin: true, provider: "aws", want: true},
+		{name: "unscoped rule manager", roles: deploy.AdminRoleAssignments{{Role: deploy.AdminRoleRuleManager}}, provider: "aws", want: true},
+		{name: "scoped rule manager", roles: deploy.AdminRoleAssignments{{Role: deploy.AdminRoleRuleManager, Providers: []string{"aws"}}}, provider: "aws", want: true},
+		{name: "different provider", roles: deploy.AdminRoleAssignments{{Role: deploy.AdminRoleRuleManager, Providers: []string{"okta"}}}, provider: "aws", want: false},
+		{name: "not a rule manager", roles: deploy.AdminRoleAssignments{{Role: deploy.AdminRoleAuditor}}, provider: "aws", want: false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := TestingSetIsAdmin(context.Background(), tc.isAdmin)
+			ctx = TestingSetAdminRoles(ctx, tc.roles)
+			assert.Equal(t, tc.want, CanManageRulesForProvider(ctx, tc.provider))
+		})
+	}
+}
@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 
+	"github.com/common-fate/granted-approvals/pkg/deploy"
 	"github.com/common-fate/granted-approvals/pkg/identity"
 )
 
@@ -20,3 +21,13 @@ func TestingSetUser(ctx context.Context, user identity.User) context.Context {
 func TestingSetIsAdmin(ctx context.Context, isAdmin bool) context.Context {
 	return context.WithValue(ctx, adminContext, isAdmin)
 }
+
+// TestingSetAPIKey allows the API key to be set in the context for testing purposes.
+func TestingSetAPIKey(ctx context.Context, key identity.APIKey) context.Context {
+	return context.WithValue(ctx, apiKeyContext, &key)
+}
+
+// TestingSetAdminRoles allows the admin roles to be set in the context for testing purposes.
+func TestingSetAdminRoles(ctx context.Context, roles deploy.AdminRoleAssignments) context.Context {
+	return context.WithValue(ctx, adminRolesContext, roles)
+}
@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 type Config struct {
 	Host              string `env:"APPROVALS_HOST,default=0.0.0.0:8080"`
 	LogLevel          string `env:"LOG_LEVEL,default=info"`
@@ -21,6 +23,17 @@ type Config struct {
 	IdentitySettings              string `env:"IDENTITY_SETTINGS,default={}"`
 	PaginationKMSKeyARN           string `env:"PAGINATION_KMS_KEY_ARN,required"`
 	AccessHandlerExecutionRoleARN string `env:"ACCESS_HANDLER_EXECUTION_ROLE_ARN,required"`
+	// IdentitySyncArchiveThreshold is the percentage of active users which an identity sync may archive before it is aborted.
+	IdentitySyncArchiveThreshold int `env:"IDENTITY_SYNC_ARCHIVE_THRESHOLD"`
+	// This should be an instance of deploy.IdentityGroupMappings
+	// Use deploy.UnmarshalIdentityGroupMappings to unmarshal this data
+	IdentityGroupMappings string `env:"IDENTITY_GROUP_MAPPINGS,default=[]"`
+	// This should be an instance of deploy.AdminRoleAssignments
+	// Use deploy.UnmarshalAdminRoles to unmarshal this data
+	AdminRoles string `env:"ADMIN_ROLES,default=[]"`
+	// This should be an instance of deploy.FeatureMap which is a specific json format for this
+	// Use deploy.UnmarshalFeatureMap to unmarshal this data into a FeatureMap
+	TicketSettings string `env:"TICKET_SETTINGS,default={}"`
 }
 
 type NotificationsConfig struct {
@@ -45,6 +58,28 @@ type SyncConfig struct {
 	// This should be an instance of deploy.FeatureMap which is a specific json format for this
 	// Use deploy.UnmarshalFeatureMap to unmarshal this data into a FeatureMap
 	IdentitySettings string `env:"IDENTITY_SETTINGS,default={}"`
+	// AccessHandlerURL and EventBusArn are used to offboard users who are archived by a sync.
+	// Offboarding is disabled if AccessHandlerURL is empty.
+	AccessHandlerURL string `env:"ACCESS_HANDLER_URL"`
+	EventBusArn      string `env:"EVENT_BUS_ARN"`
+	Region           string `env:"AWS_REGION"`
+	// ArchiveThreshold is the percentage of active users which a sync may archive before it is aborted.
+	ArchiveThreshold int `env:"IDENTITY_SYNC_ARCHIVE_THRESHOLD"`
+	// This should be an instance of deploy.IdentityGroupMappings
+	// Use deploy.UnmarshalIdentityGroupMappings to unmarshal this data
+	IdentityGroupMappings string `env:"IDENTITY_GROUP_MAPPINGS,default=[]"`
+}
+
+type RemindersConfig struct {
+	LogLevel    string `env:"LOG_LEVEL,default=info"`
+	DynamoTable string `env:"APPROVALS_TABLE_NAME,required"`
+	EventBusArn string `env:"EVENT_BUS_ARN,required"`
+	// ReminderInterval is how long a request can be pending before reviewers are reminded.
+	ReminderInterval time.Duration `env:"REMINDER_INTERVAL,default=4h"`
+	// EscalationInterval is how long a request can be pending before it is escalated.
+	EscalationInterval time.Duration `env:"ESCALATION_INTERVAL,default=24h"`
+	// GrantExpiryWarning is how long before a grant ends that the user is warned. Set to 0 to disable warnings.
+	GrantExpiryWarning time.Duration `env:"GRANT_EXPIRY_WARNING,default=15m"`
 }
 
 type FrontendDeployerConfig struct {
@@ -0,0 +1,69 @@
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Admin roles grant a subset of administrative permissions. Members of the AdministratorGroupID have every permission.
+const (
+	// AdminRoleRuleManager can manage Access Rules. It can be limited to Access Rules for particular providers.
+	AdminRoleRuleManager = "RULE_MANAGER"
+	// AdminRoleAuditor can view all requests and failed events, but can't change anything.
+	AdminRoleAuditor = "AUDITOR"
+	// AdminRoleIdentityManager can manage users and groups and run identity syncs.
+	AdminRoleIdentityManager = "IDENTITY_MANAGER"
+	// AdminRoleProviderAdmin can manage Access Providers.
+	AdminRoleProviderAdmin = "PROVIDER_ADMIN"
+)
+
+// AdminRoleAssignment assigns an admin role to the members of a group.
+//
+//	AdminRoles:
+//	  - Group: platform
+//	    Role: RULE_MANAGER
+//	    Providers:
+//	      - aws-sso
+//	  - Group: security
+//	    Role: AUDITOR
+type AdminRoleAssignment struct {
+	Group string `yaml:"Group" json:"group"`
+	Role  string `yaml:"Role" json:"role"`
+	// Providers limits a RULE_MANAGER to Access Rules for these providers.
+	// If it is empty, the role applies to Access Rules for every provider.
+	Providers []string `yaml:"Providers,omitempty" json:"providers,omitempty"`
+}
+
+type AdminRoleAssignments []AdminRoleAssignment
+
+// Validate returns an error if an assignment is missing a group or has an unknown role.
+func (a AdminRoleAssignments) Validate() error {
+	for i, ra := range a {
+		if ra.Group == "" {
+			return fmt.Errorf("admin role assignment %d must have a group", i)
+		}
+		switch ra.Role {
+		case AdminRoleRuleManager, AdminRoleAuditor, AdminRoleIdentityManager, AdminRoleProviderAdmin:
+		default:
+			return fmt.Errorf("admin role assignment %d has an unknown role %q", i, ra.Role)
+		}
+		if len(ra.Providers) > 0 && ra.Role != AdminRoleRuleManager {
+			return fmt.Errorf("admin role assignment %d can only limit the %s role to providers", i, AdminRoleRuleManager)
+		}
+	}
+	return nil
+}
+
+// UnmarshalAdminRoles parses the JSON admin role assignments.
+// If `data` is an empty string, no assignments are returned.
+func UnmarshalAdminRoles(data string) (AdminRoleAssignments, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var a AdminRoleAssignments
+	err := json.Unmarshal([]byte(data), &a)
+	if err != nil {
+		return nil, err
+	}
+	return a, a.Validate()
+}
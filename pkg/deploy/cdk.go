@@ -49,6 +49,30 @@ func (c Config) CDKContextArgs() []string {
 		args = append(args, "-c", fmt.Sprintf("notificationsConfiguration=%s", string(cfg)))
 	}
 
+	if c.Deployment.Parameters.IdentityGroupMappings != nil {
+		cfg, err := json.Marshal(c.Deployment.Parameters.IdentityGroupMappings)
+		if err != nil {
+			panic(err)
+		}
+		args = append(args, "-c", fmt.Sprintf("identityGroupMappings=%s", string(cfg)))
+	}
+
+	if c.Deployment.Parameters.AdminRoles != nil {
+		cfg, err := json.Marshal(c.Deployment.Parameters.AdminRoles)
+		if err != nil {
+			panic(err)
+		}
+		args = append(args, "-c", fmt.Sprintf("adminRoles=%s", string(cfg)))
+	}
+
+	if c.Deployment.Parameters.TicketConfiguration != nil {
+		cfg, err := json.Marshal(c.Deployment.Parameters.TicketConfiguration)
+		if err != nil {
+			panic(err)
+		}
+		args = append(args, "-c", fmt.Sprintf("ticketConfiguration=%s", string(cfg)))
+	}
+
 	if c.Deployment.Parameters.IdentityProviderType != "" {
 		args = append(args, "-c", fmt.Sprintf("idpType=%s", string(c.Deployment.Parameters.IdentityProviderType)))
 	}
@@ -61,6 +85,9 @@ func (c Config) CDKContextArgs() []string {
 	if c.Deployment.Parameters.SamlSSOMetadataURL != "" {
 		args = append(args, "-c", fmt.Sprintf("samlMetadataUrl=%s", string(c.Deployment.Parameters.SamlSSOMetadataURL)))
 	}
+	if c.Deployment.Parameters.IdentitySyncArchiveThreshold != 0 {
+		args = append(args, "-c", fmt.Sprintf("identitySyncArchiveThreshold=%d", c.Deployment.Parameters.IdentitySyncArchiveThreshold))
+	}
 	return args
 }
 
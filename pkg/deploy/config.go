@@ -6,6 +6,7 @@ import (
 
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -164,6 +165,16 @@ type Parameters struct {
 	ProviderConfiguration      ProviderMap `yaml:"ProviderConfiguration,omitempty"`
 	IdentityConfiguration      FeatureMap  `yaml:"IdentityConfiguration,omitempty"`
 	NotificationsConfiguration FeatureMap  `yaml:"NotificationsConfiguration,omitempty"`
+	// IdentityGroupMappings map groups from the identity provider onto Granted groups.
+	// If any mappings are configured, identity provider groups are no longer imported 1:1.
+	IdentityGroupMappings IdentityGroupMappings `yaml:"IdentityGroupMappings,omitempty"`
+	// AdminRoles assign admin roles to groups, which grant a subset of the permissions of the AdministratorGroupID.
+	AdminRoles AdminRoleAssignments `yaml:"AdminRoles,omitempty"`
+	// TicketConfiguration configures the ticket systems which access rules can require requests to reference.
+	TicketConfiguration FeatureMap `yaml:"TicketConfiguration,omitempty"`
+	// IdentitySyncArchiveThreshold is the percentage of active users which an identity sync may archive before it is aborted.
+	// If it is zero, the default threshold is used.
+	IdentitySyncArchiveThreshold int `yaml:"IdentitySyncArchiveThreshold,omitempty"`
 }
 
 // UnmarshalFeatureMap parses the JSON configuration data and returns
@@ -239,6 +250,7 @@ func (c *Config) ResetIdentityProviderToCognito(filepath string) error {
 	c.Deployment.Parameters.IdentityProviderType = ""
 	c.Deployment.Parameters.AdministratorGroupID = "granted_administrators"
 	c.Deployment.Parameters.IdentityConfiguration = nil
+	c.Deployment.Parameters.IdentityGroupMappings = nil
 	c.Deployment.Parameters.SamlSSOMetadataURL = ""
 	c.Deployment.Parameters.SamlSSOMetadata = ""
 
@@ -354,6 +366,39 @@ func (c *Config) CfnParams() ([]types.Parameter, error) {
 			ParameterValue: &configStr,
 		})
 	}
+	if c.Deployment.Parameters.IdentityGroupMappings != nil {
+		config, err := json.Marshal(c.Deployment.Parameters.IdentityGroupMappings)
+		if err != nil {
+			return nil, err
+		}
+		configStr := string(config)
+		res = append(res, types.Parameter{
+			ParameterKey:   aws.String("IdentityGroupMappings"),
+			ParameterValue: &configStr,
+		})
+	}
+	if c.Deployment.Parameters.AdminRoles != nil {
+		config, err := json.Marshal(c.Deployment.Parameters.AdminRoles)
+		if err != nil {
+			return nil, err
+		}
+		configStr := string(config)
+		res = append(res, types.Parameter{
+			ParameterKey:   aws.String("AdminRoles"),
+			ParameterValue: &configStr,
+		})
+	}
+	if c.Deployment.Parameters.TicketConfiguration != nil {
+		config, err := json.Marshal(c.Deployment.Parameters.TicketConfiguration)
+		if err != nil {
+			return nil, err
+		}
+		configStr := string(config)
+		res = append(res, types.Parameter{
+			ParameterKey:   aws.String("TicketConfiguration"),
+			ParameterValue: &configStr,
+		})
+	}
 	if p.AdministratorGroupID != "" {
 		res = append(res, types.Parameter{
 			ParameterKey:   aws.String("AdministratorGroupID"),
@@ -396,6 +441,13 @@ func (c *Config) CfnParams() ([]types.Parameter, error) {
 		})
 	}
 
+	if c.Deployment.Parameters.IdentitySyncArchiveThreshold != 0 {
+		res = append(res, types.Parameter{
+			ParameterKey:   aws.String("IdentitySyncArchiveThreshold"),
+			ParameterValue: aws.String(strconv.Itoa(p.IdentitySyncArchiveThreshold)),
+		})
+	}
+
 	return res, nil
 }
 
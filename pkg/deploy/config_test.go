@@ -70,6 +70,18 @@ func TestTestCfnParams(t *testing.T) {
 			},
 			want: `[{"ParameterKey":"CognitoDomainPrefix","ParameterValue":"","ResolvedValue":null,"UsePreviousValue":null},{"ParameterKey":"ProviderConfiguration","ParameterValue":"{\"okta\":{\"uses\":\"commonfate/okta@v1\",\"with\":{\"orgUrl\":\"test.internal\"}}}","ResolvedValue":null,"UsePreviousValue":null}]`,
 		},
+		{
+			name: "identity sync archive threshold",
+			give: Config{
+				Deployment: Deployment{
+					Parameters: Parameters{
+						CognitoDomainPrefix:          "test",
+						IdentitySyncArchiveThreshold: 80,
+					},
+				},
+			},
+			want: `[{"ParameterKey":"CognitoDomainPrefix","ParameterValue":"test","ResolvedValue":null,"UsePreviousValue":null},{"ParameterKey":"IdentitySyncArchiveThreshold","ParameterValue":"80","ResolvedValue":null,"UsePreviousValue":null}]`,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -0,0 +1,74 @@
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// IdentityGroupMapping maps groups from the identity provider onto a Granted group.
+//
+// A user is a member of the Granted group if they are a member of any identity provider group
+// whose name matches one of the Include patterns and none of the Exclude patterns.
+//
+//	IdentityGroupMappings:
+//	  - ID: engineering
+//	    Name: Engineering
+//	    Include:
+//	      - ^eng-.*
+//	      - ^platform$
+//	    Exclude:
+//	      - ^eng-contractors$
+type IdentityGroupMapping struct {
+	// ID is the ID of the Granted group, which is used in access rules and as the AdministratorGroupID.
+	ID string `yaml:"ID" json:"id"`
+	// Name is the name of the Granted group. It defaults to the ID.
+	Name        string `yaml:"Name,omitempty" json:"name,omitempty"`
+	Description string `yaml:"Description,omitempty" json:"description,omitempty"`
+	// Include contains regular expressions which are matched against the names of identity provider groups.
+	Include []string `yaml:"Include" json:"include"`
+	// Exclude contains regular expressions for identity provider groups which are never included.
+	Exclude []string `yaml:"Exclude,omitempty" json:"exclude,omitempty"`
+}
+
+type IdentityGroupMappings []IdentityGroupMapping
+
+// Validate returns an error if a mapping is missing an ID, has a duplicate ID, or has an invalid pattern.
+func (m IdentityGroupMappings) Validate() error {
+	ids := make(map[string]bool)
+	for i, mapping := range m {
+		if mapping.ID == "" {
+			return fmt.Errorf("identity group mapping %d must have an ID", i)
+		}
+		if ids[mapping.ID] {
+			return fmt.Errorf("identity group mapping %s is defined more than once", mapping.ID)
+		}
+		ids[mapping.ID] = true
+		if len(mapping.Include) == 0 {
+			return fmt.Errorf("identity group mapping %s must include at least one pattern", mapping.ID)
+		}
+		for _, p := range append(append([]string{}, mapping.Include...), mapping.Exclude...) {
+			_, err := regexp.Compile(p)
+			if err != nil {
+				return fmt.Errorf("identity group mapping %s has an invalid pattern %q: %w", mapping.ID, p, err)
+			}
+		}
+	}
+	return nil
+}
+
+// UnmarshalIdentityGroupMappings parses the JSON identity group mappings.
+// If `data` is an empty string, no mappings are returned.
+//
+// Unlike UnmarshalFeatureMap, backslashes are not removed as they are used to escape regular expressions.
+func UnmarshalIdentityGroupMappings(data string) (IdentityGroupMappings, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var m IdentityGroupMappings
+	err := json.Unmarshal([]byte(data), &m)
+	if err != nil {
+		return nil, err
+	}
+	return m, m.Validate()
+}
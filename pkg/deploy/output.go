@@ -43,6 +43,7 @@ type Output struct {
 	Region                        string `json:"Region"`
 	PaginationKMSKeyARN           string `json:"PaginationKMSKeyARN"`
 	AccessHandlerExecutionRoleARN string `json:"AccessHandlerExecutionRoleARN"`
+	AccessHandlerAPIURL           string `json:"AccessHandlerAPIURL"`
 }
 
 func (c Output) FrontendURL() string {
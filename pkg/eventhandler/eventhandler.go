@@ -7,8 +7,10 @@ import (
 	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/benbjohnson/clock"
 	"github.com/common-fate/ddb"
 	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/eventlog"
 	"github.com/common-fate/granted-approvals/pkg/gevent"
 	"github.com/common-fate/granted-approvals/pkg/storage"
 	"github.com/common-fate/granted-approvals/pkg/storage/dbupdate"
@@ -17,31 +19,102 @@ import (
 
 // EventHandler provides handler methods for updating items in Db in response to external events such as from teh access handler
 type EventHandler struct {
-	db ddb.Storage
+	db    ddb.Storage
+	clock clock.Clock
 }
 
 func New(ctx context.Context, db ddb.Storage) (*EventHandler, error) {
-	return &EventHandler{db: db}, nil
+	return &EventHandler{db: db, clock: clock.New()}, nil
 }
 
+// HandleEvent processes an event. If processing fails, the event is saved as a dead letter
+// so that it can be replayed later, and the error is returned so that EventBridge retries it.
+// If a retry succeeds, the dead letter saved by the earlier attempt is removed.
 func (n *EventHandler) HandleEvent(ctx context.Context, event events.CloudWatchEvent) (err error) {
 	log := zap.S().With("event", event)
 	log.Info("received event from eventbridge")
+	err = n.handleEvent(ctx, log, event)
+	if err != nil {
+		n.saveDeadLetter(ctx, log, event, err)
+		return err
+	}
+	// deleting an item which doesn't exist has no effect, so the dead letter isn't looked up first.
+	// Errors are logged rather than returned, as the event has been processed.
+	err = n.db.Delete(ctx, &eventlog.DeadLetter{ID: event.ID})
+	if err != nil {
+		log.Errorw("failed to remove dead letter", "error", err)
+	}
+	return nil
+}
+
+// Replay re-drives a dead letter through the event handler.
+// The dead letter is removed if the event is processed successfully.
+// Returns ddb.ErrNoItems if there is no dead letter for the event ID.
+func (n *EventHandler) Replay(ctx context.Context, eventID string) error {
+	q := storage.GetDeadLetter{ID: eventID}
+	_, err := n.db.Query(ctx, &q)
+	if err != nil {
+		return err
+	}
+	log := zap.S().With("event", q.Result.Event)
+	log.Info("replaying failed event")
+	err = n.handleEvent(ctx, log, q.Result.Event)
+	if err != nil {
+		n.saveDeadLetter(ctx, log, q.Result.Event, err)
+		return err
+	}
+	return n.db.Delete(ctx, q.Result)
+}
+
+func (n *EventHandler) handleEvent(ctx context.Context, log *zap.SugaredLogger, event events.CloudWatchEvent) error {
 	if strings.HasPrefix(event.DetailType, "grant") {
-		err = n.HandleGrantEvent(ctx, log, event)
-		if err != nil {
-			return err
-		}
-	} else {
-		log.Info("ignoring unhandled event type")
+		return n.HandleGrantEvent(ctx, log, event)
 	}
+	log.Info("ignoring unhandled event type")
 	return nil
 }
 
+// saveDeadLetter records a failed event, incrementing the attempt count if it has failed before.
+// Errors are logged rather than returned so that the original error is surfaced to the caller.
+func (n *EventHandler) saveDeadLetter(ctx context.Context, log *zap.SugaredLogger, event events.CloudWatchEvent, handleErr error) {
+	now := n.clock.Now()
+	q := storage.GetDeadLetter{ID: event.ID}
+	_, err := n.db.Query(ctx, &q)
+	if err != nil && err != ddb.ErrNoItems {
+		log.Errorw("failed to look up dead letter", "error", err)
+		return
+	}
+
+	var dl eventlog.DeadLetter
+	if q.Result != nil {
+		dl = *q.Result
+		dl.RecordFailure(handleErr, now)
+	} else {
+		dl = eventlog.NewDeadLetter(event, handleErr, now)
+	}
+
+	log.Errorw("failed to handle event, saving dead letter", "error", handleErr, "attempts", dl.Attempts)
+	err = n.db.Put(ctx, &dl)
+	if err != nil {
+		log.Errorw("failed to save dead letter", "error", err)
+	}
+}
+
 // HandleGrantEvent will update the status of a grant in response to events emitted by the access handler
 func (n *EventHandler) HandleGrantEvent(ctx context.Context, log *zap.SugaredLogger, event events.CloudWatchEvent) error {
+	// the event ID is used as an idempotency key, so that retried or replayed events are only applied once.
+	pq := storage.GetProcessedEvent{ID: event.ID}
+	_, err := n.db.Query(ctx, &pq)
+	if err == nil {
+		log.Infow("Ignored event which has already been processed", "processedAt", pq.Result.ProcessedAt)
+		return nil
+	}
+	if err != ddb.ErrNoItems {
+		return err
+	}
+
 	var grantEvent gevent.GrantEventPayload
-	err := json.Unmarshal(event.Detail, &grantEvent)
+	err = json.Unmarshal(event.Detail, &grantEvent)
 	if err != nil {
 		return err
 	}
@@ -59,6 +132,13 @@ func (n *EventHandler) HandleGrantEvent(ctx context.Context, log *zap.SugaredLog
 		log.Infow("Ignored grant revoke event")
 		return nil
 	}
+	// expiry warnings don't change the status of the grant
+	if event.DetailType == gevent.GrantExpiringType {
+		log.Infow("Ignored grant expiring event")
+		return nil
+	}
+	processed := eventlog.Processed{ID: event.ID, DetailType: event.DetailType, ProcessedAt: n.clock.Now()}
+
 	oldStatus := gq.Result.Grant.Status
 	newStatus := grantEvent.Grant.Status
 	gq.Result.Grant.Status = newStatus
@@ -66,10 +146,11 @@ func (n *EventHandler) HandleGrantEvent(ctx context.Context, log *zap.SugaredLog
 	// I anticipate that this would be succeptible to a race condition, recoverable if the eventbridge retries the event handler
 	// this is because the grant events are sourced from the access handler prior to the request being saved to dynamodb on creation
 	// we could solve this by saving the request to the DB prior to making the call to the access handler?
+	// If the retries are exhausted, the event is saved as a dead letter and can be replayed.
 	if event.DetailType == gevent.GrantCreatedType {
 		requestEvent := access.NewGrantCreatedEvent(gq.Result.ID, event.Time)
 		log.Infow("inserting request event for grant created")
-		return n.db.Put(ctx, &requestEvent)
+		return n.db.TransactWriteItems(ctx, []ddb.TransactWriteItem{{Put: &requestEvent}, {Put: &processed}})
 	}
 	var requestEvent access.RequestEvent
 
@@ -90,7 +171,17 @@ func (n *EventHandler) HandleGrantEvent(ctx context.Context, log *zap.SugaredLog
 	if err != nil {
 		return err
 	}
-	items = append(items, &requestEvent)
-	// Updates the grant status
-	return n.db.PutBatch(ctx, items...)
+
+	// The first item is the request, the rest are the copies of it saved for each reviewer.
+	// Transactions are limited to 100 items, so the reviewer copies are written before the transaction
+	// which records the event as processed. If writing them fails the event is retried, and writing them again has no other effect.
+	if len(items) > 1 {
+		err = n.db.PutBatch(ctx, items[1:]...)
+		if err != nil {
+			return err
+		}
+	}
+	// Updates the grant status and records the event as processed in a single transaction,
+	// so that the status change is never applied twice.
+	return n.db.TransactWriteItems(ctx, []ddb.TransactWriteItem{{Put: items[0]}, {Put: &requestEvent}, {Put: &processed}})
 }
@@ -0,0 +1,180 @@
+package eventhandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/benbjohnson/clock"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/ddb/ddbmock"
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/eventlog"
+	"github.com/common-fate/granted-approvals/pkg/gevent"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleEvent(t *testing.T) {
+	type testcase struct {
+		name          string
+		processed     *eventlog.Processed
+		request       *access.Request
+		deadLetter    *eventlog.DeadLetter
+		deadLetterErr error
+		wantErr       string
+	}
+
+	now := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	event := events.CloudWatchEvent{
+		ID:         "evt_123",
+		DetailType: gevent.GrantActivatedType,
+		Source:     "commonfate.io/granted",
+		Time:       now,
+		Detail:     []byte(`{"grant":{"id":"req_123","status":"ACTIVE"}}`),
+	}
+
+	testcases := []testcase{
+		{
+			name:      "already processed events are skipped",
+			processed: &eventlog.Processed{ID: "evt_123", DetailType: gevent.GrantActivatedType, ProcessedAt: now},
+		},
+		{
+			name:          "failed events are saved as a dead letter",
+			request:       &access.Request{ID: "req_123"},
+			deadLetterErr: ddb.ErrNoItems,
+			wantErr:       "request: req_123 does not have a grant",
+		},
+		{
+			name:       "repeated failures update the existing dead letter",
+			request:    &access.Request{ID: "req_123"},
+			deadLetter: &eventlog.DeadLetter{ID: "evt_123", Attempts: 1},
+			wantErr:    "request: req_123 does not have a grant",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			if tc.processed != nil {
+				db.MockQuery(&storage.GetProcessedEvent{Result: tc.processed})
+			} else {
+				db.MockQueryWithErr(&storage.GetProcessedEvent{}, ddb.ErrNoItems)
+			}
+			db.MockQuery(&storage.GetRequest{Result: tc.request})
+			db.MockQueryWithErr(&storage.GetDeadLetter{Result: tc.deadLetter}, tc.deadLetterErr)
+
+			clk := clock.NewMock()
+			clk.Set(now)
+			h := EventHandler{db: db, clock: clk}
+
+			err := h.HandleEvent(context.Background(), event)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHandleGrantEventManyReviewers(t *testing.T) {
+	now := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	event := events.CloudWatchEvent{
+		ID:         "evt_123",
+		DetailType: gevent.GrantActivatedType,
+		Source:     "commonfate.io/granted",
+		Time:       now,
+		Detail:     []byte(`{"grant":{"id":"req_123","status":"ACTIVE"}}`),
+	}
+	request := access.Request{ID: "req_123", Grant: &access.Grant{Status: "PENDING"}}
+	// more reviewers than can be written in a single transaction
+	var reviewers []access.Reviewer
+	for i := 0; i < 150; i++ {
+		reviewers = append(reviewers, access.Reviewer{ReviewerID: fmt.Sprintf("usr_%d", i), Request: request})
+	}
+
+	db := ddbmock.New(t)
+	db.MockQueryWithErr(&storage.GetProcessedEvent{}, ddb.ErrNoItems)
+	db.MockQuery(&storage.GetRequest{Result: &request})
+	db.MockQuery(&storage.ListRequestReviewers{Result: reviewers})
+	rdb := recordingDB{Client: db}
+
+	clk := clock.NewMock()
+	clk.Set(now)
+	h := EventHandler{db: &rdb, clock: clk}
+
+	err := h.HandleEvent(context.Background(), event)
+	assert.NoError(t, err)
+
+	assert.Len(t, rdb.tx, 3)
+	assert.IsType(t, &access.Request{}, rdb.tx[0].Put)
+	assert.IsType(t, &access.RequestEvent{}, rdb.tx[1].Put)
+	assert.IsType(t, &eventlog.Processed{}, rdb.tx[2].Put)
+	assert.Len(t, rdb.batch, 150)
+	for _, item := range rdb.batch {
+		assert.Equal(t, "ACTIVE", string(item.(*access.Reviewer).Request.Grant.Status))
+	}
+	// the event succeeded, so any dead letter from an earlier attempt is removed.
+	assert.Equal(t, []ddb.Keyer{&eventlog.DeadLetter{ID: "evt_123"}}, rdb.deleted)
+}
+
+func TestHandleGrantEventReviewerCopiesFail(t *testing.T) {
+	now := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	event := events.CloudWatchEvent{
+		ID:         "evt_123",
+		DetailType: gevent.GrantActivatedType,
+		Source:     "commonfate.io/granted",
+		Time:       now,
+		Detail:     []byte(`{"grant":{"id":"req_123","status":"ACTIVE"}}`),
+	}
+	request := access.Request{ID: "req_123", Grant: &access.Grant{Status: "PENDING"}}
+
+	db := ddbmock.New(t)
+	db.MockQueryWithErr(&storage.GetProcessedEvent{}, ddb.ErrNoItems)
+	db.MockQuery(&storage.GetRequest{Result: &request})
+	db.MockQuery(&storage.ListRequestReviewers{Result: []access.Reviewer{{ReviewerID: "usr_1", Request: request}}})
+	db.MockQueryWithErr(&storage.GetDeadLetter{}, ddb.ErrNoItems)
+	rdb := recordingDB{Client: db, batchErr: errors.New("throttled")}
+
+	clk := clock.NewMock()
+	clk.Set(now)
+	h := EventHandler{db: &rdb, clock: clk}
+
+	err := h.HandleEvent(context.Background(), event)
+	assert.EqualError(t, err, "throttled")
+	// the event isn't recorded as processed, so that it's applied again when it's retried.
+	assert.Empty(t, rdb.tx)
+	assert.Empty(t, rdb.deleted)
+}
+
+// recordingDB records the items which are written and deleted.
+type recordingDB struct {
+	*ddbmock.Client
+	tx      []ddb.TransactWriteItem
+	batch   []ddb.Keyer
+	deleted []ddb.Keyer
+	// batchErr is returned by PutBatch, without writing the items.
+	batchErr error
+}
+
+func (r *recordingDB) TransactWriteItems(ctx context.Context, tx []ddb.TransactWriteItem) error {
+	r.tx = append(r.tx, tx...)
+	return nil
+}
+
+func (r *recordingDB) PutBatch(ctx context.Context, items ...ddb.Keyer) error {
+	if r.batchErr != nil {
+		return r.batchErr
+	}
+	r.batch = append(r.batch, items...)
+	return nil
+}
+
+func (r *recordingDB) Delete(ctx context.Context, item ddb.Keyer) error {
+	r.deleted = append(r.deleted, item)
+	return nil
+}
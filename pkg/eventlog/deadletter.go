@@ -0,0 +1,67 @@
+package eventlog
+
+import (
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/storage/keys"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+// DeadLetter is an event which the event handler failed to process.
+type DeadLetter struct {
+	// ID is the EventBridge event ID, which is used as the idempotency key for the event.
+	ID         string `json:"id" dynamodbav:"id"`
+	DetailType string `json:"detailType" dynamodbav:"detailType"`
+	Source     string `json:"source" dynamodbav:"source"`
+	// Event is the original event, which is passed back to the event handler when replaying.
+	Event events.CloudWatchEvent `json:"event" dynamodbav:"event"`
+	// Error is the error returned by the most recent attempt to process the event.
+	Error string `json:"error" dynamodbav:"error"`
+	// Attempts is the number of times the event has failed to be processed.
+	Attempts      int       `json:"attempts" dynamodbav:"attempts"`
+	FirstFailedAt time.Time `json:"firstFailedAt" dynamodbav:"firstFailedAt"`
+	LastFailedAt  time.Time `json:"lastFailedAt" dynamodbav:"lastFailedAt"`
+}
+
+// NewDeadLetter creates a dead letter for an event which failed for the first time.
+func NewDeadLetter(event events.CloudWatchEvent, err error, now time.Time) DeadLetter {
+	return DeadLetter{
+		ID:            event.ID,
+		DetailType:    event.DetailType,
+		Source:        event.Source,
+		Event:         event,
+		Error:         err.Error(),
+		Attempts:      1,
+		FirstFailedAt: now,
+		LastFailedAt:  now,
+	}
+}
+
+// RecordFailure updates the dead letter after another failed attempt to process the event.
+func (d *DeadLetter) RecordFailure(err error, now time.Time) {
+	d.Error = err.Error()
+	d.Attempts++
+	d.LastFailedAt = now
+}
+
+func (d *DeadLetter) ToAPI() types.FailedEvent {
+	return types.FailedEvent{
+		Id:            d.ID,
+		DetailType:    d.DetailType,
+		Source:        d.Source,
+		Error:         d.Error,
+		Attempts:      d.Attempts,
+		FirstFailedAt: d.FirstFailedAt,
+		LastFailedAt:  d.LastFailedAt,
+	}
+}
+
+func (d *DeadLetter) DDBKeys() (ddb.Keys, error) {
+	keys := ddb.Keys{
+		PK: keys.DeadLetter.PK1,
+		SK: keys.DeadLetter.SK1(d.ID),
+	}
+	return keys, nil
+}
@@ -0,0 +1,6 @@
+// Package eventlog records the delivery state of events processed by the event handler.
+//
+// Events which fail to be handled are persisted as dead letters so that they can be
+// inspected and replayed by an administrator. Events which are handled successfully are
+// recorded so that a redelivered or replayed event is never applied twice.
+package eventlog
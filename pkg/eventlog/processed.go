@@ -0,0 +1,26 @@
+package eventlog
+
+import (
+	"time"
+
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/storage/keys"
+)
+
+// Processed marks an event as having been applied by the event handler.
+// It is written in the same transaction as the changes made by the event,
+// so if it exists the event must not be applied again.
+type Processed struct {
+	// ID is the EventBridge event ID.
+	ID          string    `json:"id" dynamodbav:"id"`
+	DetailType  string    `json:"detailType" dynamodbav:"detailType"`
+	ProcessedAt time.Time `json:"processedAt" dynamodbav:"processedAt"`
+}
+
+func (p *Processed) DDBKeys() (ddb.Keys, error) {
+	keys := ddb.Keys{
+		PK: keys.ProcessedEvent.PK1,
+		SK: keys.ProcessedEvent.SK1(p.ID),
+	}
+	return keys, nil
+}
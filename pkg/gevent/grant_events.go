@@ -8,6 +8,7 @@ const (
 	GrantExpiredType   = "grant.expired"
 	GrantRevokedType   = "grant.revoked"
 	GrantFailedType    = "grant.failed"
+	GrantExpiringType  = "grant.expiring"
 )
 
 // GrantCreated is emitted when a new grant is
@@ -79,6 +80,17 @@ func (GrantFailed) EventType() string {
 	return GrantFailedType
 }
 
+// GrantExpiring is emitted ahead of an active
+// grant's end time, so that the user can be warned
+// that their access is about to expire.
+type GrantExpiring struct {
+	Grant types.Grant `json:"grant"`
+}
+
+func (GrantExpiring) EventType() string {
+	return GrantExpiringType
+}
+
 // GrantEventPayload is a payload which is common to
 // all Grant events. It is used to conveniently unmarshal
 // the Grant payloads in our event handler code.
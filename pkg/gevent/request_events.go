@@ -7,6 +7,8 @@ const (
 	RequestApprovedType  = "request.approved"
 	RequestCancelledType = "request.cancelled"
 	RequestDeclinedType  = "request.declined"
+	RequestReminderType  = "request.reminder"
+	RequestEscalatedType = "request.escalated"
 )
 
 // RequestCreated is emitted when a user requests access
@@ -47,10 +49,34 @@ func (RequestDeclined) EventType() string {
 	return RequestDeclinedType
 }
 
+// RequestReminder is emitted when reviewers are reminded
+// about a request which is still pending.
+type RequestReminder struct {
+	Request     access.Request `json:"request"`
+	ReviewerIDs []string       `json:"reviewerIds"`
+}
+
+func (RequestReminder) EventType() string {
+	return RequestReminderType
+}
+
+// RequestEscalated is emitted when a pending request has not been
+// reviewed in time and is escalated to the rule's escalation groups.
+type RequestEscalated struct {
+	Request     access.Request `json:"request"`
+	ReviewerIDs []string       `json:"reviewerIds"`
+}
+
+func (RequestEscalated) EventType() string {
+	return RequestEscalatedType
+}
+
 // RequestEventPayload is a payload which is common to
 // all Request events. It is used to conveniently unmarshal
 // the Request payloads in our event handler code.
 type RequestEventPayload struct {
 	Request    access.Request `json:"request"`
 	ReviewerID string         `json:"reviewerId"`
+	// ReviewerIDs is only set for reminder and escalation events.
+	ReviewerIDs []string `json:"reviewerIds,omitempty"`
 }
@@ -0,0 +1,17 @@
+package gevent
+
+import "github.com/common-fate/granted-approvals/pkg/identity"
+
+const (
+	UserOffboardedType = "user.offboarded"
+)
+
+// UserOffboarded is emitted when a user who was removed from the identity provider
+// has had their pending requests cancelled and their active grants revoked.
+type UserOffboarded struct {
+	Offboarding identity.Offboarding `json:"offboarding"`
+}
+
+func (UserOffboarded) EventType() string {
+	return UserOffboardedType
+}
@@ -0,0 +1,110 @@
+package identity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/storage/keys"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+// APIKeyPrefix is the prefix of every API key, which distinguishes API keys from Cognito tokens.
+const APIKeyPrefix = "gak_"
+
+// APIKey provides programmatic access to the Approvals API as a service account.
+// The key itself is only returned when it is created; only its hash is stored.
+type APIKey struct {
+	ID          string             `json:"id" dynamodbav:"id"`
+	Name        string             `json:"name" dynamodbav:"name"`
+	Description string             `json:"description" dynamodbav:"description"`
+	Scope       types.APIKeyScope  `json:"scope" dynamodbav:"scope"`
+	Status      types.APIKeyStatus `json:"status" dynamodbav:"status"`
+	// Hash is the hex-encoded SHA-256 hash of the key.
+	Hash string `json:"hash" dynamodbav:"hash"`
+	// Prefix is the first characters of the key, which are shown to admins to identify it.
+	Prefix string `json:"prefix" dynamodbav:"prefix"`
+	// CreatedBy is the ID of the user who created the key.
+	CreatedBy string    `json:"createdBy" dynamodbav:"createdBy"`
+	CreatedAt time.Time `json:"createdAt" dynamodbav:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" dynamodbav:"updatedAt"`
+}
+
+// NewAPIKey generates a new API key. The returned secret is the key itself, which must be shown to the
+// admin who created it as it can't be recovered from the stored hash.
+func NewAPIKey(name, description string, scope types.APIKeyScope, createdBy string, now time.Time) (APIKey, string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return APIKey{}, "", err
+	}
+	secret := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+	k := APIKey{
+		ID:          types.NewAPIKeyID(),
+		Name:        name,
+		Description: description,
+		Scope:       scope,
+		Status:      types.APIKeyStatusACTIVE,
+		Hash:        HashAPIKey(secret),
+		Prefix:      secret[:len(APIKeyPrefix)+6],
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	return k, secret, nil
+}
+
+// HashAPIKey returns the hash of an API key which is stored in the database.
+// API keys have enough entropy that a salt isn't required.
+func HashAPIKey(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
+
+// IsAPIKey returns true if the token is an API key rather than a Cognito token.
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+// Principal returns the service account user which requests authenticated with the key act as.
+// The service account has the same ID as the key, so that the key shows up as the actor in request events.
+// It doesn't belong to any groups, so it can't request access itself.
+func (k *APIKey) Principal() User {
+	return User{
+		ID:        k.ID,
+		FirstName: k.Name,
+		Groups:    []string{},
+		Status:    types.IdpStatusACTIVE,
+		CreatedAt: k.CreatedAt,
+		UpdatedAt: k.UpdatedAt,
+	}
+}
+
+func (k *APIKey) ToAPI() types.APIKey {
+	description := k.Description
+	return types.APIKey{
+		Id:          k.ID,
+		Name:        k.Name,
+		Description: &description,
+		Scope:       k.Scope,
+		Status:      k.Status,
+		Prefix:      k.Prefix,
+		CreatedBy:   k.CreatedBy,
+		CreatedAt:   k.CreatedAt,
+	}
+}
+
+func (k *APIKey) DDBKeys() (ddb.Keys, error) {
+	keys := ddb.Keys{
+		PK:     keys.APIKeys.PK1,
+		SK:     keys.APIKeys.SK1(k.ID),
+		GSI1PK: keys.APIKeys.GSI1PK,
+		GSI1SK: keys.APIKeys.GSI1SK(k.Hash),
+	}
+
+	return keys, nil
+}
@@ -12,18 +12,22 @@ type IDPGroup struct {
 	ID          string
 	Name        string
 	Description string
+	// ParentGroups are the idp ids of the groups this group is a direct member of.
+	// It should be set by identity providers which support nested groups.
+	ParentGroups []string
 }
 
 func (g IDPGroup) ToInternalGroup() Group {
 	now := time.Now()
 	return Group{
-		ID:          g.ID,
-		IdpID:       g.ID,
-		Name:        g.Name,
-		Description: g.Description,
-		Status:      types.IdpStatusACTIVE,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:           g.ID,
+		IdpID:        g.ID,
+		Name:         g.Name,
+		Description:  g.Description,
+		Status:       types.IdpStatusACTIVE,
+		ParentGroups: append([]string{}, g.ParentGroups...),
+		CreatedAt:    now,
+		UpdatedAt:    now,
 	}
 }
 
@@ -34,11 +38,30 @@ type Group struct {
 	Name        string          `json:"name" dynamodbav:"name"`
 	Description string          `json:"description" dynamodbav:"description"`
 	Status      types.IdpStatus `json:"status" dynamodbav:"status"`
-	Users       []string        `json:"users" dynamodbav:"users"`
+	// Users are the direct members of the group.
+	Users []string `json:"users" dynamodbav:"users"`
+	// InheritedUsers are the users who are members of the group through nested groups, but not directly.
+	InheritedUsers []string `json:"inheritedUsers,omitempty" dynamodbav:"inheritedUsers,omitempty"`
+	// ParentGroups are the groups this group is a direct member of.
+	ParentGroups []string `json:"parentGroups,omitempty" dynamodbav:"parentGroups,omitempty"`
 
 	// CreatedAt is a read-only field after the request has been created.
 	CreatedAt time.Time `json:"createdAt" dynamodbav:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt" dynamodbav:"updatedAt"`
+	// Version is incremented when the group is written by an incremental sync,
+	// so that concurrent changes to the group's members aren't lost.
+	Version int `json:"version,omitempty" dynamodbav:"version,omitempty"`
+}
+
+// EffectiveUsers returns the users who are members of the group, either directly or through nested groups.
+func (g *Group) EffectiveUsers() []string {
+	users := append([]string{}, g.Users...)
+	for _, u := range g.InheritedUsers {
+		if !contains(users, u) {
+			users = append(users, u)
+		}
+	}
+	return users
 }
 
 func (g *Group) ToAPI() types.Group {
@@ -46,7 +69,7 @@ func (g *Group) ToAPI() types.Group {
 		Name:        g.Name,
 		Description: g.Description,
 		Id:          g.ID,
-		MemberCount: len(g.Users),
+		MemberCount: len(g.EffectiveUsers()),
 	}
 
 	return req
@@ -1,7 +1,6 @@
 package identitysync
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -24,6 +23,8 @@ type AzureSync struct {
 	clientID        gconfig.StringValue
 	clientSecret    gconfig.SecretStringValue
 	emailIdentifier gconfig.OptionalStringValue
+	// webhookSecret is the clientState of the Microsoft Graph change notification subscriptions.
+	webhookSecret gconfig.OptionalStringValue
 }
 
 func (s *AzureSync) Config() gconfig.Config {
@@ -32,6 +33,7 @@ func (s *AzureSync) Config() gconfig.Config {
 		gconfig.StringField("clientId", &s.clientID, "the Azure AD client ID"),
 		gconfig.OptionalStringField("emailIdentifier", &s.emailIdentifier, "the user attribute to be used as the email address"),
 		gconfig.SecretStringField("clientSecret", &s.clientSecret, "the Azure AD client secret", gconfig.WithNoArgs("/granted/secrets/identity/azure/secret")),
+		gconfig.OptionalStringField("webhookSecret", &s.webhookSecret, "the clientState of the Microsoft Graph change notification subscriptions (optional, enables incremental sync)"),
 	}
 }
 
@@ -97,12 +99,6 @@ type AzureGroup struct {
 	DisplayName string `json:"displayName"`
 }
 
-type UserGroups struct {
-	OdataNextLink *string  `json:"@odata.nextLink,omitempty"`
-	OdataContext  string   `json:"@odata.context"`
-	Value         []string `json:"value"`
-}
-
 // safeMapGet returns an empty string if the field doesn't exist
 // it uses fmt.Sprintf to convert the field to a string.
 func safeMapGet(dict map[string]interface{}, key string) string {
@@ -151,60 +147,45 @@ func (a *AzureSync) idpUserFromAzureUser(ctx context.Context, azureUser map[stri
 		return identity.IDPUser{}, fmt.Errorf("could not find email for user %s (using attribute %s)", u.ID, emailAttribute)
 	}
 
-	// g, err := a.GetMemberGroups(u.ID)
-	// if err != nil {
-	// 	return identity.IDPUser{}, err
-	// }
-	// u.Groups = g
-
 	return u, nil
 }
 
-func (a *AzureSync) GetMemberGroups(userID string) ([]string, error) {
-	var userGroups []string
-
-	hasMore := true
-	var nextToken *string
-	url := MSGraphBaseURL + fmt.Sprintf("/directoryObjects/%s/getMemberGroups", userID)
-
-	for hasMore {
-		var jsonStr = []byte(`{ "securityEnabledOnly": false}`)
-		req, _ := http.NewRequest("POST", url, bytes.NewBuffer(jsonStr))
-		req.Header.Add("Authorization", "Bearer "+a.token.Get())
-		req.Header.Set("Content-Type", "application/json")
+// memberOfResponse is a page of groups which a directory object is a direct member of.
+type memberOfResponse struct {
+	OdataNextLink *string `json:"@odata.nextLink,omitempty"`
+	Value         []struct {
+		ID string `json:"id"`
+	} `json:"value"`
+}
 
-		res, err := http.DefaultClient.Do(req)
+// GetMemberOf returns the IDs of the groups which a user or group is a direct member of.
+// Membership of nested groups is resolved by identity sync, so transitive memberships aren't included.
+//
+// objectType is either "users" or "groups".
+//
+// see: https://learn.microsoft.com/en-us/graph/api/user-list-memberof?view=graph-rest-1.0
+func (a *AzureSync) GetMemberOf(objectType string, id string) ([]string, error) {
+	groups := []string{}
+	url := MSGraphBaseURL + fmt.Sprintf("/%s/%s/memberOf/microsoft.graph.group?$select=id", objectType, id)
+	for url != "" {
+		b, err := a.get(url)
 		if err != nil {
 			return nil, err
 		}
-
-		b, err := io.ReadAll(res.Body)
+		var res memberOfResponse
+		err = json.Unmarshal(b, &res)
 		if err != nil {
 			return nil, err
 		}
-
-		//return the error if its anything but a 200
-		if res.StatusCode != 200 {
-			return nil, fmt.Errorf(string(b))
-		}
-
-		var lu UserGroups
-		err = json.Unmarshal(b, &lu)
-		if err != nil {
-			return nil, err
+		for _, g := range res.Value {
+			groups = append(groups, g.ID)
 		}
-
-		userGroups = append(userGroups, lu.Value...)
-
-		nextToken = lu.OdataNextLink
-		if nextToken != nil {
-			url = *nextToken
-		} else {
-			hasMore = false
+		url = ""
+		if res.OdataNextLink != nil {
+			url = *res.OdataNextLink
 		}
-
 	}
-	return userGroups, nil
+	return groups, nil
 }
 
 func (a *AzureSync) ListUsers(ctx context.Context) ([]identity.IDPUser, error) {
@@ -239,7 +220,7 @@ func (a *AzureSync) ListUsers(ctx context.Context) ([]identity.IDPUser, error) {
 		}
 
 		for _, u := range lu.Value {
-			groups, err := a.GetMemberGroups(safeMapGet(u, "id"))
+			groups, err := a.GetMemberOf("users", safeMapGet(u, "id"))
 			if err != nil {
 				return nil, err
 			}
@@ -301,6 +282,8 @@ func (a *AzureSync) ListGroups(ctx context.Context) ([]identity.IDPGroup, error)
 		for _, u := range lu.Value {
 
 			group := idpGroupFromAzureGroup(u)
+			// Azure AD groups can be members of other groups.
+			group.ParentGroups, err = a.GetMemberOf("groups", u.ID)
 			if err != nil {
 				return nil, err
 			}
@@ -0,0 +1,127 @@
+package identitysync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/common-fate/granted-approvals/pkg/identity"
+)
+
+// azureChangeNotifications is the payload of a Microsoft Graph change notification.
+//
+// see: https://docs.microsoft.com/en-us/graph/webhooks
+type azureChangeNotifications struct {
+	Value []struct {
+		ClientState  string `json:"clientState"`
+		ChangeType   string `json:"changeType"`
+		Resource     string `json:"resource"`
+		ResourceData struct {
+			ODataType string `json:"@odata.type"`
+			ID        string `json:"id"`
+			// MembersDelta is included in notifications for group subscriptions when the members of a group change.
+			MembersDelta []struct {
+				ID string `json:"id"`
+			} `json:"members@delta"`
+		} `json:"resourceData"`
+	} `json:"value"`
+}
+
+// ParseWebhook parses a Microsoft Graph change notification for the users and groups resources.
+// Azure AD doesn't include the email of a deleted user in notifications,
+// so deleting a user results in a full sync.
+func (a *AzureSync) ParseWebhook(r *http.Request) (*WebhookResult, error) {
+	if a.webhookSecret.Get() == "" {
+		return nil, ErrWebhookNotConfigured
+	}
+
+	// Microsoft Graph validates the endpoint when a subscription is created by sending a validation token,
+	// which must be returned as plain text.
+	if token := r.URL.Query().Get("validationToken"); token != "" {
+		return &WebhookResult{Challenge: &WebhookChallenge{ContentType: "text/plain", Body: []byte(token)}}, nil
+	}
+
+	var n azureChangeNotifications
+	err := json.NewDecoder(r.Body).Decode(&n)
+	if err != nil {
+		return nil, err
+	}
+
+	res := WebhookResult{Changes: []Change{}}
+	for _, v := range n.Value {
+		err = verifyWebhookSecret(a.webhookSecret.Get(), v.ClientState)
+		if err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(v.ResourceData.ODataType) {
+		case "#microsoft.graph.user":
+			res.Changes = append(res.Changes, Change{Kind: ChangeKindUser, ID: v.ResourceData.ID})
+		case "#microsoft.graph.group":
+			res.Changes = append(res.Changes, Change{Kind: ChangeKindGroup, ID: v.ResourceData.ID})
+			for _, m := range v.ResourceData.MembersDelta {
+				res.Changes = append(res.Changes, Change{Kind: ChangeKindUser, ID: m.ID})
+			}
+		}
+	}
+	return &res, nil
+}
+
+// get makes a GET request to the Microsoft Graph API and returns the response body.
+func (a *AzureSync) get(url string) ([]byte, error) {
+	req, _ := http.NewRequest("GET", url, nil)
+	req.Header.Add("Authorization", "Bearer "+a.token.Get())
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	//return the error if its anything but a 200
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf(string(b))
+	}
+	return b, nil
+}
+
+func (a *AzureSync) GetUser(ctx context.Context, id string) (identity.IDPUser, error) {
+	b, err := a.get(MSGraphBaseURL + "/users/" + id)
+	if err != nil {
+		return identity.IDPUser{}, err
+	}
+	var u map[string]interface{}
+	err = json.Unmarshal(b, &u)
+	if err != nil {
+		return identity.IDPUser{}, err
+	}
+	groups, err := a.GetMemberOf("users", id)
+	if err != nil {
+		return identity.IDPUser{}, err
+	}
+	return a.idpUserFromAzureUser(ctx, u, groups)
+}
+
+func (a *AzureSync) GetGroup(ctx context.Context, id string) (identity.IDPGroup, error) {
+	b, err := a.get(MSGraphBaseURL + "/groups/" + id)
+	if err != nil {
+		return identity.IDPGroup{}, err
+	}
+	var g AzureGroup
+	err = json.Unmarshal(b, &g)
+	if err != nil {
+		return identity.IDPGroup{}, err
+	}
+	group := idpGroupFromAzureGroup(g)
+	group.ParentGroups, err = a.GetMemberOf("groups", id)
+	if err != nil {
+		return identity.IDPGroup{}, err
+	}
+	return group, nil
+}
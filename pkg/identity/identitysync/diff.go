@@ -0,0 +1,152 @@
+package identitysync
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+// DefaultArchiveThreshold is the percentage of active users which a sync may archive before it is aborted.
+const DefaultArchiveThreshold = 50
+
+// ArchiveThresholdError is returned when a sync would archive more than the allowed percentage of active users.
+// This usually means that the identity provider is misconfigured, for example if its API token only has
+// access to a subset of users.
+type ArchiveThresholdError struct {
+	Archived  int
+	Active    int
+	Threshold int
+}
+
+func (e *ArchiveThresholdError) Error() string {
+	return fmt.Sprintf("sync aborted: %d of %d active users would be archived, which is more than the threshold of %d%%. Run the sync with force enabled if this is expected", e.Archived, e.Active, e.Threshold)
+}
+
+// Diff describes the changes a sync makes to users and groups.
+type Diff struct {
+	// UsersAdded contains the emails of users who are created or reactivated.
+	UsersAdded []string `json:"usersAdded"`
+	// UsersArchived contains the emails of users who are archived.
+	UsersArchived []string `json:"usersArchived"`
+	// UsersChanged contains the users whose group memberships change.
+	UsersChanged []UserGroupsChange `json:"usersChanged"`
+	// GroupsAdded contains the IDs of groups which are created or reactivated.
+	GroupsAdded []string `json:"groupsAdded"`
+	// GroupsRemoved contains the IDs of groups which are archived.
+	GroupsRemoved []string `json:"groupsRemoved"`
+	// ActiveUsers is the number of active users before the sync.
+	ActiveUsers int `json:"activeUsers"`
+}
+
+// UserGroupsChange describes the groups a user is added to or removed from by a sync.
+type UserGroupsChange struct {
+	Email         string   `json:"email"`
+	GroupsAdded   []string `json:"groupsAdded"`
+	GroupsRemoved []string `json:"groupsRemoved"`
+}
+
+// IsEmpty returns true if the sync doesn't change any users or groups.
+func (d Diff) IsEmpty() bool {
+	return len(d.UsersAdded) == 0 && len(d.UsersArchived) == 0 && len(d.UsersChanged) == 0 && len(d.GroupsAdded) == 0 && len(d.GroupsRemoved) == 0
+}
+
+// ExceedsArchiveThreshold returns true if the sync archives more than threshold percent of active users.
+func (d Diff) ExceedsArchiveThreshold(threshold int) bool {
+	if d.ActiveUsers == 0 {
+		return false
+	}
+	return len(d.UsersArchived)*100 > threshold*d.ActiveUsers
+}
+
+// diffUsersAndGroups compares the users and groups in the database with the result of processUsersAndGroups.
+// Each list in the diff is sorted so that the diff is stable between runs.
+func diffUsersAndGroups(beforeUsers []identity.User, beforeGroups []identity.Group, afterUsers map[string]identity.User, afterGroups map[string]identity.Group) Diff {
+	d := Diff{
+		UsersAdded:    []string{},
+		UsersArchived: []string{},
+		UsersChanged:  []UserGroupsChange{},
+		GroupsAdded:   []string{},
+		GroupsRemoved: []string{},
+	}
+
+	beforeUserMap := make(map[string]identity.User)
+	for _, u := range beforeUsers {
+		beforeUserMap[u.Email] = u
+		if u.Status == types.IdpStatusACTIVE {
+			d.ActiveUsers++
+		}
+	}
+	for email, after := range afterUsers {
+		before, existed := beforeUserMap[email]
+		wasActive := existed && before.Status == types.IdpStatusACTIVE
+		isActive := after.Status == types.IdpStatusACTIVE
+		switch {
+		case isActive && !wasActive:
+			d.UsersAdded = append(d.UsersAdded, email)
+		case wasActive && !isActive:
+			d.UsersArchived = append(d.UsersArchived, email)
+		case wasActive && isActive:
+			// nested group memberships are compared too, as they grant access in the same way as direct memberships.
+			added := difference(after.EffectiveGroups(), before.EffectiveGroups())
+			removed := difference(before.EffectiveGroups(), after.EffectiveGroups())
+			if len(added) > 0 || len(removed) > 0 {
+				d.UsersChanged = append(d.UsersChanged, UserGroupsChange{Email: email, GroupsAdded: added, GroupsRemoved: removed})
+			}
+		}
+	}
+
+	beforeGroupMap := make(map[string]identity.Group)
+	for _, g := range beforeGroups {
+		beforeGroupMap[g.IdpID] = g
+	}
+	for id, after := range afterGroups {
+		before, existed := beforeGroupMap[id]
+		wasActive := existed && before.Status == types.IdpStatusACTIVE
+		isActive := after.Status == types.IdpStatusACTIVE
+		if isActive && !wasActive {
+			d.GroupsAdded = append(d.GroupsAdded, id)
+		} else if wasActive && !isActive {
+			d.GroupsRemoved = append(d.GroupsRemoved, id)
+		}
+	}
+
+	sort.Strings(d.UsersAdded)
+	sort.Strings(d.UsersArchived)
+	sort.Slice(d.UsersChanged, func(i, j int) bool { return d.UsersChanged[i].Email < d.UsersChanged[j].Email })
+	sort.Strings(d.GroupsAdded)
+	sort.Strings(d.GroupsRemoved)
+	return d
+}
+
+// difference returns the sorted elements of a which aren't in b.
+func difference(a, b []string) []string {
+	res := []string{}
+	for _, s := range a {
+		if !containsString(b, s) && !containsString(res, s) {
+			res = append(res, s)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+func (d Diff) ToAPI() types.IdentitySyncDiff {
+	res := types.IdentitySyncDiff{
+		UsersAdded:    d.UsersAdded,
+		UsersArchived: d.UsersArchived,
+		UsersChanged:  []types.IdentitySyncUserChange{},
+		GroupsAdded:   d.GroupsAdded,
+		GroupsRemoved: d.GroupsRemoved,
+		ActiveUsers:   d.ActiveUsers,
+	}
+	for _, u := range d.UsersChanged {
+		res.UsersChanged = append(res.UsersChanged, types.IdentitySyncUserChange{
+			Email:         u.Email,
+			GroupsAdded:   u.GroupsAdded,
+			GroupsRemoved: u.GroupsRemoved,
+		})
+	}
+	return res
+}
@@ -0,0 +1,109 @@
+package identitysync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/common-fate/ddb/ddbmock"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffUsersAndGroups(t *testing.T) {
+	beforeUsers := []identity.User{
+		{ID: "usr_alice", Email: "alice@example.com", Status: types.IdpStatusACTIVE, Groups: []string{"engineering"}},
+		{ID: "usr_bob", Email: "bob@example.com", Status: types.IdpStatusACTIVE, Groups: []string{"engineering"}},
+		{ID: "usr_carol", Email: "carol@example.com", Status: types.IdpStatusARCHIVED, Groups: []string{}},
+	}
+	beforeGroups := []identity.Group{
+		{ID: "engineering", IdpID: "engineering", Status: types.IdpStatusACTIVE},
+		{ID: "marketing", IdpID: "marketing", Status: types.IdpStatusACTIVE},
+	}
+	afterUsers := map[string]identity.User{
+		"alice@example.com": {ID: "usr_alice", Email: "alice@example.com", Status: types.IdpStatusACTIVE, Groups: []string{"admins"}},
+		"bob@example.com":   {ID: "usr_bob", Email: "bob@example.com", Status: types.IdpStatusARCHIVED, Groups: []string{}},
+		"carol@example.com": {ID: "usr_carol", Email: "carol@example.com", Status: types.IdpStatusARCHIVED, Groups: []string{}},
+		"dave@example.com":  {ID: "usr_dave", Email: "dave@example.com", Status: types.IdpStatusACTIVE, Groups: []string{"admins"}},
+	}
+	afterGroups := map[string]identity.Group{
+		"engineering": {ID: "engineering", IdpID: "engineering", Status: types.IdpStatusACTIVE},
+		"marketing":   {ID: "marketing", IdpID: "marketing", Status: types.IdpStatusARCHIVED},
+		"admins":      {ID: "admins", IdpID: "admins", Status: types.IdpStatusACTIVE},
+	}
+
+	got := diffUsersAndGroups(beforeUsers, beforeGroups, afterUsers, afterGroups)
+	want := Diff{
+		UsersAdded:    []string{"dave@example.com"},
+		UsersArchived: []string{"bob@example.com"},
+		UsersChanged:  []UserGroupsChange{{Email: "alice@example.com", GroupsAdded: []string{"admins"}, GroupsRemoved: []string{"engineering"}}},
+		GroupsAdded:   []string{"admins"},
+		GroupsRemoved: []string{"marketing"},
+		ActiveUsers:   2,
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestSyncArchiveThreshold(t *testing.T) {
+	type testcase struct {
+		name         string
+		threshold    int
+		opts         RunOpts
+		wantErr      error
+		wantExceeded bool
+		wantArchived []string
+	}
+
+	testcases := []testcase{
+		{
+			name:    "default threshold exceeded",
+			wantErr: &ArchiveThresholdError{Archived: 2, Active: 3, Threshold: DefaultArchiveThreshold},
+		},
+		{
+			name:         "higher threshold",
+			threshold:    70,
+			wantArchived: []string{"bob@example.com", "carol@example.com"},
+		},
+		{
+			name:         "dry run reports exceeded threshold",
+			opts:         RunOpts{DryRun: true},
+			wantExceeded: true,
+			wantArchived: []string{"bob@example.com", "carol@example.com"},
+		},
+		{
+			name:         "force",
+			opts:         RunOpts{Force: true},
+			wantExceeded: true,
+			wantArchived: []string{"bob@example.com", "carol@example.com"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			db.MockQuery(&storage.ListUsers{Result: []identity.User{
+				{ID: "usr_alice", Email: "alice@example.com", Status: types.IdpStatusACTIVE, Groups: []string{}},
+				{ID: "usr_bob", Email: "bob@example.com", Status: types.IdpStatusACTIVE, Groups: []string{}},
+				{ID: "usr_carol", Email: "carol@example.com", Status: types.IdpStatusACTIVE, Groups: []string{}},
+			}})
+			db.MockQuery(&storage.ListGroups{})
+
+			s := IdentitySyncer{
+				db:               db,
+				idp:              &testIdentityProvider{users: []identity.IDPUser{{ID: "alice", Email: "alice@example.com"}}},
+				archiveThreshold: tc.threshold,
+			}
+			got, err := s.SyncWithOpts(context.Background(), tc.opts)
+			if tc.wantErr != nil {
+				assert.Equal(t, tc.wantErr, err)
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.wantExceeded, got.ArchiveThresholdExceeded)
+			assert.Equal(t, tc.wantArchived, got.Diff.UsersArchived)
+		})
+	}
+}
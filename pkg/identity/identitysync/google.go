@@ -16,6 +16,8 @@ type GoogleSync struct {
 	domain     gconfig.StringValue
 	adminEmail gconfig.StringValue
 	apiToken   gconfig.SecretStringValue
+	// webhookSecret is the token of the Directory API push notification channel.
+	webhookSecret gconfig.OptionalStringValue
 }
 
 func (s *GoogleSync) Config() gconfig.Config {
@@ -23,6 +25,7 @@ func (s *GoogleSync) Config() gconfig.Config {
 		gconfig.StringField("domain", &s.domain, "the Google domain"),
 		gconfig.StringField("adminEmail", &s.adminEmail, "the Google admin email"),
 		gconfig.SecretStringField("apiToken", &s.apiToken, "the Google API token", gconfig.WithNoArgs("/granted/secrets/identity/google/token")),
+		gconfig.OptionalStringField("webhookSecret", &s.webhookSecret, "the token of the Directory API push notification channel (optional, enables incremental sync)"),
 	}
 }
 
@@ -0,0 +1,70 @@
+package identitysync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"google.golang.org/api/googleapi"
+)
+
+// googleUserNotification is the payload of a Directory API push notification for the users resource.
+//
+// see: https://developers.google.com/admin-sdk/directory/v1/guides/push
+type googleUserNotification struct {
+	ID           string `json:"id"`
+	PrimaryEmail string `json:"primaryEmail"`
+}
+
+// ParseWebhook parses a Directory API push notification.
+// The Directory API only supports push notifications for users,
+// so changes to groups are picked up by the periodic full sync.
+func (c *GoogleSync) ParseWebhook(r *http.Request) (*WebhookResult, error) {
+	err := verifyWebhookSecret(c.webhookSecret.Get(), r.Header.Get("X-Goog-Channel-Token"))
+	if err != nil {
+		return nil, err
+	}
+
+	res := WebhookResult{Changes: []Change{}}
+	// a sync message is sent when the notification channel is created, and doesn't describe a change.
+	if r.Header.Get("X-Goog-Resource-State") == "sync" {
+		return &res, nil
+	}
+
+	var n googleUserNotification
+	err = json.NewDecoder(r.Body).Decode(&n)
+	if err != nil {
+		return nil, err
+	}
+	res.Changes = append(res.Changes, Change{Kind: ChangeKindUser, ID: n.ID, Email: n.PrimaryEmail})
+	return &res, nil
+}
+
+func (c *GoogleSync) GetUser(ctx context.Context, id string) (identity.IDPUser, error) {
+	u, err := c.client.Users.Get(id).Context(ctx).Do()
+	if isGoogleNotFound(err) {
+		return identity.IDPUser{}, ErrNotFound
+	}
+	if err != nil {
+		return identity.IDPUser{}, err
+	}
+	return c.idpUserFromGoogleUser(ctx, u)
+}
+
+func (c *GoogleSync) GetGroup(ctx context.Context, id string) (identity.IDPGroup, error) {
+	g, err := c.client.Groups.Get(id).Context(ctx).Do()
+	if isGoogleNotFound(err) {
+		return identity.IDPGroup{}, ErrNotFound
+	}
+	if err != nil {
+		return identity.IDPGroup{}, err
+	}
+	return idpGroupFromGoogleGroup(g), nil
+}
+
+func isGoogleNotFound(err error) bool {
+	var gerr *googleapi.Error
+	return errors.As(err, &gerr) && gerr.Code == http.StatusNotFound
+}
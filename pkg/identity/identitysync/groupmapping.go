@@ -0,0 +1,105 @@
+package identitysync
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+)
+
+// MappedGroup describes the identity provider groups which are mapped onto a Granted group by a sync.
+type MappedGroup struct {
+	ID string `json:"id"`
+	// IdentityProviderGroups contains the names of the identity provider groups matched by the mapping.
+	IdentityProviderGroups []string `json:"identityProviderGroups"`
+}
+
+// groupMapping is a deploy.IdentityGroupMapping with its patterns compiled.
+type groupMapping struct {
+	deploy.IdentityGroupMapping
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// matches returns true if the name of an identity provider group matches any include pattern and no exclude pattern.
+func (m groupMapping) matches(name string) bool {
+	for _, re := range m.exclude {
+		if re.MatchString(name) {
+			return false
+		}
+	}
+	for _, re := range m.include {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// compileGroupMappings validates the mappings and compiles their patterns.
+func compileGroupMappings(mappings deploy.IdentityGroupMappings) ([]groupMapping, error) {
+	err := mappings.Validate()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]groupMapping, len(mappings))
+	for i, m := range mappings {
+		res[i].IdentityGroupMapping = m
+		for _, p := range m.Include {
+			res[i].include = append(res[i].include, regexp.MustCompile(p))
+		}
+		for _, p := range m.Exclude {
+			res[i].exclude = append(res[i].exclude, regexp.MustCompile(p))
+		}
+	}
+	return res, nil
+}
+
+// applyGroupMappings replaces the groups from the identity provider with the mapped groups.
+//
+// A user is a member of a mapped group if they are a member of any matching identity provider group,
+// including through nested groups. The mapped groups aren't nested, so the returned groups have no parents.
+func applyGroupMappings(mappings []groupMapping, idpUsers []identity.IDPUser, idpGroups []identity.IDPGroup) ([]identity.IDPUser, []identity.IDPGroup, []MappedGroup) {
+	parents := make(map[string][]string)
+	for _, g := range idpGroups {
+		parents[g.ID] = g.ParentGroups
+	}
+
+	groups := make([]identity.IDPGroup, len(mappings))
+	preview := make([]MappedGroup, len(mappings))
+	// matched contains the IDs of the identity provider groups matched by each mapping.
+	matched := make([]map[string]bool, len(mappings))
+	for i, m := range mappings {
+		name := m.Name
+		if name == "" {
+			name = m.ID
+		}
+		groups[i] = identity.IDPGroup{ID: m.ID, Name: name, Description: m.Description}
+		preview[i] = MappedGroup{ID: m.ID, IdentityProviderGroups: []string{}}
+		matched[i] = make(map[string]bool)
+		for _, g := range idpGroups {
+			if m.matches(g.Name) {
+				matched[i][g.ID] = true
+				preview[i].IdentityProviderGroups = append(preview[i].IdentityProviderGroups, g.Name)
+			}
+		}
+		sort.Strings(preview[i].IdentityProviderGroups)
+	}
+
+	users := make([]identity.IDPUser, len(idpUsers))
+	for i, u := range idpUsers {
+		effective := append(append([]string{}, u.Groups...), inheritedGroups(u.Groups, parents)...)
+		u.Groups = []string{}
+		for j, m := range mappings {
+			for _, g := range effective {
+				if matched[j][g] {
+					u.Groups = append(u.Groups, m.ID)
+					break
+				}
+			}
+		}
+		users[i] = u
+	}
+	return users, groups, preview
+}
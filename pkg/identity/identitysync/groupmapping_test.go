@@ -0,0 +1,123 @@
+package identitysync
+
+import (
+	"testing"
+
+	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyGroupMappings(t *testing.T) {
+	idpGroups := []identity.IDPGroup{
+		{ID: "1", Name: "eng-backend"},
+		{ID: "2", Name: "eng-frontend"},
+		{ID: "3", Name: "eng-contractors"},
+		{ID: "4", Name: "platform", ParentGroups: []string{"5"}},
+		{ID: "5", Name: "infrastructure"},
+		{ID: "6", Name: "sales"},
+	}
+	idpUsers := []identity.IDPUser{
+		{ID: "a", Email: "alice@example.com", Groups: []string{"1"}},
+		{ID: "b", Email: "bob@example.com", Groups: []string{"3"}},
+		{ID: "c", Email: "carol@example.com", Groups: []string{"4", "6"}},
+		{ID: "d", Email: "dave@example.com", Groups: []string{}},
+	}
+
+	type testcase struct {
+		name        string
+		mappings    deploy.IdentityGroupMappings
+		wantGroups  []identity.IDPGroup
+		wantUsers   map[string][]string
+		wantPreview []MappedGroup
+	}
+
+	testcases := []testcase{
+		{
+			name:       "regex with exclude",
+			mappings:   deploy.IdentityGroupMappings{{ID: "engineering", Name: "Engineering", Include: []string{"^eng-"}, Exclude: []string{"^eng-contractors$"}}},
+			wantGroups: []identity.IDPGroup{{ID: "engineering", Name: "Engineering"}},
+			wantUsers: map[string][]string{
+				"alice@example.com": {"engineering"},
+				"bob@example.com":   {},
+				"carol@example.com": {},
+				"dave@example.com":  {},
+			},
+			wantPreview: []MappedGroup{{ID: "engineering", IdentityProviderGroups: []string{"eng-backend", "eng-frontend"}}},
+		},
+		{
+			name:       "union of groups",
+			mappings:   deploy.IdentityGroupMappings{{ID: "staff", Include: []string{"^eng-backend$", "^sales$"}}},
+			wantGroups: []identity.IDPGroup{{ID: "staff", Name: "staff"}},
+			wantUsers: map[string][]string{
+				"alice@example.com": {"staff"},
+				"bob@example.com":   {},
+				"carol@example.com": {"staff"},
+				"dave@example.com":  {},
+			},
+			wantPreview: []MappedGroup{{ID: "staff", IdentityProviderGroups: []string{"eng-backend", "sales"}}},
+		},
+		{
+			name: "nested groups are resolved before mapping",
+			mappings: deploy.IdentityGroupMappings{
+				{ID: "infra", Include: []string{"^infrastructure$"}},
+				{ID: "nobody", Include: []string{"^marketing$"}},
+			},
+			wantGroups: []identity.IDPGroup{{ID: "infra", Name: "infra"}, {ID: "nobody", Name: "nobody"}},
+			wantUsers: map[string][]string{
+				"alice@example.com": {},
+				"bob@example.com":   {},
+				"carol@example.com": {"infra"},
+				"dave@example.com":  {},
+			},
+			wantPreview: []MappedGroup{{ID: "infra", IdentityProviderGroups: []string{"infrastructure"}}, {ID: "nobody", IdentityProviderGroups: []string{}}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mappings, err := compileGroupMappings(tc.mappings)
+			if err != nil {
+				t.Fatal(err)
+			}
+			users, groups, preview := applyGroupMappings(mappings, idpUsers, idpGroups)
+			gotUsers := make(map[string][]string)
+			for _, u := range users {
+				gotUsers[u.Email] = u.Groups
+			}
+			assert.Equal(t, tc.wantGroups, groups)
+			assert.Equal(t, tc.wantUsers, gotUsers)
+			assert.Equal(t, tc.wantPreview, preview)
+		})
+	}
+}
+
+func TestCompileGroupMappingsInvalid(t *testing.T) {
+	testcases := []struct {
+		name     string
+		mappings deploy.IdentityGroupMappings
+		wantErr  string
+	}{
+		{
+			name:     "missing ID",
+			mappings: deploy.IdentityGroupMappings{{Include: []string{"a"}}},
+			wantErr:  "identity group mapping 0 must have an ID",
+		},
+		{
+			name:     "duplicate ID",
+			mappings: deploy.IdentityGroupMappings{{ID: "a", Include: []string{"a"}}, {ID: "a", Include: []string{"b"}}},
+			wantErr:  "identity group mapping a is defined more than once",
+		},
+		{
+			name:     "invalid pattern",
+			mappings: deploy.IdentityGroupMappings{{ID: "a", Include: []string{"("}}},
+			wantErr:  "identity group mapping a has an invalid pattern \"(\": error parsing regexp: missing closing ): `(`",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := compileGroupMappings(tc.mappings)
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,453 @@
+package identitysync
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	ddbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/common-fate/apikit/logger"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/storage/dbcond"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+// maxChangeAttempts is the number of times a change is applied before giving up, if the groups it
+// changes are written concurrently by another change.
+const maxChangeAttempts = 5
+
+// ErrNotFound is returned by an IncrementalIdentityProvider if a user or group doesn't exist in the identity provider.
+var ErrNotFound = errors.New("not found in identity provider")
+
+// IncrementalIdentityProvider is implemented by identity providers which can look up individual users and groups.
+// It allows a single user or group to be synced, rather than running a full sync.
+type IncrementalIdentityProvider interface {
+	// GetUser returns the user with the identity provider ID, or ErrNotFound if the user has been deleted or deactivated.
+	GetUser(ctx context.Context, id string) (identity.IDPUser, error)
+	// GetGroup returns the group with the identity provider ID, or ErrNotFound if the group has been deleted.
+	GetGroup(ctx context.Context, id string) (identity.IDPGroup, error)
+}
+
+type ChangeKind string
+
+const (
+	ChangeKindUser  ChangeKind = "user"
+	ChangeKindGroup ChangeKind = "group"
+)
+
+// Change is a change to a single user or group in the identity provider.
+type Change struct {
+	Kind ChangeKind
+	// ID is the identity provider's ID for the user or group.
+	ID string
+	// Email is the email address of a user, if the identity provider included it in the notification.
+	// It's required to archive a user who has been deleted from the identity provider.
+	Email string
+}
+
+// ApplyChange syncs a single user or group from the identity provider, creating, updating or archiving it.
+// If the identity provider doesn't support looking up individual users and groups, or group mappings
+// are configured, a full sync is run instead.
+// Groups are only written if they haven't changed since they were read, and the change is applied again
+// if they have, so that concurrent changes to the same group don't overwrite each other.
+func (s *IdentitySyncer) ApplyChange(ctx context.Context, c Change) error {
+	idp, ok := s.idp.(IncrementalIdentityProvider)
+	if !ok || len(s.groupMappings) > 0 {
+		return s.Sync(ctx)
+	}
+	var err error
+	for i := 0; i < maxChangeAttempts; i++ {
+		err = s.applyChange(ctx, idp, c)
+		if err != dbcond.ErrConditionFailed {
+			return err
+		}
+		logger.Get(ctx).Infow("group was changed concurrently, applying change again", "change.kind", c.Kind, "change.id", c.ID, "attempt", i+1)
+	}
+	return err
+}
+
+func (s *IdentitySyncer) applyChange(ctx context.Context, idp IncrementalIdentityProvider, c Change) error {
+	switch c.Kind {
+	case ChangeKindUser:
+		return s.syncUser(ctx, idp, c)
+	case ChangeKindGroup:
+		return s.syncGroup(ctx, idp, c)
+	}
+	return errors.New("unhandled change kind: " + string(c.Kind))
+}
+
+func (s *IdentitySyncer) syncUser(ctx context.Context, idp IncrementalIdentityProvider, c Change) error {
+	log := logger.Get(ctx).With("user.idpId", c.ID)
+
+	idpUser, err := idp.GetUser(ctx, c.ID)
+	if err == ErrNotFound {
+		return s.archiveUser(ctx, c)
+	}
+	if err != nil {
+		return err
+	}
+
+	var existing *identity.User
+	uq := storage.GetUserByEmail{Email: idpUser.Email}
+	_, err = s.db.Query(ctx, &uq)
+	if err != nil && err != ddb.ErrNoItems {
+		return err
+	}
+	if err == nil {
+		existing = uq.Result
+	}
+
+	// look up the groups the user currently belongs to, as well as the groups they should belong to.
+	groupIDs := append([]string{}, idpUser.Groups...)
+	if existing != nil {
+		groupIDs = append(groupIDs, existing.EffectiveGroups()...)
+	}
+	groups := make(map[string]identity.Group)
+	for _, id := range groupIDs {
+		if _, ok := groups[id]; ok {
+			continue
+		}
+		gq := storage.GetGroup{ID: id}
+		_, err = s.db.Query(ctx, &gq)
+		if err != nil && err != ddb.ErrNoItems {
+			return err
+		}
+		if err == nil {
+			groups[id] = *gq.Result
+			continue
+		}
+		// the group hasn't been synced yet, so fetch it from the identity provider.
+		idpGroup, err := idp.GetGroup(ctx, id)
+		if err == ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		g := idpGroup.ToInternalGroup()
+		g.Users = []string{}
+		groups[id] = g
+	}
+	// the parents of nested groups are needed to resolve the groups the user inherits.
+	err = s.loadParentGroups(ctx, groups)
+	if err != nil {
+		return err
+	}
+
+	user, changedGroups := processUserChange(idpUser, existing, groups, time.Now())
+	log.Infow("syncing user from identity provider", "user.id", user.ID, "groups.changed", len(changedGroups))
+	return s.putUserAndGroups(ctx, user, changedGroups)
+}
+
+func (s *IdentitySyncer) archiveUser(ctx context.Context, c Change) error {
+	log := logger.Get(ctx).With("user.idpId", c.ID)
+	// users are matched by email, so if the identity provider didn't tell us the email
+	// of the deleted user we can't find them without a full sync.
+	if c.Email == "" {
+		log.Infow("user was not found in identity provider and no email was provided, running a full sync")
+		return s.Sync(ctx)
+	}
+
+	uq := storage.GetUserByEmail{Email: c.Email}
+	_, err := s.db.Query(ctx, &uq)
+	if err == ddb.ErrNoItems {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	groups := make(map[string]identity.Group)
+	for _, id := range uq.Result.EffectiveGroups() {
+		gq := storage.GetGroup{ID: id}
+		_, err = s.db.Query(ctx, &gq)
+		if err == ddb.ErrNoItems {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		groups[id] = *gq.Result
+	}
+
+	if s.offboarder != nil && uq.Result.Status == types.IdpStatusACTIVE {
+		_, err = s.offboarder.Offboard(ctx, *uq.Result, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	user, changedGroups := processUserArchive(*uq.Result, groups, time.Now())
+	log.Infow("archiving user which was removed from identity provider", "user.id", user.ID)
+	return s.putUserAndGroups(ctx, user, changedGroups)
+}
+
+func (s *IdentitySyncer) syncGroup(ctx context.Context, idp IncrementalIdentityProvider, c Change) error {
+	log := logger.Get(ctx).With("group.id", c.ID)
+
+	var existing *identity.Group
+	gq := storage.GetGroup{ID: c.ID}
+	_, err := s.db.Query(ctx, &gq)
+	if err != nil && err != ddb.ErrNoItems {
+		return err
+	}
+	if err == nil {
+		existing = gq.Result
+	}
+
+	idpGroup, err := idp.GetGroup(ctx, c.ID)
+	if err == ErrNotFound {
+		if existing == nil {
+			return nil
+		}
+		if len(existing.ParentGroups) > 0 || len(existing.InheritedUsers) > 0 {
+			log.Infow("nested group was removed from identity provider, running a full sync")
+			return s.Sync(ctx)
+		}
+		users := make(map[string]identity.User)
+		for _, id := range existing.Users {
+			uq := storage.GetUser{ID: id}
+			_, err = s.db.Query(ctx, &uq)
+			if err == ddb.ErrNoItems {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			users[id] = *uq.Result
+		}
+		group, changedUsers := processGroupArchive(*existing, users, time.Now())
+		log.Infow("archiving group which was removed from identity provider")
+		err = s.putGroup(ctx, group)
+		if err != nil {
+			return err
+		}
+		items := make([]ddb.Keyer, len(changedUsers))
+		for i := range changedUsers {
+			items[i] = &changedUsers[i]
+		}
+		return s.db.PutBatch(ctx, items...)
+	}
+	if err != nil {
+		return err
+	}
+
+	// changing a group's parents changes the inherited groups of all of its members, so run a full sync.
+	var existingParents []string
+	if existing != nil {
+		existingParents = existing.ParentGroups
+	}
+	if len(difference(idpGroup.ParentGroups, existingParents)) > 0 || len(difference(existingParents, idpGroup.ParentGroups)) > 0 {
+		log.Infow("group's parent groups changed, running a full sync")
+		return s.Sync(ctx)
+	}
+
+	group := processGroupChange(idpGroup, existing, time.Now())
+	log.Infow("syncing group from identity provider")
+	return s.putGroup(ctx, group)
+}
+
+// putUserAndGroups writes the changed groups of a user, followed by the user.
+// The user is only written once all of the groups have been written, so if a group was changed
+// concurrently the change can be applied again.
+func (s *IdentitySyncer) putUserAndGroups(ctx context.Context, user identity.User, groups []identity.Group) error {
+	for _, g := range groups {
+		err := s.putGroup(ctx, g)
+		if err != nil {
+			return err
+		}
+	}
+	return s.db.Put(ctx, &user)
+}
+
+// putGroup writes the group and increments its version.
+// dbcond.ErrConditionFailed is returned if the group's version has changed since it was read.
+func (s *IdentitySyncer) putGroup(ctx context.Context, g identity.Group) error {
+	put := dbcond.Put{
+		Condition: "attribute_not_exists(#version)",
+		Names:     map[string]string{"#version": "version"},
+	}
+	if g.Version > 0 {
+		put.Condition = "#version = :version"
+		put.Values = map[string]ddbtypes.AttributeValue{":version": &ddbtypes.AttributeValueMemberN{Value: strconv.Itoa(g.Version)}}
+	}
+	g.Version++
+	put.Item = &g
+	return s.writer.Put(ctx, put)
+}
+
+// loadParentGroups adds the ancestors of each group to groups, so that nested group membership can be resolved.
+// Parent groups which haven't been synced yet are skipped.
+func (s *IdentitySyncer) loadParentGroups(ctx context.Context, groups map[string]identity.Group) error {
+	queue := sortedGroupIDs(groups)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, p := range groups[id].ParentGroups {
+			if _, ok := groups[p]; ok {
+				continue
+			}
+			gq := storage.GetGroup{ID: p}
+			_, err := s.db.Query(ctx, &gq)
+			if err == ddb.ErrNoItems {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			groups[p] = *gq.Result
+			queue = append(queue, p)
+		}
+	}
+	return nil
+}
+
+// processUserChange updates a user and their group memberships to match the identity provider.
+// groups must contain the groups the user currently belongs to, directly or through nested groups,
+// the groups they should belong to, and the ancestors of those groups.
+// It returns the updated user and the groups whose members have changed.
+func processUserChange(idpUser identity.IDPUser, existing *identity.User, groups map[string]identity.Group, now time.Time) (identity.User, []identity.Group) {
+	var user identity.User
+	if existing != nil {
+		user = *existing
+		user.FirstName = idpUser.FirstName
+		user.LastName = idpUser.LastName
+		user.Status = types.IdpStatusACTIVE
+		user.UpdatedAt = now
+	} else {
+		user = idpUser.ToInternalUser()
+	}
+
+	wanted := make(map[string]bool)
+	for _, id := range idpUser.Groups {
+		wanted[id] = true
+	}
+
+	user.Groups = []string{}
+	parents := make(map[string][]string)
+	for _, id := range sortedGroupIDs(groups) {
+		if wanted[id] {
+			user.Groups = append(user.Groups, id)
+		}
+		parents[id] = groups[id].ParentGroups
+	}
+	user.InheritedGroups = inheritedGroups(user.Groups, parents)
+
+	changed := []identity.Group{}
+	for _, id := range sortedGroupIDs(groups) {
+		g := groups[id]
+		var usersChanged, inheritedChanged bool
+		g.Users, usersChanged = setMember(g.Users, user.ID, wanted[id])
+		g.InheritedUsers, inheritedChanged = setMember(g.InheritedUsers, user.ID, containsString(user.InheritedGroups, id))
+		if usersChanged || inheritedChanged {
+			g.UpdatedAt = now
+			changed = append(changed, g)
+		}
+	}
+	return user, changed
+}
+
+// setMember adds or removes a user from a list of members. It returns the updated list and whether it changed.
+func setMember(members []string, userID string, isMember bool) ([]string, bool) {
+	wasMember := containsString(members, userID)
+	if isMember && !wasMember {
+		return append(members, userID), true
+	}
+	if !isMember && wasMember {
+		return removeString(members, userID), true
+	}
+	return members, false
+}
+
+// processUserArchive archives a user and removes them from all of their groups, including nested groups.
+func processUserArchive(user identity.User, groups map[string]identity.Group, now time.Time) (identity.User, []identity.Group) {
+	user.Status = types.IdpStatusARCHIVED
+	user.Groups = []string{}
+	user.InheritedGroups = nil
+	user.UpdatedAt = now
+
+	changed := []identity.Group{}
+	for _, id := range sortedGroupIDs(groups) {
+		g := groups[id]
+		var usersChanged, inheritedChanged bool
+		g.Users, usersChanged = setMember(g.Users, user.ID, false)
+		g.InheritedUsers, inheritedChanged = setMember(g.InheritedUsers, user.ID, false)
+		if usersChanged || inheritedChanged {
+			g.UpdatedAt = now
+			changed = append(changed, g)
+		}
+	}
+	return user, changed
+}
+
+// processGroupChange creates or updates a group to match the identity provider.
+// Group membership is synced when users change, so the members of the group are left as they are.
+func processGroupChange(idpGroup identity.IDPGroup, existing *identity.Group, now time.Time) identity.Group {
+	if existing == nil {
+		g := idpGroup.ToInternalGroup()
+		g.Users = []string{}
+		return g
+	}
+	g := *existing
+	g.Name = idpGroup.Name
+	g.Description = idpGroup.Description
+	g.Status = types.IdpStatusACTIVE
+	g.UpdatedAt = now
+	return g
+}
+
+// processGroupArchive archives a group and removes it from all of its members.
+func processGroupArchive(group identity.Group, users map[string]identity.User, now time.Time) (identity.Group, []identity.User) {
+	group.Status = types.IdpStatusARCHIVED
+	group.Users = []string{}
+	group.UpdatedAt = now
+
+	ids := make([]string, 0, len(users))
+	for id := range users {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	changed := []identity.User{}
+	for _, id := range ids {
+		u := users[id]
+		if containsString(u.Groups, group.ID) {
+			u.Groups = removeString(u.Groups, group.ID)
+			u.UpdatedAt = now
+			changed = append(changed, u)
+		}
+	}
+	return group, changed
+}
+
+func sortedGroupIDs(groups map[string]identity.Group) []string {
+	ids := make([]string, 0, len(groups))
+	for id := range groups {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func containsString(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(s []string, e string) []string {
+	res := []string{}
+	for _, a := range s {
+		if a != e {
+			res = append(res, a)
+		}
+	}
+	return res
+}
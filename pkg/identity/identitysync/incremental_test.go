@@ -0,0 +1,214 @@
+package identitysync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ddbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/common-fate/ddb/ddbmock"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/storage/dbcond"
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessUserChange(t *testing.T) {
+	type testcase struct {
+		name        string
+		giveIdpUser identity.IDPUser
+		giveUser    *identity.User
+		giveGroups  map[string]identity.Group
+		wantUser    identity.User
+		wantChanged []identity.Group
+	}
+	now := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	testcases := []testcase{
+		{
+			name:        "adds user to new group",
+			giveIdpUser: identity.IDPUser{ID: "okta_1", FirstName: "Josh", LastName: "Wilkes", Email: "josh@test.go", Groups: []string{"everyone", "admins"}},
+			giveUser:    &identity.User{ID: "usr_1", FirstName: "josh", LastName: "wilkes", Email: "josh@test.go", Groups: []string{"everyone"}, Status: types.IdpStatusACTIVE},
+			giveGroups: map[string]identity.Group{
+				"everyone": {ID: "everyone", IdpID: "everyone", Users: []string{"usr_1"}},
+				"admins":   {ID: "admins", IdpID: "admins", Users: []string{"usr_2"}},
+			},
+			wantUser: identity.User{ID: "usr_1", FirstName: "Josh", LastName: "Wilkes", Email: "josh@test.go", Groups: []string{"admins", "everyone"}, Status: types.IdpStatusACTIVE, UpdatedAt: now},
+			wantChanged: []identity.Group{
+				{ID: "admins", IdpID: "admins", Users: []string{"usr_2", "usr_1"}, UpdatedAt: now},
+			},
+		},
+		{
+			name:        "removes user from group",
+			giveIdpUser: identity.IDPUser{ID: "okta_1", Email: "josh@test.go", Groups: []string{}},
+			giveUser:    &identity.User{ID: "usr_1", Email: "josh@test.go", Groups: []string{"admins"}, Status: types.IdpStatusACTIVE},
+			giveGroups: map[string]identity.Group{
+				"admins": {ID: "admins", IdpID: "admins", Users: []string{"usr_1", "usr_2"}},
+			},
+			wantUser: identity.User{ID: "usr_1", Email: "josh@test.go", Groups: []string{}, Status: types.IdpStatusACTIVE, UpdatedAt: now},
+			wantChanged: []identity.Group{
+				{ID: "admins", IdpID: "admins", Users: []string{"usr_2"}, UpdatedAt: now},
+			},
+		},
+		{
+			name:        "adds user to nested groups",
+			giveIdpUser: identity.IDPUser{ID: "okta_1", Email: "josh@test.go", Groups: []string{"platform"}},
+			giveUser:    &identity.User{ID: "usr_1", Email: "josh@test.go", Groups: []string{}, Status: types.IdpStatusACTIVE},
+			giveGroups: map[string]identity.Group{
+				"platform":    {ID: "platform", IdpID: "platform", Users: []string{}, ParentGroups: []string{"engineering"}},
+				"engineering": {ID: "engineering", IdpID: "engineering", Users: []string{"usr_2"}, ParentGroups: []string{"everyone"}},
+				"everyone":    {ID: "everyone", IdpID: "everyone", Users: []string{}},
+			},
+			wantUser: identity.User{ID: "usr_1", Email: "josh@test.go", Groups: []string{"platform"}, InheritedGroups: []string{"engineering", "everyone"}, Status: types.IdpStatusACTIVE, UpdatedAt: now},
+			wantChanged: []identity.Group{
+				{ID: "engineering", IdpID: "engineering", Users: []string{"usr_2"}, InheritedUsers: []string{"usr_1"}, ParentGroups: []string{"everyone"}, UpdatedAt: now},
+				{ID: "everyone", IdpID: "everyone", Users: []string{}, InheritedUsers: []string{"usr_1"}, UpdatedAt: now},
+				{ID: "platform", IdpID: "platform", Users: []string{"usr_1"}, ParentGroups: []string{"engineering"}, UpdatedAt: now},
+			},
+		},
+		{
+			name:        "removes user from nested groups",
+			giveIdpUser: identity.IDPUser{ID: "okta_1", Email: "josh@test.go", Groups: []string{}},
+			giveUser:    &identity.User{ID: "usr_1", Email: "josh@test.go", Groups: []string{"platform"}, InheritedGroups: []string{"engineering"}, Status: types.IdpStatusACTIVE},
+			giveGroups: map[string]identity.Group{
+				"platform":    {ID: "platform", IdpID: "platform", Users: []string{"usr_1"}, ParentGroups: []string{"engineering"}},
+				"engineering": {ID: "engineering", IdpID: "engineering", Users: []string{}, InheritedUsers: []string{"usr_1"}},
+			},
+			wantUser: identity.User{ID: "usr_1", Email: "josh@test.go", Groups: []string{}, Status: types.IdpStatusACTIVE, UpdatedAt: now},
+			wantChanged: []identity.Group{
+				{ID: "engineering", IdpID: "engineering", Users: []string{}, InheritedUsers: []string{}, UpdatedAt: now},
+				{ID: "platform", IdpID: "platform", Users: []string{}, ParentGroups: []string{"engineering"}, UpdatedAt: now},
+			},
+		},
+		{
+			name:        "reactivates archived user",
+			giveIdpUser: identity.IDPUser{ID: "okta_1", Email: "josh@test.go", Groups: []string{}},
+			giveUser:    &identity.User{ID: "usr_1", Email: "josh@test.go", Groups: []string{}, Status: types.IdpStatusARCHIVED},
+			giveGroups:  map[string]identity.Group{},
+			wantUser:    identity.User{ID: "usr_1", Email: "josh@test.go", Groups: []string{}, Status: types.IdpStatusACTIVE, UpdatedAt: now},
+			wantChanged: []identity.Group{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotUser, gotChanged := processUserChange(tc.giveIdpUser, tc.giveUser, tc.giveGroups, now)
+			assert.Equal(t, tc.wantUser, gotUser)
+			assert.Equal(t, tc.wantChanged, gotChanged)
+		})
+	}
+}
+
+func TestProcessUserChangeCreatesUser(t *testing.T) {
+	now := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	groups := map[string]identity.Group{
+		"everyone": {ID: "everyone", IdpID: "everyone", Users: []string{}},
+	}
+	gotUser, gotChanged := processUserChange(identity.IDPUser{ID: "okta_1", Email: "josh@test.go", Groups: []string{"everyone"}}, nil, groups, now)
+
+	assert.NotEmpty(t, gotUser.ID)
+	assert.Equal(t, types.IdpStatusACTIVE, gotUser.Status)
+	assert.Equal(t, []string{"everyone"}, gotUser.Groups)
+	assert.Equal(t, []identity.Group{{ID: "everyone", IdpID: "everyone", Users: []string{gotUser.ID}, UpdatedAt: now}}, gotChanged)
+}
+
+func TestProcessUserArchive(t *testing.T) {
+	now := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	user := identity.User{ID: "usr_1", Email: "josh@test.go", Groups: []string{"everyone"}, Status: types.IdpStatusACTIVE}
+	groups := map[string]identity.Group{
+		"everyone": {ID: "everyone", Users: []string{"usr_1", "usr_2"}},
+	}
+
+	gotUser, gotChanged := processUserArchive(user, groups, now)
+
+	assert.Equal(t, identity.User{ID: "usr_1", Email: "josh@test.go", Groups: []string{}, Status: types.IdpStatusARCHIVED, UpdatedAt: now}, gotUser)
+	assert.Equal(t, []identity.Group{{ID: "everyone", Users: []string{"usr_2"}, UpdatedAt: now}}, gotChanged)
+}
+
+func TestProcessGroupArchive(t *testing.T) {
+	now := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	group := identity.Group{ID: "admins", Users: []string{"usr_1"}, Status: types.IdpStatusACTIVE}
+	users := map[string]identity.User{
+		"usr_1": {ID: "usr_1", Groups: []string{"admins", "everyone"}},
+	}
+
+	gotGroup, gotChanged := processGroupArchive(group, users, now)
+
+	assert.Equal(t, identity.Group{ID: "admins", Users: []string{}, Status: types.IdpStatusARCHIVED, UpdatedAt: now}, gotGroup)
+	assert.Equal(t, []identity.User{{ID: "usr_1", Groups: []string{"everyone"}, UpdatedAt: now}}, gotChanged)
+}
+
+type testIncrementalProvider struct {
+	testIdentityProvider
+	groups map[string]identity.IDPGroup
+}
+
+func (p *testIncrementalProvider) GetUser(ctx context.Context, id string) (identity.IDPUser, error) {
+	return identity.IDPUser{}, ErrNotFound
+}
+
+func (p *testIncrementalProvider) GetGroup(ctx context.Context, id string) (identity.IDPGroup, error) {
+	g, ok := p.groups[id]
+	if !ok {
+		return identity.IDPGroup{}, ErrNotFound
+	}
+	return g, nil
+}
+
+// testWriter fails the first conflicts puts with dbcond.ErrConditionFailed.
+type testWriter struct {
+	conflicts int
+	puts      []dbcond.Put
+}
+
+func (w *testWriter) Put(ctx context.Context, p dbcond.Put) error {
+	w.puts = append(w.puts, p)
+	if len(w.puts) <= w.conflicts {
+		return dbcond.ErrConditionFailed
+	}
+	return nil
+}
+
+func (w *testWriter) Update(ctx context.Context, u dbcond.Update) error { return nil }
+
+func (w *testWriter) TransactPut(ctx context.Context, puts ...dbcond.Put) error { return nil }
+
+func TestApplyChangeGroupConflict(t *testing.T) {
+	type testcase struct {
+		name      string
+		conflicts int
+		wantPuts  int
+		wantErr   error
+	}
+	testcases := []testcase{
+		{name: "ok", wantPuts: 1},
+		{name: "retries conflict", conflicts: 2, wantPuts: 3},
+		{name: "gives up after max attempts", conflicts: maxChangeAttempts, wantPuts: maxChangeAttempts, wantErr: dbcond.ErrConditionFailed},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			db.MockQuery(&storage.GetGroup{Result: &identity.Group{ID: "admins", IdpID: "admins", Name: "old", Users: []string{"usr_1"}, Status: types.IdpStatusACTIVE, Version: 2}})
+
+			w := &testWriter{conflicts: tc.conflicts}
+			s := IdentitySyncer{
+				db:     db,
+				idp:    &testIncrementalProvider{groups: map[string]identity.IDPGroup{"admins": {ID: "admins", Name: "new"}}},
+				writer: w,
+			}
+			err := s.ApplyChange(context.Background(), Change{Kind: ChangeKindGroup, ID: "admins"})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Len(t, w.puts, tc.wantPuts)
+
+			last := w.puts[len(w.puts)-1]
+			assert.Equal(t, "#version = :version", last.Condition)
+			assert.Equal(t, &ddbtypes.AttributeValueMemberN{Value: "2"}, last.Values[":version"])
+			g := last.Item.(*identity.Group)
+			assert.Equal(t, 3, g.Version)
+			assert.Equal(t, "new", g.Name)
+			assert.Equal(t, []string{"usr_1"}, g.Users)
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package identitysync
+
+import (
+	"context"
+	"crypto/tls"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/common-fate/granted-approvals/accesshandler/pkg/diagnostics"
+	"github.com/common-fate/granted-approvals/accesshandler/pkg/providers"
+	"github.com/common-fate/granted-approvals/pkg/gconfig"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultLDAPUserFilter matches users in OpenLDAP and Active Directory which have an email address.
+	defaultLDAPUserFilter = "(&(|(objectClass=inetOrgPerson)(objectClass=user))(mail=*))"
+	// defaultLDAPGroupFilter matches groups in OpenLDAP and Active Directory.
+	defaultLDAPGroupFilter = "(|(objectClass=groupOfNames)(objectClass=groupOfUniqueNames)(objectClass=group))"
+	// ldapPageSize is below the default Active Directory MaxPageSize of 1000.
+	ldapPageSize = 500
+)
+
+var _ providers.ConfigValidator = &LDAPSync{}
+
+// LDAPSync syncs users and groups from an LDAP directory, such as OpenLDAP or Active Directory.
+// Groups are identified by their distinguished name. Groups which are members of other groups
+// are returned with their parent groups, so that identity sync can resolve nested group membership.
+type LDAPSync struct {
+	url                gconfig.StringValue
+	bindDN             gconfig.StringValue
+	bindPassword       gconfig.SecretStringValue
+	baseDN             gconfig.StringValue
+	userFilter         gconfig.OptionalStringValue
+	groupFilter        gconfig.OptionalStringValue
+	startTLS           gconfig.OptionalStringValue
+	insecureSkipVerify gconfig.OptionalStringValue
+
+	tlsConfig   *tls.Config
+	useStartTLS bool
+}
+
+func (s *LDAPSync) Config() gconfig.Config {
+	return gconfig.Config{
+		gconfig.StringField("url", &s.url, "the LDAP server URL, such as ldaps://ldap.example.com:636"),
+		gconfig.StringField("bindDn", &s.bindDN, "the distinguished name of the account used to read users and groups"),
+		gconfig.SecretStringField("bindPassword", &s.bindPassword, "the password of the bind account", gconfig.WithNoArgs("/granted/secrets/identity/ldap/password")),
+		gconfig.StringField("baseDn", &s.baseDN, "the distinguished name to search for users and groups under, such as dc=example,dc=com"),
+		gconfig.OptionalStringField("userFilter", &s.userFilter, "the LDAP filter used to find users (optional, defaults to "+defaultLDAPUserFilter+")"),
+		gconfig.OptionalStringField("groupFilter", &s.groupFilter, "the LDAP filter used to find groups (optional, defaults to "+defaultLDAPGroupFilter+")"),
+		gconfig.OptionalStringField("startTls", &s.startTLS, "'true' to upgrade ldap:// connections with StartTLS (optional)"),
+		gconfig.OptionalStringField("insecureSkipVerify", &s.insecureSkipVerify, "'true' to skip verifying the server's TLS certificate, for testing only (optional)"),
+	}
+}
+
+func (s *LDAPSync) Init(ctx context.Context) error {
+	u, err := url.Parse(s.url.Get())
+	if err != nil {
+		return errors.Wrap(err, "parsing LDAP url")
+	}
+	if u.Scheme != "ldap" && u.Scheme != "ldaps" {
+		return errors.New("LDAP url must start with ldap:// or ldaps://")
+	}
+
+	s.useStartTLS, err = parseOptionalBool(s.startTLS)
+	if err != nil {
+		return errors.Wrap(err, "parsing startTls")
+	}
+	skipVerify, err := parseOptionalBool(s.insecureSkipVerify)
+	if err != nil {
+		return errors.Wrap(err, "parsing insecureSkipVerify")
+	}
+	s.tlsConfig = &tls.Config{
+		ServerName:         u.Hostname(),
+		InsecureSkipVerify: skipVerify, //#nosec G402 this is opt-in for testing against servers with self-signed certificates
+	}
+	return nil
+}
+
+func (s *LDAPSync) TestConfig(ctx context.Context) error {
+	_, err := s.ListUsers(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list users while testing LDAP identity provider configuration")
+	}
+	_, err = s.ListGroups(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list groups while testing LDAP identity provider configuration")
+	}
+	return nil
+}
+
+func (s *LDAPSync) ValidateConfig() map[string]providers.ConfigValidationStep {
+	return map[string]providers.ConfigValidationStep{
+		"ldap-bind": {
+			Name:            "Connect and bind to the LDAP server",
+			FieldsValidated: []string{"url", "bindDn", "bindPassword", "startTls", "insecureSkipVerify"},
+			Run: func(ctx context.Context) diagnostics.Logs {
+				conn, err := s.connect()
+				if err != nil {
+					return diagnostics.Error(err)
+				}
+				conn.Close()
+				return diagnostics.Info("Bound to %s as %s", s.url.Get(), s.bindDN.Get())
+			},
+		},
+		"ldap-list-users": {
+			Name:            "List users in the LDAP directory",
+			FieldsValidated: []string{"baseDn", "userFilter"},
+			Run: func(ctx context.Context) diagnostics.Logs {
+				users, err := s.ListUsers(ctx)
+				if err != nil {
+					return diagnostics.Error(err)
+				}
+				return diagnostics.Info("LDAP returned %d users", len(users))
+			},
+		},
+		"ldap-list-groups": {
+			Name:            "List groups in the LDAP directory",
+			FieldsValidated: []string{"baseDn", "groupFilter"},
+			Run: func(ctx context.Context) diagnostics.Logs {
+				groups, err := s.ListGroups(ctx)
+				if err != nil {
+					return diagnostics.Error(err)
+				}
+				return diagnostics.Info("LDAP returned %d groups", len(groups))
+			},
+		},
+	}
+}
+
+func (s *LDAPSync) ListUsers(ctx context.Context) ([]identity.IDPUser, error) {
+	conn, err := s.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	users, err := s.searchUsers(conn)
+	if err != nil {
+		return nil, err
+	}
+	// group membership is stored on groups, so the groups are needed to find each user's groups.
+	groups, err := s.searchGroups(conn)
+	if err != nil {
+		return nil, err
+	}
+	return resolveLDAPUsers(users, groups), nil
+}
+
+func (s *LDAPSync) ListGroups(ctx context.Context) ([]identity.IDPGroup, error) {
+	conn, err := s.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	groups, err := s.searchGroups(conn)
+	if err != nil {
+		return nil, err
+	}
+	return resolveLDAPGroups(groups), nil
+}
+
+// connect dials the LDAP server and binds with the configured account.
+func (s *LDAPSync) connect() (*ldap.Conn, error) {
+	conn, err := ldap.DialURL(s.url.Get(), ldap.DialWithTLSConfig(s.tlsConfig))
+	if err != nil {
+		return nil, errors.Wrap(err, "connecting to LDAP server")
+	}
+	if s.useStartTLS {
+		err = conn.StartTLS(s.tlsConfig)
+		if err != nil {
+			conn.Close()
+			return nil, errors.Wrap(err, "starting TLS")
+		}
+	}
+	err = conn.Bind(s.bindDN.Get(), s.bindPassword.Get())
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "binding to LDAP server")
+	}
+	return conn, nil
+}
+
+type ldapUser struct {
+	DN        string
+	Email     string
+	FirstName string
+	LastName  string
+}
+
+type ldapGroup struct {
+	DN          string
+	Name        string
+	Description string
+	// Members are the distinguished names of the users and groups in the group.
+	Members []string
+}
+
+func (s *LDAPSync) searchUsers(conn *ldap.Conn) ([]ldapUser, error) {
+	filter := defaultLDAPUserFilter
+	if s.userFilter.IsSet() && s.userFilter.Get() != "" {
+		filter = s.userFilter.Get()
+	}
+	req := ldap.NewSearchRequest(s.baseDN.Get(), ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter, []string{"mail", "givenName", "sn"}, nil)
+
+	res, err := conn.SearchWithPaging(req, ldapPageSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "searching for users")
+	}
+	users := []ldapUser{}
+	for _, e := range res.Entries {
+		u := ldapUser{
+			DN:        e.DN,
+			Email:     e.GetAttributeValue("mail"),
+			FirstName: e.GetAttributeValue("givenName"),
+			LastName:  e.GetAttributeValue("sn"),
+		}
+		if u.Email == "" {
+			continue
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+func (s *LDAPSync) searchGroups(conn *ldap.Conn) ([]ldapGroup, error) {
+	filter := defaultLDAPGroupFilter
+	if s.groupFilter.IsSet() && s.groupFilter.Get() != "" {
+		filter = s.groupFilter.Get()
+	}
+	req := ldap.NewSearchRequest(s.baseDN.Get(), ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter, []string{"cn", "description", "member", "uniqueMember"}, nil)
+
+	res, err := conn.SearchWithPaging(req, ldapPageSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "searching for groups")
+	}
+	groups := []ldapGroup{}
+	for _, e := range res.Entries {
+		groups = append(groups, ldapGroup{
+			DN:          e.DN,
+			Name:        e.GetAttributeValue("cn"),
+			Description: e.GetAttributeValue("description"),
+			Members:     append(e.GetAttributeValues("member"), e.GetAttributeValues("uniqueMember")...),
+		})
+	}
+	return groups, nil
+}
+
+// ldapParents maps the normalised DN of each user or group to the DNs of the groups it is a direct member of.
+func ldapParents(groups []ldapGroup) map[string][]string {
+	parents := make(map[string][]string)
+	for _, g := range groups {
+		for _, m := range g.Members {
+			key := normaliseDN(m)
+			if !containsString(parents[key], g.DN) {
+				parents[key] = append(parents[key], g.DN)
+			}
+		}
+	}
+	for k := range parents {
+		sort.Strings(parents[k])
+	}
+	return parents
+}
+
+// resolveLDAPUsers returns the users with the groups they are a direct member of.
+// Membership of nested groups is resolved by identity sync using the parents of each group.
+func resolveLDAPUsers(users []ldapUser, groups []ldapGroup) []identity.IDPUser {
+	parents := ldapParents(groups)
+	idpUsers := []identity.IDPUser{}
+	for _, u := range users {
+		idpUsers = append(idpUsers, identity.IDPUser{
+			ID:        u.DN,
+			FirstName: u.FirstName,
+			LastName:  u.LastName,
+			Email:     u.Email,
+			Groups:    append([]string{}, parents[normaliseDN(u.DN)]...),
+		})
+	}
+	return idpUsers
+}
+
+// resolveLDAPGroups returns the groups with the groups they are a direct member of.
+func resolveLDAPGroups(groups []ldapGroup) []identity.IDPGroup {
+	parents := ldapParents(groups)
+	idpGroups := []identity.IDPGroup{}
+	for _, g := range groups {
+		idpGroups = append(idpGroups, identity.IDPGroup{
+			ID:           g.DN,
+			Name:         g.Name,
+			Description:  g.Description,
+			ParentGroups: parents[normaliseDN(g.DN)],
+		})
+	}
+	return idpGroups
+}
+
+// normaliseDN normalises a distinguished name so that member attributes can be compared with entry DNs,
+// which may differ in case and whitespace. DNs which can't be parsed are lowercased.
+func normaliseDN(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil {
+		return strings.ToLower(dn)
+	}
+	rdns := make([]string, len(parsed.RDNs))
+	for i, rdn := range parsed.RDNs {
+		attrs := make([]string, len(rdn.Attributes))
+		for j, a := range rdn.Attributes {
+			attrs[j] = strings.ToLower(a.Type) + "=" + strings.ToLower(a.Value)
+		}
+		rdns[i] = strings.Join(attrs, "+")
+	}
+	return strings.Join(rdns, ",")
+}
+
+func parseOptionalBool(v gconfig.OptionalStringValue) (bool, error) {
+	if !v.IsSet() || v.Get() == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(v.Get())
+}
@@ -0,0 +1,116 @@
+package identitysync
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/common-fate/granted-approvals/pkg/gconfig"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveLDAPUsers(t *testing.T) {
+	type testcase struct {
+		name   string
+		users  []ldapUser
+		groups []ldapGroup
+		want   []identity.IDPUser
+	}
+
+	alice := ldapUser{DN: "uid=alice,ou=people,dc=example,dc=com", Email: "alice@example.com", FirstName: "Alice", LastName: "Smith"}
+	bob := ldapUser{DN: "uid=bob,ou=people,dc=example,dc=com", Email: "bob@example.com", FirstName: "Bob", LastName: "Jones"}
+
+	testcases := []testcase{
+		{
+			name:   "no groups",
+			users:  []ldapUser{alice},
+			groups: []ldapGroup{},
+			want: []identity.IDPUser{
+				{ID: alice.DN, Email: "alice@example.com", FirstName: "Alice", LastName: "Smith", Groups: []string{}},
+			},
+		},
+		{
+			name:  "direct membership",
+			users: []ldapUser{alice, bob},
+			groups: []ldapGroup{
+				{DN: "cn=engineers,ou=groups,dc=example,dc=com", Name: "engineers", Members: []string{alice.DN}},
+			},
+			want: []identity.IDPUser{
+				{ID: alice.DN, Email: "alice@example.com", FirstName: "Alice", LastName: "Smith", Groups: []string{"cn=engineers,ou=groups,dc=example,dc=com"}},
+				{ID: bob.DN, Email: "bob@example.com", FirstName: "Bob", LastName: "Jones", Groups: []string{}},
+			},
+		},
+		{
+			name:  "nested groups are not included",
+			users: []ldapUser{alice},
+			groups: []ldapGroup{
+				{DN: "cn=platform,ou=groups,dc=example,dc=com", Name: "platform", Members: []string{alice.DN}},
+				{DN: "cn=engineers,ou=groups,dc=example,dc=com", Name: "engineers", Members: []string{"cn=platform,ou=groups,dc=example,dc=com"}},
+			},
+			want: []identity.IDPUser{
+				{ID: alice.DN, Email: "alice@example.com", FirstName: "Alice", LastName: "Smith", Groups: []string{"cn=platform,ou=groups,dc=example,dc=com"}},
+			},
+		},
+		{
+			name:  "member DN differs in case and spacing",
+			users: []ldapUser{alice},
+			groups: []ldapGroup{
+				{DN: "cn=engineers,ou=groups,dc=example,dc=com", Name: "engineers", Members: []string{"UID=Alice, OU=People, DC=example, DC=com"}},
+			},
+			want: []identity.IDPUser{
+				{ID: alice.DN, Email: "alice@example.com", FirstName: "Alice", LastName: "Smith", Groups: []string{"cn=engineers,ou=groups,dc=example,dc=com"}},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := resolveLDAPUsers(tc.users, tc.groups)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestResolveLDAPGroups(t *testing.T) {
+	groups := []ldapGroup{
+		{DN: "cn=platform,ou=groups,dc=example,dc=com", Name: "platform", Members: []string{"uid=alice,ou=people,dc=example,dc=com"}},
+		{DN: "cn=engineers,ou=groups,dc=example,dc=com", Name: "engineers", Members: []string{"CN=Platform, OU=Groups, DC=example, DC=com"}},
+		{DN: "cn=staff,ou=groups,dc=example,dc=com", Name: "staff", Members: []string{"cn=engineers,ou=groups,dc=example,dc=com", "cn=platform,ou=groups,dc=example,dc=com"}},
+	}
+	want := []identity.IDPGroup{
+		{ID: "cn=platform,ou=groups,dc=example,dc=com", Name: "platform", ParentGroups: []string{"cn=engineers,ou=groups,dc=example,dc=com", "cn=staff,ou=groups,dc=example,dc=com"}},
+		{ID: "cn=engineers,ou=groups,dc=example,dc=com", Name: "engineers", ParentGroups: []string{"cn=staff,ou=groups,dc=example,dc=com"}},
+		{ID: "cn=staff,ou=groups,dc=example,dc=com", Name: "staff"},
+	}
+	assert.Equal(t, want, resolveLDAPGroups(groups))
+}
+
+// TestLDAPIntegration runs against a local OpenLDAP server, such as:
+//
+//	docker run -p 389:389 -e LDAP_ORGANISATION=example -e LDAP_DOMAIN=example.org -e LDAP_ADMIN_PASSWORD=admin osixia/openldap
+func TestLDAPIntegration(t *testing.T) {
+	if os.Getenv("GRANTED_INTEGRATION_TEST") == "" || os.Getenv("LDAP_URL") == "" {
+		t.Skip("GRANTED_INTEGRATION_TEST or LDAP_URL is not set, skipping integration testing")
+	}
+	ctx := context.Background()
+
+	s := LDAPSync{}
+	err := s.Config().Load(ctx, &gconfig.MapLoader{Values: map[string]string{
+		"url":          os.Getenv("LDAP_URL"),
+		"bindDn":       os.Getenv("LDAP_BIND_DN"),
+		"bindPassword": os.Getenv("LDAP_BIND_PASSWORD"),
+		"baseDn":       os.Getenv("LDAP_BASE_DN"),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Init(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range s.ValidateConfig() {
+		logs := v.Run(ctx)
+		assert.True(t, logs.HasSucceeded(), "%s: %v", k, logs)
+	}
+}
@@ -0,0 +1,29 @@
+package identitysync
+
+import "sort"
+
+// inheritedGroups returns the groups which a member of the direct groups belongs to through nested groups.
+// parents maps a group ID to the IDs of the groups it is a direct member of.
+// The direct groups aren't included in the result, and cycles in the group hierarchy are ignored.
+func inheritedGroups(direct []string, parents map[string][]string) []string {
+	seen := make(map[string]bool)
+	for _, g := range direct {
+		seen[g] = true
+	}
+	var res []string
+	queue := append([]string{}, direct...)
+	for len(queue) > 0 {
+		g := queue[0]
+		queue = queue[1:]
+		for _, p := range parents[g] {
+			if seen[p] {
+				continue
+			}
+			seen[p] = true
+			res = append(res, p)
+			queue = append(queue, p)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
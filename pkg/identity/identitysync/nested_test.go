@@ -0,0 +1,85 @@
+package identitysync
+
+import (
+	"testing"
+
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInheritedGroups(t *testing.T) {
+	type testcase struct {
+		name    string
+		direct  []string
+		parents map[string][]string
+		want    []string
+	}
+
+	testcases := []testcase{
+		{
+			name:    "no nested groups",
+			direct:  []string{"a"},
+			parents: map[string][]string{},
+		},
+		{
+			name:    "nested groups",
+			direct:  []string{"platform"},
+			parents: map[string][]string{"platform": {"engineering"}, "engineering": {"everyone"}},
+			want:    []string{"engineering", "everyone"},
+		},
+		{
+			name:    "direct groups are not inherited",
+			direct:  []string{"platform", "engineering"},
+			parents: map[string][]string{"platform": {"engineering"}},
+		},
+		{
+			name:    "cycles are ignored",
+			direct:  []string{"a"},
+			parents: map[string][]string{"a": {"b"}, "b": {"a", "c"}},
+			want:    []string{"b", "c"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, inheritedGroups(tc.direct, tc.parents))
+		})
+	}
+}
+
+func TestProcessUsersAndGroupsNestedGroups(t *testing.T) {
+	idpUsers := []identity.IDPUser{
+		{ID: "1", Email: "alice@example.com", Groups: []string{"platform"}},
+		{ID: "2", Email: "bob@example.com", Groups: []string{"engineering"}},
+	}
+	idpGroups := []identity.IDPGroup{
+		{ID: "platform", Name: "platform", ParentGroups: []string{"engineering"}},
+		{ID: "engineering", Name: "engineering", ParentGroups: []string{"everyone"}},
+		{ID: "everyone", Name: "everyone"},
+	}
+	internalUsers := []identity.User{
+		{ID: "usr_alice", Email: "alice@example.com", Status: types.IdpStatusACTIVE},
+		{ID: "usr_bob", Email: "bob@example.com", Status: types.IdpStatusACTIVE},
+	}
+	internalGroups := []identity.Group{
+		{ID: "platform", IdpID: "platform", Status: types.IdpStatusACTIVE},
+		{ID: "engineering", IdpID: "engineering", Status: types.IdpStatusACTIVE},
+		{ID: "everyone", IdpID: "everyone", Status: types.IdpStatusACTIVE},
+	}
+
+	users, groups := processUsersAndGroups(idpUsers, idpGroups, internalUsers, internalGroups)
+
+	alice := users["alice@example.com"]
+	assert.Equal(t, []string{"platform"}, alice.Groups)
+	assert.Equal(t, []string{"engineering", "everyone"}, alice.InheritedGroups)
+	bob := users["bob@example.com"]
+	assert.Equal(t, []string{"engineering"}, bob.Groups)
+	assert.Equal(t, []string{"everyone"}, bob.InheritedGroups)
+
+	assert.Equal(t, []string{"usr_alice"}, groups["engineering"].InheritedUsers)
+	assert.Equal(t, []string{"usr_bob"}, groups["engineering"].Users)
+	assert.Equal(t, []string{"usr_alice", "usr_bob"}, groups["everyone"].InheritedUsers)
+	assert.Equal(t, []string{"engineering"}, groups["platform"].ParentGroups)
+	assert.Nil(t, groups["platform"].InheritedUsers)
+}
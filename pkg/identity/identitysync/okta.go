@@ -14,12 +14,15 @@ type OktaSync struct {
 	client   *okta.Client
 	orgURL   gconfig.StringValue
 	apiToken gconfig.SecretStringValue
+	// webhookSecret is the value of the Authorization header sent with Okta Event Hooks.
+	webhookSecret gconfig.OptionalStringValue
 }
 
 func (s *OktaSync) Config() gconfig.Config {
 	return gconfig.Config{
 		gconfig.StringField("orgUrl", &s.orgURL, "the Okta organization URL"),
 		gconfig.SecretStringField("apiToken", &s.apiToken, "the Okta API token", gconfig.WithNoArgs("/granted/secrets/identity/okta/token")),
+		gconfig.OptionalStringField("webhookSecret", &s.webhookSecret, "the Authorization header value sent with Okta Event Hooks (optional, enables incremental sync)"),
 	}
 }
 
@@ -70,6 +73,9 @@ func (o *OktaSync) idpUserFromOktaUser(ctx context.Context, oktaUser *okta.User)
 }
 
 // idpGroupFromOktaGroup converts a okta group to the identityprovider interface group type
+//
+// Okta groups can't be members of other groups, so ParentGroups is always empty. Groups imported
+// into Okta from a directory such as Active Directory are flattened by Okta when they are imported.
 func idpGroupFromOktaGroup(oktaGroup *okta.Group) identity.IDPGroup {
 	return identity.IDPGroup{
 		ID:          oktaGroup.Id,
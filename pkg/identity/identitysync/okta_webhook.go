@@ -0,0 +1,90 @@
+package identitysync
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/common-fate/granted-approvals/pkg/identity"
+)
+
+// oktaEventHook is the payload of an Okta Event Hook.
+//
+// see: https://developer.okta.com/docs/concepts/event-hooks/
+type oktaEventHook struct {
+	Data struct {
+		Events []struct {
+			EventType string `json:"eventType"`
+			Target    []struct {
+				ID          string `json:"id"`
+				Type        string `json:"type"`
+				AlternateID string `json:"alternateId"`
+			} `json:"target"`
+		} `json:"events"`
+	} `json:"data"`
+}
+
+// ParseWebhook parses an Okta Event Hook.
+// Every user and group which is the target of an event is synced,
+// so the event hook should subscribe to user lifecycle, profile, group lifecycle and group membership events.
+func (o *OktaSync) ParseWebhook(r *http.Request) (*WebhookResult, error) {
+	err := verifyWebhookSecret(o.webhookSecret.Get(), r.Header.Get("Authorization"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Okta verifies the endpoint by sending a one-time GET request with a challenge header.
+	if challenge := r.Header.Get("X-Okta-Verification-Challenge"); challenge != "" {
+		b, err := json.Marshal(map[string]string{"verification": challenge})
+		if err != nil {
+			return nil, err
+		}
+		return &WebhookResult{Challenge: &WebhookChallenge{ContentType: "application/json", Body: b}}, nil
+	}
+
+	var hook oktaEventHook
+	err = json.NewDecoder(r.Body).Decode(&hook)
+	if err != nil {
+		return nil, err
+	}
+
+	res := WebhookResult{Changes: []Change{}}
+	for _, e := range hook.Data.Events {
+		for _, t := range e.Target {
+			switch t.Type {
+			case "User":
+				// for users, the alternate ID is their Okta login which is their email address.
+				res.Changes = append(res.Changes, Change{Kind: ChangeKindUser, ID: t.ID, Email: t.AlternateID})
+			case "UserGroup":
+				res.Changes = append(res.Changes, Change{Kind: ChangeKindGroup, ID: t.ID})
+			}
+		}
+	}
+	return &res, nil
+}
+
+func (o *OktaSync) GetUser(ctx context.Context, id string) (identity.IDPUser, error) {
+	u, res, err := o.client.User.GetUser(ctx, id)
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return identity.IDPUser{}, ErrNotFound
+	}
+	if err != nil {
+		return identity.IDPUser{}, err
+	}
+	// deactivated users aren't returned when listing users, so treat them as if they have been deleted.
+	if u.Status == "DEPROVISIONED" {
+		return identity.IDPUser{}, ErrNotFound
+	}
+	return o.idpUserFromOktaUser(ctx, u)
+}
+
+func (o *OktaSync) GetGroup(ctx context.Context, id string) (identity.IDPGroup, error) {
+	g, res, err := o.client.Group.GetGroup(ctx, id)
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return identity.IDPGroup{}, ErrNotFound
+	}
+	if err != nil {
+		return identity.IDPGroup{}, err
+	}
+	return idpGroupFromOktaGroup(g), nil
+}
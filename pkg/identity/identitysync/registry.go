@@ -13,6 +13,8 @@ const (
 	IDPTypeOkta    = "okta"
 	IDPTypeAzureAD = "azure"
 	IDPTypeGoogle  = "google"
+	IDPTypeSCIM    = "scim"
+	IDPTypeLDAP    = "ldap"
 )
 
 type RegisteredIdentityProvider struct {
@@ -51,6 +53,16 @@ func Registry() IdentityProviderRegistry {
 				Description:      "Google Workspaces",
 				DocsID:           "google",
 			},
+			IDPTypeSCIM: {
+				IdentityProvider: &SCIMSync{},
+				Description:      "SCIM 2.0 provisioning",
+				DocsID:           "scim",
+			},
+			IDPTypeLDAP: {
+				IdentityProvider: &LDAPSync{},
+				Description:      "LDAP / Active Directory",
+				DocsID:           "ldap",
+			},
 		},
 	}
 }
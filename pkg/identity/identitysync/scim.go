@@ -0,0 +1,51 @@
+package identitysync
+
+import (
+	"context"
+	"errors"
+
+	"github.com/common-fate/granted-approvals/pkg/gconfig"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+)
+
+// ErrPushProvider is returned when listing users or groups from an identity provider which pushes changes to us.
+var ErrPushProvider = errors.New("users and groups are pushed by this identity provider and can't be listed")
+
+// PushIdentityProvider is implemented by identity providers which push changes to users and groups
+// to us, such as SCIM. Users and groups are written as soon as they are pushed, so a full sync is a no-op.
+type PushIdentityProvider interface {
+	PushesChanges() bool
+}
+
+// SCIMSync is used when users and groups are provisioned by the identity provider
+// via the SCIM 2.0 API served by the webhook handler.
+type SCIMSync struct {
+	bearerToken gconfig.SecretStringValue
+}
+
+func (s *SCIMSync) Config() gconfig.Config {
+	return gconfig.Config{
+		gconfig.SecretStringField("bearerToken", &s.bearerToken, "the bearer token your identity provider uses to authenticate to the SCIM API", gconfig.WithNoArgs("/granted/secrets/identity/scim/token")),
+	}
+}
+
+func (s *SCIMSync) Init(ctx context.Context) error {
+	return nil
+}
+
+// BearerToken is the token which SCIM requests must be authenticated with.
+func (s *SCIMSync) BearerToken() string {
+	return s.bearerToken.Get()
+}
+
+func (s *SCIMSync) PushesChanges() bool {
+	return true
+}
+
+func (s *SCIMSync) ListUsers(ctx context.Context) ([]identity.IDPUser, error) {
+	return nil, ErrPushProvider
+}
+
+func (s *SCIMSync) ListGroups(ctx context.Context) ([]identity.IDPGroup, error) {
+	return nil, ErrPushProvider
+}
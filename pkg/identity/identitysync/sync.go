@@ -2,7 +2,7 @@ package identitysync
 
 import (
 	"context"
-	"errors"
+	"sort"
 
 	"github.com/common-fate/apikit/logger"
 	"github.com/common-fate/ddb"
@@ -10,7 +10,10 @@ import (
 	"github.com/common-fate/granted-approvals/pkg/gconfig"
 	"github.com/common-fate/granted-approvals/pkg/identity"
 	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/storage/dbcond"
 	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
 )
 
 type IdentityProvider interface {
@@ -20,9 +23,25 @@ type IdentityProvider interface {
 	gconfig.Initer
 }
 
+// Offboarder is called for each user who is archived because they were removed from the identity provider.
+type Offboarder interface {
+	// Offboard cancels the user's pending requests and revokes their active grants.
+	// If dryRun is true, the changes which would be made are returned without making them.
+	Offboard(ctx context.Context, user identity.User, dryRun bool) (*identity.Offboarding, error)
+}
+
 type IdentitySyncer struct {
 	db  ddb.Storage
 	idp IdentityProvider
+	// writer makes the conditional writes of incremental syncs.
+	writer dbcond.Writer
+	// offboarder is optional. If it is nil, archived users keep their requests and grants.
+	offboarder Offboarder
+	// archiveThreshold is the percentage of active users which a sync may archive.
+	// If it is zero, DefaultArchiveThreshold is used.
+	archiveThreshold int
+	// groupMappings replace the 1:1 import of identity provider groups if any are configured.
+	groupMappings []groupMapping
 }
 
 type SyncOpts struct {
@@ -30,13 +49,89 @@ type SyncOpts struct {
 	IdpType        string
 	UserPoolId     string
 	IdentityConfig deploy.FeatureMap
+	// Offboarder is optional and is called for each user who is archived by a sync.
+	Offboarder Offboarder
+	// ArchiveThreshold is the percentage of active users which a sync may archive before it is aborted.
+	// If it is zero, DefaultArchiveThreshold is used. Set it to 100 to disable the check.
+	ArchiveThreshold int
+	// GroupMappings map identity provider groups onto Granted groups.
+	// If it is empty, identity provider groups are imported 1:1.
+	GroupMappings deploy.IdentityGroupMappings
+}
+
+// RunOpts configures a single sync. It is also the payload of the sync Lambda function.
+type RunOpts struct {
+	// DryRun returns the changes which would be made without writing them.
+	DryRun bool `json:"dryRun"`
+	// Force runs the sync even if it would archive more than the archive threshold.
+	Force bool `json:"force"`
+}
+
+// SyncResult describes the changes made by a sync.
+type SyncResult struct {
+	DryRun bool `json:"dryRun"`
+	// Diff contains the changes made by the sync, or the changes which would be made for a dry run.
+	Diff Diff `json:"diff"`
+	// ArchiveThresholdExceeded is true if the sync archives more than the archive threshold.
+	// It is only set for dry runs and forced syncs, as otherwise the sync is aborted.
+	ArchiveThresholdExceeded bool `json:"archiveThresholdExceeded"`
+	// Offboarded contains the users who were archived by the sync.
+	Offboarded []identity.Offboarding `json:"offboarded"`
+	// OffboardingFailures contains the users who couldn't be offboarded.
+	// They are kept active, so that offboarding them is retried by the next sync.
+	OffboardingFailures []OffboardingFailure `json:"offboardingFailures,omitempty"`
+	// MappedGroups contains the identity provider groups matched by each group mapping.
+	// It is empty if no group mappings are configured.
+	MappedGroups []MappedGroup `json:"mappedGroups,omitempty"`
+}
+
+// OffboardingFailure describes a user who should have been archived by a sync, but couldn't be offboarded.
+type OffboardingFailure struct {
+	UserID string `json:"userId"`
+	Email  string `json:"email"`
+	Error  string `json:"error"`
+}
+
+func (r SyncResult) ToAPI() types.IdentitySyncResponse {
+	res := types.IdentitySyncResponse{
+		DryRun:                   r.DryRun,
+		Diff:                     r.Diff.ToAPI(),
+		ArchiveThresholdExceeded: r.ArchiveThresholdExceeded,
+		Offboarded:               []types.IdentitySyncOffboarding{},
+	}
+	for _, o := range r.Offboarded {
+		res.Offboarded = append(res.Offboarded, o.ToAPI())
+	}
+	if len(r.OffboardingFailures) > 0 {
+		failures := []types.IdentitySyncOffboardingFailure{}
+		for _, f := range r.OffboardingFailures {
+			failures = append(failures, types.IdentitySyncOffboardingFailure{UserId: f.UserID, Email: f.Email, Error: f.Error})
+		}
+		res.OffboardingFailures = &failures
+	}
+	if len(r.MappedGroups) > 0 {
+		mapped := []types.IdentitySyncMappedGroup{}
+		for _, m := range r.MappedGroups {
+			mapped = append(mapped, types.IdentitySyncMappedGroup{Id: m.ID, IdentityProviderGroups: m.IdentityProviderGroups})
+		}
+		res.MappedGroups = &mapped
+	}
+	return res
 }
 
 func NewIdentitySyncer(ctx context.Context, opts SyncOpts) (*IdentitySyncer, error) {
+	groupMappings, err := compileGroupMappings(opts.GroupMappings)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid identity group mappings")
+	}
 	db, err := ddb.New(ctx, opts.TableName)
 	if err != nil {
 		return nil, err
 	}
+	writer, err := dbcond.New(ctx, opts.TableName)
+	if err != nil {
+		return nil, err
+	}
 
 	idp, err := Registry().Lookup(opts.IdpType)
 	if err != nil {
@@ -72,39 +167,98 @@ func NewIdentitySyncer(ctx context.Context, opts SyncOpts) (*IdentitySyncer, err
 		return nil, err
 	}
 	return &IdentitySyncer{
-		db:  db,
-		idp: idp.IdentityProvider,
+		db:               db,
+		idp:              idp.IdentityProvider,
+		writer:           writer,
+		offboarder:       opts.Offboarder,
+		archiveThreshold: opts.ArchiveThreshold,
+		groupMappings:    groupMappings,
 	}, nil
 }
 
 func (s *IdentitySyncer) Sync(ctx context.Context) error {
+	_, err := s.SyncWithOpts(ctx, RunOpts{})
+	return err
+}
+
+// SyncWithOpts syncs users and groups from the identity provider.
+// If the sync would archive more than the archive threshold of active users, it is aborted with an ArchiveThresholdError
+// unless opts.Force is set. Dry runs are never aborted, and report whether the threshold is exceeded instead.
+//
+// Users who are archived by the sync are offboarded before the sync is written. If offboarding a user fails,
+// the user is kept active and the failure is reported in the result, so that offboarding is retried on the next sync
+// without stopping the rest of the sync from being written.
+func (s *IdentitySyncer) SyncWithOpts(ctx context.Context, opts RunOpts) (*SyncResult, error) {
 	log := logger.Get(ctx)
+	res := SyncResult{DryRun: opts.DryRun, Diff: diffUsersAndGroups(nil, nil, nil, nil), Offboarded: []identity.Offboarding{}}
+
+	if p, ok := s.idp.(PushIdentityProvider); ok && p.PushesChanges() {
+		log.Infow("skipping sync as users and groups are pushed by the identity provider")
+		return &res, nil
+	}
 
 	//Fetch all users from IDP
 	// The IDP should return the group mappings for users, these group IDs will be internal to the IDP
 	idpUsers, err := s.idp.ListUsers(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// Fetch all groups from IDP
 	idpGroups, err := s.idp.ListGroups(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	log.Infow("fetched users and groups from IDP", "users.count", len(idpUsers), "groups.count", len(idpGroups))
 
+	if len(s.groupMappings) > 0 {
+		idpUsers, idpGroups, res.MappedGroups = applyGroupMappings(s.groupMappings, idpUsers, idpGroups)
+		log.Infow("applied identity group mappings", "mappings.count", len(s.groupMappings))
+	}
+
 	uq := &storage.ListUsers{}
 	_, err = s.db.Query(ctx, uq)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	gq := &storage.ListGroups{}
 	_, err = s.db.Query(ctx, gq)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	usersMap, groupsMap := processUsersAndGroups(idpUsers, idpGroups, uq.Result, gq.Result)
+
+	res.Diff = diffUsersAndGroups(uq.Result, gq.Result, usersMap, groupsMap)
+	log.Infow("computed identity sync diff", "users.added", len(res.Diff.UsersAdded), "users.archived", len(res.Diff.UsersArchived), "users.changed", len(res.Diff.UsersChanged), "groups.added", len(res.Diff.GroupsAdded), "groups.removed", len(res.Diff.GroupsRemoved), "dryRun", opts.DryRun)
+
+	threshold := s.archiveThreshold
+	if threshold == 0 {
+		threshold = DefaultArchiveThreshold
+	}
+	if res.Diff.ExceedsArchiveThreshold(threshold) {
+		if !opts.DryRun && !opts.Force {
+			return nil, &ArchiveThresholdError{Archived: len(res.Diff.UsersArchived), Active: res.Diff.ActiveUsers, Threshold: threshold}
+		}
+		res.ArchiveThresholdExceeded = true
+		log.Warnw("sync archives more than the archive threshold of active users", "threshold", threshold, "force", opts.Force)
+	}
+
+	if s.offboarder != nil {
+		for _, u := range archivedUsers(uq.Result, usersMap) {
+			o, err := s.offboarder.Offboard(ctx, u, opts.DryRun)
+			if err != nil {
+				log.Errorw("failed to offboard user, keeping them active", "user.id", u.ID, zap.Error(err))
+				res.OffboardingFailures = append(res.OffboardingFailures, OffboardingFailure{UserID: u.ID, Email: u.Email, Error: err.Error()})
+				keepUser(u, usersMap, groupsMap)
+				continue
+			}
+			res.Offboarded = append(res.Offboarded, *o)
+		}
+	}
+	if opts.DryRun {
+		return &res, nil
+	}
+
 	items := make([]ddb.Keyer, 0, len(usersMap)+len(groupsMap))
 	for _, v := range usersMap {
 		vi := v
@@ -115,7 +269,40 @@ func (s *IdentitySyncer) Sync(ctx context.Context) error {
 		items = append(items, &vi)
 	}
 
-	return s.db.PutBatch(ctx, items...)
+	err = s.db.PutBatch(ctx, items...)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// archivedUsers returns the users who were active before a sync and are archived by it, sorted by email.
+func archivedUsers(before []identity.User, after map[string]identity.User) []identity.User {
+	archived := []identity.User{}
+	for _, u := range before {
+		if u.Status != types.IdpStatusACTIVE {
+			continue
+		}
+		if updated, ok := after[u.Email]; ok && updated.Status == types.IdpStatusARCHIVED {
+			archived = append(archived, u)
+		}
+	}
+	sort.Slice(archived, func(i, j int) bool { return archived[i].Email < archived[j].Email })
+	return archived
+}
+
+// keepUser restores the user as they were before the sync, along with their membership of the groups which still exist.
+func keepUser(u identity.User, users map[string]identity.User, groups map[string]identity.Group) {
+	users[u.Email] = u
+	for k, g := range groups {
+		if containsString(u.Groups, g.ID) && !containsString(g.Users, u.ID) {
+			g.Users = append(g.Users, u.ID)
+		}
+		if containsString(u.InheritedGroups, g.ID) && !containsString(g.InheritedUsers, u.ID) {
+			g.InheritedUsers = append(g.InheritedUsers, u.ID)
+		}
+		groups[k] = g
+	}
 }
 
 // processUsersAndGroups conatins all the logic for create/update/archive for users and groups
@@ -165,6 +352,19 @@ func processUsersAndGroups(idpUsers []identity.IDPUser, idpGroups []identity.IDP
 			internalGroupUsers[newGroup.ID] = make(map[string]string)
 		}
 	}
+	// parents maps the internal ID of each group to the internal IDs of the groups it is a direct member of.
+	parents := make(map[string][]string)
+	for _, g := range idpGroups {
+		group := ddbGroupMap[g.ID]
+		group.ParentGroups = nil
+		for _, p := range g.ParentGroups {
+			if parent, ok := ddbGroupMap[p]; ok {
+				group.ParentGroups = append(group.ParentGroups, parent.ID)
+			}
+		}
+		parents[group.ID] = group.ParentGroups
+		ddbGroupMap[g.ID] = group
+	}
 
 	// archive deleted users
 	for k, u := range ddbUserMap {
@@ -172,6 +372,7 @@ func processUsersAndGroups(idpUsers []identity.IDPUser, idpGroups []identity.IDP
 			u.Status = types.IdpStatusARCHIVED
 			// Remove all group associations from archived users
 			u.Groups = []string{}
+			u.InheritedGroups = nil
 			ddbUserMap[k] = u
 		}
 	}
@@ -181,10 +382,14 @@ func processUsersAndGroups(idpUsers []identity.IDPUser, idpGroups []identity.IDP
 			g.Status = types.IdpStatusARCHIVED
 			// Remove all user associations from archived groups
 			g.Users = []string{}
+			g.InheritedUsers = nil
+			g.ParentGroups = nil
 			ddbGroupMap[k] = g
 		}
 	}
 
+	// This map ensures we have a distinct list of users who are members of each group through nested groups
+	inheritedGroupUsers := make(map[string]map[string]string)
 	for _, idpUser := range idpUserMap {
 
 		// This map ensures we have a distinct list of ids
@@ -201,6 +406,13 @@ func processUsersAndGroups(idpUsers []identity.IDPUser, idpGroups []identity.IDP
 			keys = append(keys, k)
 		}
 		internalUser.Groups = keys
+		internalUser.InheritedGroups = inheritedGroups(keys, parents)
+		for _, gid := range internalUser.InheritedGroups {
+			if inheritedGroupUsers[gid] == nil {
+				inheritedGroupUsers[gid] = make(map[string]string)
+			}
+			inheritedGroupUsers[gid][internalUser.ID] = internalUser.ID
+		}
 		ddbUserMap[idpUser.Email] = internalUser
 	}
 
@@ -212,6 +424,11 @@ func processUsersAndGroups(idpUsers []identity.IDPUser, idpGroups []identity.IDP
 			keys = append(keys, k2)
 		}
 		v.Users = keys
+		v.InheritedUsers = nil
+		for uid := range inheritedGroupUsers[v.ID] {
+			v.InheritedUsers = append(v.InheritedUsers, uid)
+		}
+		sort.Strings(v.InheritedUsers)
 		ddbGroupMap[k] = v
 	}
 
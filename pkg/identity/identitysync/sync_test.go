@@ -1,10 +1,17 @@
 package identitysync
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/ddb/ddbmock"
+	"github.com/common-fate/granted-approvals/pkg/gconfig"
 	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/storage"
 	"github.com/common-fate/granted-approvals/pkg/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -290,3 +297,121 @@ func TestIdentitySyncProcessor(t *testing.T) {
 		})
 	}
 }
+
+type testIdentityProvider struct {
+	users  []identity.IDPUser
+	groups []identity.IDPGroup
+}
+
+func (p *testIdentityProvider) Config() gconfig.Config         { return gconfig.Config{} }
+func (p *testIdentityProvider) Init(ctx context.Context) error { return nil }
+func (p *testIdentityProvider) ListUsers(ctx context.Context) ([]identity.IDPUser, error) {
+	return p.users, nil
+}
+func (p *testIdentityProvider) ListGroups(ctx context.Context) ([]identity.IDPGroup, error) {
+	return p.groups, nil
+}
+
+type testOffboarder struct {
+	calls []string
+	// err is returned when offboarding the user with the ID failOn.
+	failOn string
+	err    error
+}
+
+func (o *testOffboarder) Offboard(ctx context.Context, user identity.User, dryRun bool) (*identity.Offboarding, error) {
+	o.calls = append(o.calls, fmt.Sprintf("%s dryRun=%v", user.ID, dryRun))
+	if user.ID == o.failOn {
+		return nil, o.err
+	}
+	return &identity.Offboarding{UserID: user.ID, Email: user.Email, CancelledRequests: []string{"req_123"}}, nil
+}
+
+func TestSyncOffboardsArchivedUsers(t *testing.T) {
+	type testcase struct {
+		name      string
+		dryRun    bool
+		wantCalls []string
+	}
+
+	testcases := []testcase{
+		{name: "ok", wantCalls: []string{"usr_bob dryRun=false"}},
+		{name: "dry run", dryRun: true, wantCalls: []string{"usr_bob dryRun=true"}},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			db.MockQuery(&storage.ListUsers{Result: []identity.User{
+				{ID: "usr_alice", Email: "alice@example.com", Status: types.IdpStatusACTIVE, Groups: []string{}},
+				{ID: "usr_bob", Email: "bob@example.com", Status: types.IdpStatusACTIVE, Groups: []string{}},
+				// already archived users aren't offboarded again.
+				{ID: "usr_carol", Email: "carol@example.com", Status: types.IdpStatusARCHIVED, Groups: []string{}},
+			}})
+			db.MockQuery(&storage.ListGroups{})
+
+			o := &testOffboarder{}
+			s := IdentitySyncer{
+				db:         db,
+				idp:        &testIdentityProvider{users: []identity.IDPUser{{ID: "alice", Email: "alice@example.com"}}},
+				offboarder: o,
+			}
+			got, err := s.SyncWithOpts(context.Background(), RunOpts{DryRun: tc.dryRun})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.wantCalls, o.calls)
+			assert.Equal(t, tc.dryRun, got.DryRun)
+			assert.Equal(t, []identity.Offboarding{{UserID: "usr_bob", Email: "bob@example.com", CancelledRequests: []string{"req_123"}}}, got.Offboarded)
+		})
+	}
+}
+
+func TestSyncKeepsUsersWhoCantBeOffboarded(t *testing.T) {
+	db := ddbmock.New(t)
+	db.MockQuery(&storage.ListUsers{Result: []identity.User{
+		{ID: "usr_bob", Email: "bob@example.com", Status: types.IdpStatusACTIVE, Groups: []string{"grp_admins"}},
+		{ID: "usr_dave", Email: "dave@example.com", Status: types.IdpStatusACTIVE, Groups: []string{}},
+	}})
+	db.MockQuery(&storage.ListGroups{Result: []identity.Group{
+		{ID: "grp_admins", IdpID: "admins", Name: "admins", Status: types.IdpStatusACTIVE, Users: []string{"usr_bob"}},
+	}})
+
+	var saved []ddb.Keyer
+	o := &testOffboarder{failOn: "usr_bob", err: errors.New("revoking grant")}
+	s := IdentitySyncer{
+		db:         &recordingDB{Client: db, saved: &saved},
+		idp:        &testIdentityProvider{groups: []identity.IDPGroup{{ID: "admins", Name: "admins"}}},
+		offboarder: o,
+	}
+	got, err := s.SyncWithOpts(context.Background(), RunOpts{Force: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"usr_bob dryRun=false", "usr_dave dryRun=false"}, o.calls)
+	assert.Equal(t, []OffboardingFailure{{UserID: "usr_bob", Email: "bob@example.com", Error: "revoking grant"}}, got.OffboardingFailures)
+	assert.Equal(t, []identity.Offboarding{{UserID: "usr_dave", Email: "dave@example.com", CancelledRequests: []string{"req_123"}}}, got.Offboarded)
+
+	// the rest of the sync is written, with bob kept as an active member of their groups.
+	status := make(map[string]types.IdpStatus)
+	for _, item := range saved {
+		switch v := item.(type) {
+		case *identity.User:
+			status[v.ID] = v.Status
+		case *identity.Group:
+			assert.Equal(t, []string{"usr_bob"}, v.Users)
+		}
+	}
+	assert.Equal(t, map[string]types.IdpStatus{"usr_bob": types.IdpStatusACTIVE, "usr_dave": types.IdpStatusARCHIVED}, status)
+}
+
+// recordingDB records the items which are saved in a batch.
+type recordingDB struct {
+	*ddbmock.Client
+	saved *[]ddb.Keyer
+}
+
+func (r *recordingDB) PutBatch(ctx context.Context, items ...ddb.Keyer) error {
+	*r.saved = append(*r.saved, items...)
+	return nil
+}
@@ -0,0 +1,107 @@
+package identitysync
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/common-fate/apikit/apio"
+	"github.com/common-fate/apikit/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrWebhookNotConfigured is returned by ParseWebhook if no webhook secret has been configured for the identity provider.
+	ErrWebhookNotConfigured = errors.New("webhooks are not configured for this identity provider")
+	// ErrWebhookUnauthorized is returned by ParseWebhook if the notification couldn't be verified.
+	ErrWebhookUnauthorized = errors.New("webhook notification could not be verified")
+)
+
+var (
+	_ WebhookIdentityProvider = &OktaSync{}
+	_ WebhookIdentityProvider = &AzureSync{}
+	_ WebhookIdentityProvider = &GoogleSync{}
+)
+
+// WebhookIdentityProvider is implemented by identity providers which can notify us
+// about changes to users and groups via webhooks, such as Okta Event Hooks.
+type WebhookIdentityProvider interface {
+	IncrementalIdentityProvider
+	// ParseWebhook verifies a webhook notification and returns the users and groups which have changed.
+	ParseWebhook(r *http.Request) (*WebhookResult, error)
+}
+
+type WebhookResult struct {
+	// Challenge is set if the identity provider is verifying the webhook endpoint,
+	// rather than sending a notification. It must be written back to the identity provider.
+	Challenge *WebhookChallenge
+	Changes   []Change
+}
+
+type WebhookChallenge struct {
+	ContentType string
+	Body        []byte
+}
+
+// HandleWebhook handles a webhook notification from the identity provider by syncing each user or group which has changed.
+// An error response is returned if syncing fails, so that the identity provider retries the notification.
+func (s *IdentitySyncer) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := logger.Get(ctx)
+
+	wh, ok := s.idp.(WebhookIdentityProvider)
+	if !ok {
+		apio.ErrorString(ctx, w, "webhooks are not supported by this identity provider", http.StatusNotFound)
+		return
+	}
+
+	res, err := wh.ParseWebhook(r)
+	if err == ErrWebhookNotConfigured {
+		apio.ErrorString(ctx, w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err == ErrWebhookUnauthorized {
+		log.Infow("identity provider webhook error", zap.Error(err))
+		apio.ErrorString(ctx, w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		apio.Error(ctx, w, apio.NewRequestError(err, http.StatusBadRequest))
+		return
+	}
+
+	if res.Challenge != nil {
+		log.Infow("responding to identity provider webhook verification challenge")
+		w.Header().Set("Content-Type", res.Challenge.ContentType)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(res.Challenge.Body)
+		return
+	}
+
+	// a notification may contain several events for the same user or group.
+	seen := make(map[Change]bool)
+	for _, c := range res.Changes {
+		if seen[c] {
+			continue
+		}
+		seen[c] = true
+		err = s.ApplyChange(ctx, c)
+		if err != nil {
+			apio.Error(ctx, w, err)
+			return
+		}
+	}
+	log.Infow("handled identity provider webhook", "changes.count", len(seen))
+	w.WriteHeader(http.StatusOK)
+}
+
+// verifyWebhookSecret compares a secret received in a webhook notification with the configured secret in constant time.
+func verifyWebhookSecret(configured, received string) error {
+	if configured == "" {
+		return ErrWebhookNotConfigured
+	}
+	if subtle.ConstantTimeCompare([]byte(configured), []byte(received)) != 1 {
+		return ErrWebhookUnauthorized
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package identitysync
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOktaParseWebhook(t *testing.T) {
+	type testcase struct {
+		name       string
+		giveSecret string
+		headers    map[string]string
+		body       string
+		want       *WebhookResult
+		wantErr    error
+	}
+
+	testcases := []testcase{
+		{
+			name:       "verification challenge",
+			giveSecret: "secret",
+			headers:    map[string]string{"Authorization": "secret", "X-Okta-Verification-Challenge": "abc"},
+			want:       &WebhookResult{Challenge: &WebhookChallenge{ContentType: "application/json", Body: []byte(`{"verification":"abc"}`)}},
+		},
+		{
+			name:       "group membership event",
+			giveSecret: "secret",
+			headers:    map[string]string{"Authorization": "secret"},
+			body:       `{"data":{"events":[{"eventType":"group.user_membership.add","target":[{"id":"00u1","type":"User","alternateId":"josh@test.go"},{"id":"00g1","type":"UserGroup","alternateId":"unknown"}]}]}}`,
+			want: &WebhookResult{Changes: []Change{
+				{Kind: ChangeKindUser, ID: "00u1", Email: "josh@test.go"},
+				{Kind: ChangeKindGroup, ID: "00g1"},
+			}},
+		},
+		{
+			name:       "wrong secret",
+			giveSecret: "secret",
+			headers:    map[string]string{"Authorization": "wrong"},
+			wantErr:    ErrWebhookUnauthorized,
+		},
+		{
+			name:    "not configured",
+			headers: map[string]string{"Authorization": ""},
+			wantErr: ErrWebhookNotConfigured,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := OktaSync{}
+			if tc.giveSecret != "" {
+				o.webhookSecret.Set(tc.giveSecret)
+			}
+			r, err := http.NewRequest("POST", "/webhook/v1/identity", strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for k, v := range tc.headers {
+				r.Header.Set(k, v)
+			}
+			got, err := o.ParseWebhook(r)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestAzureParseWebhook(t *testing.T) {
+	type testcase struct {
+		name    string
+		url     string
+		body    string
+		want    *WebhookResult
+		wantErr error
+	}
+
+	testcases := []testcase{
+		{
+			name: "validation",
+			url:  "/webhook/v1/identity?validationToken=abc",
+			want: &WebhookResult{Challenge: &WebhookChallenge{ContentType: "text/plain", Body: []byte("abc")}},
+		},
+		{
+			name: "user and group membership changes",
+			url:  "/webhook/v1/identity",
+			body: `{"value":[{"clientState":"secret","changeType":"deleted","resourceData":{"@odata.type":"#Microsoft.Graph.User","id":"u1"}},{"clientState":"secret","changeType":"updated","resourceData":{"@odata.type":"#Microsoft.Graph.Group","id":"g1","members@delta":[{"id":"u2"}]}}]}`,
+			want: &WebhookResult{Changes: []Change{
+				{Kind: ChangeKindUser, ID: "u1"},
+				{Kind: ChangeKindGroup, ID: "g1"},
+				{Kind: ChangeKindUser, ID: "u2"},
+			}},
+		},
+		{
+			name:    "wrong client state",
+			url:     "/webhook/v1/identity",
+			body:    `{"value":[{"clientState":"wrong","changeType":"deleted","resourceData":{"@odata.type":"#Microsoft.Graph.User","id":"u1"}}]}`,
+			wantErr: ErrWebhookUnauthorized,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := AzureSync{}
+			a.webhookSecret.Set("secret")
+			r, err := http.NewRequest("POST", tc.url, strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := a.ParseWebhook(r)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGoogleParseWebhook(t *testing.T) {
+	type testcase struct {
+		name    string
+		headers map[string]string
+		body    string
+		want    *WebhookResult
+		wantErr error
+	}
+
+	testcases := []testcase{
+		{
+			name:    "sync message",
+			headers: map[string]string{"X-Goog-Channel-Token": "secret", "X-Goog-Resource-State": "sync"},
+			want:    &WebhookResult{Changes: []Change{}},
+		},
+		{
+			name:    "user deleted",
+			headers: map[string]string{"X-Goog-Channel-Token": "secret", "X-Goog-Resource-State": "delete"},
+			body:    `{"kind":"admin#directory#user","id":"123","primaryEmail":"josh@test.go"}`,
+			want:    &WebhookResult{Changes: []Change{{Kind: ChangeKindUser, ID: "123", Email: "josh@test.go"}}},
+		},
+		{
+			name:    "wrong token",
+			headers: map[string]string{"X-Goog-Channel-Token": "wrong"},
+			wantErr: ErrWebhookUnauthorized,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := GoogleSync{}
+			g.webhookSecret.Set("secret")
+			r, err := http.NewRequest("POST", "/webhook/v1/identity", strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for k, v := range tc.headers {
+				r.Header.Set(k, v)
+			}
+			got, err := g.ParseWebhook(r)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package identity
+
+import "github.com/common-fate/granted-approvals/pkg/types"
+
+// Offboarding describes the changes made to the requests of a user
+// who was archived because they were removed from the identity provider.
+type Offboarding struct {
+	UserID string `json:"userId"`
+	Email  string `json:"email"`
+	// CancelledRequests are the IDs of the user's pending requests which were cancelled.
+	CancelledRequests []string `json:"cancelledRequests"`
+	// RevokedGrants are the IDs of the requests whose active grants were revoked.
+	RevokedGrants []string `json:"revokedGrants"`
+	// RemovedReviews are the IDs of the pending requests which the user was removed as a reviewer from.
+	RemovedReviews []string `json:"removedReviews"`
+}
+
+func (o Offboarding) ToAPI() types.IdentitySyncOffboarding {
+	// ensures that the lists are never nil
+	return types.IdentitySyncOffboarding{
+		UserId:            o.UserID,
+		Email:             o.Email,
+		CancelledRequests: append([]string{}, o.CancelledRequests...),
+		RevokedGrants:     append([]string{}, o.RevokedGrants...),
+		RemovedReviews:    append([]string{}, o.RemovedReviews...),
+	}
+}
@@ -16,6 +16,8 @@ type IDPUser struct {
 	LastName  string
 	Email     string
 	// groups is a list of idp group ids, these will not match the internal dynamo ids
+	// Only groups which the user is a direct member of should be included. Membership of
+	// nested groups is resolved by identity sync using IDPGroup.ParentGroups.
 	Groups []string
 }
 
@@ -36,10 +38,13 @@ type User struct {
 	// internal id of the user
 	ID string `json:"id" dynamodbav:"id"`
 
-	FirstName string   `json:"firstName" dynamodbav:"firstName"`
-	LastName  string   `json:"lastName" dynamodbav:"lastName"`
-	Email     string   `json:"email" dynamodbav:"email"`
-	Groups    []string `json:"groups" dynamodbav:"groups"`
+	FirstName string `json:"firstName" dynamodbav:"firstName"`
+	LastName  string `json:"lastName" dynamodbav:"lastName"`
+	Email     string `json:"email" dynamodbav:"email"`
+	// Groups are the groups the user is a direct member of.
+	Groups []string `json:"groups" dynamodbav:"groups"`
+	// InheritedGroups are the groups the user is a member of through nested groups, but not directly.
+	InheritedGroups []string `json:"inheritedGroups,omitempty" dynamodbav:"inheritedGroups,omitempty"`
 
 	Status types.IdpStatus `json:"status" dynamodbav:"status"`
 
@@ -58,8 +63,20 @@ func contains(s []string, e string) bool {
 	return false
 }
 
+// EffectiveGroups returns the groups the user is a member of, either directly or through nested groups.
+func (u *User) EffectiveGroups() []string {
+	groups := append([]string{}, u.Groups...)
+	for _, g := range u.InheritedGroups {
+		if !contains(groups, g) {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// BelongsToGroup returns true if the user is a member of the group, either directly or through nested groups.
 func (u *User) BelongsToGroup(groupID string) bool {
-	return contains(u.Groups, groupID)
+	return contains(u.Groups, groupID) || contains(u.InheritedGroups, groupID)
 }
 func (u *User) ToAPI() types.User {
 	req := types.User{
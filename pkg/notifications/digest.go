@@ -0,0 +1,30 @@
+package notifications
+
+import (
+	"time"
+
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/storage/keys"
+)
+
+// DigestMessage is a notification which has been held back to be
+// sent in a digest, because the user prefers digest delivery.
+type DigestMessage struct {
+	ID     string `json:"id" dynamodbav:"id"`
+	UserID string `json:"userId" dynamodbav:"userId"`
+	// Channel the message should be delivered to.
+	Channel   Channel   `json:"channel" dynamodbav:"channel"`
+	EventType string    `json:"eventType" dynamodbav:"eventType"`
+	Message   string    `json:"message" dynamodbav:"message"`
+	CreatedAt time.Time `json:"createdAt" dynamodbav:"createdAt"`
+}
+
+func (d *DigestMessage) DDBKeys() (ddb.Keys, error) {
+	keys := ddb.Keys{
+		PK:     keys.DigestMessage.PK1,
+		SK:     keys.DigestMessage.SK1(d.UserID, d.ID),
+		GSI1PK: keys.DigestMessage.GSI1PK(string(d.Channel)),
+		GSI1SK: keys.DigestMessage.GSI1SK(d.UserID, d.ID),
+	}
+	return keys, nil
+}
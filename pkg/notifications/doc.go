@@ -0,0 +1,3 @@
+// Package notifications contains types describing how users
+// want to be notified about events in Granted Approvals.
+package notifications
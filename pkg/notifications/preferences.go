@@ -0,0 +1,104 @@
+package notifications
+
+import (
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/storage/keys"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+type Channel string
+
+const (
+	ChannelSlack Channel = "SLACK"
+	ChannelEmail Channel = "EMAIL"
+	ChannelTeams Channel = "TEAMS"
+)
+
+// channelsWithNotifiers are the channels which have a notifier.
+// Users who choose another channel are notified via Slack until a notifier exists for it,
+// so that they don't miss notifications.
+var channelsWithNotifiers = map[Channel]bool{
+	ChannelSlack: true,
+}
+
+// DeliveryChannel returns the channel which the user is notified via.
+// This is the channel they chose, or Slack if the channel doesn't have a notifier yet.
+func (p Preferences) DeliveryChannel() Channel {
+	if channelsWithNotifiers[p.Channel] {
+		return p.Channel
+	}
+	return ChannelSlack
+}
+
+type Delivery string
+
+const (
+	// DeliveryImmediate sends notifications as soon as the event occurs.
+	DeliveryImmediate Delivery = "IMMEDIATE"
+	// DeliveryDigest batches notifications into a daily digest.
+	DeliveryDigest Delivery = "DIGEST"
+)
+
+// Preferences are a user's notification preferences.
+// Notifiers must consult the preferences of a user before sending them a notification.
+type Preferences struct {
+	UserID   string   `json:"userId" dynamodbav:"userId"`
+	Channel  Channel  `json:"channel" dynamodbav:"channel"`
+	Delivery Delivery `json:"delivery" dynamodbav:"delivery"`
+	// MutedEvents are the event types, such as request.approved, which the user doesn't want to be notified about.
+	MutedEvents []string `json:"mutedEvents" dynamodbav:"mutedEvents"`
+}
+
+// DefaultPreferences are used for users who haven't set any preferences.
+// They are notified immediately via Slack about all events.
+func DefaultPreferences(userID string) Preferences {
+	return Preferences{
+		UserID:      userID,
+		Channel:     ChannelSlack,
+		Delivery:    DeliveryImmediate,
+		MutedEvents: []string{},
+	}
+}
+
+// Wants returns true if the user wants to be notified about the event type via the channel.
+func (p Preferences) Wants(channel Channel, eventType string) bool {
+	if p.DeliveryChannel() != channel {
+		return false
+	}
+	for _, e := range p.MutedEvents {
+		if e == eventType {
+			return false
+		}
+	}
+	return true
+}
+
+func PreferencesFromAPI(userID string, in types.NotificationPreferences) Preferences {
+	p := Preferences{
+		UserID:      userID,
+		Channel:     Channel(in.Channel),
+		Delivery:    Delivery(in.Delivery),
+		MutedEvents: in.MutedEvents,
+	}
+	if p.MutedEvents == nil {
+		p.MutedEvents = []string{}
+	}
+	return p
+}
+
+func (p Preferences) ToAPI() types.NotificationPreferences {
+	return types.NotificationPreferences{
+		Channel:  types.NotificationPreferencesChannel(p.Channel),
+		Delivery: types.NotificationPreferencesDelivery(p.Delivery),
+		// ensures that this is never nil
+		MutedEvents: append([]string{}, p.MutedEvents...),
+	}
+}
+
+func (p *Preferences) DDBKeys() (ddb.Keys, error) {
+	keys := ddb.Keys{
+		PK: keys.NotificationPreferences.PK1,
+		SK: keys.NotificationPreferences.SK1(p.UserID),
+	}
+	return keys, nil
+}
@@ -0,0 +1,54 @@
+package notifications
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreferencesWants(t *testing.T) {
+	type testcase struct {
+		name      string
+		prefs     Preferences
+		channel   Channel
+		eventType string
+		want      bool
+	}
+
+	testcases := []testcase{
+		{
+			name:      "defaults",
+			prefs:     DefaultPreferences("usr_1"),
+			channel:   ChannelSlack,
+			eventType: "request.created",
+			want:      true,
+		},
+		{
+			name:      "different channel",
+			prefs:     Preferences{Channel: ChannelSlack},
+			channel:   ChannelEmail,
+			eventType: "request.created",
+			want:      false,
+		},
+		{
+			name:      "channel without a notifier falls back to Slack",
+			prefs:     Preferences{Channel: ChannelTeams},
+			channel:   ChannelSlack,
+			eventType: "request.created",
+			want:      true,
+		},
+		{
+			name:      "muted event",
+			prefs:     Preferences{Channel: ChannelSlack, MutedEvents: []string{"grant.expiring"}},
+			channel:   ChannelSlack,
+			eventType: "grant.expiring",
+			want:      false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.prefs.Wants(tc.channel, tc.eventType))
+		})
+	}
+}
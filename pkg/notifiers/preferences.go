@@ -0,0 +1,39 @@
+package notifiers
+
+import (
+	"context"
+	"time"
+
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/notifications"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+// GetPreferences returns the notification preferences for a user.
+// If the user hasn't set any preferences, the default preferences are returned.
+func GetPreferences(ctx context.Context, db ddb.Storage, userID string) (notifications.Preferences, error) {
+	q := storage.GetNotificationPreferences{UserID: userID}
+	_, err := db.Query(ctx, &q)
+	if err == ddb.ErrNoItems {
+		return notifications.DefaultPreferences(userID), nil
+	}
+	if err != nil {
+		return notifications.Preferences{}, err
+	}
+	return *q.Result, nil
+}
+
+// QueueDigestMessage saves a message to be sent to the user in their next digest,
+// via the channel in their notification preferences.
+func QueueDigestMessage(ctx context.Context, db ddb.Storage, prefs notifications.Preferences, eventType, message string, now time.Time) error {
+	d := notifications.DigestMessage{
+		ID:        types.NewDigestMessageID(),
+		UserID:    prefs.UserID,
+		Channel:   prefs.DeliveryChannel(),
+		EventType: eventType,
+		Message:   message,
+		CreatedAt: now,
+	}
+	return db.Put(ctx, &d)
+}
@@ -0,0 +1,16 @@
+package notifiers
+
+import (
+	"net/url"
+	"path"
+)
+
+// RequestAccessURL returns the URL in the frontend where users can request access to a rule.
+func RequestAccessURL(frontendURL, ruleID string) (string, error) {
+	u, err := url.Parse(frontendURL)
+	if err != nil {
+		return "", err
+	}
+	u.Path = path.Join(u.Path, "access", "request", ruleID)
+	return u.String(), nil
+}
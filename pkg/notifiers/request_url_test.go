@@ -0,0 +1,41 @@
+package notifiers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestAccessURL(t *testing.T) {
+	type testcase struct {
+		name    string
+		giveURL string
+		giveID  string
+		want    string
+	}
+
+	testcases := []testcase{
+		{
+			name:    "ok",
+			giveURL: "https://grantedtest.com",
+			giveID:  "rul_123",
+			want:    "https://grantedtest.com/access/request/rul_123",
+		},
+		{
+			name:    "with path",
+			giveURL: "https://grantedtest.com/prod",
+			giveID:  "rul_123",
+			want:    "https://grantedtest.com/prod/access/request/rul_123",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := RequestAccessURL(tc.giveURL, tc.giveID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
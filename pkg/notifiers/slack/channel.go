@@ -0,0 +1,102 @@
+package slacknotifier
+
+import (
+	"context"
+
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/pkg/errors"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// SendChannelMessageBlocks is a utility for posting a message to a Slack channel.
+// The channel may be a channel name like #approvals or a channel ID.
+//
+// It returns the ID of the channel as well as the message timestamp, which are
+// both required to update the message later.
+func SendChannelMessageBlocks(ctx context.Context, slackClient *slack.Client, channel string, message slack.Message, summary string) (channelID string, timestamp string, err error) {
+	return slackClient.PostMessageContext(ctx, channel, slack.MsgOptionBlocks(message.Blocks.BlockSet...), slack.MsgOptionText(summary, false))
+}
+
+// UpdateChannelMessageBlocks is a utility for updating a message which was posted to a Slack channel.
+func UpdateChannelMessageBlocks(ctx context.Context, slackClient *slack.Client, channelID string, message slack.Message) error {
+	_, _, _, err := slackClient.UpdateMessageContext(ctx, channelID, message.Timestamp, slack.MsgOptionBlocks(message.Blocks.BlockSet...))
+	return err
+}
+
+// SendChannelMessages posts the request message to each of the Slack channels configured on the Access Rule.
+// The message IDs are saved so that the messages can be updated as the request is reviewed.
+//
+// This will log any errors and continue, so that a misconfigured channel doesn't prevent other notifications being sent.
+func (n *SlackNotifier) SendChannelMessages(ctx context.Context, log *zap.SugaredLogger, opts RequestMessageOpts) {
+	summary, msg := BuildRequestMessage(opts)
+	for _, channel := range opts.Rule.Notifications.SlackChannels {
+		channelID, ts, err := SendChannelMessageBlocks(ctx, n.client, channel, msg, summary)
+		if err != nil {
+			log.Errorw("failed to send request message to channel", "channel", channel, zap.Error(err))
+			continue
+		}
+		cm := access.ChannelMessage{
+			RequestID: opts.Request.ID,
+			Channel:   channel,
+			Notifications: access.Notifications{
+				SlackMessageID: &ts,
+				SlackChannelID: &channelID,
+			},
+		}
+		log.Infow("saving slack channel message id", "channel", channel, "channel.id", channelID, "ts", ts)
+		err = n.DB.Put(ctx, &cm)
+		if err != nil {
+			log.Errorw("failed to save channel message", "channel", channel, zap.Error(err))
+		}
+	}
+}
+
+// UpdateChannelMessages updates the request messages which were previously posted to Slack channels
+// so that they reflect the current status of the request.
+func (n *SlackNotifier) UpdateChannelMessages(ctx context.Context, log *zap.SugaredLogger, opts RequestMessageOpts) error {
+	q := storage.ListRequestChannelMessages{RequestID: opts.Request.ID}
+	_, err := n.DB.Query(ctx, &q)
+	if err != nil {
+		return errors.Wrap(err, "listing channel messages")
+	}
+	_, msg := BuildRequestMessage(opts)
+	for _, cm := range q.Result {
+		if cm.Notifications.SlackMessageID == nil || cm.Notifications.SlackChannelID == nil {
+			continue
+		}
+		msg.Timestamp = *cm.Notifications.SlackMessageID
+		err = UpdateChannelMessageBlocks(ctx, n.client, *cm.Notifications.SlackChannelID, msg)
+		if err != nil {
+			log.Errorw("failed to update channel message", "channel", cm.Channel, zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// ReplyToChannelMessages posts a message in the thread of each request message which was
+// previously posted to a Slack channel. It's used for lifecycle events which happen after
+// a request has been reviewed, such as the grant failing.
+func (n *SlackNotifier) ReplyToChannelMessages(ctx context.Context, log *zap.SugaredLogger, requestID string, message string, summary string) error {
+	q := storage.ListRequestChannelMessages{RequestID: requestID}
+	_, err := n.DB.Query(ctx, &q)
+	if err != nil {
+		return errors.Wrap(err, "listing channel messages")
+	}
+	block := slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, message, false, false), nil, nil)
+	for _, cm := range q.Result {
+		if cm.Notifications.SlackMessageID == nil || cm.Notifications.SlackChannelID == nil {
+			continue
+		}
+		_, _, err = n.client.PostMessageContext(ctx, *cm.Notifications.SlackChannelID,
+			slack.MsgOptionBlocks(block),
+			slack.MsgOptionText(summary, false),
+			slack.MsgOptionTS(*cm.Notifications.SlackMessageID),
+		)
+		if err != nil {
+			log.Errorw("failed to reply to channel message", "channel", cm.Channel, zap.Error(err))
+		}
+	}
+	return nil
+}
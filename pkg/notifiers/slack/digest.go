@@ -0,0 +1,85 @@
+package slacknotifier
+
+import (
+	"context"
+
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/notifications"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/pkg/errors"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// maxDigestItems is the number of messages included in a single digest DM.
+// Slack messages can contain at most 50 blocks, and one is used for the heading.
+const maxDigestItems = 49
+
+// SendDigests sends each user with queued digest messages a single DM containing all of their messages.
+// Messages are deleted once they've been sent, and are kept to be retried in the next digest if sending fails.
+func (n *SlackNotifier) SendDigests(ctx context.Context, log *zap.SugaredLogger) error {
+	var messages []notifications.DigestMessage
+	var opts []func(*ddb.QueryOpts)
+	for {
+		q := storage.ListDigestMessagesForChannel{Channel: notifications.ChannelSlack}
+		res, err := n.DB.Query(ctx, &q, opts...)
+		if err != nil {
+			return errors.Wrap(err, "listing digest messages")
+		}
+		messages = append(messages, q.Result...)
+		if res == nil || res.NextPage == "" {
+			break
+		}
+		opts = []func(*ddb.QueryOpts){ddb.Page(res.NextPage)}
+	}
+
+	// messages are ordered by user, so they can be grouped in a single pass.
+	var userMessages []notifications.DigestMessage
+	for i, m := range messages {
+		userMessages = append(userMessages, m)
+		if i+1 < len(messages) && messages[i+1].UserID == m.UserID {
+			continue
+		}
+		err := n.sendDigest(ctx, m.UserID, userMessages)
+		if err != nil {
+			log.Errorw("failed to send digest", "user.id", m.UserID, zap.Error(err))
+		}
+		userMessages = nil
+	}
+	return nil
+}
+
+func (n *SlackNotifier) sendDigest(ctx context.Context, userID string, messages []notifications.DigestMessage) error {
+	u := storage.GetUser{ID: userID}
+	_, err := n.DB.Query(ctx, &u)
+	if err != nil {
+		return errors.Wrap(err, "getting user")
+	}
+
+	for start := 0; start < len(messages); start += maxDigestItems {
+		end := start + maxDigestItems
+		if end > len(messages) {
+			end = len(messages)
+		}
+		batch := messages[start:end]
+
+		blocks := []slack.Block{
+			slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, "*Here's your Granted digest:*", false, false), nil, nil),
+		}
+		items := make([]ddb.Keyer, len(batch))
+		for i := range batch {
+			blocks = append(blocks, slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, batch[i].Message, false, false), nil, nil))
+			items[i] = &batch[i]
+		}
+
+		_, err = SendMessageBlocks(ctx, n.client, u.Result.Email, slack.NewBlockMessage(blocks...), "Your Granted digest")
+		if err != nil {
+			return errors.Wrap(err, "sending digest")
+		}
+		err = n.DB.DeleteBatch(ctx, items...)
+		if err != nil {
+			return errors.Wrap(err, "deleting sent digest messages")
+		}
+	}
+	return nil
+}
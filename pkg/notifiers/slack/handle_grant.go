@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/common-fate/granted-approvals/pkg/gevent"
+	"github.com/common-fate/granted-approvals/pkg/notifiers"
 	"github.com/common-fate/granted-approvals/pkg/storage"
 	"go.uber.org/zap"
 )
@@ -41,15 +44,48 @@ func (n *SlackNotifier) HandleGrantEvent(ctx context.Context, log *zap.SugaredLo
 	case gevent.GrantFailedType:
 		msg = fmt.Sprintf("We've had an issue trying to provision or clean up your access to *%s*. We'll keep trying, but if you urgently need access to the role please contact your cloud administrator.", rq.Result.Name)
 		fallback = fmt.Sprintf("We've had an issue with your access to %s", rq.Result.Name)
+	case gevent.GrantExpiringType:
+		requestURL, err := notifiers.RequestAccessURL(n.FrontendURL, rq.Result.ID)
+		if err != nil {
+			return err
+		}
+		remaining := time.Until(gq.Result.Grant.End).Round(time.Minute)
+		msg = fmt.Sprintf(":hourglass_flowing_sand: Your access to *%s* ends in %s. If you still need access, you can <%s|request access again>.", rq.Result.Name, shortDuration(remaining), requestURL)
+		fallback = fmt.Sprintf("Your access to %s ends in %s.", rq.Result.Name, shortDuration(remaining))
 	case gevent.GrantRevokedType:
 		msg = fmt.Sprintf("Your access to *%s* has been cancelled by your administrator. Please contact your cloud administrator for more information.", rq.Result.Name)
 		fallback = fmt.Sprintf("Your access to %s has been cancelled by your administrator", rq.Result.Name)
 	default:
 		zap.S().Infow("unhandled grant event", "detailType", event.DetailType)
 	}
-	if msg != "" {
+	if event.DetailType == gevent.GrantFailedType && len(rq.Result.Notifications.SlackChannels) > 0 {
+		var grantFailedEvent gevent.GrantFailed
+		err = json.Unmarshal(event.Detail, &grantFailedEvent)
+		if err != nil {
+			return err
+		}
+		channelMsg := fmt.Sprintf(":warning: We've had an issue trying to provision or clean up access to *%s* for %s: %s", rq.Result.Name, gq.Result.Grant.Subject, grantFailedEvent.Reason)
+		channelFallback := fmt.Sprintf("We've had an issue with access to %s for %s", rq.Result.Name, gq.Result.Grant.Subject)
+		err = n.ReplyToChannelMessages(ctx, log, gq.Result.ID, channelMsg, channelFallback)
+		if err != nil {
+			log.Errorw("failed to reply to slack channel messages", zap.Error(err))
+		}
+	}
+	if msg != "" && n.shouldSendNow(ctx, log, gq.Result.RequestedBy, event.DetailType, msg) {
 		_, err = SendMessage(ctx, n.client, gq.Result.Grant.Subject, msg, fallback)
 		return err
 	}
 	return nil
 }
+
+// shortDuration formats a duration without trailing zero units, e.g. "15m" rather than "15m0s".
+func shortDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = s[:len(s)-2]
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = s[:len(s)-2]
+	}
+	return s
+}
@@ -47,9 +47,11 @@ func (n *SlackNotifier) HandleRequestEvent(ctx context.Context, log *zap.Sugared
 			msg := fmt.Sprintf("Your request to access *%s* requires approval. We've notified the approvers and will let you know once your request has been reviewed.", ruleQuery.Result.Name)
 			fallback := fmt.Sprintf("Your request to access %s requires approval.", ruleQuery.Result.Name)
 
-			_, err = SendMessage(ctx, n.client, userQuery.Result.Email, msg, fallback)
-			if err != nil {
-				log.Errorw("Failed to send direct message", "email", userQuery.Result.Email, "msg", msg, "error", err)
+			if n.shouldSendNow(ctx, log, req.RequestedBy, event.DetailType, msg) {
+				_, err = SendMessage(ctx, n.client, userQuery.Result.Email, msg, fallback)
+				if err != nil {
+					log.Errorw("Failed to send direct message", "email", userQuery.Result.Email, "msg", msg, "error", err)
+				}
 			}
 
 			// Notify approvers
@@ -103,15 +105,17 @@ func (n *SlackNotifier) HandleRequestEvent(ctx context.Context, log *zap.Sugared
 						ReviewURLs:       reviewURL,
 					})
 
+					if !n.shouldSendNow(ctx, log, usr.ReviewerID, event.DetailType, fmt.Sprintf("%s: <%s|review the request>", summary, reviewURL.Review)) {
+						return
+					}
+
 					ts, err := SendMessageBlocks(ctx, n.client, approver.Result.Email, msg, summary)
 					if err != nil {
 						log.Errorw("failed to send request approval message", "user", usr, zap.Error(err))
 					}
 
 					updatedUsr := usr
-					updatedUsr.Notifications = access.Notifications{
-						SlackMessageID: &ts,
-					}
+					updatedUsr.Notifications.SlackMessageID = &ts
 					log.Infow("updating reviewer with slack msg id", "updatedUsr.SlackMessageID", ts)
 
 					err = n.DB.Put(ctx, &updatedUsr)
@@ -127,12 +131,55 @@ func (n *SlackNotifier) HandleRequestEvent(ctx context.Context, log *zap.Sugared
 			//Review not required
 			msg := fmt.Sprintf(":white_check_mark: Your request to access *%s* has been automatically approved. Hang tight - we're provisioning the role now and will let you know when it's ready.", ruleQuery.Result.Name)
 			fallback := fmt.Sprintf("Your request to access %s has been automatically approved.", ruleQuery.Result.Name)
-			_ = n.SendDMWithLogOnError(ctx, log, req.RequestedBy, msg, fallback)
+			if n.shouldSendNow(ctx, log, req.RequestedBy, event.DetailType, msg) {
+				_ = n.SendDMWithLogOnError(ctx, log, req.RequestedBy, msg, fallback)
+			}
+		}
+
+		if len(rule.Notifications.SlackChannels) > 0 {
+			reviewURL, err := notifiers.ReviewURL(n.FrontendURL, req.ID)
+			if err != nil {
+				return errors.Wrap(err, "building review URL")
+			}
+			n.SendChannelMessages(ctx, log, RequestMessageOpts{
+				Request:           req,
+				Rule:              rule,
+				RequestorSlackID:  n.slackUserIDFromEmail(ctx, userQuery.Result.Email),
+				RequestorEmail:    userQuery.Result.Email,
+				ReviewURLs:        reviewURL,
+				HideReviewActions: true,
+			})
+		}
+	case gevent.RequestReminderType:
+		err = n.SendReviewReminders(ctx, log, ReviewReminderOpts{
+			Request:     req,
+			Rule:        rule,
+			Requestor:   userQuery.Result,
+			ReviewerIDs: requestEvent.ReviewerIDs,
+			EventType:   event.DetailType,
+			Note:        ":alarm_clock: *Reminder:* this request is still waiting for a review.",
+		})
+		if err != nil {
+			return err
+		}
+	case gevent.RequestEscalatedType:
+		err = n.SendReviewReminders(ctx, log, ReviewReminderOpts{
+			Request:     req,
+			Rule:        rule,
+			Requestor:   userQuery.Result,
+			ReviewerIDs: requestEvent.ReviewerIDs,
+			EventType:   event.DetailType,
+			Note:        ":rotating_light: This request hasn't been reviewed in time and has been escalated to you.",
+		})
+		if err != nil {
+			return err
 		}
 	case gevent.RequestApprovedType:
 		msg := fmt.Sprintf("Your request to access *%s* has been approved. Hang tight - we're provisioning the access now and will let you know when it's ready.", ruleQuery.Result.Name)
 		fallback := fmt.Sprintf("Your request to access %s has been approved.", ruleQuery.Result.Name)
-		_ = n.SendDMWithLogOnError(ctx, log, req.RequestedBy, msg, fallback)
+		if n.shouldSendNow(ctx, log, req.RequestedBy, event.DetailType, msg) {
+			_ = n.SendDMWithLogOnError(ctx, log, req.RequestedBy, msg, fallback)
+		}
 
 		// Loop over the request reviewers
 		reviewers := storage.ListRequestReviewers{RequestID: req.ID}
@@ -155,6 +202,11 @@ func (n *SlackNotifier) HandleRequestEvent(ctx context.Context, log *zap.Sugared
 				log.Errorw("failed to update slack message", "user", rev, zap.Error(err))
 			}
 		}
+
+		err = n.updateChannelMessagesForReview(ctx, log, req, rule, userQuery.Result, requestEvent.ReviewerID)
+		if err != nil {
+			log.Errorw("failed to update slack channel messages", zap.Error(err))
+		}
 	case gevent.RequestCancelledType:
 		// Loop over the request reviewers
 		reviewers := storage.ListRequestReviewers{RequestID: req.ID}
@@ -177,10 +229,17 @@ func (n *SlackNotifier) HandleRequestEvent(ctx context.Context, log *zap.Sugared
 				log.Errorw("failed to update slack message", "user", usr, "req", req, zap.Error(err))
 			}
 		}
+
+		err = n.updateChannelMessagesForReview(ctx, log, req, rule, userQuery.Result, req.RequestedBy)
+		if err != nil {
+			log.Errorw("failed to update slack channel messages", zap.Error(err))
+		}
 	case gevent.RequestDeclinedType:
 		msg := fmt.Sprintf("Your request to access *%s* has been declined.", ruleQuery.Result.Name)
 		fallback := fmt.Sprintf("Your request to access %s has been declined.", ruleQuery.Result.Name)
-		_ = n.SendDMWithLogOnError(ctx, log, req.RequestedBy, msg, fallback)
+		if n.shouldSendNow(ctx, log, req.RequestedBy, event.DetailType, msg) {
+			_ = n.SendDMWithLogOnError(ctx, log, req.RequestedBy, msg, fallback)
+		}
 
 		// Loop over the request reviewers
 		reviewers := storage.ListRequestReviewers{RequestID: req.ID}
@@ -204,10 +263,53 @@ func (n *SlackNotifier) HandleRequestEvent(ctx context.Context, log *zap.Sugared
 				log.Errorw("failed to update slack message", "user", usr, zap.Error(err))
 			}
 		}
+
+		err = n.updateChannelMessagesForReview(ctx, log, req, rule, userQuery.Result, requestEvent.ReviewerID)
+		if err != nil {
+			log.Errorw("failed to update slack channel messages", zap.Error(err))
+		}
 	}
 	return nil
 }
 
+// updateChannelMessagesForReview updates any messages posted to Slack channels for the request
+// to show the outcome of the review. reviewerID is the user who reviewed or cancelled the request.
+func (n *SlackNotifier) updateChannelMessagesForReview(ctx context.Context, log *zap.SugaredLogger, req access.Request, rule rule.AccessRule, requestor *identity.User, reviewerID string) error {
+	if len(rule.Notifications.SlackChannels) == 0 {
+		return nil
+	}
+	reviewer := storage.GetUser{ID: reviewerID}
+	_, err := n.DB.Query(ctx, &reviewer)
+	if err != nil && req.Status != access.CANCELLED {
+		return errors.Wrap(err, "getting reviewer")
+	}
+	reviewURL, err := notifiers.ReviewURL(n.FrontendURL, req.ID)
+	if err != nil {
+		return errors.Wrap(err, "building review URL")
+	}
+	return n.UpdateChannelMessages(ctx, log, RequestMessageOpts{
+		Request:           req,
+		Rule:              rule,
+		RequestorSlackID:  n.slackUserIDFromEmail(ctx, requestor.Email),
+		RequestorEmail:    requestor.Email,
+		ReviewURLs:        reviewURL,
+		Reviewer:          reviewer.Result,
+		RequestReviewer:   reviewer.Result,
+		HideReviewActions: true,
+	})
+}
+
+// slackUserIDFromEmail returns the Slack user ID for a user so that they can be rendered nicely in messages.
+// If the user can't be found in Slack an empty string is returned and callers should fall back to the email address.
+func (n *SlackNotifier) slackUserIDFromEmail(ctx context.Context, email string) string {
+	u, err := n.client.GetUserByEmailContext(ctx, email)
+	if err != nil {
+		zap.S().Infow("couldn't get slack user - falling back to email address", "email", email, zap.Error(err))
+		return ""
+	}
+	return u.ID
+}
+
 type UpdateSlackMessageOpts struct {
 	Review            access.Reviewer
 	Request           access.Request
@@ -217,6 +319,10 @@ type UpdateSlackMessageOpts struct {
 }
 
 func (n *SlackNotifier) UpdateSlackMessage(ctx context.Context, log *zap.SugaredLogger, opts UpdateSlackMessageOpts) error {
+	// reviewers who haven't been sent a message, such as escalation reviewers who couldn't be found in Slack, have nothing to update.
+	if opts.Review.Notifications.SlackMessageID == nil {
+		return nil
+	}
 
 	// Skip if requestor == reviewer
 
@@ -274,8 +380,14 @@ type RequestMessageOpts struct {
 	RequestorEmail   string
 	Reviewer         *identity.User
 	RequestReviewer  *identity.User
+	// HideReviewActions omits the approve and deny buttons from the message.
+	// It's used for messages posted to shared channels, where most readers are not reviewers.
+	HideReviewActions bool
 }
 
+// maxSectionFields is the most fields which Slack allows in a section block.
+const maxSectionFields = 10
+
 func BuildRequestMessage(o RequestMessageOpts) (summary string, msg slack.Message) {
 	requestor := o.RequestorEmail
 	if o.RequestorSlackID != "" {
@@ -316,6 +428,17 @@ func BuildRequestMessage(o RequestMessageOpts) (summary string, msg slack.Messag
 		})
 	}
 
+	// show the request fields in the order they are defined on the rule
+	var requestFields []*slack.TextBlockObject
+	for _, f := range o.Rule.RequestFields {
+		if v := o.Request.Data.Fields[f.ID]; v != "" {
+			requestFields = append(requestFields, &slack.TextBlockObject{
+				Type: "mrkdwn",
+				Text: fmt.Sprintf("*%s:*\n%s", f.Label, v),
+			})
+		}
+	}
+
 	msg = slack.NewBlockMessage(
 		slack.SectionBlock{
 			Type: slack.MBTSection,
@@ -330,6 +453,19 @@ func BuildRequestMessage(o RequestMessageOpts) (summary string, msg slack.Messag
 		},
 	)
 
+	// rules can have any number of request fields, so they are shown in their own sections to stay within Slack's limit on the fields in a section.
+	for len(requestFields) > 0 {
+		n := len(requestFields)
+		if n > maxSectionFields {
+			n = maxSectionFields
+		}
+		msg.Blocks.BlockSet = append(msg.Blocks.BlockSet, slack.SectionBlock{
+			Type:   slack.MBTSection,
+			Fields: requestFields[:n],
+		})
+		requestFields = requestFields[n:]
+	}
+
 	if o.Reviewer != nil || o.Request.Status == access.CANCELLED {
 		t := time.Now()
 		when = fmt.Sprintf("<!date^%d^{date_short_pretty} at {time}|%s>", t.Unix(), t.String())
@@ -349,7 +485,7 @@ func BuildRequestMessage(o RequestMessageOpts) (summary string, msg slack.Messag
 	}
 
 	// If the request has just been sent (PENDING), then append Action Blocks
-	if o.Request.Status == access.PENDING {
+	if o.Request.Status == access.PENDING && !o.HideReviewActions {
 		msg.Blocks.BlockSet = append(msg.Blocks.BlockSet, slack.NewActionBlock("review_actions",
 			slack.ButtonBlockElement{
 				Type:     slack.METButton,
@@ -44,6 +44,11 @@ func (n *SlackNotifier) HandleEvent(ctx context.Context, event events.CloudWatch
 
 	log.Infow("received event", "event", event)
 
+	// digests are triggered by a scheduled EventBridge rule.
+	if event.Source == "aws.events" && event.DetailType == "Scheduled Event" {
+		return n.SendDigests(ctx, log)
+	}
+
 	if strings.HasPrefix(event.DetailType, "grant") {
 		err = n.HandleGrantEvent(ctx, log, event)
 		if err != nil {
@@ -0,0 +1,35 @@
+package slacknotifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/common-fate/granted-approvals/pkg/notifications"
+	"github.com/common-fate/granted-approvals/pkg/notifiers"
+	"go.uber.org/zap"
+)
+
+// shouldSendNow consults the user's notification preferences before a message is sent to them.
+// It returns true if the message should be sent to the user in Slack now.
+// If the user prefers to receive a digest, the message is queued for their next digest and false is returned.
+func (n *SlackNotifier) shouldSendNow(ctx context.Context, log *zap.SugaredLogger, userID, eventType, digestMessage string) bool {
+	prefs, err := notifiers.GetPreferences(ctx, n.DB, userID)
+	if err != nil {
+		// fall back to the defaults so that notifications aren't lost.
+		log.Errorw("failed to get notification preferences, using defaults", "user.id", userID, zap.Error(err))
+		prefs = notifications.DefaultPreferences(userID)
+	}
+	if !prefs.Wants(notifications.ChannelSlack, eventType) {
+		log.Infow("skipping notification due to user preferences", "user.id", userID, "event", eventType)
+		return false
+	}
+	if prefs.Delivery == notifications.DeliveryDigest {
+		err = notifiers.QueueDigestMessage(ctx, n.DB, prefs, eventType, digestMessage, time.Now())
+		if err != nil {
+			log.Errorw("failed to queue digest message, sending immediately", "user.id", userID, zap.Error(err))
+			return true
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package slacknotifier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/notifiers"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/pkg/errors"
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+type ReviewReminderOpts struct {
+	Request   access.Request
+	Rule      rule.AccessRule
+	Requestor *identity.User
+	// ReviewerIDs are the reviewers to send the reminder to.
+	ReviewerIDs []string
+	// EventType is the event which caused the reminder, used to check the reviewers' notification preferences.
+	EventType string
+	// Note is shown above the request details to explain why the reviewer is being messaged.
+	Note string
+}
+
+// SendReviewReminders sends a fresh copy of the review message to reviewers of a pending request.
+// If a reviewer hasn't been messaged about the request before, such as when the request has been escalated to them,
+// the message ID is recorded on the reviewer so that the message is updated when the request is reviewed.
+func (n *SlackNotifier) SendReviewReminders(ctx context.Context, log *zap.SugaredLogger, opts ReviewReminderOpts) error {
+	reviewURL, err := notifiers.ReviewURL(n.FrontendURL, opts.Request.ID)
+	if err != nil {
+		return errors.Wrap(err, "building review URL")
+	}
+
+	reviewers := storage.ListRequestReviewers{RequestID: opts.Request.ID}
+	_, err = n.DB.Query(ctx, &reviewers)
+	if err != nil {
+		return errors.Wrap(err, "getting reviewers")
+	}
+
+	toMessage := make(map[string]bool)
+	for _, id := range opts.ReviewerIDs {
+		toMessage[id] = true
+	}
+
+	summary, msg := BuildRequestMessage(RequestMessageOpts{
+		Request:          opts.Request,
+		Rule:             opts.Rule,
+		RequestorSlackID: n.slackUserIDFromEmail(ctx, opts.Requestor.Email),
+		RequestorEmail:   opts.Requestor.Email,
+		ReviewURLs:       reviewURL,
+	})
+	note := slack.NewContextBlock("", slack.NewTextBlockObject(slack.MarkdownType, opts.Note, false, false))
+	msg.Blocks.BlockSet = append([]slack.Block{note}, msg.Blocks.BlockSet...)
+
+	var wg sync.WaitGroup
+	for _, rev := range reviewers.Result {
+		if !toMessage[rev.ReviewerID] {
+			continue
+		}
+
+		wg.Add(1)
+		go func(rev access.Reviewer) {
+			defer wg.Done()
+			reviewer := storage.GetUser{ID: rev.ReviewerID}
+			_, err := n.DB.Query(ctx, &reviewer)
+			if err != nil {
+				log.Errorw("failed to fetch user by id while trying to send reminder in slack", "user.id", rev.ReviewerID, zap.Error(err))
+				return
+			}
+
+			if !n.shouldSendNow(ctx, log, rev.ReviewerID, opts.EventType, fmt.Sprintf("%s %s: <%s|review the request>", opts.Note, summary, reviewURL.Review)) {
+				return
+			}
+
+			ts, err := SendMessageBlocks(ctx, n.client, reviewer.Result.Email, msg, summary)
+			if err != nil {
+				log.Errorw("failed to send review reminder", "user.id", rev.ReviewerID, zap.Error(err))
+				return
+			}
+
+			if rev.Notifications.SlackMessageID != nil {
+				return
+			}
+			rev.Notifications.SlackMessageID = &ts
+			err = n.DB.Put(ctx, &rev)
+			if err != nil {
+				log.Errorw("failed to update reviewer", "user.id", rev.ReviewerID, zap.Error(err))
+			}
+		}(rev)
+	}
+	wg.Wait()
+	return nil
+}
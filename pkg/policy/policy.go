@@ -0,0 +1,221 @@
+// Package policy evaluates the policies of access rules, which decide whether
+// requests are approved automatically, require approval or are denied.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/google/cel-go/cel"
+)
+
+// ErrInvalidPolicy is returned if a policy has an unsupported language or effect, or an expression which doesn't compile.
+var ErrInvalidPolicy = errors.New("invalid policy")
+
+// Requester is the user making the request.
+type Requester struct {
+	ID     string
+	Email  string
+	Groups []string
+}
+
+// Request is the access being requested.
+type Request struct {
+	Reason string
+	// With are the options selected by the requester.
+	With     map[string]string
+	Duration time.Duration
+	// StartTime is nil if access was requested as soon as possible.
+	StartTime *time.Time
+}
+
+// Input is the data which the conditions of a policy can use.
+type Input struct {
+	Requester Requester
+	Request   Request
+	Now       time.Time
+}
+
+// Result is the outcome of evaluating a policy.
+type Result struct {
+	// Matched is false if none of the conditions were true.
+	Matched bool
+	Effect  rule.PolicyEffect
+	// Condition is the index of the condition which decided the effect.
+	Condition   int
+	Description string
+	Expression  string
+	// Error is set if the condition failed to evaluate.
+	Error string
+}
+
+// Event returns the result as a recorded request event, for the audit log.
+func (r Result) Event() map[string]string {
+	if !r.Matched {
+		return map[string]string{"policy": "no conditions matched"}
+	}
+	e := map[string]string{
+		"policyEffect":     string(r.Effect),
+		"policyCondition":  strconv.Itoa(r.Condition),
+		"policyExpression": r.Expression,
+	}
+	if r.Description != "" {
+		e["policyDescription"] = r.Description
+	}
+	if r.Error != "" {
+		e["policyError"] = r.Error
+	}
+	return e
+}
+
+func newEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("requester", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("request", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("now", cel.TimestampType),
+	)
+}
+
+// Validate returns ErrInvalidPolicy if any of the conditions of the policy are invalid.
+func Validate(p rule.Policy) error {
+	_, err := compile(p)
+	return err
+}
+
+// ValidateApprovers returns ErrInvalidPolicy if the policy has conditions which require approval
+// but the rule has no approvers, as the requests which match the conditions could never be reviewed.
+func ValidateApprovers(p rule.Policy, a rule.Approval) error {
+	if a.IsRequired() {
+		return nil
+	}
+	for i, c := range p.Conditions {
+		if c.Effect == rule.PolicyRequireApproval {
+			return fmt.Errorf("%w: condition %d requires approval but the rule has no approval users or groups", ErrInvalidPolicy, i)
+		}
+	}
+	return nil
+}
+
+func compile(p rule.Policy) ([]cel.Program, error) {
+	if !p.IsSet() {
+		return nil, nil
+	}
+	if p.Language != "cel" {
+		return nil, fmt.Errorf("%w: unsupported language %s", ErrInvalidPolicy, p.Language)
+	}
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+	programs := make([]cel.Program, len(p.Conditions))
+	for i, c := range p.Conditions {
+		switch c.Effect {
+		case rule.PolicyAutoApprove, rule.PolicyRequireApproval, rule.PolicyDeny:
+		default:
+			return nil, fmt.Errorf("%w: condition %d has an unsupported effect %s", ErrInvalidPolicy, i, c.Effect)
+		}
+		ast, iss := env.Compile(c.Expression)
+		if iss.Err() != nil {
+			return nil, fmt.Errorf("%w: condition %d: %s", ErrInvalidPolicy, i, iss.Err())
+		}
+		if !cel.BoolType.IsAssignableType(ast.OutputType()) {
+			return nil, fmt.Errorf("%w: condition %d must return a bool, not %s", ErrInvalidPolicy, i, ast.OutputType())
+		}
+		programs[i], err = env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("%w: condition %d: %s", ErrInvalidPolicy, i, err)
+		}
+	}
+	return programs, nil
+}
+
+// Cache holds compiled policies, so that they aren't compiled again for every request.
+// The zero value is ready to use.
+type Cache struct {
+	programs sync.Map
+}
+
+// Evaluate evaluates the policy like the Evaluate function, reusing the programs compiled for the key.
+// Policies can't change without the key changing, so the key should identify the version of the rule the policy belongs to.
+func (c *Cache) Evaluate(key string, p rule.Policy, in Input) (Result, error) {
+	if cached, ok := c.programs.Load(key); ok {
+		return evaluate(p, cached.([]cel.Program), in)
+	}
+	programs, err := compile(p)
+	if err != nil {
+		return Result{}, err
+	}
+	c.programs.Store(key, programs)
+	return evaluate(p, programs, in)
+}
+
+// Evaluate returns the effect of the first condition of the policy which is true.
+//
+// If a condition fails to evaluate, for example because it uses an option which the request doesn't have,
+// the request requires approval so that a broken policy never approves access automatically.
+func Evaluate(p rule.Policy, in Input) (Result, error) {
+	programs, err := compile(p)
+	if err != nil {
+		return Result{}, err
+	}
+	return evaluate(p, programs, in)
+}
+
+func evaluate(p rule.Policy, programs []cel.Program, in Input) (Result, error) {
+	vars := in.vars()
+	for i, prg := range programs {
+		c := p.Conditions[i]
+		res := Result{Matched: true, Effect: c.Effect, Condition: i, Description: c.Description, Expression: c.Expression}
+
+		out, _, err := prg.Eval(vars)
+		if err != nil {
+			res.Effect = rule.PolicyRequireApproval
+			res.Error = err.Error()
+			return res, nil
+		}
+		matched, ok := out.Value().(bool)
+		if !ok {
+			res.Effect = rule.PolicyRequireApproval
+			res.Error = fmt.Sprintf("condition returned %v rather than a bool", out.Value())
+			return res, nil
+		}
+		if matched {
+			return res, nil
+		}
+	}
+	return Result{}, nil
+}
+
+func (in Input) vars() map[string]interface{} {
+	groups := in.Requester.Groups
+	if groups == nil {
+		groups = []string{}
+	}
+	with := in.Request.With
+	if with == nil {
+		with = map[string]string{}
+	}
+	timing := map[string]interface{}{
+		"durationSeconds": int64(in.Request.Duration.Seconds()),
+	}
+	if in.Request.StartTime != nil {
+		timing["startTime"] = *in.Request.StartTime
+	}
+	return map[string]interface{}{
+		"requester": map[string]interface{}{
+			"id":     in.Requester.ID,
+			"email":  in.Requester.Email,
+			"groups": groups,
+		},
+		"request": map[string]interface{}{
+			"reason": in.Request.Reason,
+			"with":   with,
+			"timing": timing,
+		},
+		"now": in.Now,
+	}
+}
@@ -0,0 +1,171 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	p := rule.Policy{
+		Language: "cel",
+		Conditions: []rule.PolicyCondition{
+			{Expression: `request.with.accountId == "prod"`, Effect: rule.PolicyRequireApproval},
+			{Description: "SREs don't need approval for non-prod", Expression: `"sre" in requester.groups`, Effect: rule.PolicyAutoApprove},
+			{Expression: `request.timing.durationSeconds > 3600`, Effect: rule.PolicyDeny},
+		},
+	}
+	start := time.Date(2022, time.August, 1, 9, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		name string
+		in   Input
+		want Result
+	}{
+		{
+			name: "first matching condition decides",
+			in:   Input{Requester: Requester{Groups: []string{"sre"}}, Request: Request{With: map[string]string{"accountId": "prod"}}},
+			want: Result{Matched: true, Effect: rule.PolicyRequireApproval, Condition: 0, Expression: `request.with.accountId == "prod"`},
+		},
+		{
+			name: "auto approve",
+			in:   Input{Requester: Requester{Groups: []string{"sre"}}, Request: Request{With: map[string]string{"accountId": "dev"}}},
+			want: Result{Matched: true, Effect: rule.PolicyAutoApprove, Condition: 1, Description: "SREs don't need approval for non-prod", Expression: `"sre" in requester.groups`},
+		},
+		{
+			name: "deny",
+			in:   Input{Request: Request{With: map[string]string{"accountId": "dev"}, Duration: 2 * time.Hour}},
+			want: Result{Matched: true, Effect: rule.PolicyDeny, Condition: 2, Expression: `request.timing.durationSeconds > 3600`},
+		},
+		{
+			name: "no conditions match",
+			in:   Input{Request: Request{With: map[string]string{"accountId": "dev"}, Duration: time.Hour, StartTime: &start}},
+			want: Result{},
+		},
+		{
+			name: "conditions which fail require approval",
+			in:   Input{Requester: Requester{Groups: []string{"sre"}}},
+			want: Result{Matched: true, Effect: rule.PolicyRequireApproval, Condition: 0, Expression: `request.with.accountId == "prod"`, Error: "no such key: accountId"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Evaluate(p, tc.in)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCacheEvaluate(t *testing.T) {
+	var c Cache
+	deny := rule.Policy{Language: "cel", Conditions: []rule.PolicyCondition{{Expression: `true`, Effect: rule.PolicyDeny}}}
+	got, err := c.Evaluate("rule/1", deny, Input{})
+	assert.NoError(t, err)
+	assert.Equal(t, rule.PolicyDeny, got.Effect)
+
+	// the programs compiled for the key are reused.
+	got, err = c.Evaluate("rule/1", deny, Input{})
+	assert.NoError(t, err)
+	assert.Equal(t, rule.PolicyDeny, got.Effect)
+
+	approve := rule.Policy{Language: "cel", Conditions: []rule.PolicyCondition{{Expression: `true`, Effect: rule.PolicyAutoApprove}}}
+	got, err = c.Evaluate("rule/2", approve, Input{})
+	assert.NoError(t, err)
+	assert.Equal(t, rule.PolicyAutoApprove, got.Effect)
+
+	_, err = c.Evaluate("rule/3", rule.Policy{Language: "cel", Conditions: []rule.PolicyCondition{{Expression: `1 +`, Effect: rule.PolicyDeny}}}, Input{})
+	assert.True(t, errors.Is(err, ErrInvalidPolicy))
+}
+
+func TestValidate(t *testing.T) {
+	testcases := []struct {
+		name    string
+		give    rule.Policy
+		wantErr string
+	}{
+		{
+			name: "no policy",
+		},
+		{
+			name: "ok",
+			give: rule.Policy{Language: "cel", Conditions: []rule.PolicyCondition{{Expression: `now.getHours() < 17 && request.reason != ""`, Effect: rule.PolicyAutoApprove}}},
+		},
+		{
+			name:    "unsupported language",
+			give:    rule.Policy{Language: "rego", Conditions: []rule.PolicyCondition{{Expression: `true`, Effect: rule.PolicyAutoApprove}}},
+			wantErr: "invalid policy: unsupported language rego",
+		},
+		{
+			name:    "unsupported effect",
+			give:    rule.Policy{Language: "cel", Conditions: []rule.PolicyCondition{{Expression: `true`, Effect: "MAYBE"}}},
+			wantErr: "invalid policy: condition 0 has an unsupported effect MAYBE",
+		},
+		{
+			name:    "undeclared variable",
+			give:    rule.Policy{Language: "cel", Conditions: []rule.PolicyCondition{{Expression: `user.id == "a"`, Effect: rule.PolicyDeny}}},
+			wantErr: "invalid policy: condition 0: ERROR: <input>:1:1: undeclared reference to 'user' (in container '')\n | user.id == \"a\"\n | ^",
+		},
+		{
+			name:    "doesn't return a bool",
+			give:    rule.Policy{Language: "cel", Conditions: []rule.PolicyCondition{{Expression: `1 + 1`, Effect: rule.PolicyDeny}}},
+			wantErr: "invalid policy: condition 0 must return a bool, not int",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.give)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, ErrInvalidPolicy))
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestValidateApprovers(t *testing.T) {
+	requireApproval := rule.Policy{Language: "cel", Conditions: []rule.PolicyCondition{{Expression: `true`, Effect: rule.PolicyRequireApproval}}}
+	testcases := []struct {
+		name     string
+		policy   rule.Policy
+		approval rule.Approval
+		wantErr  string
+	}{
+		{
+			name: "no policy",
+		},
+		{
+			name:     "require approval with approvers",
+			policy:   requireApproval,
+			approval: rule.Approval{Groups: []string{"grp_approvers"}},
+		},
+		{
+			name:    "require approval without approvers",
+			policy:  requireApproval,
+			wantErr: "invalid policy: condition 0 requires approval but the rule has no approval users or groups",
+		},
+		{
+			name:   "auto approve without approvers",
+			policy: rule.Policy{Language: "cel", Conditions: []rule.PolicyCondition{{Expression: `true`, Effect: rule.PolicyAutoApprove}}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateApprovers(tc.policy, tc.approval)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, ErrInvalidPolicy))
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
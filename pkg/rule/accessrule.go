@@ -1,10 +1,12 @@
 package rule
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/common-fate/ddb"
 	"github.com/common-fate/granted-approvals/pkg/cache"
+	"github.com/common-fate/granted-approvals/pkg/identity"
 	"github.com/common-fate/granted-approvals/pkg/storage/keys"
 	"github.com/common-fate/granted-approvals/pkg/types"
 )
@@ -32,6 +34,19 @@ type AccessRule struct {
 	Name            string                `json:"name" dynamodbav:"name"`
 	Target          Target                `json:"target" dynamodbav:"target"`
 	TimeConstraints types.TimeConstraints `json:"timeConstraints" dynamodbav:"timeConstraints"`
+	// Notifications configures where notifications about requests for this rule are sent,
+	// in addition to the requestor and reviewers.
+	Notifications Notifications `json:"notifications" dynamodbav:"notifications"`
+	// Owners can manage the rule and view requests made for it without being admins.
+	Owners Owners `json:"owners" dynamodbav:"owners"`
+	// Limits cap the requests which each user can make for the rule.
+	Limits Limits `json:"limits" dynamodbav:"limits"`
+	// Policy decides whether requests are approved automatically, require approval or are denied.
+	Policy Policy `json:"policy" dynamodbav:"policy"`
+	// RequestFields are filled in by requesters when making a request, such as a ticket ID.
+	RequestFields []RequestField `json:"requestFields,omitempty" dynamodbav:"requestFields,omitempty"`
+	// Ticket requires requests to reference a ticket in a ticket system, such as a Jira issue.
+	Ticket TicketRequirement `json:"ticket" dynamodbav:"ticket"`
 }
 
 func (a AccessRule) ToAPIDetail() types.AccessRuleDetail {
@@ -53,23 +68,31 @@ func (a AccessRule) ToAPIDetail() types.AccessRuleDetail {
 	} else {
 		approval.Users = make([]string, 0)
 	}
+	if len(a.Approval.EscalationGroups) > 0 {
+		approval.EscalationGroups = &a.Approval.EscalationGroups
+	}
 
 	return types.AccessRuleDetail{
 		ID:          a.ID,
 		Description: a.Description,
 		Name:        a.Name,
 		Metadata: types.AccessRuleMetadata{
-			CreatedAt:     a.Metadata.CreatedAt,
-			UpdatedAt:     a.Metadata.UpdatedAt,
-			UpdateMessage: a.Metadata.UpdateMessage,
-			CreatedBy:     a.Metadata.CreatedBy,
-			UpdatedBy:     a.Metadata.UpdatedBy,
-		},
-		Groups: a.Groups,
-		TimeConstraints: types.TimeConstraints{
-			MaxDurationSeconds: a.TimeConstraints.MaxDurationSeconds,
+			CreatedAt:      a.Metadata.CreatedAt,
+			UpdatedAt:      a.Metadata.UpdatedAt,
+			UpdateMessage:  a.Metadata.UpdateMessage,
+			UpdateMetadata: a.Metadata.UpdateMetadata,
+			CreatedBy:      a.Metadata.CreatedBy,
+			UpdatedBy:      a.Metadata.UpdatedBy,
 		},
-		Approval: approval,
+		Groups:          a.Groups,
+		TimeConstraints: a.TimeConstraints,
+		Approval:        approval,
+		Notifications:   a.Notifications.ToAPI(),
+		Owners:          a.Owners.ToAPI(),
+		Limits:          a.Limits.ToAPI(),
+		Policy:          a.Policy.ToAPI(),
+		RequestFields:   requestFieldsToAPI(a.RequestFields),
+		Ticket:          a.Ticket.ToAPI(),
 
 		Target: a.Target.ToAPI(),
 
@@ -81,29 +104,27 @@ func (a AccessRule) ToAPIDetail() types.AccessRuleDetail {
 func (a AccessRule) ToAPI() types.AccessRule {
 
 	return types.AccessRule{
-		ID:          a.ID,
-		Version:     a.Version,
-		Description: a.Description,
-		Name:        a.Name,
-		TimeConstraints: types.TimeConstraints{
-			MaxDurationSeconds: a.TimeConstraints.MaxDurationSeconds,
-		},
-		Target:    a.Target.ToAPI(),
-		IsCurrent: a.Current,
+		ID:              a.ID,
+		Version:         a.Version,
+		Description:     a.Description,
+		Name:            a.Name,
+		TimeConstraints: a.TimeConstraints,
+		RequestFields:   requestFieldsToAPI(a.RequestFields),
+		Target:          a.Target.ToAPI(),
+		IsCurrent:       a.Current,
 	}
 }
 
 func (a AccessRule) ToAPIWithSelectables(argOptions []cache.ProviderOption) types.AccessRuleWithSelectables {
 	return types.AccessRuleWithSelectables{
-		ID:          a.ID,
-		Version:     a.Version,
-		Description: a.Description,
-		Name:        a.Name,
-		TimeConstraints: types.TimeConstraints{
-			MaxDurationSeconds: a.TimeConstraints.MaxDurationSeconds,
-		},
-		Target:    a.Target.ToAPIDetail(argOptions),
-		IsCurrent: a.Current,
+		ID:              a.ID,
+		Version:         a.Version,
+		Description:     a.Description,
+		Name:            a.Name,
+		TimeConstraints: a.TimeConstraints,
+		RequestFields:   requestFieldsToAPI(a.RequestFields),
+		Target:          a.Target.ToAPIDetail(argOptions),
+		IsCurrent:       a.Current,
 	}
 }
 
@@ -126,12 +147,279 @@ type Approval struct {
 	//List of users ids represents the individual users who may approve requests for this rule.
 	// This does not represent members of the approval groups
 	Users []string `json:"users" dynamodbav:"users"`
+	// List of group ids which pending requests are escalated to if they haven't been reviewed in time.
+	EscalationGroups []string `json:"escalationGroups,omitempty" dynamodbav:"escalationGroups,omitempty"`
+}
+
+// ApprovalFromAPI converts the api representation of approver config to the internal type
+func ApprovalFromAPI(in types.ApproverConfig) Approval {
+	a := Approval{
+		Groups: in.Groups,
+		Users:  in.Users,
+	}
+	if in.EscalationGroups != nil {
+		a.EscalationGroups = *in.EscalationGroups
+	}
+	return a
 }
 
 func (a *Approval) IsRequired() bool {
 	return len(a.Users) > 0 || len(a.Groups) > 0
 }
 
+// Notifications config for access rules
+type Notifications struct {
+	// SlackChannels are the Slack channels which receive a shared feed of the requests for this rule.
+	// A message is posted when a request is created and is updated in place as the request is reviewed.
+	SlackChannels []string `json:"slackChannels,omitempty" dynamodbav:"slackChannels,omitempty"`
+}
+
+// NotificationsFromAPI converts the optional api representation of rule notifications to the internal type
+func NotificationsFromAPI(n *types.AccessRuleNotifications) Notifications {
+	if n == nil {
+		return Notifications{}
+	}
+	return Notifications{SlackChannels: n.SlackChannels}
+}
+
+// ToAPI returns nil if no notification channels are configured for the rule.
+func (n Notifications) ToAPI() *types.AccessRuleNotifications {
+	if len(n.SlackChannels) == 0 {
+		return nil
+	}
+	return &types.AccessRuleNotifications{SlackChannels: n.SlackChannels}
+}
+
+// Owners of an access rule
+type Owners struct {
+	// List of user ids who own the rule
+	Users []string `json:"users,omitempty" dynamodbav:"users,omitempty"`
+	// List of group ids whose members own the rule
+	Groups []string `json:"groups,omitempty" dynamodbav:"groups,omitempty"`
+}
+
+// OwnersFromAPI converts the optional api representation of rule owners to the internal type
+func OwnersFromAPI(o *types.AccessRuleOwners) Owners {
+	if o == nil {
+		return Owners{}
+	}
+	return Owners{Users: o.Users, Groups: o.Groups}
+}
+
+// ToAPI returns nil if the rule has no owners.
+func (o Owners) ToAPI() *types.AccessRuleOwners {
+	if len(o.Users) == 0 && len(o.Groups) == 0 {
+		return nil
+	}
+	res := types.AccessRuleOwners{Users: o.Users, Groups: o.Groups}
+	if res.Users == nil {
+		res.Users = []string{}
+	}
+	if res.Groups == nil {
+		res.Groups = []string{}
+	}
+	return &res
+}
+
+// Limits on the requests which each user can make for an access rule.
+// Limits which are zero aren't enforced.
+type Limits struct {
+	// MaxActiveGrants is the maximum number of grants a user can have for the rule which haven't ended, including pending requests.
+	MaxActiveGrants int `json:"maxActiveGrants,omitempty" dynamodbav:"maxActiveGrants,omitempty"`
+	// MaxRequestsPerDay is the maximum number of requests a user can make for the rule in 24 hours.
+	MaxRequestsPerDay int `json:"maxRequestsPerDay,omitempty" dynamodbav:"maxRequestsPerDay,omitempty"`
+	// CooldownSeconds is the minimum time between a user's grant for the rule ending and their next grant starting.
+	CooldownSeconds int `json:"cooldownSeconds,omitempty" dynamodbav:"cooldownSeconds,omitempty"`
+}
+
+// LimitsFromAPI converts the optional api representation of rule limits to the internal type
+func LimitsFromAPI(l *types.AccessRuleLimits) Limits {
+	var res Limits
+	if l == nil {
+		return res
+	}
+	if l.MaxActiveGrants != nil {
+		res.MaxActiveGrants = *l.MaxActiveGrants
+	}
+	if l.MaxRequestsPerDay != nil {
+		res.MaxRequestsPerDay = *l.MaxRequestsPerDay
+	}
+	if l.CooldownSeconds != nil {
+		res.CooldownSeconds = *l.CooldownSeconds
+	}
+	return res
+}
+
+// IsSet returns true if any of the limits are enforced.
+func (l Limits) IsSet() bool {
+	return l.MaxActiveGrants > 0 || l.MaxRequestsPerDay > 0 || l.CooldownSeconds > 0
+}
+
+// ToAPI returns nil if the rule has no limits.
+func (l Limits) ToAPI() *types.AccessRuleLimits {
+	if !l.IsSet() {
+		return nil
+	}
+	var res types.AccessRuleLimits
+	if l.MaxActiveGrants > 0 {
+		res.MaxActiveGrants = &l.MaxActiveGrants
+	}
+	if l.MaxRequestsPerDay > 0 {
+		res.MaxRequestsPerDay = &l.MaxRequestsPerDay
+	}
+	if l.CooldownSeconds > 0 {
+		res.CooldownSeconds = &l.CooldownSeconds
+	}
+	return &res
+}
+
+// Policy for the requests made for an access rule.
+// The conditions are evaluated in order, and the effect of the first condition which is true is applied.
+type Policy struct {
+	Language   string            `json:"language,omitempty" dynamodbav:"language,omitempty"`
+	Conditions []PolicyCondition `json:"conditions,omitempty" dynamodbav:"conditions,omitempty"`
+}
+
+type PolicyEffect string
+
+const (
+	PolicyAutoApprove     PolicyEffect = "AUTO_APPROVE"
+	PolicyRequireApproval PolicyEffect = "REQUIRE_APPROVAL"
+	PolicyDeny            PolicyEffect = "DENY"
+)
+
+type PolicyCondition struct {
+	Description string       `json:"description,omitempty" dynamodbav:"description,omitempty"`
+	Expression  string       `json:"expression" dynamodbav:"expression"`
+	Effect      PolicyEffect `json:"effect" dynamodbav:"effect"`
+}
+
+// PolicyFromAPI converts the optional api representation of a rule policy to the internal type.
+// A policy without conditions is stored as an empty policy.
+func PolicyFromAPI(p *types.AccessRulePolicy) Policy {
+	if p == nil || len(p.Conditions) == 0 {
+		return Policy{}
+	}
+	res := Policy{Language: string(p.Language)}
+	for _, c := range p.Conditions {
+		cond := PolicyCondition{Expression: c.Expression, Effect: PolicyEffect(c.Effect)}
+		if c.Description != nil {
+			cond.Description = *c.Description
+		}
+		res.Conditions = append(res.Conditions, cond)
+	}
+	return res
+}
+
+// IsSet returns true if the policy has any conditions.
+func (p Policy) IsSet() bool {
+	return len(p.Conditions) > 0
+}
+
+// ToAPI returns nil if the rule has no policy.
+func (p Policy) ToAPI() *types.AccessRulePolicy {
+	if !p.IsSet() {
+		return nil
+	}
+	res := types.AccessRulePolicy{
+		Language:   types.AccessRulePolicyLanguage(p.Language),
+		Conditions: make([]types.AccessRulePolicyCondition, len(p.Conditions)),
+	}
+	for i, c := range p.Conditions {
+		cond := types.AccessRulePolicyCondition{Expression: c.Expression, Effect: types.AccessRulePolicyConditionEffect(c.Effect)}
+		if c.Description != "" {
+			desc := c.Description
+			cond.Description = &desc
+		}
+		res.Conditions[i] = cond
+	}
+	return &res
+}
+
+// TicketRequirement requires requests to reference a ticket in a ticket system.
+// The ticket ID is entered in one of the request fields of the rule.
+type TicketRequirement struct {
+	System  string `json:"system,omitempty" dynamodbav:"system,omitempty"`
+	FieldID string `json:"fieldId,omitempty" dynamodbav:"fieldId,omitempty"`
+	// AllowedStates are the states which the ticket must be in. If it is empty, tickets in any state are allowed.
+	AllowedStates []string `json:"allowedStates,omitempty" dynamodbav:"allowedStates,omitempty"`
+	// AutoApproveStates are the states in which requests are approved automatically, such as an approved change.
+	// Requests are only approved automatically if the requester is assigned to the ticket, or reported or requested it.
+	AutoApproveStates []string `json:"autoApproveStates,omitempty" dynamodbav:"autoApproveStates,omitempty"`
+}
+
+// TicketRequirementFromAPI converts the optional api representation of a ticket requirement to the internal type.
+// A requirement without a field is stored as an empty requirement.
+func TicketRequirementFromAPI(t *types.AccessRuleTicket) TicketRequirement {
+	if t == nil || t.FieldId == "" {
+		return TicketRequirement{}
+	}
+	res := TicketRequirement{System: string(t.System), FieldID: t.FieldId}
+	if len(t.AllowedStates) > 0 {
+		res.AllowedStates = t.AllowedStates
+	}
+	if t.AutoApproveStates != nil && len(*t.AutoApproveStates) > 0 {
+		res.AutoApproveStates = *t.AutoApproveStates
+	}
+	return res
+}
+
+// IsSet returns true if requests for the rule must reference a ticket.
+func (t TicketRequirement) IsSet() bool {
+	return t.FieldID != ""
+}
+
+// ToAPI returns nil if the rule doesn't require a ticket.
+func (t TicketRequirement) ToAPI() *types.AccessRuleTicket {
+	if !t.IsSet() {
+		return nil
+	}
+	res := types.AccessRuleTicket{
+		System:        types.AccessRuleTicketSystem(t.System),
+		FieldId:       t.FieldID,
+		AllowedStates: t.AllowedStates,
+	}
+	if res.AllowedStates == nil {
+		res.AllowedStates = []string{}
+	}
+	if len(t.AutoApproveStates) > 0 {
+		res.AutoApproveStates = &t.AutoApproveStates
+	}
+	return &res
+}
+
+// ValidateTicketRequirement returns an error if the ticket ID isn't entered in a required TEXT request field of the rule.
+func ValidateTicketRequirement(t TicketRequirement, fields []RequestField) error {
+	if !t.IsSet() {
+		return nil
+	}
+	for _, f := range fields {
+		if f.ID != t.FieldID {
+			continue
+		}
+		if f.Type != RequestFieldText || !f.Required {
+			return fmt.Errorf("request field %s must be a required TEXT field to hold the ticket ID", f.ID)
+		}
+		return nil
+	}
+	return fmt.Errorf("the rule doesn't have a request field %s for the ticket ID", t.FieldID)
+}
+
+// IsOwner returns true if the user owns the rule, either directly or through one of their groups.
+func (a AccessRule) IsOwner(user *identity.User) bool {
+	for _, u := range a.Owners.Users {
+		if u == user.ID {
+			return true
+		}
+	}
+	for _, g := range a.Owners.Groups {
+		if user.BelongsToGroup(g) {
+			return true
+		}
+	}
+	return false
+}
+
 // Provider defines model for Provider.
 // I expect this will be different to what gets returned in the api response
 type Target struct {
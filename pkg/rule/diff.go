@@ -0,0 +1,220 @@
+package rule
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+// FieldChange is a change to a field between two versions of an access rule.
+// Changes to single values set From and To, and changes to lists set Added and Removed.
+type FieldChange struct {
+	// Field is the path of the field, such as approval.groups or target.with.accountId.
+	Field   string
+	From    string
+	To      string
+	Added   []string
+	Removed []string
+}
+
+func (c FieldChange) ToAPI() types.AccessRuleFieldChange {
+	res := types.AccessRuleFieldChange{Field: c.Field}
+	if c.Added != nil || c.Removed != nil {
+		added := c.Added
+		if added == nil {
+			added = []string{}
+		}
+		removed := c.Removed
+		if removed == nil {
+			removed = []string{}
+		}
+		res.Added = &added
+		res.Removed = &removed
+		return res
+	}
+	res.From = &c.From
+	res.To = &c.To
+	return res
+}
+
+// Diff returns the changes between two versions of an access rule.
+// The changes are returned in a stable order, and changes to the order of lists are ignored.
+func Diff(from, to AccessRule) []FieldChange {
+	var d differ
+	d.value("name", from.Name, to.Name)
+	d.value("description", from.Description, to.Description)
+	d.value("status", string(from.Status), string(to.Status))
+	d.list("groups", from.Groups, to.Groups)
+	d.list("approval.users", from.Approval.Users, to.Approval.Users)
+	d.list("approval.groups", from.Approval.Groups, to.Approval.Groups)
+	d.list("approval.escalationGroups", from.Approval.EscalationGroups, to.Approval.EscalationGroups)
+	d.value("target.providerId", from.Target.ProviderID, to.Target.ProviderID)
+	for _, k := range stringKeys(from.Target.With, to.Target.With) {
+		d.value("target.with."+k, from.Target.With[k], to.Target.With[k])
+	}
+	for _, k := range listKeys(from.Target.WithSelectable, to.Target.WithSelectable) {
+		d.list("target.withSelectable."+k, from.Target.WithSelectable[k], to.Target.WithSelectable[k])
+	}
+	d.value("timeConstraints.maxDurationSeconds", strconv.Itoa(from.TimeConstraints.MaxDurationSeconds), strconv.Itoa(to.TimeConstraints.MaxDurationSeconds))
+	d.value("timeConstraints.minNoticeSeconds", optionalInt(from.TimeConstraints.MinNoticeSeconds), optionalInt(to.TimeConstraints.MinNoticeSeconds))
+	d.value("timeConstraints.maxAdvanceSeconds", optionalInt(from.TimeConstraints.MaxAdvanceSeconds), optionalInt(to.TimeConstraints.MaxAdvanceSeconds))
+	fromWindow, toWindow := accessWindow(from.TimeConstraints), accessWindow(to.TimeConstraints)
+	d.value("timeConstraints.accessWindow.timezone", fromWindow.Timezone, toWindow.Timezone)
+	d.list("timeConstraints.accessWindow.days", windowDays(fromWindow), windowDays(toWindow))
+	d.value("timeConstraints.accessWindow.startTime", optionalString(fromWindow.StartTime), optionalString(toWindow.StartTime))
+	d.value("timeConstraints.accessWindow.endTime", optionalString(fromWindow.EndTime), optionalString(toWindow.EndTime))
+	d.list("notifications.slackChannels", from.Notifications.SlackChannels, to.Notifications.SlackChannels)
+	d.list("owners.users", from.Owners.Users, to.Owners.Users)
+	d.list("owners.groups", from.Owners.Groups, to.Owners.Groups)
+	d.value("limits.maxActiveGrants", limit(from.Limits.MaxActiveGrants), limit(to.Limits.MaxActiveGrants))
+	d.value("limits.maxRequestsPerDay", limit(from.Limits.MaxRequestsPerDay), limit(to.Limits.MaxRequestsPerDay))
+	d.value("limits.cooldownSeconds", limit(from.Limits.CooldownSeconds), limit(to.Limits.CooldownSeconds))
+	d.value("policy.language", from.Policy.Language, to.Policy.Language)
+	for i := 0; i < len(from.Policy.Conditions) || i < len(to.Policy.Conditions); i++ {
+		d.value(fmt.Sprintf("policy.conditions.%d", i), policyCondition(from.Policy, i), policyCondition(to.Policy, i))
+	}
+	d.value("ticket.system", from.Ticket.System, to.Ticket.System)
+	d.value("ticket.fieldId", from.Ticket.FieldID, to.Ticket.FieldID)
+	d.list("ticket.allowedStates", from.Ticket.AllowedStates, to.Ticket.AllowedStates)
+	d.list("ticket.autoApproveStates", from.Ticket.AutoApproveStates, to.Ticket.AutoApproveStates)
+	fromFields, toFields := requestFields(from.RequestFields), requestFields(to.RequestFields)
+	for _, id := range stringKeys(fromFields, toFields) {
+		d.value("requestFields."+id, fromFields[id], toFields[id])
+	}
+	return d.changes
+}
+
+type differ struct {
+	changes []FieldChange
+}
+
+func (d *differ) value(field, from, to string) {
+	if from != to {
+		d.changes = append(d.changes, FieldChange{Field: field, From: from, To: to})
+	}
+}
+
+func (d *differ) list(field string, from, to []string) {
+	added := missing(to, from)
+	removed := missing(from, to)
+	if len(added) > 0 || len(removed) > 0 {
+		d.changes = append(d.changes, FieldChange{Field: field, Added: added, Removed: removed})
+	}
+}
+
+// missing returns the values in a which aren't in b, in the order they appear in a.
+func missing(a, b []string) []string {
+	inB := make(map[string]bool)
+	for _, v := range b {
+		inB[v] = true
+	}
+	var res []string
+	for _, v := range a {
+		if !inB[v] {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// stringKeys returns the sorted keys which are in any of the maps.
+func stringKeys(maps ...map[string]string) []string {
+	keys := make(map[string]bool)
+	for _, m := range maps {
+		for k := range m {
+			keys[k] = true
+		}
+	}
+	return sortedKeys(keys)
+}
+
+// listKeys returns the sorted keys which are in any of the maps.
+func listKeys(maps ...map[string][]string) []string {
+	keys := make(map[string]bool)
+	for _, m := range maps {
+		for k := range m {
+			keys[k] = true
+		}
+	}
+	return sortedKeys(keys)
+}
+
+func sortedKeys(keys map[string]bool) []string {
+	res := make([]string, 0, len(keys))
+	for k := range keys {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func optionalInt(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
+
+// policyCondition returns the effect and expression of the condition, or an empty string if the policy doesn't have the condition.
+func policyCondition(p Policy, i int) string {
+	if i >= len(p.Conditions) {
+		return ""
+	}
+	return fmt.Sprintf("%s: %s", p.Conditions[i].Effect, p.Conditions[i].Expression)
+}
+
+// requestFields returns a summary of each request field, keyed by the field ID.
+func requestFields(fields []RequestField) map[string]string {
+	res := make(map[string]string)
+	for _, f := range fields {
+		summary := fmt.Sprintf("%s %s", f.Type, f.Label)
+		if f.Required {
+			summary += " (required)"
+		}
+		if f.Pattern != "" {
+			summary += fmt.Sprintf(" matching %s", f.Pattern)
+		}
+		if len(f.Options) > 0 {
+			summary += fmt.Sprintf(" with options %s", strings.Join(f.Options, ", "))
+		}
+		res[f.ID] = summary
+	}
+	return res
+}
+
+// limit returns an empty string for limits which aren't enforced.
+func limit(i int) string {
+	if i == 0 {
+		return ""
+	}
+	return strconv.Itoa(i)
+}
+
+func optionalString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// accessWindow returns the access window of the time constraints, or an empty window if there isn't one.
+func accessWindow(tc types.TimeConstraints) types.AccessWindow {
+	if tc.AccessWindow == nil {
+		return types.AccessWindow{}
+	}
+	return *tc.AccessWindow
+}
+
+func windowDays(w types.AccessWindow) []string {
+	if w.Days == nil {
+		return nil
+	}
+	days := make([]string, len(*w.Days))
+	for i, d := range *w.Days {
+		days[i] = string(d)
+	}
+	return days
+}
@@ -0,0 +1,84 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	from := AccessRule{
+		Name:   "Production",
+		Status: ACTIVE,
+		Groups: []string{"engineering", "ops"},
+		Approval: Approval{
+			Users:  []string{"usr_1"},
+			Groups: []string{"leads"},
+		},
+		Target: Target{
+			ProviderID:     "aws",
+			With:           map[string]string{"accountId": "123"},
+			WithSelectable: map[string][]string{"permissionSetArn": {"a", "b"}},
+		},
+		TimeConstraints: types.TimeConstraints{MaxDurationSeconds: 3600},
+	}
+
+	type testcase struct {
+		name string
+		to   func(r AccessRule) AccessRule
+		want []FieldChange
+	}
+
+	testcases := []testcase{
+		{
+			name: "no changes",
+			to:   func(r AccessRule) AccessRule { return r },
+		},
+		{
+			name: "reordering lists isn't a change",
+			to: func(r AccessRule) AccessRule {
+				r.Groups = []string{"ops", "engineering"}
+				return r
+			},
+		},
+		{
+			name: "approvers, groups, target and time constraints",
+			to: func(r AccessRule) AccessRule {
+				r.Groups = []string{"ops", "security"}
+				r.Approval = Approval{Groups: []string{"leads"}, EscalationGroups: []string{"admins"}}
+				r.Target = Target{
+					ProviderID:     "aws",
+					With:           map[string]string{"accountId": "456", "region": "us-east-1"},
+					WithSelectable: map[string][]string{"permissionSetArn": {"a", "c"}},
+				}
+				r.TimeConstraints.MaxDurationSeconds = 600
+				return r
+			},
+			want: []FieldChange{
+				{Field: "groups", Added: []string{"security"}, Removed: []string{"engineering"}},
+				{Field: "approval.users", Removed: []string{"usr_1"}},
+				{Field: "approval.escalationGroups", Added: []string{"admins"}},
+				{Field: "target.with.accountId", From: "123", To: "456"},
+				{Field: "target.with.region", From: "", To: "us-east-1"},
+				{Field: "target.withSelectable.permissionSetArn", Added: []string{"c"}, Removed: []string{"b"}},
+				{Field: "timeConstraints.maxDurationSeconds", From: "3600", To: "600"},
+			},
+		},
+		{
+			name: "archived",
+			to: func(r AccessRule) AccessRule {
+				r.Status = ARCHIVED
+				return r
+			},
+			want: []FieldChange{{Field: "status", From: "ACTIVE", To: "ARCHIVED"}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Diff(from, tc.to(from))
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package rule
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+type RequestFieldType string
+
+const (
+	RequestFieldText   RequestFieldType = "TEXT"
+	RequestFieldURL    RequestFieldType = "URL"
+	RequestFieldSelect RequestFieldType = "SELECT"
+)
+
+// RequestField is a field which requesters fill in when making a request for an access rule.
+type RequestField struct {
+	ID          string           `json:"id" dynamodbav:"id"`
+	Label       string           `json:"label" dynamodbav:"label"`
+	Description string           `json:"description,omitempty" dynamodbav:"description,omitempty"`
+	Type        RequestFieldType `json:"type" dynamodbav:"type"`
+	Required    bool             `json:"required" dynamodbav:"required"`
+	// Pattern is a regular expression which the whole value of a TEXT field must match.
+	Pattern string `json:"pattern,omitempty" dynamodbav:"pattern,omitempty"`
+	// Options are the values which can be selected for a SELECT field.
+	Options []string `json:"options,omitempty" dynamodbav:"options,omitempty"`
+}
+
+// RequestFieldError is returned if a value for a request field is invalid.
+// Field is the request field which the error relates to, such as fields.ticketId.
+type RequestFieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *RequestFieldError) Error() string {
+	return e.Message
+}
+
+var requestFieldID = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]{0,63}$`)
+
+// RequestFieldsFromAPI converts the optional api representation of request fields to the internal type.
+func RequestFieldsFromAPI(in *[]types.AccessRuleRequestField) []RequestField {
+	if in == nil || len(*in) == 0 {
+		return nil
+	}
+	res := make([]RequestField, len(*in))
+	for i, f := range *in {
+		field := RequestField{ID: f.Id, Label: f.Label, Type: RequestFieldType(f.Type), Required: f.Required}
+		if f.Description != nil {
+			field.Description = *f.Description
+		}
+		if f.Pattern != nil {
+			field.Pattern = *f.Pattern
+		}
+		if f.Options != nil && len(*f.Options) > 0 {
+			field.Options = *f.Options
+		}
+		res[i] = field
+	}
+	return res
+}
+
+// requestFieldsToAPI returns nil if the rule has no request fields.
+func requestFieldsToAPI(fields []RequestField) *[]types.AccessRuleRequestField {
+	if len(fields) == 0 {
+		return nil
+	}
+	res := make([]types.AccessRuleRequestField, len(fields))
+	for i := range fields {
+		f := fields[i]
+		field := types.AccessRuleRequestField{Id: f.ID, Label: f.Label, Type: types.AccessRuleRequestFieldType(f.Type), Required: f.Required}
+		if f.Description != "" {
+			field.Description = &f.Description
+		}
+		if f.Pattern != "" {
+			field.Pattern = &f.Pattern
+		}
+		if len(f.Options) > 0 {
+			field.Options = &f.Options
+		}
+		res[i] = field
+	}
+	return &res
+}
+
+// ValidateRequestFields returns an error if the request fields of a rule are invalid.
+func ValidateRequestFields(fields []RequestField) error {
+	ids := make(map[string]bool)
+	for _, f := range fields {
+		if !requestFieldID.MatchString(f.ID) {
+			return fmt.Errorf("request field id %s must start with a letter and contain at most 64 letters, numbers, underscores and hyphens", f.ID)
+		}
+		if ids[f.ID] {
+			return fmt.Errorf("request field %s is defined more than once", f.ID)
+		}
+		ids[f.ID] = true
+		if f.Label == "" {
+			return fmt.Errorf("request field %s must have a label", f.ID)
+		}
+		switch f.Type {
+		case RequestFieldText:
+			if _, err := compilePattern(f.Pattern); err != nil {
+				return fmt.Errorf("request field %s has an invalid pattern: %w", f.ID, err)
+			}
+		case RequestFieldURL:
+		case RequestFieldSelect:
+			if len(f.Options) == 0 {
+				return fmt.Errorf("request field %s must have options", f.ID)
+			}
+		default:
+			return fmt.Errorf("request field %s has an unsupported type %s", f.ID, f.Type)
+		}
+		if f.Type != RequestFieldText && f.Pattern != "" {
+			return fmt.Errorf("request field %s can only have a pattern if it is a TEXT field", f.ID)
+		}
+		if f.Type != RequestFieldSelect && len(f.Options) > 0 {
+			return fmt.Errorf("request field %s can only have options if it is a SELECT field", f.ID)
+		}
+	}
+	return nil
+}
+
+// CheckRequestFields returns a *RequestFieldError if the values don't meet the request fields of the rule.
+// Values for fields which the rule doesn't have are rejected, and empty values are treated as missing.
+func CheckRequestFields(fields []RequestField, values map[string]string) error {
+	defined := make(map[string]bool)
+	for _, f := range fields {
+		defined[f.ID] = true
+		name := "fields." + f.ID
+		v := values[f.ID]
+		if v == "" {
+			if f.Required {
+				return &RequestFieldError{Field: name, Message: fmt.Sprintf("%s is required", f.Label)}
+			}
+			continue
+		}
+		switch f.Type {
+		case RequestFieldText:
+			re, err := compilePattern(f.Pattern)
+			if err != nil {
+				return err
+			}
+			if !re.MatchString(v) {
+				return &RequestFieldError{Field: name, Message: fmt.Sprintf("%s must match the pattern %s", f.Label, f.Pattern)}
+			}
+		case RequestFieldURL:
+			u, err := url.Parse(v)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return &RequestFieldError{Field: name, Message: fmt.Sprintf("%s must be an http or https link", f.Label)}
+			}
+		case RequestFieldSelect:
+			if !containsString(f.Options, v) {
+				return &RequestFieldError{Field: name, Message: fmt.Sprintf("%s must be one of the options", f.Label)}
+			}
+		}
+	}
+	// report unexpected fields in a stable order
+	ids := make([]string, 0, len(values))
+	for id := range values {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if !defined[id] {
+			return &RequestFieldError{Field: "fields." + id, Message: fmt.Sprintf("the access rule doesn't have a %s field", id)}
+		}
+	}
+	return nil
+}
+
+// compilePattern anchors the pattern so that it must match the whole value.
+// An empty pattern matches any value.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		pattern = ".*"
+	}
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
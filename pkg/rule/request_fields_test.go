@@ -0,0 +1,104 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckRequestFields(t *testing.T) {
+	fields := []RequestField{
+		{ID: "ticketId", Label: "Ticket ID", Type: RequestFieldText, Required: true, Pattern: `OPS-[0-9]+`},
+		{ID: "incident", Label: "Incident", Type: RequestFieldURL},
+		{ID: "category", Label: "Change category", Type: RequestFieldSelect, Options: []string{"standard", "emergency"}},
+	}
+
+	testcases := []struct {
+		name   string
+		values map[string]string
+		want   error
+	}{
+		{
+			name:   "ok",
+			values: map[string]string{"ticketId": "OPS-123", "incident": "https://status.example.com/incidents/1", "category": "emergency"},
+		},
+		{
+			name:   "optional fields can be omitted",
+			values: map[string]string{"ticketId": "OPS-123"},
+		},
+		{
+			name: "missing required field",
+			want: &RequestFieldError{Field: "fields.ticketId", Message: "Ticket ID is required"},
+		},
+		{
+			name:   "pattern must match the whole value",
+			values: map[string]string{"ticketId": "see OPS-123"},
+			want:   &RequestFieldError{Field: "fields.ticketId", Message: "Ticket ID must match the pattern OPS-[0-9]+"},
+		},
+		{
+			name:   "invalid link",
+			values: map[string]string{"ticketId": "OPS-123", "incident": "javascript:alert(1)"},
+			want:   &RequestFieldError{Field: "fields.incident", Message: "Incident must be an http or https link"},
+		},
+		{
+			name:   "not an option",
+			values: map[string]string{"ticketId": "OPS-123", "category": "normal"},
+			want:   &RequestFieldError{Field: "fields.category", Message: "Change category must be one of the options"},
+		},
+		{
+			name:   "unknown field",
+			values: map[string]string{"ticketId": "OPS-123", "team": "platform"},
+			want:   &RequestFieldError{Field: "fields.team", Message: "the access rule doesn't have a team field"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, CheckRequestFields(fields, tc.values))
+		})
+	}
+}
+
+func TestValidateRequestFields(t *testing.T) {
+	testcases := []struct {
+		name    string
+		give    []RequestField
+		wantErr string
+	}{
+		{
+			name: "ok",
+			give: []RequestField{{ID: "ticketId", Label: "Ticket ID", Type: RequestFieldText, Pattern: `[A-Z]+-[0-9]+`}},
+		},
+		{
+			name:    "duplicate id",
+			give:    []RequestField{{ID: "a", Label: "A", Type: RequestFieldURL}, {ID: "a", Label: "A", Type: RequestFieldURL}},
+			wantErr: "request field a is defined more than once",
+		},
+		{
+			name:    "invalid pattern",
+			give:    []RequestField{{ID: "a", Label: "A", Type: RequestFieldText, Pattern: "("}},
+			wantErr: "request field a has an invalid pattern: error parsing regexp: missing closing ): `^(?:()$`",
+		},
+		{
+			name:    "select without options",
+			give:    []RequestField{{ID: "a", Label: "A", Type: RequestFieldSelect}},
+			wantErr: "request field a must have options",
+		},
+		{
+			name:    "unsupported type",
+			give:    []RequestField{{ID: "a", Label: "A", Type: "NUMBER"}},
+			wantErr: "request field a has an unsupported type NUMBER",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRequestFields(tc.give)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
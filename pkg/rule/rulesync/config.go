@@ -0,0 +1,430 @@
+// Package rulesync manages access rules declaratively from a YAML or JSON file,
+// so that they can be reviewed and versioned in git.
+package rulesync
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/common-fate/granted-approvals/pkg/policy"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/tickets"
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// File is the declarative configuration of the access rules in a deployment.
+type File struct {
+	Rules []Rule `yaml:"rules" json:"rules"`
+}
+
+// Rule mirrors rule.AccessRule, omitting the fields which are managed by Granted such as versions and metadata.
+type Rule struct {
+	// ID is empty for rules which haven't been created yet.
+	ID              string          `yaml:"id,omitempty" json:"id,omitempty"`
+	Name            string          `yaml:"name" json:"name"`
+	Description     string          `yaml:"description" json:"description"`
+	Groups          []string        `yaml:"groups" json:"groups"`
+	Target          Target          `yaml:"target" json:"target"`
+	Approval        Approval        `yaml:"approval,omitempty" json:"approval,omitempty"`
+	TimeConstraints TimeConstraints `yaml:"timeConstraints" json:"timeConstraints"`
+	Notifications   Notifications   `yaml:"notifications,omitempty" json:"notifications,omitempty"`
+	Owners          Owners          `yaml:"owners,omitempty" json:"owners,omitempty"`
+	Limits          Limits          `yaml:"limits,omitempty" json:"limits,omitempty"`
+	Policy          *Policy         `yaml:"policy,omitempty" json:"policy,omitempty"`
+	RequestFields   []RequestField  `yaml:"requestFields,omitempty" json:"requestFields,omitempty"`
+	Ticket          *Ticket         `yaml:"ticket,omitempty" json:"ticket,omitempty"`
+}
+
+type Target struct {
+	ProviderID     string              `yaml:"providerId" json:"providerId"`
+	With           map[string]string   `yaml:"with,omitempty" json:"with,omitempty"`
+	WithSelectable map[string][]string `yaml:"withSelectable,omitempty" json:"withSelectable,omitempty"`
+}
+
+type Approval struct {
+	Users            []string `yaml:"users,omitempty" json:"users,omitempty"`
+	Groups           []string `yaml:"groups,omitempty" json:"groups,omitempty"`
+	EscalationGroups []string `yaml:"escalationGroups,omitempty" json:"escalationGroups,omitempty"`
+}
+
+type TimeConstraints struct {
+	MaxDurationSeconds int           `yaml:"maxDurationSeconds" json:"maxDurationSeconds"`
+	AccessWindow       *AccessWindow `yaml:"accessWindow,omitempty" json:"accessWindow,omitempty"`
+	MinNoticeSeconds   *int          `yaml:"minNoticeSeconds,omitempty" json:"minNoticeSeconds,omitempty"`
+	MaxAdvanceSeconds  *int          `yaml:"maxAdvanceSeconds,omitempty" json:"maxAdvanceSeconds,omitempty"`
+}
+
+type AccessWindow struct {
+	Timezone  string   `yaml:"timezone" json:"timezone"`
+	Days      []string `yaml:"days,omitempty" json:"days,omitempty"`
+	StartTime string   `yaml:"startTime,omitempty" json:"startTime,omitempty"`
+	EndTime   string   `yaml:"endTime,omitempty" json:"endTime,omitempty"`
+}
+
+type Notifications struct {
+	SlackChannels []string `yaml:"slackChannels,omitempty" json:"slackChannels,omitempty"`
+}
+
+type Owners struct {
+	Users  []string `yaml:"users,omitempty" json:"users,omitempty"`
+	Groups []string `yaml:"groups,omitempty" json:"groups,omitempty"`
+}
+
+type Limits struct {
+	MaxActiveGrants   int `yaml:"maxActiveGrants,omitempty" json:"maxActiveGrants,omitempty"`
+	MaxRequestsPerDay int `yaml:"maxRequestsPerDay,omitempty" json:"maxRequestsPerDay,omitempty"`
+	CooldownSeconds   int `yaml:"cooldownSeconds,omitempty" json:"cooldownSeconds,omitempty"`
+}
+
+type Policy struct {
+	Language   string            `yaml:"language" json:"language"`
+	Conditions []PolicyCondition `yaml:"conditions" json:"conditions"`
+}
+
+type PolicyCondition struct {
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Expression  string `yaml:"expression" json:"expression"`
+	Effect      string `yaml:"effect" json:"effect"`
+}
+
+type RequestField struct {
+	ID          string   `yaml:"id" json:"id"`
+	Label       string   `yaml:"label" json:"label"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Type        string   `yaml:"type" json:"type"`
+	Required    bool     `yaml:"required,omitempty" json:"required,omitempty"`
+	Pattern     string   `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+	Options     []string `yaml:"options,omitempty" json:"options,omitempty"`
+}
+
+type Ticket struct {
+	System            string   `yaml:"system" json:"system"`
+	FieldID           string   `yaml:"fieldId" json:"fieldId"`
+	AllowedStates     []string `yaml:"allowedStates,omitempty" json:"allowedStates,omitempty"`
+	AutoApproveStates []string `yaml:"autoApproveStates,omitempty" json:"autoApproveStates,omitempty"`
+}
+
+// Parse reads a rules file. JSON files are supported as JSON is valid YAML.
+func Parse(data []byte) (File, error) {
+	var f File
+	err := yaml.Unmarshal(data, &f)
+	if err != nil {
+		return File{}, err
+	}
+	return f, f.Validate()
+}
+
+// Validate returns an error if any of the rules are invalid.
+// It checks the same constraints as the admin API so that invalid rules are caught before a plan is applied.
+func (f File) Validate() error {
+	ids := make(map[string]bool)
+	for i, r := range f.Rules {
+		name := r.Name
+		if name == "" {
+			return fmt.Errorf("rule %d must have a name", i)
+		}
+		if r.ID != "" {
+			if ids[r.ID] {
+				return fmt.Errorf("rule %s is defined more than once", r.ID)
+			}
+			ids[r.ID] = true
+		}
+		if r.Target.ProviderID == "" {
+			return fmt.Errorf("rule %s must have a target providerId", name)
+		}
+		for k, v := range r.Target.WithSelectable {
+			if _, ok := r.Target.With[k]; ok {
+				return fmt.Errorf("rule %s sets %s in both with and withSelectable", name, k)
+			}
+			if len(v) < 2 {
+				return fmt.Errorf("rule %s must have at least two options for %s in withSelectable, use with for a single value", name, k)
+			}
+		}
+		if r.TimeConstraints.MaxDurationSeconds < 60 {
+			return fmt.Errorf("rule %s must have a timeConstraints maxDurationSeconds of at least 60", name)
+		}
+		if r.Limits.MaxActiveGrants < 0 || r.Limits.MaxRequestsPerDay < 0 || r.Limits.CooldownSeconds < 0 {
+			return fmt.Errorf("rule %s must not have negative limits", name)
+		}
+		if r.Policy != nil {
+			err := policy.Validate(rule.PolicyFromAPI(r.policy()))
+			if err != nil {
+				return fmt.Errorf("rule %s has an invalid policy: %w", name, err)
+			}
+			err = policy.ValidateApprovers(rule.PolicyFromAPI(r.policy()), rule.ApprovalFromAPI(r.approverConfig()))
+			if err != nil {
+				return fmt.Errorf("rule %s has an invalid policy: %w", name, err)
+			}
+		}
+		err := rule.ValidateRequestFields(rule.RequestFieldsFromAPI(r.requestFields()))
+		if err != nil {
+			return fmt.Errorf("rule %s has invalid requestFields: %w", name, err)
+		}
+		if r.Ticket != nil {
+			if r.Ticket.FieldID == "" {
+				return fmt.Errorf("rule %s must have a ticket fieldId", name)
+			}
+			_, err := tickets.Registry().Lookup(r.Ticket.System)
+			if err != nil {
+				return fmt.Errorf("rule %s has an invalid ticket: %w", name, err)
+			}
+			err = rule.ValidateTicketRequirement(rule.TicketRequirementFromAPI(r.ticket()), rule.RequestFieldsFromAPI(r.requestFields()))
+			if err != nil {
+				return fmt.Errorf("rule %s has an invalid ticket: %w", name, err)
+			}
+		}
+		if r.TimeConstraints.AccessWindow != nil {
+			err := rule.ValidateAccessWindow(*r.TimeConstraints.toAPI().AccessWindow)
+			if err != nil {
+				return fmt.Errorf("rule %s has an invalid timeConstraints accessWindow: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Marshal returns the YAML representation of the file.
+func (f File) Marshal() ([]byte, error) {
+	return yaml.Marshal(f)
+}
+
+// SetIDs sets the IDs of the rules at the positions in the rules file, so that rules which were created
+// by applying the file are updated rather than created again the next time it is applied.
+// Comments in YAML files are kept, and JSON files are re-encoded as indented JSON.
+func SetIDs(data []byte, ids map[int]string) ([]byte, error) {
+	if json.Valid(data) {
+		var f File
+		err := json.Unmarshal(data, &f)
+		if err != nil {
+			return nil, err
+		}
+		for i, id := range ids {
+			if i < 0 || i >= len(f.Rules) {
+				return nil, fmt.Errorf("rule %d is not in the rules file", i)
+			}
+			f.Rules[i].ID = id
+		}
+		b, err := json.MarshalIndent(f, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(b, '\n'), nil
+	}
+
+	var doc yaml.Node
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, err
+	}
+	rules := rulesNode(&doc)
+	if rules == nil {
+		return nil, errors.New("the rules file doesn't contain a list of rules")
+	}
+	for i, id := range ids {
+		if i < 0 || i >= len(rules.Content) || rules.Content[i].Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("rule %d is not in the rules file", i)
+		}
+		setMappingValue(rules.Content[i], "id", id)
+	}
+	return yaml.Marshal(&doc)
+}
+
+// rulesNode returns the sequence node of the rules in a YAML document, or nil if there isn't one.
+func rulesNode(doc *yaml.Node) *yaml.Node {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+	root := doc.Content[0]
+	// mapping nodes contain the keys and values in turn.
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "rules" && root.Content[i+1].Kind == yaml.SequenceNode {
+			return root.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue sets the value of the key in a mapping node. New keys are added first, where the id of a rule is exported.
+func setMappingValue(m *yaml.Node, key, value string) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1].SetString(value)
+			return
+		}
+	}
+	k := &yaml.Node{}
+	k.SetString(key)
+	v := &yaml.Node{}
+	v.SetString(value)
+	m.Content = append([]*yaml.Node{k, v}, m.Content...)
+}
+
+// FromAccessRule converts an access rule to its declarative configuration.
+func FromAccessRule(r rule.AccessRule) Rule {
+	res := Rule{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Groups:      r.Groups,
+		Target: Target{
+			ProviderID:     r.Target.ProviderID,
+			With:           r.Target.With,
+			WithSelectable: r.Target.WithSelectable,
+		},
+		Approval: Approval{
+			Users:            r.Approval.Users,
+			Groups:           r.Approval.Groups,
+			EscalationGroups: r.Approval.EscalationGroups,
+		},
+		TimeConstraints: timeConstraintsFromAPI(r.TimeConstraints),
+		Notifications:   Notifications{SlackChannels: r.Notifications.SlackChannels},
+		Owners:          Owners{Users: r.Owners.Users, Groups: r.Owners.Groups},
+		Limits:          Limits(r.Limits),
+	}
+	if r.Policy.IsSet() {
+		p := Policy{Language: r.Policy.Language}
+		for _, c := range r.Policy.Conditions {
+			p.Conditions = append(p.Conditions, PolicyCondition{Description: c.Description, Expression: c.Expression, Effect: string(c.Effect)})
+		}
+		res.Policy = &p
+	}
+	if r.Ticket.IsSet() {
+		res.Ticket = &Ticket{
+			System:            r.Ticket.System,
+			FieldID:           r.Ticket.FieldID,
+			AllowedStates:     r.Ticket.AllowedStates,
+			AutoApproveStates: r.Ticket.AutoApproveStates,
+		}
+	}
+	for _, f := range r.RequestFields {
+		res.RequestFields = append(res.RequestFields, RequestField{
+			ID:          f.ID,
+			Label:       f.Label,
+			Description: f.Description,
+			Type:        string(f.Type),
+			Required:    f.Required,
+			Pattern:     f.Pattern,
+			Options:     f.Options,
+		})
+	}
+	return res.normalize()
+}
+
+// Export returns the declarative configuration of the active rules, sorted by name so that exports are stable.
+func Export(rules []rule.AccessRule) File {
+	f := File{Rules: []Rule{}}
+	for _, r := range rules {
+		if r.Status == rule.ACTIVE {
+			f.Rules = append(f.Rules, FromAccessRule(r))
+		}
+	}
+	sort.SliceStable(f.Rules, func(i, j int) bool {
+		if f.Rules[i].Name == f.Rules[j].Name {
+			return f.Rules[i].ID < f.Rules[j].ID
+		}
+		return f.Rules[i].Name < f.Rules[j].Name
+	})
+	return f
+}
+
+// normalize replaces empty slices and maps with nil, so that rules can be compared regardless of how they were loaded.
+func (r Rule) normalize() Rule {
+	r.Groups = nilIfEmpty(r.Groups)
+	if len(r.Target.With) == 0 {
+		r.Target.With = nil
+	}
+	if len(r.Target.WithSelectable) == 0 {
+		r.Target.WithSelectable = nil
+	}
+	r.Approval.Users = nilIfEmpty(r.Approval.Users)
+	r.Approval.Groups = nilIfEmpty(r.Approval.Groups)
+	r.Approval.EscalationGroups = nilIfEmpty(r.Approval.EscalationGroups)
+	r.Notifications.SlackChannels = nilIfEmpty(r.Notifications.SlackChannels)
+	r.Owners.Users = nilIfEmpty(r.Owners.Users)
+	r.Owners.Groups = nilIfEmpty(r.Owners.Groups)
+	if r.TimeConstraints.AccessWindow != nil {
+		w := *r.TimeConstraints.AccessWindow
+		w.Days = nilIfEmpty(w.Days)
+		r.TimeConstraints.AccessWindow = &w
+	}
+	if r.Policy != nil && len(r.Policy.Conditions) == 0 {
+		r.Policy = nil
+	}
+	if r.Ticket != nil {
+		t := *r.Ticket
+		t.AllowedStates = nilIfEmpty(t.AllowedStates)
+		t.AutoApproveStates = nilIfEmpty(t.AutoApproveStates)
+		r.Ticket = &t
+	}
+	if len(r.RequestFields) == 0 {
+		r.RequestFields = nil
+	} else {
+		fields := make([]RequestField, len(r.RequestFields))
+		for i, f := range r.RequestFields {
+			f.Options = nilIfEmpty(f.Options)
+			fields[i] = f
+		}
+		r.RequestFields = fields
+	}
+	return r
+}
+
+func timeConstraintsFromAPI(tc types.TimeConstraints) TimeConstraints {
+	res := TimeConstraints{
+		MaxDurationSeconds: tc.MaxDurationSeconds,
+		MinNoticeSeconds:   tc.MinNoticeSeconds,
+		MaxAdvanceSeconds:  tc.MaxAdvanceSeconds,
+	}
+	if tc.AccessWindow != nil {
+		w := AccessWindow{Timezone: tc.AccessWindow.Timezone}
+		if tc.AccessWindow.Days != nil {
+			for _, d := range *tc.AccessWindow.Days {
+				w.Days = append(w.Days, string(d))
+			}
+		}
+		if tc.AccessWindow.StartTime != nil {
+			w.StartTime = *tc.AccessWindow.StartTime
+		}
+		if tc.AccessWindow.EndTime != nil {
+			w.EndTime = *tc.AccessWindow.EndTime
+		}
+		res.AccessWindow = &w
+	}
+	return res
+}
+
+func (tc TimeConstraints) toAPI() types.TimeConstraints {
+	res := types.TimeConstraints{
+		MaxDurationSeconds: tc.MaxDurationSeconds,
+		MinNoticeSeconds:   tc.MinNoticeSeconds,
+		MaxAdvanceSeconds:  tc.MaxAdvanceSeconds,
+	}
+	if tc.AccessWindow != nil {
+		w := types.AccessWindow{Timezone: tc.AccessWindow.Timezone}
+		if len(tc.AccessWindow.Days) > 0 {
+			days := make([]types.AccessWindowDays, len(tc.AccessWindow.Days))
+			for i, d := range tc.AccessWindow.Days {
+				days[i] = types.AccessWindowDays(d)
+			}
+			w.Days = &days
+		}
+		if tc.AccessWindow.StartTime != "" {
+			w.StartTime = &tc.AccessWindow.StartTime
+		}
+		if tc.AccessWindow.EndTime != "" {
+			w.EndTime = &tc.AccessWindow.EndTime
+		}
+		res.AccessWindow = &w
+	}
+	return res
+}
+
+func nilIfEmpty(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
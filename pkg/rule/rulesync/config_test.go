@@ -0,0 +1,209 @@
+package rulesync
+
+import (
+	"testing"
+
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestExportRoundTrip(t *testing.T) {
+	rules := []rule.AccessRule{
+		{
+			ID:              "rul_2",
+			Name:            "Production admin",
+			Status:          rule.ACTIVE,
+			Groups:          []string{"engineering"},
+			Target:          rule.Target{ProviderID: "aws", With: map[string]string{"accountId": "123"}, WithSelectable: map[string][]string{"permissionSetArn": {"a", "b"}}},
+			Approval:        rule.Approval{Groups: []string{"leads"}},
+			TimeConstraints: types.TimeConstraints{MaxDurationSeconds: 3600},
+		},
+		{
+			ID:              "rul_1",
+			Name:            "Okta admin",
+			Status:          rule.ACTIVE,
+			Target:          rule.Target{ProviderID: "okta"},
+			TimeConstraints: types.TimeConstraints{MaxDurationSeconds: 60},
+			Owners:          rule.Owners{Groups: []string{"it"}},
+		},
+		{ID: "rul_3", Name: "Archived", Status: rule.ARCHIVED},
+	}
+
+	f := Export(rules)
+	b, err := f.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `rules:
+    - id: rul_1
+      name: Okta admin
+      description: ""
+      groups: []
+      target:
+        providerId: okta
+      timeConstraints:
+        maxDurationSeconds: 60
+      owners:
+        groups:
+            - it
+    - id: rul_2
+      name: Production admin
+      description: ""
+      groups:
+        - engineering
+      target:
+        providerId: aws
+        with:
+            accountId: "123"
+        withSelectable:
+            permissionSetArn:
+                - a
+                - b
+      approval:
+        groups:
+            - leads
+      timeConstraints:
+        maxDurationSeconds: 3600
+`
+	assert.Equal(t, want, string(b))
+
+	parsed, err := Parse(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := ComputePlan(parsed, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, plan.IsEmpty(), "an exported file should produce an empty plan: %+v", plan.Changes)
+}
+
+func TestParseInvalid(t *testing.T) {
+	testcases := []struct {
+		name    string
+		give    string
+		wantErr string
+	}{
+		{
+			name:    "missing name",
+			give:    `{"rules":[{"target":{"providerId":"aws"},"timeConstraints":{"maxDurationSeconds":60}}]}`,
+			wantErr: "rule 0 must have a name",
+		},
+		{
+			name:    "duplicate ID",
+			give:    `{"rules":[{"id":"rul_1","name":"a","target":{"providerId":"aws"},"timeConstraints":{"maxDurationSeconds":60}},{"id":"rul_1","name":"b","target":{"providerId":"aws"},"timeConstraints":{"maxDurationSeconds":60}}]}`,
+			wantErr: "rule rul_1 is defined more than once",
+		},
+		{
+			name:    "missing provider",
+			give:    `{"rules":[{"name":"a","timeConstraints":{"maxDurationSeconds":60}}]}`,
+			wantErr: "rule a must have a target providerId",
+		},
+		{
+			name:    "duration too short",
+			give:    `{"rules":[{"name":"a","target":{"providerId":"aws"},"timeConstraints":{"maxDurationSeconds":30}}]}`,
+			wantErr: "rule a must have a timeConstraints maxDurationSeconds of at least 60",
+		},
+		{
+			name:    "with and withSelectable",
+			give:    `{"rules":[{"name":"a","target":{"providerId":"aws","with":{"accountId":"1"},"withSelectable":{"accountId":["1","2"]}},"timeConstraints":{"maxDurationSeconds":60}}]}`,
+			wantErr: "rule a sets accountId in both with and withSelectable",
+		},
+		{
+			name:    "invalid access window",
+			give:    `{"rules":[{"name":"a","target":{"providerId":"aws"},"timeConstraints":{"maxDurationSeconds":60,"accessWindow":{"timezone":"UTC","startTime":"17:00","endTime":"09:00"}}}]}`,
+			wantErr: "rule a has an invalid timeConstraints accessWindow: startTime must be before endTime",
+		},
+		{
+			name:    "ticket field isn't required",
+			give:    `{"rules":[{"name":"a","target":{"providerId":"aws"},"timeConstraints":{"maxDurationSeconds":60},"requestFields":[{"id":"ticketId","label":"Ticket","type":"TEXT"}],"ticket":{"system":"jira","fieldId":"ticketId"}}]}`,
+			wantErr: "rule a has an invalid ticket: request field ticketId must be a required TEXT field to hold the ticket ID",
+		},
+		{
+			name:    "unsupported ticket system",
+			give:    `{"rules":[{"name":"a","target":{"providerId":"aws"},"timeConstraints":{"maxDurationSeconds":60},"ticket":{"system":"zendesk","fieldId":"ticketId"}}]}`,
+			wantErr: "rule a has an invalid ticket: could not find ticket system zendesk",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.give))
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestSetIDs(t *testing.T) {
+	type testcase struct {
+		name string
+		give string
+		ids  map[int]string
+		want string
+	}
+
+	testcases := []testcase{
+		{
+			name: "yaml keeps comments",
+			give: `rules:
+    # created by the platform team
+    - name: Staging admin
+      groups: [engineering]
+    - id: rul_1
+      name: Production admin
+`,
+			ids: map[int]string{0: "rul_new"},
+			want: `rules:
+    # created by the platform team
+    - id: rul_new
+      name: Staging admin
+      groups: [engineering]
+    - id: rul_1
+      name: Production admin
+`,
+		},
+		{
+			name: "json",
+			give: `{"rules":[{"name":"Staging admin","groups":["engineering"],"target":{"providerId":"aws"},"timeConstraints":{"maxDurationSeconds":60}}]}`,
+			ids:  map[int]string{0: "rul_new"},
+			want: `{
+  "rules": [
+    {
+      "id": "rul_new",
+      "name": "Staging admin",
+      "description": "",
+      "groups": [
+        "engineering"
+      ],
+      "target": {
+        "providerId": "aws"
+      },
+      "approval": {},
+      "timeConstraints": {
+        "maxDurationSeconds": 60
+      },
+      "notifications": {},
+      "owners": {},
+      "limits": {}
+    }
+  ]
+}
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SetIDs([]byte(tc.give), tc.ids)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, string(got))
+
+			// the rules in the file are updated rather than created when the file is applied again.
+			var f File
+			err = yaml.Unmarshal(got, &f)
+			assert.NoError(t, err)
+			assert.Equal(t, "rul_new", f.Rules[0].ID)
+		})
+	}
+}
@@ -0,0 +1,312 @@
+package rulesync
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/service/rulesvc"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+// ChangeType is the type of change made to a rule when a plan is applied.
+type ChangeType string
+
+const (
+	ChangeCreate  ChangeType = "create"
+	ChangeUpdate  ChangeType = "update"
+	ChangeArchive ChangeType = "archive"
+)
+
+// UpdateMessage is recorded on the versions of rules which are updated by applying a plan.
+const UpdateMessage = "Updated from rules file"
+
+// Change is a change to a single rule.
+type Change struct {
+	Type ChangeType
+	// RuleID is empty for rules which are created.
+	RuleID string
+	// Index is the position of the rule in the file, which is used to write the IDs of created rules back to the file.
+	Index int
+	Name  string
+	// Fields contains the names of the fields which are changed by an update.
+	Fields []string
+	// Desired is the configuration of the rule after the change. It is nil for archived rules.
+	Desired *Rule
+	// Current is the rule before the change. It is nil for created rules.
+	Current *rule.AccessRule
+}
+
+// Plan contains the changes required to make the rules in a deployment match a rules file.
+//
+// Changes are ordered by the order of the rules in the file, followed by the archived rules sorted by ID,
+// so that the same file and rules always produce the same plan.
+type Plan struct {
+	Changes []Change
+}
+
+// IsEmpty returns true if the rules already match the file.
+func (p Plan) IsEmpty() bool {
+	return len(p.Changes) == 0
+}
+
+// ComputePlan compares the rules file with the current access rules.
+//
+// Rules in the file without an ID are created, and rules with an ID are updated if they differ.
+// Active rules which aren't in the file are archived.
+// Pending requests for options which are removed from the target of a rule are declined when the plan is applied.
+func ComputePlan(f File, current []rule.AccessRule) (Plan, error) {
+	err := f.Validate()
+	if err != nil {
+		return Plan{}, err
+	}
+	byID := make(map[string]rule.AccessRule)
+	for _, r := range current {
+		byID[r.ID] = r
+	}
+
+	var p Plan
+	inFile := make(map[string]bool)
+	for i := range f.Rules {
+		desired := f.Rules[i].normalize()
+		if desired.ID == "" {
+			p.Changes = append(p.Changes, Change{Type: ChangeCreate, Index: i, Name: desired.Name, Desired: &desired})
+			continue
+		}
+		inFile[desired.ID] = true
+		cur, ok := byID[desired.ID]
+		if !ok {
+			return Plan{}, fmt.Errorf("rule %s (%s) doesn't exist, remove its id to create it", desired.ID, desired.Name)
+		}
+		if cur.Status == rule.ARCHIVED {
+			return Plan{}, fmt.Errorf("rule %s (%s) is archived and can't be updated", desired.ID, desired.Name)
+		}
+		fields := changedFields(FromAccessRule(cur), desired)
+		if len(fields) == 0 {
+			continue
+		}
+		p.Changes = append(p.Changes, Change{Type: ChangeUpdate, RuleID: cur.ID, Index: i, Name: desired.Name, Fields: fields, Desired: &desired, Current: &cur})
+	}
+
+	var archived []Change
+	for _, r := range current {
+		if r.Status == rule.ACTIVE && !inFile[r.ID] {
+			cur := r
+			archived = append(archived, Change{Type: ChangeArchive, RuleID: r.ID, Name: r.Name, Current: &cur})
+		}
+	}
+	sort.Slice(archived, func(i, j int) bool { return archived[i].RuleID < archived[j].RuleID })
+	p.Changes = append(p.Changes, archived...)
+	return p, nil
+}
+
+// changedFields returns the names of the fields which differ between the rules, in the order they are defined in Rule.
+func changedFields(a, b Rule) []string {
+	var fields []string
+	check := func(name string, x, y interface{}) {
+		if !reflect.DeepEqual(x, y) {
+			fields = append(fields, name)
+		}
+	}
+	check("name", a.Name, b.Name)
+	check("description", a.Description, b.Description)
+	check("groups", a.Groups, b.Groups)
+	check("target", a.Target, b.Target)
+	check("approval", a.Approval, b.Approval)
+	check("timeConstraints", a.TimeConstraints, b.TimeConstraints)
+	check("notifications", a.Notifications, b.Notifications)
+	check("owners", a.Owners, b.Owners)
+	check("limits", a.Limits, b.Limits)
+	check("policy", a.Policy, b.Policy)
+	check("requestFields", a.RequestFields, b.RequestFields)
+	check("ticket", a.Ticket, b.Ticket)
+	return fields
+}
+
+// RuleService creates, updates and archives access rules.
+type RuleService interface {
+	CreateAccessRule(ctx context.Context, user *identity.User, in types.CreateAccessRuleRequest) (*rule.AccessRule, error)
+	UpdateRule(ctx context.Context, in *rulesvc.UpdateOpts) (*rule.AccessRule, error)
+	ArchiveAccessRule(ctx context.Context, user *identity.User, in rule.AccessRule, isAdmin bool) (*rule.AccessRule, error)
+}
+
+// Apply makes the changes in the plan as the user, stopping at the first change which fails.
+// It returns the IDs of the rules which were created keyed by their position in the file, even if a later change fails,
+// so that the IDs can be written back to the rules file with SetIDs.
+func (p Plan) Apply(ctx context.Context, rs RuleService, user *identity.User) (map[int]string, error) {
+	created := make(map[int]string)
+	for _, c := range p.Changes {
+		switch c.Type {
+		case ChangeCreate:
+			r, err := rs.CreateAccessRule(ctx, user, c.Desired.createRequest())
+			if err != nil {
+				return created, fmt.Errorf("creating rule %s: %w", c.Name, err)
+			}
+			created[c.Index] = r.ID
+		case ChangeUpdate:
+			_, err := rs.UpdateRule(ctx, &rulesvc.UpdateOpts{
+				Updater:       user,
+				Rule:          *c.Current,
+				UpdateRequest: c.Desired.updateRequest(),
+				IsAdmin:       true,
+			})
+			if err != nil {
+				return created, fmt.Errorf("updating rule %s (%s): %w", c.RuleID, c.Name, err)
+			}
+		case ChangeArchive:
+			_, err := rs.ArchiveAccessRule(ctx, user, *c.Current, true)
+			if err != nil {
+				return created, fmt.Errorf("archiving rule %s (%s): %w", c.RuleID, c.Name, err)
+			}
+		}
+	}
+	return created, nil
+}
+
+func (r Rule) createRequest() types.CreateAccessRuleRequest {
+	return types.CreateAccessRuleRequest{
+		Name:            r.Name,
+		Description:     r.Description,
+		Groups:          emptyIfNil(r.Groups),
+		Target:          r.targetRequest(),
+		Approval:        r.approverConfig(),
+		TimeConstraints: r.TimeConstraints.toAPI(),
+		Notifications:   &types.AccessRuleNotifications{SlackChannels: emptyIfNil(r.Notifications.SlackChannels)},
+		Owners:          &types.AccessRuleOwners{Users: emptyIfNil(r.Owners.Users), Groups: emptyIfNil(r.Owners.Groups)},
+		Limits:          r.limits(),
+		Policy:          r.policy(),
+		RequestFields:   r.requestFields(),
+		Ticket:          r.ticket(),
+	}
+}
+
+// updateRequest always sets the optional fields of the request, even if they aren't in the file.
+// Optional fields which aren't set are left as they are by the update, so this means that settings
+// which are removed from the file are removed from the rule.
+func (r Rule) updateRequest() types.UpdateAccessRuleRequest {
+	msg := UpdateMessage
+	target := r.targetRequest()
+	return types.UpdateAccessRuleRequest{
+		Name:            r.Name,
+		Description:     r.Description,
+		Groups:          emptyIfNil(r.Groups),
+		Target:          &target,
+		Approval:        r.approverConfig(),
+		TimeConstraints: r.TimeConstraints.toAPI(),
+		Notifications:   &types.AccessRuleNotifications{SlackChannels: emptyIfNil(r.Notifications.SlackChannels)},
+		Owners:          &types.AccessRuleOwners{Users: emptyIfNil(r.Owners.Users), Groups: emptyIfNil(r.Owners.Groups)},
+		Limits:          r.limits(),
+		Policy:          r.policy(),
+		RequestFields:   r.requestFields(),
+		Ticket:          r.ticket(),
+		UpdateMessage:   &msg,
+	}
+}
+
+func (r Rule) targetRequest() types.CreateAccessRuleTarget {
+	with := make(map[string][]string)
+	for k, v := range r.Target.With {
+		with[k] = []string{v}
+	}
+	for k, v := range r.Target.WithSelectable {
+		with[k] = v
+	}
+	return types.CreateAccessRuleTarget{
+		ProviderId: r.Target.ProviderID,
+		With:       types.CreateAccessRuleTarget_With{AdditionalProperties: with},
+	}
+}
+
+func (r Rule) approverConfig() types.ApproverConfig {
+	a := types.ApproverConfig{
+		Users:  emptyIfNil(r.Approval.Users),
+		Groups: emptyIfNil(r.Approval.Groups),
+	}
+	if len(r.Approval.EscalationGroups) > 0 {
+		a.EscalationGroups = &r.Approval.EscalationGroups
+	}
+	return a
+}
+
+// limits returns the limits of the rule.
+func (r Rule) limits() *types.AccessRuleLimits {
+	var l types.AccessRuleLimits
+	if r.Limits.MaxActiveGrants > 0 {
+		l.MaxActiveGrants = &r.Limits.MaxActiveGrants
+	}
+	if r.Limits.MaxRequestsPerDay > 0 {
+		l.MaxRequestsPerDay = &r.Limits.MaxRequestsPerDay
+	}
+	if r.Limits.CooldownSeconds > 0 {
+		l.CooldownSeconds = &r.Limits.CooldownSeconds
+	}
+	return &l
+}
+
+// policy returns the policy of the rule, which has no conditions if the rule doesn't have a policy.
+func (r Rule) policy() *types.AccessRulePolicy {
+	p := types.AccessRulePolicy{Language: types.Cel, Conditions: []types.AccessRulePolicyCondition{}}
+	if r.Policy == nil {
+		return &p
+	}
+	p.Language = types.AccessRulePolicyLanguage(r.Policy.Language)
+	for _, c := range r.Policy.Conditions {
+		cond := types.AccessRulePolicyCondition{Expression: c.Expression, Effect: types.AccessRulePolicyConditionEffect(c.Effect)}
+		if c.Description != "" {
+			desc := c.Description
+			cond.Description = &desc
+		}
+		p.Conditions = append(p.Conditions, cond)
+	}
+	return &p
+}
+
+// requestFields returns the request fields of the rule.
+func (r Rule) requestFields() *[]types.AccessRuleRequestField {
+	fields := []types.AccessRuleRequestField{}
+	for _, f := range r.RequestFields {
+		field := types.AccessRuleRequestField{Id: f.ID, Label: f.Label, Type: types.AccessRuleRequestFieldType(f.Type), Required: f.Required}
+		if f.Description != "" {
+			desc := f.Description
+			field.Description = &desc
+		}
+		if f.Pattern != "" {
+			pattern := f.Pattern
+			field.Pattern = &pattern
+		}
+		if len(f.Options) > 0 {
+			options := f.Options
+			field.Options = &options
+		}
+		fields = append(fields, field)
+	}
+	return &fields
+}
+
+// ticket returns the ticket requirement of the rule.
+// If the rule doesn't require a ticket, a requirement without a field ID is returned, which removes the requirement.
+func (r Rule) ticket() *types.AccessRuleTicket {
+	if r.Ticket == nil {
+		return &types.AccessRuleTicket{System: types.Jira, AllowedStates: []string{}}
+	}
+	t := types.AccessRuleTicket{
+		System:        types.AccessRuleTicketSystem(r.Ticket.System),
+		FieldId:       r.Ticket.FieldID,
+		AllowedStates: emptyIfNil(r.Ticket.AllowedStates),
+	}
+	if len(r.Ticket.AutoApproveStates) > 0 {
+		states := r.Ticket.AutoApproveStates
+		t.AutoApproveStates = &states
+	}
+	return &t
+}
+
+func emptyIfNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
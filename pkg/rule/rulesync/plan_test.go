@@ -0,0 +1,133 @@
+package rulesync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/service/rulesvc"
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputePlan(t *testing.T) {
+	current := []rule.AccessRule{
+		{
+			ID:              "rul_1",
+			Name:            "Production admin",
+			Status:          rule.ACTIVE,
+			Groups:          []string{"engineering"},
+			Target:          rule.Target{ProviderID: "aws", With: map[string]string{"accountId": "123"}, WithSelectable: map[string][]string{}},
+			Approval:        rule.Approval{Groups: []string{"leads"}, Users: []string{}},
+			TimeConstraints: types.TimeConstraints{MaxDurationSeconds: 3600},
+		},
+		{
+			ID:              "rul_2",
+			Name:            "Okta admin",
+			Status:          rule.ACTIVE,
+			Target:          rule.Target{ProviderID: "okta"},
+			TimeConstraints: types.TimeConstraints{MaxDurationSeconds: 3600},
+		},
+		{
+			ID:     "rul_3",
+			Name:   "Old rule",
+			Status: rule.ARCHIVED,
+			Target: rule.Target{ProviderID: "okta"},
+		},
+	}
+	unchanged := FromAccessRule(current[0])
+
+	type testcase struct {
+		name    string
+		file    File
+		want    []Change
+		wantErr string
+	}
+
+	updated := unchanged
+	updated.Description = "Admin access to production"
+	updated.Approval = Approval{}
+
+	created := Rule{Name: "Staging admin", Target: Target{ProviderID: "aws"}, TimeConstraints: TimeConstraints{MaxDurationSeconds: 60}}
+
+	retargeted := unchanged
+	retargeted.Target = Target{ProviderID: "okta"}
+
+	testcases := []testcase{
+		{
+			name: "rules which aren't in the file are archived",
+			file: File{Rules: []Rule{unchanged}},
+			want: []Change{{Type: ChangeArchive, RuleID: "rul_2", Name: "Okta admin", Current: &current[1]}},
+		},
+		{
+			name: "create and update",
+			file: File{Rules: []Rule{created, updated, FromAccessRule(current[1])}},
+			want: []Change{
+				{Type: ChangeCreate, Name: "Staging admin", Desired: &created},
+				{Type: ChangeUpdate, RuleID: "rul_1", Index: 1, Name: "Production admin", Fields: []string{"description", "approval"}, Desired: &updated, Current: &current[0]},
+			},
+		},
+		{
+			name:    "unknown rule",
+			file:    File{Rules: []Rule{{ID: "rul_4", Name: "Missing", Target: Target{ProviderID: "aws"}, TimeConstraints: TimeConstraints{MaxDurationSeconds: 60}}}},
+			wantErr: "rule rul_4 (Missing) doesn't exist, remove its id to create it",
+		},
+		{
+			name:    "archived rule",
+			file:    File{Rules: []Rule{{ID: "rul_3", Name: "Old rule", Target: Target{ProviderID: "okta"}, TimeConstraints: TimeConstraints{MaxDurationSeconds: 60}}}},
+			wantErr: "rule rul_3 (Old rule) is archived and can't be updated",
+		},
+		{
+			name: "target",
+			file: File{Rules: []Rule{retargeted, FromAccessRule(current[1])}},
+			want: []Change{{Type: ChangeUpdate, RuleID: "rul_1", Name: "Production admin", Fields: []string{"target"}, Desired: &retargeted, Current: &current[0]}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ComputePlan(tc.file, current)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got.Changes)
+		})
+	}
+}
+
+// testRuleService records the changes made by a plan.
+type testRuleService struct {
+	changes []string
+}
+
+func (s *testRuleService) CreateAccessRule(ctx context.Context, user *identity.User, in types.CreateAccessRuleRequest) (*rule.AccessRule, error) {
+	s.changes = append(s.changes, "create "+in.Name)
+	return &rule.AccessRule{ID: "rul_new", Name: in.Name}, nil
+}
+
+func (s *testRuleService) UpdateRule(ctx context.Context, in *rulesvc.UpdateOpts) (*rule.AccessRule, error) {
+	s.changes = append(s.changes, "update "+in.Rule.ID+" "+in.UpdateRequest.Description)
+	return &in.Rule, nil
+}
+
+func (s *testRuleService) ArchiveAccessRule(ctx context.Context, user *identity.User, in rule.AccessRule, isAdmin bool) (*rule.AccessRule, error) {
+	s.changes = append(s.changes, "archive "+in.ID)
+	return &in, nil
+}
+
+func TestPlanApply(t *testing.T) {
+	desired := Rule{Name: "Staging admin", Description: "new", Target: Target{ProviderID: "aws"}}
+	p := Plan{Changes: []Change{
+		{Type: ChangeCreate, Index: 2, Name: "Staging admin", Desired: &desired},
+		{Type: ChangeUpdate, RuleID: "rul_1", Desired: &desired, Current: &rule.AccessRule{ID: "rul_1"}},
+		{Type: ChangeArchive, RuleID: "rul_2", Current: &rule.AccessRule{ID: "rul_2"}},
+	}}
+	var s testRuleService
+	created, err := p.Apply(context.Background(), &s, &identity.User{ID: "gdeploy"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]string{2: "rul_new"}, created)
+	assert.Equal(t, []string{"create Staging admin", "update rul_1 new", "archive rul_2"}, s.changes)
+}
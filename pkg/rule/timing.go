@@ -0,0 +1,199 @@
+package rule
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/common-fate/granted-approvals/pkg/types"
+
+	// the timezone database is embedded, as it may not be installed where the API runs.
+	_ "time/tzdata"
+)
+
+// TimingError is returned if access doesn't meet the time constraints of a rule.
+// Field is the request field which the error relates to, such as timing.startTime.
+type TimingError struct {
+	Field   string
+	Message string
+}
+
+func (e *TimingError) Error() string {
+	return e.Message
+}
+
+// AccessTiming is the interval which access is requested for.
+type AccessTiming struct {
+	Start time.Time
+	End   time.Time
+	// Scheduled is true if the requester chose the start time, rather than requesting access as soon as possible.
+	Scheduled bool
+	// ChosenAt is when the start time was chosen.
+	// The minimum notice and the scheduling horizon are measured from this time.
+	ChosenAt time.Time
+}
+
+// CheckTiming returns a *TimingError if the access doesn't meet the access window,
+// minimum notice or scheduling horizon of the time constraints.
+// The notice and horizon only apply to scheduled access.
+//
+// The maximum duration isn't checked here, as reviewers may override it when approving a request.
+func CheckTiming(tc types.TimeConstraints, t AccessTiming) error {
+	if t.Scheduled {
+		lead := t.Start.Sub(t.ChosenAt)
+		if tc.MinNoticeSeconds != nil && lead < time.Duration(*tc.MinNoticeSeconds)*time.Second {
+			return &TimingError{
+				Field:   "timing.startTime",
+				Message: fmt.Sprintf("access must be scheduled at least %s in advance", time.Duration(*tc.MinNoticeSeconds)*time.Second),
+			}
+		}
+		if tc.MaxAdvanceSeconds != nil && lead > time.Duration(*tc.MaxAdvanceSeconds)*time.Second {
+			return &TimingError{
+				Field:   "timing.startTime",
+				Message: fmt.Sprintf("access can't be scheduled more than %s in advance", time.Duration(*tc.MaxAdvanceSeconds)*time.Second),
+			}
+		}
+	}
+	if tc.AccessWindow != nil {
+		return checkAccessWindow(*tc.AccessWindow, t.Start, t.End)
+	}
+	return nil
+}
+
+// checkAccessWindow returns a *TimingError unless every part of the interval is within the window.
+// The interval is split at midnight in the window's timezone, so that access can run overnight
+// if the window covers the whole of consecutive days.
+func checkAccessWindow(w types.AccessWindow, start, end time.Time) error {
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return err
+	}
+	startMins, endMins, err := windowMinutes(w)
+	if err != nil {
+		return err
+	}
+	start, end = start.In(loc), end.In(loc)
+
+	y, m, d := start.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	for first := true; first || day.Before(end); first = false {
+		next := time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, loc)
+		from, to := start, end
+		if day.After(from) {
+			from = day
+		}
+		if next.Before(to) {
+			to = next
+		}
+
+		opens := clockTime(day, startMins)
+		closes := clockTime(day, endMins)
+		allowed := dayAllowed(w, day.Weekday())
+		if first && (!allowed || from.Before(opens) || !from.Before(closes)) {
+			return &TimingError{Field: "timing.startTime", Message: "access can only start " + describeWindow(w)}
+		}
+		if !allowed || from.Before(opens) || to.After(closes) {
+			return &TimingError{Field: "timing.durationSeconds", Message: "access can only run " + describeWindow(w)}
+		}
+		day = next
+	}
+	return nil
+}
+
+// ValidateAccessWindow returns an error if the timezone, days or times of the window are invalid.
+func ValidateAccessWindow(w types.AccessWindow) error {
+	if w.Timezone == "" {
+		return errors.New("timezone is required")
+	}
+	if _, err := time.LoadLocation(w.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone %s", w.Timezone)
+	}
+	if w.Days != nil {
+		for _, d := range *w.Days {
+			if _, ok := weekdays[d]; !ok {
+				return fmt.Errorf("invalid day %s", d)
+			}
+		}
+	}
+	_, _, err := windowMinutes(w)
+	return err
+}
+
+var weekdays = map[types.AccessWindowDays]time.Weekday{
+	types.Monday:    time.Monday,
+	types.Tuesday:   time.Tuesday,
+	types.Wednesday: time.Wednesday,
+	types.Thursday:  time.Thursday,
+	types.Friday:    time.Friday,
+	types.Saturday:  time.Saturday,
+	types.Sunday:    time.Sunday,
+}
+
+// dayAllowed returns true if the window has no days, or includes the day.
+func dayAllowed(w types.AccessWindow, day time.Weekday) bool {
+	if w.Days == nil || len(*w.Days) == 0 {
+		return true
+	}
+	for _, d := range *w.Days {
+		if weekdays[d] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// windowMinutes returns the start and end of the window as minutes after midnight.
+func windowMinutes(w types.AccessWindow) (start int, end int, err error) {
+	start, end = 0, 24*60
+	if w.StartTime != nil {
+		start, err = parseClock(*w.StartTime)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid startTime: %w", err)
+		}
+	}
+	if w.EndTime != nil {
+		end, err = parseClock(*w.EndTime)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid endTime: %w", err)
+		}
+	}
+	if start >= end {
+		return 0, 0, errors.New("startTime must be before endTime")
+	}
+	return start, end, nil
+}
+
+// parseClock parses a time of day in HH:MM format.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not in HH:MM format", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// clockTime returns the time on the day which is the given minutes after midnight.
+func clockTime(day time.Time, mins int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, mins, 0, 0, day.Location())
+}
+
+// describeWindow returns a description of the window for error messages, such as "between 09:00 and 17:00 on monday, friday (Australia/Sydney)".
+func describeWindow(w types.AccessWindow) string {
+	var parts []string
+	if w.StartTime != nil || w.EndTime != nil {
+		from, to := "00:00", "24:00"
+		if w.StartTime != nil {
+			from = *w.StartTime
+		}
+		if w.EndTime != nil {
+			to = *w.EndTime
+		}
+		parts = append(parts, fmt.Sprintf("between %s and %s", from, to))
+	}
+	if days := windowDays(w); len(days) > 0 {
+		parts = append(parts, "on "+strings.Join(days, ", "))
+	}
+	parts = append(parts, fmt.Sprintf("(%s)", w.Timezone))
+	return strings.Join(parts, " ")
+}
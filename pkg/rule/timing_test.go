@@ -0,0 +1,166 @@
+package rule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckTiming(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(day, hour, min int) time.Time {
+		// 2022-08-01 is a Monday
+		return time.Date(2022, time.August, day, hour, min, 0, 0, sydney)
+	}
+	strPtr := func(s string) *string { return &s }
+	intPtr := func(i int) *int { return &i }
+
+	businessHours := types.TimeConstraints{
+		AccessWindow: &types.AccessWindow{
+			Timezone:  "Australia/Sydney",
+			Days:      &[]types.AccessWindowDays{types.Monday, types.Tuesday, types.Wednesday, types.Thursday, types.Friday},
+			StartTime: strPtr("09:00"),
+			EndTime:   strPtr("17:00"),
+		},
+	}
+	weekdays := types.TimeConstraints{
+		AccessWindow: &types.AccessWindow{
+			Timezone: "Australia/Sydney",
+			Days:     &[]types.AccessWindowDays{types.Monday, types.Tuesday, types.Wednesday, types.Thursday, types.Friday},
+		},
+	}
+	scheduling := types.TimeConstraints{
+		MinNoticeSeconds:  intPtr(3600),
+		MaxAdvanceSeconds: intPtr(86400),
+	}
+
+	testcases := []struct {
+		name   string
+		tc     types.TimeConstraints
+		timing AccessTiming
+		want   error
+	}{
+		{
+			name:   "no constraints",
+			timing: AccessTiming{Start: at(6, 23, 0), End: at(7, 2, 0)},
+		},
+		{
+			name:   "within business hours",
+			tc:     businessHours,
+			timing: AccessTiming{Start: at(1, 9, 0), End: at(1, 17, 0)},
+		},
+		{
+			name:   "starts before business hours",
+			tc:     businessHours,
+			timing: AccessTiming{Start: at(1, 8, 59), End: at(1, 10, 0)},
+			want:   &TimingError{Field: "timing.startTime", Message: "access can only start between 09:00 and 17:00 on monday, tuesday, wednesday, thursday, friday (Australia/Sydney)"},
+		},
+		{
+			name:   "runs past business hours",
+			tc:     businessHours,
+			timing: AccessTiming{Start: at(1, 16, 0), End: at(1, 17, 1)},
+			want:   &TimingError{Field: "timing.durationSeconds", Message: "access can only run between 09:00 and 17:00 on monday, tuesday, wednesday, thursday, friday (Australia/Sydney)"},
+		},
+		{
+			name:   "starts on a saturday",
+			tc:     businessHours,
+			timing: AccessTiming{Start: at(6, 10, 0), End: at(6, 11, 0)},
+			want:   &TimingError{Field: "timing.startTime", Message: "access can only start between 09:00 and 17:00 on monday, tuesday, wednesday, thursday, friday (Australia/Sydney)"},
+		},
+		{
+			name:   "window is in the timezone of the rule",
+			tc:     businessHours,
+			timing: AccessTiming{Start: time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2022, time.August, 1, 1, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:   "weekdays can run overnight",
+			tc:     weekdays,
+			timing: AccessTiming{Start: at(1, 22, 0), End: at(2, 2, 0)},
+		},
+		{
+			name:   "weekdays can't run into the weekend",
+			tc:     weekdays,
+			timing: AccessTiming{Start: at(5, 22, 0), End: at(6, 2, 0)},
+			want:   &TimingError{Field: "timing.durationSeconds", Message: "access can only run on monday, tuesday, wednesday, thursday, friday (Australia/Sydney)"},
+		},
+		{
+			name:   "scheduled with enough notice",
+			tc:     scheduling,
+			timing: AccessTiming{Start: at(1, 10, 0), End: at(1, 11, 0), Scheduled: true, ChosenAt: at(1, 9, 0)},
+		},
+		{
+			name:   "scheduled without enough notice",
+			tc:     scheduling,
+			timing: AccessTiming{Start: at(1, 9, 30), End: at(1, 11, 0), Scheduled: true, ChosenAt: at(1, 9, 0)},
+			want:   &TimingError{Field: "timing.startTime", Message: "access must be scheduled at least 1h0m0s in advance"},
+		},
+		{
+			name:   "scheduled too far in advance",
+			tc:     scheduling,
+			timing: AccessTiming{Start: at(3, 9, 0), End: at(3, 10, 0), Scheduled: true, ChosenAt: at(1, 9, 0)},
+			want:   &TimingError{Field: "timing.startTime", Message: "access can't be scheduled more than 24h0m0s in advance"},
+		},
+		{
+			name:   "notice doesn't apply to asap access",
+			tc:     scheduling,
+			timing: AccessTiming{Start: at(1, 9, 0), End: at(1, 10, 0), ChosenAt: at(1, 9, 0)},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, CheckTiming(tc.tc, tc.timing))
+		})
+	}
+}
+
+func TestValidateAccessWindow(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	testcases := []struct {
+		name    string
+		give    types.AccessWindow
+		wantErr string
+	}{
+		{
+			name: "ok",
+			give: types.AccessWindow{Timezone: "UTC", Days: &[]types.AccessWindowDays{types.Monday}, StartTime: strPtr("09:00"), EndTime: strPtr("17:30")},
+		},
+		{
+			name:    "invalid timezone",
+			give:    types.AccessWindow{Timezone: "Mars/Olympus"},
+			wantErr: "invalid timezone Mars/Olympus",
+		},
+		{
+			name:    "invalid day",
+			give:    types.AccessWindow{Timezone: "UTC", Days: &[]types.AccessWindowDays{"funday"}},
+			wantErr: "invalid day funday",
+		},
+		{
+			name:    "invalid time",
+			give:    types.AccessWindow{Timezone: "UTC", StartTime: strPtr("9am")},
+			wantErr: "invalid startTime: 9am is not in HH:MM format",
+		},
+		{
+			name:    "start after end",
+			give:    types.AccessWindow{Timezone: "UTC", StartTime: strPtr("17:00"), EndTime: strPtr("09:00")},
+			wantErr: "startTime must be before endTime",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateAccessWindow(tc.give)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/go-chi/chi/v5"
+)
+
+func (s *Server) listGroups(w http.ResponseWriter, r *http.Request) {
+	var attr, val string
+	if filter := r.URL.Query().Get("filter"); filter != "" {
+		var ok bool
+		attr, val, ok = parseEqFilter(filter)
+		if !ok || !(strings.EqualFold(attr, "displayName") || strings.EqualFold(attr, "externalId")) {
+			writeError(w, r, http.StatusBadRequest, "unsupported filter: "+filter)
+			return
+		}
+	}
+
+	groups, err := s.listActiveGroups(r.Context())
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	items := []interface{}{}
+	for _, g := range groups {
+		if strings.EqualFold(attr, "displayName") && g.Name != val {
+			continue
+		}
+		if strings.EqualFold(attr, "externalId") && g.IdpID != val {
+			continue
+		}
+		items = append(items, groupFromInternal(g))
+	}
+	writeJSON(w, http.StatusOK, page(r, items))
+}
+
+func (s *Server) getGroup(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.getGroupOrError(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, groupFromInternal(*g))
+}
+
+func (s *Server) createGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var in Group
+	err := decode(r, &in)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if in.DisplayName == "" {
+		writeError(w, r, http.StatusBadRequest, "displayName is required")
+		return
+	}
+
+	// the external ID is used as the group ID if it's provided, so that
+	// the group ID matches the identity provider and can be used in access rules.
+	// Otherwise the display name is used, so that a group which is deleted and provisioned again
+	// keeps its ID, rather than breaking the access rules which refer to it.
+	id := in.ExternalID
+	if id == "" {
+		id = in.DisplayName
+	}
+
+	now := s.Clock.Now()
+	existing, err := s.lookupGroup(ctx, id)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var g identity.Group
+	if existing != nil {
+		if existing.Status == types.IdpStatusACTIVE {
+			writeError(w, r, http.StatusConflict, "a group with this externalId or displayName already exists")
+			return
+		}
+		g = *existing
+	} else {
+		g = identity.Group{
+			ID:        id,
+			IdpID:     id,
+			Users:     []string{},
+			CreatedAt: now,
+		}
+	}
+	g.Status = types.IdpStatusACTIVE
+
+	err = s.saveGroup(ctx, &g, in, now)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, groupFromInternal(g))
+}
+
+func (s *Server) replaceGroup(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.getGroupOrError(w, r)
+	if !ok {
+		return
+	}
+	var in Group
+	err := decode(r, &in)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	err = s.saveGroup(r.Context(), g, in, s.Clock.Now())
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, groupFromInternal(*g))
+}
+
+func (s *Server) patchGroup(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.getGroupOrError(w, r)
+	if !ok {
+		return
+	}
+	var patch PatchRequest
+	err := decode(r, &patch)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	in := groupFromInternal(*g)
+	for _, op := range patch.Operations {
+		err = patchGroupAttribute(&in, op)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	err = s.saveGroup(r.Context(), g, in, s.Clock.Now())
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, groupFromInternal(*g))
+}
+
+// deleteGroup archives the group and removes all of its members.
+func (s *Server) deleteGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	g, ok := s.getGroupOrError(w, r)
+	if !ok {
+		return
+	}
+	now := s.Clock.Now()
+	users, err := s.setGroupMembers(ctx, g, []string{}, now)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	g.Status = types.IdpStatusARCHIVED
+
+	err = s.DB.PutBatch(ctx, append([]ddb.Keyer{g}, users...)...)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listActiveGroups returns every page of active groups, so that startIndex and count apply to all of them.
+func (s *Server) listActiveGroups(ctx context.Context) ([]identity.Group, error) {
+	var res []identity.Group
+	var opts []func(*ddb.QueryOpts)
+	for {
+		q := storage.ListGroupsForStatus{Status: types.IdpStatusACTIVE}
+		qr, err := s.DB.Query(ctx, &q, opts...)
+		if err != nil && err != ddb.ErrNoItems {
+			return nil, err
+		}
+		res = append(res, q.Result...)
+		if qr == nil || qr.NextPage == "" {
+			return res, nil
+		}
+		opts = []func(*ddb.QueryOpts){ddb.Page(qr.NextPage)}
+	}
+}
+
+// getGroupOrError returns the group in the URL. Archived groups are treated as not found.
+func (s *Server) getGroupOrError(w http.ResponseWriter, r *http.Request) (*identity.Group, bool) {
+	g, err := s.lookupGroup(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	if g == nil || g.Status == types.IdpStatusARCHIVED {
+		writeError(w, r, http.StatusNotFound, "group not found")
+		return nil, false
+	}
+	return g, true
+}
+
+// lookupGroup returns nil if the group doesn't exist.
+func (s *Server) lookupGroup(ctx context.Context, id string) (*identity.Group, error) {
+	q := storage.GetGroup{ID: id}
+	_, err := s.DB.Query(ctx, &q)
+	if err == ddb.ErrNoItems {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return q.Result, nil
+}
+
+// saveGroup updates a group and its members from a SCIM group and saves it.
+func (s *Server) saveGroup(ctx context.Context, g *identity.Group, in Group, now time.Time) error {
+	g.Name = in.DisplayName
+	userIDs := []string{}
+	for _, m := range in.Members {
+		userIDs = append(userIDs, m.Value)
+	}
+	users, err := s.setGroupMembers(ctx, g, userIDs, now)
+	if err != nil {
+		return err
+	}
+	return s.DB.PutBatch(ctx, append([]ddb.Keyer{g}, users...)...)
+}
+
+// patchGroupAttribute applies a patch operation to a group.
+func patchGroupAttribute(g *Group, op PatchOperation) error {
+	path := strings.ToLower(op.Path)
+
+	// Azure AD removes members with a path of 'members[value eq "id"]' and no value.
+	if strings.HasPrefix(path, "members[") {
+		_, id, ok := parseEqFilter(strings.TrimSuffix(op.Path[len("members["):], "]"))
+		if !ok || !strings.EqualFold(op.Op, "remove") {
+			return errors.New("unsupported path: " + op.Path)
+		}
+		g.Members = removeMembers(g.Members, []MemberRef{{Value: id}})
+		return nil
+	}
+
+	switch path {
+	case "":
+		if strings.EqualFold(op.Op, "remove") {
+			return errors.New("a path is required for remove operations")
+		}
+		var attrs map[string]json.RawMessage
+		err := json.Unmarshal(op.Value, &attrs)
+		if err != nil {
+			return err
+		}
+		for k, v := range attrs {
+			err = patchGroupAttribute(g, PatchOperation{Op: op.Op, Path: k, Value: v})
+			if err != nil {
+				return err
+			}
+		}
+	case "displayname":
+		return json.Unmarshal(op.Value, &g.DisplayName)
+	case "externalid":
+		// the external ID is used as the group ID, so it can't be changed.
+		return nil
+	case "members":
+		var members []MemberRef
+		if len(op.Value) > 0 {
+			err := json.Unmarshal(op.Value, &members)
+			if err != nil {
+				return err
+			}
+		}
+		switch strings.ToLower(op.Op) {
+		case "add":
+			g.Members = append(removeMembers(g.Members, members), members...)
+		case "remove":
+			if len(members) == 0 {
+				g.Members = []MemberRef{}
+			} else {
+				g.Members = removeMembers(g.Members, members)
+			}
+		case "replace":
+			g.Members = members
+		default:
+			return errors.New("unsupported operation: " + op.Op)
+		}
+	}
+	return nil
+}
+
+func removeMembers(members []MemberRef, remove []MemberRef) []MemberRef {
+	res := []MemberRef{}
+	for _, m := range members {
+		found := false
+		for _, r := range remove {
+			if m.Value == r.Value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			res = append(res, m)
+		}
+	}
+	return res
+}
@@ -0,0 +1,120 @@
+package scim
+
+import (
+	"context"
+	"time"
+
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+)
+
+// setGroupMembers sets the members of a group, updating the groups of each user which was added or removed.
+// Users who don't exist are ignored. It returns the users which need to be saved along with the group.
+func (s *Server) setGroupMembers(ctx context.Context, g *identity.Group, userIDs []string, now time.Time) ([]ddb.Keyer, error) {
+	wanted := make(map[string]bool)
+	for _, id := range userIDs {
+		wanted[id] = true
+	}
+	current := make(map[string]bool)
+	for _, id := range g.Users {
+		current[id] = true
+	}
+
+	members := []string{}
+	items := []ddb.Keyer{}
+	for _, id := range userIDs {
+		if current[id] {
+			members = append(members, id)
+			continue
+		}
+		// the user isn't currently a member, so add the group to their groups
+		u, err := s.lookupUser(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if u == nil {
+			continue
+		}
+		if !containsString(u.Groups, g.ID) {
+			u.Groups = append(u.Groups, g.ID)
+			u.UpdatedAt = now
+			items = append(items, u)
+		}
+		members = append(members, id)
+		current[id] = true
+	}
+
+	for _, id := range g.Users {
+		if wanted[id] {
+			continue
+		}
+		u, err := s.lookupUser(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if u == nil {
+			continue
+		}
+		u.Groups = removeString(u.Groups, g.ID)
+		u.UpdatedAt = now
+		items = append(items, u)
+	}
+
+	g.Users = members
+	g.UpdatedAt = now
+	return items, nil
+}
+
+// removeUserFromGroups removes a user from all of their groups.
+// It returns the groups which need to be saved along with the user.
+func (s *Server) removeUserFromGroups(ctx context.Context, u *identity.User, now time.Time) ([]ddb.Keyer, error) {
+	items := []ddb.Keyer{}
+	for _, id := range u.Groups {
+		q := storage.GetGroup{ID: id}
+		_, err := s.DB.Query(ctx, &q)
+		if err == ddb.ErrNoItems {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		q.Result.Users = removeString(q.Result.Users, u.ID)
+		q.Result.UpdatedAt = now
+		items = append(items, q.Result)
+	}
+	u.Groups = []string{}
+	return items, nil
+}
+
+// lookupUser returns nil if the user doesn't exist.
+func (s *Server) lookupUser(ctx context.Context, id string) (*identity.User, error) {
+	q := storage.GetUser{ID: id}
+	_, err := s.DB.Query(ctx, &q)
+	if err == ddb.ErrNoItems {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return q.Result, nil
+}
+
+func containsString(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(s []string, e string) []string {
+	res := []string{}
+	for _, a := range s {
+		if a != e {
+			res = append(res, a)
+		}
+	}
+	return res
+}
@@ -0,0 +1,390 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/ddb/ddbmock"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T, db ddb.Storage) http.Handler {
+	c := clock.NewMock()
+	c.Set(time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC))
+	s := Server{DB: db, Clock: c, Token: "secret"}
+	return s.Handler()
+}
+
+func TestAuthenticate(t *testing.T) {
+	type testcase struct {
+		name     string
+		header   string
+		wantCode int
+	}
+
+	testcases := []testcase{
+		{name: "ok", header: "Bearer secret", wantCode: http.StatusOK},
+		{name: "wrong token", header: "Bearer other", wantCode: http.StatusUnauthorized},
+		{name: "no token", wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newTestServer(t, ddbmock.New(t))
+			req := httptest.NewRequest("GET", "/ServiceProviderConfig", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			assert.Equal(t, tc.wantCode, rr.Code)
+		})
+	}
+}
+
+func TestCreateUser(t *testing.T) {
+	type testcase struct {
+		name     string
+		existing *identity.User
+		body     string
+		wantCode int
+		wantID   string
+	}
+
+	testcases := []testcase{
+		{
+			name:     "new user",
+			body:     `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"userName":"alice@example.com","name":{"givenName":"Alice","familyName":"Smith"},"active":true}`,
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:     "active user already exists",
+			existing: &identity.User{ID: "usr_123", Email: "alice@example.com", Status: types.IdpStatusACTIVE},
+			body:     `{"userName":"alice@example.com"}`,
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:     "archived user is reactivated",
+			existing: &identity.User{ID: "usr_123", Email: "alice@example.com", Status: types.IdpStatusARCHIVED},
+			body:     `{"userName":"alice@example.com"}`,
+			wantCode: http.StatusCreated,
+			wantID:   "usr_123",
+		},
+		{
+			name:     "missing userName",
+			body:     `{"name":{"givenName":"Alice"}}`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			if tc.existing != nil {
+				db.MockQuery(&storage.GetUserByEmail{Result: tc.existing})
+			} else {
+				db.MockQueryWithErr(&storage.GetUserByEmail{}, ddb.ErrNoItems)
+			}
+			handler := newTestServer(t, db)
+
+			req := httptest.NewRequest("POST", "/Users", strings.NewReader(tc.body))
+			req.Header.Set("Authorization", "Bearer secret")
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+			if rr.Code != http.StatusCreated {
+				return
+			}
+			var got User
+			err := json.NewDecoder(rr.Body).Decode(&got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, "alice@example.com", got.UserName)
+			assert.True(t, *got.Active)
+			if tc.wantID != "" {
+				assert.Equal(t, tc.wantID, got.ID)
+			}
+		})
+	}
+}
+
+func TestPatchUserAttribute(t *testing.T) {
+	type testcase struct {
+		name  string
+		path  string
+		value string
+		want  User
+	}
+
+	active := true
+	inactive := false
+
+	testcases := []testcase{
+		{
+			name:  "deactivate",
+			path:  "active",
+			value: `false`,
+			want:  User{Active: &inactive},
+		},
+		{
+			name:  "deactivate with string value",
+			path:  "active",
+			value: `"False"`,
+			want:  User{Active: &inactive},
+		},
+		{
+			name:  "work email",
+			path:  `emails[type eq "work"].value`,
+			value: `"bob@example.com"`,
+			want:  User{Active: &active, Emails: []Email{{Value: "bob@example.com", Type: "work", Primary: true}}},
+		},
+		{
+			name:  "no path",
+			value: `{"name.givenName":"Bob","active":false}`,
+			want:  User{Active: &inactive, Name: Name{GivenName: "Bob"}},
+		},
+		{
+			name:  "unsupported attributes are ignored",
+			path:  "title",
+			value: `"Engineer"`,
+			want:  User{Active: &active},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			u := User{Active: &active}
+			err := patchUserAttribute(&u, tc.path, json.RawMessage(tc.value))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.want, u)
+		})
+	}
+}
+
+func TestPatchGroupAttribute(t *testing.T) {
+	type testcase struct {
+		name string
+		op   PatchOperation
+		want Group
+	}
+
+	testcases := []testcase{
+		{
+			name: "add members",
+			op:   PatchOperation{Op: "add", Path: "members", Value: json.RawMessage(`[{"value":"usr_2"},{"value":"usr_1"}]`)},
+			want: Group{DisplayName: "admins", Members: []MemberRef{{Value: "usr_2"}, {Value: "usr_1"}}},
+		},
+		{
+			name: "remove members",
+			op:   PatchOperation{Op: "remove", Path: "members", Value: json.RawMessage(`[{"value":"usr_1"}]`)},
+			want: Group{DisplayName: "admins", Members: []MemberRef{}},
+		},
+		{
+			name: "remove member with filter",
+			op:   PatchOperation{Op: "Remove", Path: `members[value eq "usr_1"]`},
+			want: Group{DisplayName: "admins", Members: []MemberRef{}},
+		},
+		{
+			name: "replace members",
+			op:   PatchOperation{Op: "replace", Path: "members", Value: json.RawMessage(`[{"value":"usr_3"}]`)},
+			want: Group{DisplayName: "admins", Members: []MemberRef{{Value: "usr_3"}}},
+		},
+		{
+			name: "rename",
+			op:   PatchOperation{Op: "replace", Value: json.RawMessage(`{"displayName":"engineers"}`)},
+			want: Group{DisplayName: "engineers", Members: []MemberRef{{Value: "usr_1"}}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := Group{DisplayName: "admins", Members: []MemberRef{{Value: "usr_1"}}}
+			err := patchGroupAttribute(&g, tc.op)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.want, g)
+		})
+	}
+}
+
+func TestListGroups(t *testing.T) {
+	groups := []identity.Group{
+		{ID: "grp_1", IdpID: "grp_1", Name: "admins", Status: types.IdpStatusACTIVE, Users: []string{"usr_1"}},
+		{ID: "grp_2", IdpID: "grp_2", Name: "engineers", Status: types.IdpStatusACTIVE, Users: []string{}},
+	}
+
+	type testcase struct {
+		name     string
+		query    string
+		wantCode int
+		wantIDs  []string
+	}
+
+	testcases := []testcase{
+		{name: "all", wantCode: http.StatusOK, wantIDs: []string{"grp_1", "grp_2", "grp_3"}},
+		{name: "displayName filter", query: `?filter=displayName+eq+"engineers"`, wantCode: http.StatusOK, wantIDs: []string{"grp_2"}},
+		{name: "paginated", query: "?startIndex=2&count=1", wantCode: http.StatusOK, wantIDs: []string{"grp_2"}},
+		{name: "paginated across database pages", query: "?startIndex=3&count=1", wantCode: http.StatusOK, wantIDs: []string{"grp_3"}},
+		{name: "unsupported filter", query: `?filter=members+co+"usr_1"`, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := &pagedDB{Client: ddbmock.New(t), groups: map[string][]identity.Group{
+				"":  groups,
+				"x": {{ID: "grp_3", IdpID: "grp_3", Name: "sre", Status: types.IdpStatusACTIVE, Users: []string{}}},
+			}}
+			handler := newTestServer(t, db)
+
+			req := httptest.NewRequest("GET", "/Groups"+tc.query, nil)
+			req.Header.Set("Authorization", "Bearer secret")
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				return
+			}
+			var got struct {
+				TotalResults int     `json:"totalResults"`
+				Resources    []Group `json:"Resources"`
+			}
+			err := json.NewDecoder(rr.Body).Decode(&got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, g := range got.Resources {
+				ids = append(ids, g.ID)
+			}
+			assert.Equal(t, tc.wantIDs, ids)
+		})
+	}
+}
+
+func TestPatchUser(t *testing.T) {
+	alice := identity.User{ID: "usr_1", Email: "alice@example.com", Status: types.IdpStatusACTIVE, Groups: []string{}}
+
+	type testcase struct {
+		name      string
+		body      string
+		existing  *identity.User
+		wantCode  int
+		wantEmail string
+	}
+
+	testcases := []testcase{
+		{
+			name:      "userName changes the email",
+			body:      `{"Operations":[{"op":"replace","path":"userName","value":"alice.smith@example.com"}]}`,
+			wantCode:  http.StatusOK,
+			wantEmail: "alice.smith@example.com",
+		},
+		{
+			name:      "work email changes the email",
+			body:      `{"Operations":[{"op":"replace","path":"emails[type eq \"work\"].value","value":"alice.smith@example.com"}]}`,
+			wantCode:  http.StatusOK,
+			wantEmail: "alice.smith@example.com",
+		},
+		{
+			name:     "email belongs to another user",
+			body:     `{"Operations":[{"op":"replace","path":"userName","value":"bob@example.com"}]}`,
+			existing: &identity.User{ID: "usr_2", Email: "bob@example.com", Status: types.IdpStatusACTIVE},
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:      "other attributes keep the email",
+			body:      `{"Operations":[{"op":"replace","path":"name.givenName","value":"Alicia"}]}`,
+			wantCode:  http.StatusOK,
+			wantEmail: "alice@example.com",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			u := alice
+			db.MockQuery(&storage.GetUser{Result: &u})
+			if tc.existing != nil {
+				db.MockQuery(&storage.GetUserByEmail{Result: tc.existing})
+			} else {
+				db.MockQueryWithErr(&storage.GetUserByEmail{}, ddb.ErrNoItems)
+			}
+			handler := newTestServer(t, db)
+
+			req := httptest.NewRequest("PATCH", "/Users/usr_1", strings.NewReader(tc.body))
+			req.Header.Set("Authorization", "Bearer secret")
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.wantCode, rr.Code)
+			if rr.Code != http.StatusOK {
+				return
+			}
+			var got User
+			err := json.NewDecoder(rr.Body).Decode(&got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.wantEmail, got.UserName)
+		})
+	}
+}
+
+func TestCreateGroupWithoutExternalID(t *testing.T) {
+	db := ddbmock.New(t)
+	db.MockQueryWithErr(&storage.GetGroup{}, ddb.ErrNoItems)
+	handler := newTestServer(t, db)
+
+	req := httptest.NewRequest("POST", "/Groups", strings.NewReader(`{"displayName":"engineers","members":[]}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var got Group
+	err := json.NewDecoder(rr.Body).Decode(&got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the group keeps the same ID if it's deleted and provisioned again.
+	assert.Equal(t, "engineers", got.ID)
+}
+
+// pagedDB returns the groups in pages, keyed by the page token.
+type pagedDB struct {
+	*ddbmock.Client
+	groups map[string][]identity.Group
+}
+
+func (p *pagedDB) Query(ctx context.Context, qb ddb.QueryBuilder, opts ...func(*ddb.QueryOpts)) (*ddb.QueryResult, error) {
+	q, ok := qb.(*storage.ListGroupsForStatus)
+	if !ok {
+		return p.Client.Query(ctx, qb, opts...)
+	}
+	var o ddb.QueryOpts
+	for _, opt := range opts {
+		opt(&o)
+	}
+	q.Result = p.groups[o.PageToken]
+	next := o.PageToken + "x"
+	if _, ok := p.groups[next]; !ok {
+		next = ""
+	}
+	return &ddb.QueryResult{NextPage: next}, nil
+}
@@ -0,0 +1,158 @@
+// Package scim implements a SCIM 2.0 server which identity providers use to provision users and groups.
+//
+// Users and groups are written directly to the database when they are pushed by the identity provider,
+// so deployments using SCIM don't need to poll the identity provider for changes.
+//
+// see: https://datatracker.ietf.org/doc/html/rfc7644
+package scim
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/benbjohnson/clock"
+	"github.com/common-fate/apikit/logger"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type Server struct {
+	DB    ddb.Storage
+	Clock clock.Clock
+	// Token is the bearer token which requests must be authenticated with.
+	Token string
+	// Offboarder is called when a user is deactivated. It's optional.
+	Offboarder Offboarder
+}
+
+// Offboarder cancels the requests and revokes the grants of users who are deactivated.
+type Offboarder interface {
+	Offboard(ctx context.Context, user identity.User, dryRun bool) (*identity.Offboarding, error)
+}
+
+// Handler returns the SCIM API routes. It should be mounted at /scim/v2.
+func (s *Server) Handler() http.Handler {
+	r := chi.NewRouter()
+	r.Use(s.authenticate)
+
+	r.Get("/ServiceProviderConfig", s.getServiceProviderConfig)
+
+	r.Get("/Users", s.listUsers)
+	r.Post("/Users", s.createUser)
+	r.Get("/Users/{id}", s.getUser)
+	r.Put("/Users/{id}", s.replaceUser)
+	r.Patch("/Users/{id}", s.patchUser)
+	r.Delete("/Users/{id}", s.deleteUser)
+
+	r.Get("/Groups", s.listGroups)
+	r.Post("/Groups", s.createGroup)
+	r.Get("/Groups/{id}", s.getGroup)
+	r.Put("/Groups/{id}", s.replaceGroup)
+	r.Patch("/Groups/{id}", s.patchGroup)
+	r.Delete("/Groups/{id}", s.deleteGroup)
+	return r
+}
+
+// authenticate rejects requests which don't include the configured bearer token.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if s.Token == "" || subtle.ConstantTimeCompare([]byte(s.Token), []byte(token)) != 1 {
+			writeError(w, r, http.StatusUnauthorized, "invalid bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) getServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{"urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"},
+		"patch":          map[string]bool{"supported": true},
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": 200},
+		"changePassword": map[string]bool{"supported": false},
+		"sort":           map[string]bool{"supported": false},
+		"etag":           map[string]bool{"supported": false},
+		"authenticationSchemes": []map[string]string{
+			{"type": "oauthbearertoken", "name": "OAuth Bearer Token", "description": "Authentication using a bearer token"},
+		},
+	})
+}
+
+// Error is a SCIM error response.
+type Error struct {
+	Schemas []string `json:"schemas"`
+	Status  string   `json:"status"`
+	Detail  string   `json:"detail"`
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	if status >= http.StatusInternalServerError {
+		logger.Get(r.Context()).Errorw("scim error", zap.String("detail", detail))
+		detail = http.StatusText(status)
+	}
+	writeJSON(w, status, Error{
+		Schemas: []string{SchemaError},
+		Status:  strconv.Itoa(status),
+		Detail:  detail,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// decode decodes a SCIM request body.
+func decode(r *http.Request, v interface{}) error {
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+// page applies the startIndex and count query parameters to a list of resources.
+// startIndex is 1-based, as defined in the SCIM specification.
+func page(r *http.Request, items []interface{}) ListResponse {
+	startIndex := 1
+	if v, err := strconv.Atoi(r.URL.Query().Get("startIndex")); err == nil && v > 1 {
+		startIndex = v
+	}
+	count := len(items)
+	if v, err := strconv.Atoi(r.URL.Query().Get("count")); err == nil && v >= 0 {
+		count = v
+	}
+
+	start := startIndex - 1
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + count
+	if end > len(items) {
+		end = len(items)
+	}
+
+	res := ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: len(items),
+		StartIndex:   startIndex,
+		ItemsPerPage: end - start,
+		Resources:    append([]interface{}{}, items[start:end]...),
+	}
+	return res
+}
+
+// parseEqFilter parses a filter in the format 'attribute eq "value"', which is the only filter
+// identity providers use when provisioning. It returns false if the filter is in a different format.
+func parseEqFilter(filter string) (attribute string, value string, ok bool) {
+	parts := strings.SplitN(filter, " ", 3)
+	if len(parts) != 3 || !strings.EqualFold(parts[1], "eq") {
+		return "", "", false
+	}
+	return parts[0], strings.Trim(parts[2], `"`), true
+}
@@ -0,0 +1,129 @@
+package scim
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+const (
+	SchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+type User struct {
+	Schemas    []string `json:"schemas"`
+	ID         string   `json:"id,omitempty"`
+	ExternalID string   `json:"externalId,omitempty"`
+	// UserName is the user's email address.
+	UserName string  `json:"userName"`
+	Name     Name    `json:"name"`
+	Emails   []Email `json:"emails,omitempty"`
+	// Active defaults to true if it isn't provided.
+	Active *bool       `json:"active,omitempty"`
+	Groups []MemberRef `json:"groups,omitempty"`
+	Meta   *Meta       `json:"meta,omitempty"`
+}
+
+type Name struct {
+	GivenName  string `json:"givenName"`
+	FamilyName string `json:"familyName"`
+}
+
+type Email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type Group struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	DisplayName string      `json:"displayName"`
+	Members     []MemberRef `json:"members"`
+	Meta        *Meta       `json:"meta,omitempty"`
+}
+
+// MemberRef refers to a member of a group, or a group which a user belongs to.
+type MemberRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+type Meta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+type ListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// email returns the user's primary email, falling back to their user name.
+func (u User) email() string {
+	for _, e := range u.Emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	return u.UserName
+}
+
+func (u User) active() bool {
+	return u.Active == nil || *u.Active
+}
+
+func userFromInternal(u identity.User) User {
+	active := u.Status != types.IdpStatusARCHIVED
+	res := User{
+		Schemas:  []string{SchemaUser},
+		ID:       u.ID,
+		UserName: u.Email,
+		Name:     Name{GivenName: u.FirstName, FamilyName: u.LastName},
+		Emails:   []Email{{Value: u.Email, Type: "work", Primary: true}},
+		Active:   &active,
+		Groups:   []MemberRef{},
+		Meta:     &Meta{ResourceType: "User", Created: u.CreatedAt, LastModified: u.UpdatedAt},
+	}
+	for _, g := range u.Groups {
+		res.Groups = append(res.Groups, MemberRef{Value: g})
+	}
+	return res
+}
+
+func groupFromInternal(g identity.Group) Group {
+	res := Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          g.ID,
+		ExternalID:  g.IdpID,
+		DisplayName: g.Name,
+		Members:     []MemberRef{},
+		Meta:        &Meta{ResourceType: "Group", Created: g.CreatedAt, LastModified: g.UpdatedAt},
+	}
+	for _, u := range g.Users {
+		res.Members = append(res.Members, MemberRef{Value: u})
+	}
+	return res
+}
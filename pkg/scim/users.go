@@ -0,0 +1,309 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/go-chi/chi/v5"
+)
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var users []identity.User
+
+	if filter := r.URL.Query().Get("filter"); filter != "" {
+		attr, val, ok := parseEqFilter(filter)
+		if !ok || !strings.EqualFold(attr, "userName") {
+			writeError(w, r, http.StatusBadRequest, "unsupported filter: "+filter)
+			return
+		}
+		q := storage.GetUserByEmail{Email: val}
+		_, err := s.DB.Query(ctx, &q)
+		if err != nil && err != ddb.ErrNoItems {
+			writeError(w, r, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if err == nil {
+			users = append(users, *q.Result)
+		}
+	} else {
+		var err error
+		users, err = s.listAllUsers(ctx)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
+	items := make([]interface{}, len(users))
+	for i, u := range users {
+		items[i] = userFromInternal(u)
+	}
+	writeJSON(w, http.StatusOK, page(r, items))
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	u, ok := s.getUserOrError(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, userFromInternal(*u))
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var in User
+	err := decode(r, &in)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if in.email() == "" {
+		writeError(w, r, http.StatusBadRequest, "userName is required")
+		return
+	}
+
+	now := s.Clock.Now()
+	q := storage.GetUserByEmail{Email: in.email()}
+	_, err = s.DB.Query(ctx, &q)
+	if err != nil && err != ddb.ErrNoItems {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var u identity.User
+	if err == nil {
+		if q.Result.Status == types.IdpStatusACTIVE {
+			writeError(w, r, http.StatusConflict, "a user with this userName already exists")
+			return
+		}
+		// reuse archived users, so that their access request history is kept.
+		u = *q.Result
+	} else {
+		u = identity.User{
+			ID:        types.NewUserID(),
+			Groups:    []string{},
+			CreatedAt: now,
+		}
+	}
+
+	err = s.saveUser(ctx, &u, in, now)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, userFromInternal(u))
+}
+
+func (s *Server) replaceUser(w http.ResponseWriter, r *http.Request) {
+	u, ok := s.getUserOrError(w, r)
+	if !ok {
+		return
+	}
+	var in User
+	err := decode(r, &in)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.updateUser(w, r, u, in)
+}
+
+func (s *Server) patchUser(w http.ResponseWriter, r *http.Request) {
+	u, ok := s.getUserOrError(w, r)
+	if !ok {
+		return
+	}
+	var patch PatchRequest
+	err := decode(r, &patch)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	in := userFromInternal(*u)
+	// the user's email is both their user name and primary email, so the emails are cleared
+	// to let a patch to the user name change the email, unless the patch also sets the email.
+	in.Emails = nil
+	for _, op := range patch.Operations {
+		if strings.EqualFold(op.Op, "remove") {
+			continue
+		}
+		err = patchUserAttribute(&in, op.Path, op.Value)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	s.updateUser(w, r, u, in)
+}
+
+// updateUser saves the changes to an existing user.
+// Emails are unique, so changing a user's email to the email of another user is a conflict.
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request, u *identity.User, in User) {
+	ctx := r.Context()
+	if in.email() == "" {
+		writeError(w, r, http.StatusBadRequest, "userName is required")
+		return
+	}
+	if in.email() != u.Email {
+		q := storage.GetUserByEmail{Email: in.email()}
+		_, err := s.DB.Query(ctx, &q)
+		if err != nil && err != ddb.ErrNoItems {
+			writeError(w, r, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if err == nil && q.Result.ID != u.ID {
+			writeError(w, r, http.StatusConflict, "a user with this userName already exists")
+			return
+		}
+	}
+	err := s.saveUser(ctx, u, in, s.Clock.Now())
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, userFromInternal(*u))
+}
+
+// deleteUser archives the user rather than deleting them, so that their access request history is kept.
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	u, ok := s.getUserOrError(w, r)
+	if !ok {
+		return
+	}
+	in := userFromInternal(*u)
+	inactive := false
+	in.Active = &inactive
+
+	err := s.saveUser(r.Context(), u, in, s.Clock.Now())
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listAllUsers returns every page of users, so that startIndex and count apply to all of them.
+func (s *Server) listAllUsers(ctx context.Context) ([]identity.User, error) {
+	var res []identity.User
+	var opts []func(*ddb.QueryOpts)
+	for {
+		q := storage.ListUsers{}
+		qr, err := s.DB.Query(ctx, &q, opts...)
+		if err != nil && err != ddb.ErrNoItems {
+			return nil, err
+		}
+		res = append(res, q.Result...)
+		if qr == nil || qr.NextPage == "" {
+			return res, nil
+		}
+		opts = []func(*ddb.QueryOpts){ddb.Page(qr.NextPage)}
+	}
+}
+
+func (s *Server) getUserOrError(w http.ResponseWriter, r *http.Request) (*identity.User, bool) {
+	u, err := s.lookupUser(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	if u == nil {
+		writeError(w, r, http.StatusNotFound, "user not found")
+		return nil, false
+	}
+	return u, true
+}
+
+// saveUser updates a user from a SCIM user and saves it.
+// Deactivated users are archived and removed from all of their groups, in the same way as a full sync.
+func (s *Server) saveUser(ctx context.Context, u *identity.User, in User, now time.Time) error {
+	u.Email = in.email()
+	u.FirstName = in.Name.GivenName
+	u.LastName = in.Name.FamilyName
+	u.UpdatedAt = now
+
+	items := []ddb.Keyer{u}
+	if in.active() {
+		u.Status = types.IdpStatusACTIVE
+	} else {
+		if s.Offboarder != nil && u.Status == types.IdpStatusACTIVE {
+			_, err := s.Offboarder.Offboard(ctx, *u, false)
+			if err != nil {
+				return err
+			}
+		}
+		u.Status = types.IdpStatusARCHIVED
+		groups, err := s.removeUserFromGroups(ctx, u, now)
+		if err != nil {
+			return err
+		}
+		items = append(items, groups...)
+	}
+	return s.DB.PutBatch(ctx, items...)
+}
+
+// patchUserAttribute applies an add or replace operation to a user.
+// Attributes which aren't stored, such as a user's title, are ignored.
+func patchUserAttribute(u *User, path string, value json.RawMessage) error {
+	switch strings.ToLower(path) {
+	case "":
+		// the value is an object containing the attributes to replace.
+		var attrs map[string]json.RawMessage
+		err := json.Unmarshal(value, &attrs)
+		if err != nil {
+			return err
+		}
+		for k, v := range attrs {
+			err = patchUserAttribute(u, k, v)
+			if err != nil {
+				return err
+			}
+		}
+	case "active":
+		active, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		u.Active = &active
+	case "username":
+		return json.Unmarshal(value, &u.UserName)
+	case "name":
+		return json.Unmarshal(value, &u.Name)
+	case "name.givenname":
+		return json.Unmarshal(value, &u.Name.GivenName)
+	case "name.familyname":
+		return json.Unmarshal(value, &u.Name.FamilyName)
+	case `emails[type eq "work"].value`:
+		var email string
+		err := json.Unmarshal(value, &email)
+		if err != nil {
+			return err
+		}
+		u.Emails = []Email{{Value: email, Type: "work", Primary: true}}
+	}
+	return nil
+}
+
+// parseBool parses a boolean which may be sent as a string, as Azure AD does.
+func parseBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var str string
+	err := json.Unmarshal(value, &str)
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(strings.ToLower(str))
+}
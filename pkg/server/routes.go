@@ -2,6 +2,7 @@ package server
 
 import (
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/common-fate/apikit/logger"
@@ -13,8 +14,14 @@ import (
 	"github.com/go-chi/cors"
 )
 
+// ServicePathPrefix is the prefix of API paths for requests authenticated with an API key.
+// In production these paths don't use the API Gateway Cognito authorizer, as API keys are verified by auth.Middleware.
+// The prefix is removed before routing, so /service/api/v1/requests is handled in the same way as /api/v1/requests.
+const ServicePathPrefix = "/service"
+
 func (c *Server) Handler() http.Handler {
 	r := chi.NewRouter()
+	r.Use(stripServicePathPrefix)
 	r.Use(c.requestIDMiddleware)
 	r.Use(chiMiddleware.RealIP)
 	r.Use(chiMiddleware.Recoverer)
@@ -29,8 +36,19 @@ func (c *Server) Handler() http.Handler {
 		MaxAge:           300,
 	}))
 	r.Use(auth.Middleware(c.authenticator, c.db, c.identitySyncer))
-	r.Use(auth.AdminAuthorizer(c.cfg.AdminGroup))
+	r.Use(auth.AdminAuthorizer(c.cfg.AdminGroup, c.adminRoles, c.swagger))
 	r.Use(openapi.Validator(c.swagger))
 
 	return c.api.Handler(r)
 }
+
+func stripServicePathPrefix(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, ServicePathPrefix+"/") {
+			r.URL.Path = strings.TrimPrefix(r.URL.Path, ServicePathPrefix)
+			r.URL.RawPath = ""
+			r = r.WithContext(auth.WithServicePath(r.Context()))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/common-fate/ddb"
 	"github.com/common-fate/granted-approvals/pkg/auth"
 	"github.com/common-fate/granted-approvals/pkg/config"
+	"github.com/common-fate/granted-approvals/pkg/deploy"
 	"github.com/common-fate/granted-approvals/pkg/types"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
@@ -25,6 +26,7 @@ type Server struct {
 	identitySyncer      auth.IdentitySyncer
 	requestIDMiddleware func(next http.Handler) http.Handler
 	db                  ddb.Storage
+	adminRoles          deploy.AdminRoleAssignments
 }
 
 type Config struct {
@@ -68,6 +70,10 @@ func New(ctx context.Context, cfg Config, opts ...func(*Server)) (*Server, error
 	if err != nil {
 		return nil, err
 	}
+	adminRoles, err := deploy.UnmarshalAdminRoles(cfg.Config.AdminRoles)
+	if err != nil {
+		return nil, err
+	}
 	swagger, err := types.GetSwagger()
 	if err != nil {
 		return nil, err
@@ -76,14 +82,16 @@ func New(ctx context.Context, cfg Config, opts ...func(*Server)) (*Server, error
 	swagger.Servers = nil
 
 	s := Server{
-		log:                 log,
-		swagger:             swagger,
-		authenticator:       cfg.Authenticator,
+		log:     log,
+		swagger: swagger,
+		// requests with an API key are authenticated by the server, rather than by cfg.Authenticator.
+		authenticator:       &auth.APIKeyAuthenticator{DB: db, Authenticator: cfg.Authenticator},
 		cfg:                 cfg.Config,
 		api:                 cfg.API,
 		requestIDMiddleware: chiMiddleware.RequestID,
 		identitySyncer:      cfg.IdentitySyncer,
 		db:                  db,
+		adminRoles:          adminRoles,
 	}
 
 	for _, o := range opts {
@@ -61,7 +61,18 @@ func (s *Service) AddReviewAndGrantAccess(ctx context.Context, opts AddReviewOpt
 	case access.DecisionApproved:
 		request.Status = access.APPROVED
 		request.OverrideTiming = opts.OverrideTiming
-		start, end := request.GetInterval(access.WithNow(s.Clock.Now()))
+		now := s.Clock.Now()
+		// the rule may have changed since the request was made, and the reviewer may have overridden the timing,
+		// so the timing is checked against the time constraints of the current rule before access is granted.
+		timing, chosenAt := request.RequestedTiming, request.CreatedAt
+		if request.OverrideTiming != nil {
+			timing, chosenAt = *request.OverrideTiming, now
+		}
+		err := checkTiming(opts.AccessRule.TimeConstraints, timing, now, chosenAt)
+		if err != nil {
+			return nil, err
+		}
+		start, end := request.GetInterval(access.WithNow(now))
 		// this request must not overlap an existing grant for the user and rule
 		// This fetches all grants which end in the future, these may or may not have a grant associated yet.
 		rq := storage.ListRequestsForUserAndRuleAndRequestend{
@@ -70,7 +81,7 @@ func (s *Service) AddReviewAndGrantAccess(ctx context.Context, opts AddReviewOpt
 			RequestEndComparator: storage.GreaterThanEqual,
 			CompareTo:            end,
 		}
-		_, err := s.DB.Query(ctx, &rq)
+		_, err = s.DB.Query(ctx, &rq)
 		if err != nil && err != ddb.ErrNoItems {
 			return nil, err
 		}
@@ -2,12 +2,17 @@ package accesssvc
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/common-fate/apikit/apio"
 	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/rule"
 	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/types"
 
 	"github.com/common-fate/ddb/ddbmock"
 	"github.com/common-fate/granted-approvals/pkg/service/accesssvc/mocks"
@@ -98,6 +103,7 @@ func TestAddReview(t *testing.T) {
 		OverrideTiming: overrideTiming,
 		UpdatedAt:      clk.Now(),
 	}
+	nineAM := "09:00"
 	testcases := []testcase{
 		{
 			name: "ok",
@@ -181,6 +187,40 @@ func TestAddReview(t *testing.T) {
 				Request: requestWithOverride,
 			},
 		},
+		{
+			name: "override times outside the access window of the rule",
+			give: AddReviewOpts{
+				ReviewerID: "a",
+				Decision:   access.DecisionApproved,
+				Reviewers: []access.Reviewer{
+					{
+						ReviewerID: "a",
+						Request: access.Request{
+							Status: access.PENDING,
+						},
+					},
+				},
+				Request: access.Request{
+					Status: access.PENDING,
+				},
+				OverrideTiming: overrideTiming,
+				AccessRule: rule.AccessRule{
+					TimeConstraints: types.TimeConstraints{
+						AccessWindow: &types.AccessWindow{Timezone: "UTC", StartTime: &nineAM},
+					},
+				},
+			},
+			wantErr: &apio.APIError{
+				Err:    errors.New("request validation failed"),
+				Status: http.StatusBadRequest,
+				Fields: []apio.FieldError{
+					{
+						Field: "timing.startTime",
+						Error: "access can only start between 09:00 and 24:00 (UTC)",
+					},
+				},
+			},
+		},
 		{
 			name: "cannot review own request",
 			give: AddReviewOpts{
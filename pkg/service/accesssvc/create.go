@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/common-fate/apikit/apio"
 	"github.com/common-fate/apikit/logger"
@@ -27,9 +28,19 @@ type CreateRequestResult struct {
 	Reviewers []access.Reviewer
 }
 
+type CreateRequestOpts struct {
+	// User is the user who is requesting access.
+	User    *identity.User
+	Request types.CreateRequestRequest
+	// ActorID is the ID of the user or API key which created the request on behalf of the user.
+	// If it is empty, the user is recorded as the actor.
+	ActorID string
+}
+
 // CreateRequest creates a new request and saves it in the database.
 // Returns an error if the request is invalid.
-func (s *Service) CreateRequest(ctx context.Context, user *identity.User, in types.CreateRequestRequest) (*CreateRequestResult, error) {
+func (s *Service) CreateRequest(ctx context.Context, opts CreateRequestOpts) (*CreateRequestResult, error) {
+	user, in := opts.User, opts.Request
 	log := logger.Get(ctx).With("user.id", user.ID)
 	q := storage.GetAccessRuleCurrent{ID: in.AccessRuleId}
 	_, err := s.DB.Query(ctx, &q)
@@ -42,14 +53,26 @@ func (s *Service) CreateRequest(ctx context.Context, user *identity.User, in typ
 	}
 	rule := q.Result
 
-	log.Debugw("verifying user belongs to access rule groups", "rule.groups", rule.Groups, "user.groups", user.Groups)
-	err = groupMatches(rule.Groups, user.Groups)
+	// users can request access through groups they are a member of directly or through nested groups.
+	userGroups := user.EffectiveGroups()
+	log.Debugw("verifying user belongs to access rule groups", "rule.groups", rule.Groups, "user.groups", userGroups)
+	err = groupMatches(rule.Groups, userGroups)
 	if err != nil {
 		return nil, err
 	}
 
 	now := s.Clock.Now()
-	err = requestIsValid(in, rule)
+	err = requestIsValid(in, rule, now)
+	if err != nil {
+		return nil, err
+	}
+	timing := access.TimingFromRequestTiming(in.Timing)
+	start, _ := timing.GetInterval(access.WithNow(now))
+	err = s.checkLimits(ctx, user.ID, *rule, start)
+	if err != nil {
+		return nil, err
+	}
+	ticket, err := s.checkTicket(ctx, *rule, fieldValues(in.Fields))
 	if err != nil {
 		return nil, err
 	}
@@ -59,11 +82,12 @@ func (s *Service) CreateRequest(ctx context.Context, user *identity.User, in typ
 		RequestedBy: user.ID,
 		Data: access.RequestData{
 			Reason: in.Reason,
+			Fields: fieldValues(in.Fields),
 		},
 		CreatedAt:       now,
 		UpdatedAt:       now,
 		Status:          access.PENDING,
-		RequestedTiming: access.TimingFromRequestTiming(in.Timing),
+		RequestedTiming: timing,
 		Rule:            rule.ID,
 		RuleVersion:     rule.Version,
 		SelectedWith:    make(map[string]access.Option),
@@ -109,11 +133,46 @@ func (s *Service) CreateRequest(ctx context.Context, user *identity.User, in typ
 		}
 	}
 
+	// audit log event
+	actor := req.RequestedBy
+	if opts.ActorID != "" {
+		actor = opts.ActorID
+	}
+	reqEvent := access.NewRequestCreatedEvent(req.ID, req.CreatedAt, &actor)
+
+	// the policy of the rule can approve the request automatically, require approval or deny it.
+	policyResult, err := evaluatePolicy(*rule, user, req, now)
+	if err != nil {
+		return nil, err
+	}
+	var policyEvent *access.RequestEvent
+	if policyResult != nil {
+		e := access.NewRecordedEvent(req.ID, nil, now, policyResult.Event())
+		policyEvent = &e
+	}
+	if isDenied(policyResult) {
+		// denied requests are saved so that the policy decision is recorded in the audit log.
+		req.Status = access.DECLINED
+		err = s.DB.PutBatch(ctx, &req, &reqEvent, policyEvent)
+		if err != nil {
+			return nil, err
+		}
+		if policyResult.Description != "" {
+			return nil, fmt.Errorf("%w: %s", ErrRequestDeniedByPolicy, policyResult.Description)
+		}
+		return nil, ErrRequestDeniedByPolicy
+	}
+	autoApprove := !requiresApproval(*rule, policyResult)
+	// an approved change approves the request automatically, unless the policy decided otherwise.
+	if ticketApproves(*rule, ticket, user.Email) && (policyResult == nil || !policyResult.Matched) {
+		autoApprove = true
+	}
+
 	// If the approval is not required, auto-approve the request
 	auto := types.AUTOMATIC
 	revd := types.REVIEWED
 
-	if !rule.Approval.IsRequired() {
+	if autoApprove {
 		req.Status = access.APPROVED
 		req.ApprovalMethod = &auto
 	} else {
@@ -146,13 +205,37 @@ func (s *Service) CreateRequest(ctx context.Context, user *identity.User, in typ
 		items = append(items, &r)
 	}
 
-	log.Debugw("saving request", "request", req, "reviewers", reviewers)
+	var ticketEv *access.RequestEvent
+	if ticket != nil {
+		e := access.NewRecordedEvent(req.ID, nil, now, ticketEvent(*rule, *ticket))
+		ticketEv = &e
+	}
 
-	// audit log event
-	reqEvent := access.NewRequestCreatedEvent(req.ID, req.CreatedAt, &req.RequestedBy)
+	unreviewable, err := s.isUnreviewable(ctx, *rule, req, policyResult, autoApprove, reviewers)
+	if err != nil {
+		return nil, err
+	}
+	if unreviewable {
+		req.Status = access.DECLINED
+		declinedEvent := access.NewRecordedEvent(req.ID, nil, now, map[string]string{"declinedReason": ErrNoReviewers.Error()})
+		declined := []ddb.Keyer{&req, &reqEvent, &declinedEvent}
+		if policyEvent != nil {
+			declined = append(declined, policyEvent)
+		}
+		if ticketEv != nil {
+			declined = append(declined, ticketEv)
+		}
+		err = s.DB.PutBatch(ctx, declined...)
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrNoReviewers
+	}
+
+	log.Debugw("saving request", "request", req, "reviewers", reviewers)
 
 	//before saving the request check to see if there already is a active approved rule
-	if !rule.Approval.IsRequired() {
+	if autoApprove {
 		start, end := req.GetInterval(access.WithNow(s.Clock.Now()))
 
 		rq := storage.ListRequestsForUserAndRuleAndRequestend{
@@ -174,6 +257,12 @@ func (s *Service) CreateRequest(ctx context.Context, user *identity.User, in typ
 	}
 
 	items = append(items, &reqEvent)
+	if policyEvent != nil {
+		items = append(items, policyEvent)
+	}
+	if ticketEv != nil {
+		items = append(items, ticketEv)
+	}
 	// save the request.
 	err = s.DB.PutBatch(ctx, items...)
 	if err != nil {
@@ -188,7 +277,7 @@ func (s *Service) CreateRequest(ctx context.Context, user *identity.User, in typ
 	}
 
 	// check to see if it valid for instant approval
-	if !rule.Approval.IsRequired() {
+	if autoApprove {
 
 		log.Debugw("auto-approving", "request", req, "reviewers", reviewers)
 		updatedReq, err := s.Granter.CreateGrant(ctx, grantsvc.CreateGrantOpts{Request: req, AccessRule: *rule})
@@ -227,7 +316,7 @@ func groupMatches(ruleGroups []string, userGroups []string) error {
 
 // requestIsValid checks that the request meets the constraints of the rule
 // Add additional constraint checks here in this method.
-func requestIsValid(request types.CreateRequestRequest, rule *rule.AccessRule) error {
+func requestIsValid(request types.CreateRequestRequest, rule *rule.AccessRule, now time.Time) error {
 	if request.Timing.DurationSeconds > rule.TimeConstraints.MaxDurationSeconds {
 		return &apio.APIError{
 			Err:    errors.New("request validation failed"),
@@ -240,6 +329,10 @@ func requestIsValid(request types.CreateRequestRequest, rule *rule.AccessRule) e
 			},
 		}
 	}
+	err := checkTiming(rule.TimeConstraints, access.TimingFromRequestTiming(request.Timing), now, now)
+	if err != nil {
+		return err
+	}
 	given := types.CreateRequestWith{AdditionalProperties: make(map[string]string)}
 	expected := make(map[string][]string)
 
@@ -278,8 +371,72 @@ func requestIsValid(request types.CreateRequestRequest, rule *rule.AccessRule) e
 			}
 		}
 	}
-	return nil
+	return checkRequestFields(rule.RequestFields, request.Fields)
+}
+
+// checkRequestFields returns a field error if the values don't meet the request fields of the rule.
+func checkRequestFields(fields []rule.RequestField, values *map[string]string) error {
+	err := rule.CheckRequestFields(fields, fieldValues(values))
+	var fe *rule.RequestFieldError
+	if errors.As(err, &fe) {
+		return &apio.APIError{
+			Err:    errors.New("request validation failed"),
+			Status: http.StatusBadRequest,
+			Fields: []apio.FieldError{
+				{
+					Field: fe.Field,
+					Error: fe.Message,
+				},
+			},
+		}
+	}
+	return err
 }
+
+// fieldValues returns the non-empty values for request fields, or nil if there aren't any.
+func fieldValues(values *map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+	var res map[string]string
+	for k, v := range *values {
+		if v == "" {
+			continue
+		}
+		if res == nil {
+			res = make(map[string]string)
+		}
+		res[k] = v
+	}
+	return res
+}
+
+// checkTiming returns a field error if the timing doesn't meet the time constraints of the rule.
+// chosenAt is when the start time of the timing was chosen, which the notice and scheduling horizon are measured from.
+func checkTiming(tc types.TimeConstraints, timing access.Timing, now time.Time, chosenAt time.Time) error {
+	start, end := timing.GetInterval(access.WithNow(now))
+	err := rule.CheckTiming(tc, rule.AccessTiming{
+		Start:     start,
+		End:       end,
+		Scheduled: timing.IsScheduled(),
+		ChosenAt:  chosenAt,
+	})
+	var te *rule.TimingError
+	if errors.As(err, &te) {
+		return &apio.APIError{
+			Err:    errors.New("request validation failed"),
+			Status: http.StatusBadRequest,
+			Fields: []apio.FieldError{
+				{
+					Field: te.Field,
+					Error: te.Message,
+				},
+			},
+		}
+	}
+	return err
+}
+
 func contains(set []string, str string) bool {
 	for _, s := range set {
 		if s == str {
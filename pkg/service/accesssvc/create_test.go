@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/benbjohnson/clock"
 	"github.com/common-fate/apikit/apio"
@@ -41,6 +42,8 @@ func TestNewRequest(t *testing.T) {
 	clk := clock.NewMock()
 	autoApproval := types.AUTOMATIC
 	reviewed := types.REVIEWED
+	soon := clk.Now().Add(time.Minute)
+	hour := 3600
 	testcases := []testcase{
 		{
 			name: "ok, no approvers so should auto approve",
@@ -97,6 +100,170 @@ func TestNewRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "fails because scheduled request doesn't give enough notice",
+			giveUser: identity.User{Groups: []string{"a"}},
+			giveInput: types.CreateRequestRequest{
+				Timing: types.RequestTiming{
+					DurationSeconds: 60,
+					StartTime:       &soon,
+				},
+			},
+			rule: &rule.AccessRule{
+				Groups: []string{"a"},
+				TimeConstraints: types.TimeConstraints{
+					MaxDurationSeconds: 3600,
+					MinNoticeSeconds:   &hour,
+				},
+			},
+			wantErr: &apio.APIError{
+				Err:    errors.New("request validation failed"),
+				Status: http.StatusBadRequest,
+				Fields: []apio.FieldError{
+					{
+						Field: "timing.startTime",
+						Error: "access must be scheduled at least 1h0m0s in advance",
+					},
+				},
+			},
+		},
+		{
+			name:     "fails because a required request field is missing",
+			giveUser: identity.User{Groups: []string{"a"}},
+			giveInput: types.CreateRequestRequest{
+				Fields: &map[string]string{"ticketId": ""},
+			},
+			rule: &rule.AccessRule{
+				Groups:        []string{"a"},
+				RequestFields: []rule.RequestField{{ID: "ticketId", Label: "Ticket ID", Type: rule.RequestFieldText, Required: true, Pattern: `OPS-[0-9]+`}},
+			},
+			wantErr: &apio.APIError{
+				Err:    errors.New("request validation failed"),
+				Status: http.StatusBadRequest,
+				Fields: []apio.FieldError{
+					{
+						Field: "fields.ticketId",
+						Error: "Ticket ID is required",
+					},
+				},
+			},
+		},
+		{
+			name:     "policy denies the request",
+			giveUser: identity.User{Groups: []string{"a"}},
+			rule: &rule.AccessRule{
+				Groups: []string{"a"},
+				Policy: rule.Policy{Language: "cel", Conditions: []rule.PolicyCondition{{Expression: `request.timing.durationSeconds == 0`, Effect: rule.PolicyDeny}}},
+			},
+			wantErr: ErrRequestDeniedByPolicy,
+		},
+		{
+			name:     "policy requires approval but the rule has no approvers",
+			giveUser: identity.User{Groups: []string{"a"}},
+			rule: &rule.AccessRule{
+				Groups: []string{"a"},
+				Policy: rule.Policy{Language: "cel", Conditions: []rule.PolicyCondition{{Expression: `true`, Effect: rule.PolicyRequireApproval}}},
+			},
+			wantErr: ErrNoReviewers,
+		},
+		{
+			name:     "policy requires approval and the requestor is the only approver",
+			giveUser: identity.User{ID: "a", Groups: []string{"a"}},
+			rule: &rule.AccessRule{
+				Groups:   []string{"a"},
+				Approval: rule.Approval{Users: []string{"a"}},
+				Policy:   rule.Policy{Language: "cel", Conditions: []rule.PolicyCondition{{Expression: `true`, Effect: rule.PolicyRequireApproval}}},
+			},
+			wantErr: ErrNoReviewers,
+		},
+		{
+			// the request can be reviewed once it's escalated.
+			name:     "policy requires approval and only escalation approvers can review",
+			giveUser: identity.User{ID: "a", Groups: []string{"a"}},
+			rule: &rule.AccessRule{
+				Groups:   []string{"a"},
+				Approval: rule.Approval{Users: []string{"a"}, EscalationGroups: []string{"esc"}},
+				Policy:   rule.Policy{Language: "cel", Conditions: []rule.PolicyCondition{{Expression: `true`, Effect: rule.PolicyRequireApproval}}},
+			},
+			withGetGroupResponse: &storage.GetGroup{Result: &identity.Group{ID: "esc", Users: []string{"c"}}},
+			want: &CreateRequestResult{
+				Request: access.Request{
+					ID:             "-",
+					RequestedBy:    "a",
+					Status:         access.PENDING,
+					CreatedAt:      clk.Now(),
+					UpdatedAt:      clk.Now(),
+					ApprovalMethod: &reviewed,
+					SelectedWith:   make(map[string]access.Option),
+				},
+			},
+		},
+		{
+			// requests without a policy stay pending, as the members of the approver groups can change.
+			name:     "requestor is the only approver",
+			giveUser: identity.User{ID: "a", Groups: []string{"a"}},
+			rule: &rule.AccessRule{
+				Groups:   []string{"a"},
+				Approval: rule.Approval{Users: []string{"a"}},
+			},
+			want: &CreateRequestResult{
+				Request: access.Request{
+					ID:             "-",
+					RequestedBy:    "a",
+					Status:         access.PENDING,
+					CreatedAt:      clk.Now(),
+					UpdatedAt:      clk.Now(),
+					ApprovalMethod: &reviewed,
+					SelectedWith:   make(map[string]access.Option),
+				},
+			},
+		},
+		{
+			name:     "policy approves the request automatically",
+			giveUser: identity.User{Groups: []string{"a"}},
+			rule: &rule.AccessRule{
+				Groups: []string{"a"},
+				Approval: rule.Approval{
+					Users: []string{"b"},
+				},
+				Policy: rule.Policy{Language: "cel", Conditions: []rule.PolicyCondition{{Expression: `"a" in requester.groups`, Effect: rule.PolicyAutoApprove}}},
+			},
+			want: &CreateRequestResult{
+				Request: access.Request{
+					ID:             "-",
+					Status:         access.APPROVED,
+					CreatedAt:      clk.Now(),
+					UpdatedAt:      clk.Now(),
+					Grant:          &access.Grant{},
+					ApprovalMethod: &autoApproval,
+					SelectedWith:   make(map[string]access.Option),
+				},
+				Reviewers: []access.Reviewer{
+					{
+						ReviewerID: "b",
+						Request: access.Request{
+							ID:             "-",
+							Status:         access.APPROVED,
+							CreatedAt:      clk.Now(),
+							UpdatedAt:      clk.Now(),
+							ApprovalMethod: &autoApproval,
+							SelectedWith:   make(map[string]access.Option),
+						},
+					},
+				},
+			},
+			withCreateGrantResponse: createGrantResponse{
+				request: &access.Request{
+					ID:             "-",
+					Status:         access.APPROVED,
+					CreatedAt:      clk.Now(),
+					UpdatedAt:      clk.Now(),
+					Grant:          &access.Grant{},
+					ApprovalMethod: &autoApproval,
+					SelectedWith:   make(map[string]access.Option),
+				},
+			},
+		},
 		{
 			name:     "user not in correct group",
 			giveUser: identity.User{Groups: []string{"a"}},
@@ -105,6 +272,35 @@ func TestNewRequest(t *testing.T) {
 			},
 			wantErr: ErrNoMatchingGroup,
 		},
+		{
+			name:     "ok, user is in rule group through a nested group",
+			giveUser: identity.User{Groups: []string{"a"}, InheritedGroups: []string{"b"}},
+			rule: &rule.AccessRule{
+				Groups: []string{"b"},
+			},
+			want: &CreateRequestResult{
+				Request: access.Request{
+					ID:             "-",
+					Status:         access.APPROVED,
+					CreatedAt:      clk.Now(),
+					UpdatedAt:      clk.Now(),
+					Grant:          &access.Grant{},
+					ApprovalMethod: &autoApproval,
+					SelectedWith:   make(map[string]access.Option),
+				},
+			},
+			withCreateGrantResponse: createGrantResponse{
+				request: &access.Request{
+					ID:             "-",
+					Status:         access.APPROVED,
+					CreatedAt:      clk.Now(),
+					UpdatedAt:      clk.Now(),
+					Grant:          &access.Grant{},
+					ApprovalMethod: &autoApproval,
+					SelectedWith:   make(map[string]access.Option),
+				},
+			},
+		},
 		{
 			name:     "rule not found",
 			giveUser: identity.User{Groups: []string{"a"}},
@@ -252,7 +448,7 @@ func TestNewRequest(t *testing.T) {
 				EventPutter: ep,
 				Cache:       ca,
 			}
-			got, err := s.CreateRequest(context.Background(), &tc.giveUser, tc.giveInput)
+			got, err := s.CreateRequest(context.Background(), CreateRequestOpts{User: &tc.giveUser, Request: tc.giveInput})
 			if got != nil {
 				// ignore the autogenerated ID for testing.
 				got.Request.ID = "-"
@@ -24,6 +24,13 @@ var (
 
 	// ErrRequestOverlapsExistingGrant is returned if the request overlaps an existing grant
 	ErrRequestOverlapsExistingGrant = errors.New("this request overlaps an existing grant")
+
+	// ErrRequestDeniedByPolicy is returned if the policy of the Access Rule denies the request
+	ErrRequestDeniedByPolicy = errors.New("the request was denied by the policy of the access rule")
+
+	// ErrNoReviewers is returned if the request requires approval but nobody other than the requester can approve it.
+	// The request is saved as declined.
+	ErrNoReviewers = errors.New("the request requires approval but the access rule has no approvers who can review it")
 )
 
 // InvalidStatusError is returned if a user tries to review a request which wasn't PENDING.
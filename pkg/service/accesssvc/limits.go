@@ -0,0 +1,161 @@
+package accesssvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/common-fate/apikit/apio"
+	"github.com/common-fate/ddb"
+	ac_types "github.com/common-fate/granted-approvals/accesshandler/pkg/types"
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+)
+
+// checkLimits returns an error with a field error for each limit of the rule which a new request by the user would exceed.
+// start is when the access for the new request would start.
+//
+// Requests are indexed by their end time, and the limits look back at most a day or the cooldown period,
+// so most of the requests which count towards the limits are fetched with a single query.
+// Pending requests which were scheduled to end before the lookback period aren't indexed in it,
+// so they're counted from the user's pending requests instead.
+//
+// The limits are checked before the request is saved, so concurrent requests by the same user
+// can each pass the check and exceed the limits by the number of requests made at the same time.
+func (s *Service) checkLimits(ctx context.Context, userID string, r rule.AccessRule, start time.Time) error {
+	l := r.Limits
+	if !l.IsSet() {
+		return nil
+	}
+	now := s.Clock.Now()
+	day := 24 * time.Hour
+	cooldown := time.Duration(l.CooldownSeconds) * time.Second
+	lookback := day
+	if cooldown > lookback {
+		lookback = cooldown
+	}
+
+	q := storage.ListRequestsForUserAndRuleAndRequestend{
+		UserID:               userID,
+		RuleID:               r.ID,
+		RequestEndComparator: storage.GreaterThanEqual,
+		CompareTo:            now.Add(-lookback),
+	}
+	_, err := s.DB.Query(ctx, &q)
+	if err != nil && err != ddb.ErrNoItems {
+		return err
+	}
+
+	requests := q.Result
+	if l.MaxActiveGrants > 0 {
+		stale, err := s.listStalePendingRequests(ctx, userID, r.ID, requests)
+		if err != nil {
+			return err
+		}
+		requests = append(requests, stale...)
+	}
+
+	var active, today int
+	var cooldownUntil time.Time
+	for _, req := range requests {
+		if isActive(req, now) {
+			active++
+		}
+		if req.CreatedAt.After(now.Add(-day)) {
+			today++
+		}
+		if req.Grant != nil && req.Grant.Status != ac_types.GrantStatusERROR && req.Grant.Start.Before(start) {
+			until := grantEnd(*req.Grant).Add(cooldown)
+			if until.After(cooldownUntil) {
+				cooldownUntil = until
+			}
+		}
+	}
+
+	var fields []apio.FieldError
+	if l.MaxActiveGrants > 0 && active >= l.MaxActiveGrants {
+		fields = append(fields, apio.FieldError{
+			Field: "accessRuleId",
+			Error: fmt.Sprintf("you have %d active grants or pending requests for this access rule, the maximum is %d", active, l.MaxActiveGrants),
+		})
+	}
+	if l.MaxRequestsPerDay > 0 && today >= l.MaxRequestsPerDay {
+		fields = append(fields, apio.FieldError{
+			Field: "accessRuleId",
+			Error: fmt.Sprintf("you have made %d requests for this access rule in the last 24 hours, the maximum is %d", today, l.MaxRequestsPerDay),
+		})
+	}
+	if cooldown > 0 && start.Before(cooldownUntil) {
+		fields = append(fields, apio.FieldError{
+			Field: "timing.startTime",
+			Error: fmt.Sprintf("access for this access rule can't start until %s, %s after your previous grant ends", cooldownUntil.UTC().Format(time.RFC3339), cooldown),
+		})
+	}
+	if len(fields) > 0 {
+		return &apio.APIError{
+			Err:    errors.New("request exceeds the limits of the access rule"),
+			Status: http.StatusBadRequest,
+			Fields: fields,
+		}
+	}
+	return nil
+}
+
+// listStalePendingRequests returns the user's pending requests for the rule which aren't in found,
+// such as requests which were scheduled to start in the past and haven't been reviewed.
+func (s *Service) listStalePendingRequests(ctx context.Context, userID string, ruleID string, found []access.Request) ([]access.Request, error) {
+	seen := make(map[string]bool)
+	for _, req := range found {
+		seen[req.ID] = true
+	}
+	var res []access.Request
+	var next string
+	for {
+		q := storage.ListRequestsForUserAndStatus{UserId: userID, Status: access.PENDING}
+		var opts []func(*ddb.QueryOpts)
+		if next != "" {
+			opts = append(opts, ddb.Page(next))
+		}
+		qr, err := s.DB.Query(ctx, &q, opts...)
+		if err != nil && err != ddb.ErrNoItems {
+			return nil, err
+		}
+		for _, req := range q.Result {
+			if req.Rule == ruleID && !seen[req.ID] {
+				res = append(res, req)
+			}
+		}
+		if qr == nil || qr.NextPage == "" {
+			return res, nil
+		}
+		next = qr.NextPage
+	}
+}
+
+// isActive returns true if the request is pending, or has been approved and its grant hasn't ended.
+func isActive(req access.Request, now time.Time) bool {
+	switch req.Status {
+	case access.PENDING:
+		return true
+	case access.APPROVED:
+		if req.Grant == nil {
+			return true
+		}
+		switch req.Grant.Status {
+		case ac_types.GrantStatusPENDING, ac_types.GrantStatusACTIVE:
+			return req.Grant.End.After(now)
+		}
+	}
+	return false
+}
+
+// grantEnd returns when the grant ended, which is earlier than the scheduled end if the grant was revoked.
+func grantEnd(g access.Grant) time.Time {
+	if g.Status == ac_types.GrantStatusREVOKED && g.UpdatedAt.Before(g.End) {
+		return g.UpdatedAt
+	}
+	return g.End
+}
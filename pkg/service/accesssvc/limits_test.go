@@ -0,0 +1,141 @@
+package accesssvc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/common-fate/apikit/apio"
+	"github.com/common-fate/ddb/ddbmock"
+	ac_types "github.com/common-fate/granted-approvals/accesshandler/pkg/types"
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckLimits(t *testing.T) {
+	clk := clock.NewMock()
+	clk.Set(time.Date(2022, time.August, 1, 12, 0, 0, 0, time.UTC))
+	now := clk.Now()
+
+	pending := access.Request{ID: "pending", Status: access.PENDING, CreatedAt: now.Add(-time.Hour)}
+	active := access.Request{
+		ID:        "active",
+		Status:    access.APPROVED,
+		CreatedAt: now.Add(-2 * time.Hour),
+		Grant:     &access.Grant{Status: ac_types.GrantStatusACTIVE, Start: now.Add(-2 * time.Hour), End: now.Add(time.Hour)},
+	}
+	ended := access.Request{
+		ID:        "ended",
+		Status:    access.APPROVED,
+		CreatedAt: now.Add(-30 * time.Hour),
+		Grant:     &access.Grant{Status: ac_types.GrantStatusEXPIRED, Start: now.Add(-30 * time.Hour), End: now.Add(-10 * time.Minute)},
+	}
+
+	type testcase struct {
+		name     string
+		limits   rule.Limits
+		start    time.Time
+		requests []access.Request
+		// pending are the user's pending requests for any rule.
+		pending    []access.Request
+		wantFields []apio.FieldError
+	}
+
+	testcases := []testcase{
+		{
+			name:     "no limits",
+			start:    now,
+			requests: []access.Request{pending, active, ended},
+		},
+		{
+			name:     "under the limits",
+			limits:   rule.Limits{MaxActiveGrants: 3, MaxRequestsPerDay: 3},
+			start:    now,
+			requests: []access.Request{pending, active, ended},
+		},
+		{
+			name:     "pending requests count as active grants",
+			limits:   rule.Limits{MaxActiveGrants: 2},
+			start:    now,
+			requests: []access.Request{pending, active, ended},
+			wantFields: []apio.FieldError{
+				{Field: "accessRuleId", Error: "you have 2 active grants or pending requests for this access rule, the maximum is 2"},
+			},
+		},
+		{
+			name:     "pending requests which were scheduled to end in the past count as active grants",
+			limits:   rule.Limits{MaxActiveGrants: 2},
+			start:    now,
+			requests: []access.Request{pending, active},
+			pending:  []access.Request{pending, {ID: "stale", Rule: "rule", Status: access.PENDING, CreatedAt: now.Add(-72 * time.Hour)}, {ID: "other", Rule: "other", Status: access.PENDING}},
+			wantFields: []apio.FieldError{
+				{Field: "accessRuleId", Error: "you have 3 active grants or pending requests for this access rule, the maximum is 2"},
+			},
+		},
+		{
+			name:     "requests per day",
+			limits:   rule.Limits{MaxRequestsPerDay: 2},
+			start:    now,
+			requests: []access.Request{pending, active, ended},
+			wantFields: []apio.FieldError{
+				{Field: "accessRuleId", Error: "you have made 2 requests for this access rule in the last 24 hours, the maximum is 2"},
+			},
+		},
+		{
+			name:     "within cooldown of previous grant",
+			limits:   rule.Limits{CooldownSeconds: 3600},
+			start:    now,
+			requests: []access.Request{ended},
+			wantFields: []apio.FieldError{
+				{Field: "timing.startTime", Error: "access for this access rule can't start until 2022-08-01T12:50:00Z, 1h0m0s after your previous grant ends"},
+			},
+		},
+		{
+			name:     "scheduled after cooldown",
+			limits:   rule.Limits{CooldownSeconds: 3600},
+			start:    now.Add(time.Hour),
+			requests: []access.Request{ended},
+		},
+		{
+			name:     "cooldown starts when a grant is revoked",
+			limits:   rule.Limits{CooldownSeconds: 600},
+			start:    now,
+			requests: []access.Request{{Status: access.APPROVED, Grant: &access.Grant{Status: ac_types.GrantStatusREVOKED, Start: now.Add(-time.Hour), End: now.Add(time.Hour), UpdatedAt: now.Add(-20 * time.Minute)}}},
+		},
+		{
+			name:     "multiple limits exceeded",
+			limits:   rule.Limits{MaxActiveGrants: 1, CooldownSeconds: 3600},
+			start:    now,
+			requests: []access.Request{active, ended},
+			wantFields: []apio.FieldError{
+				{Field: "accessRuleId", Error: "you have 1 active grants or pending requests for this access rule, the maximum is 1"},
+				{Field: "timing.startTime", Error: "access for this access rule can't start until 2022-08-01T14:00:00Z, 1h0m0s after your previous grant ends"},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			db.MockQuery(&storage.ListRequestsForUserAndRuleAndRequestend{Result: tc.requests})
+			db.MockQuery(&storage.ListRequestsForUserAndStatus{Result: tc.pending})
+			s := Service{Clock: clk, DB: db}
+
+			err := s.checkLimits(context.Background(), "user", rule.AccessRule{ID: "rule", Limits: tc.limits}, tc.start)
+			if tc.wantFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, &apio.APIError{
+				Err:    errors.New("request exceeds the limits of the access rule"),
+				Status: http.StatusBadRequest,
+				Fields: tc.wantFields,
+			}, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/common-fate/granted-approvals/pkg/service/accesssvc (interfaces: TicketGetter)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	tickets "github.com/common-fate/granted-approvals/pkg/tickets"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTicketGetter is a mock of TicketGetter interface.
+type MockTicketGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockTicketGetterMockRecorder
+}
+
+// MockTicketGetterMockRecorder is the mock recorder for MockTicketGetter.
+type MockTicketGetterMockRecorder struct {
+	mock *MockTicketGetter
+}
+
+// NewMockTicketGetter creates a new mock instance.
+func NewMockTicketGetter(ctrl *gomock.Controller) *MockTicketGetter {
+	mock := &MockTicketGetter{ctrl: ctrl}
+	mock.recorder = &MockTicketGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTicketGetter) EXPECT() *MockTicketGetterMockRecorder {
+	return m.recorder
+}
+
+// GetTicket mocks base method.
+func (m *MockTicketGetter) GetTicket(arg0 context.Context, arg1, arg2 string) (*tickets.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTicket", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*tickets.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTicket indicates an expected call of GetTicket.
+func (mr *MockTicketGetterMockRecorder) GetTicket(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTicket", reflect.TypeOf((*MockTicketGetter)(nil).GetTicket), arg0, arg1, arg2)
+}
@@ -0,0 +1,86 @@
+package accesssvc
+
+import (
+	"context"
+	"time"
+
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/policy"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/service/rulesvc"
+)
+
+// policies caches the compiled policy of each rule version, which can't change once it's saved.
+var policies policy.Cache
+
+// evaluatePolicy returns the result of the rule's policy for the request, or nil if the rule doesn't have a policy.
+func evaluatePolicy(r rule.AccessRule, user *identity.User, req access.Request, now time.Time) (*policy.Result, error) {
+	if !r.Policy.IsSet() {
+		return nil, nil
+	}
+	with := make(map[string]string)
+	for k, v := range req.SelectedWith {
+		with[k] = v.Value
+	}
+	var reason string
+	if req.Data.Reason != nil {
+		reason = *req.Data.Reason
+	}
+	in := policy.Input{
+		Requester: policy.Requester{ID: user.ID, Email: user.Email, Groups: user.EffectiveGroups()},
+		Request: policy.Request{
+			Reason:    reason,
+			With:      with,
+			Duration:  req.RequestedTiming.Duration,
+			StartTime: req.RequestedTiming.StartTime,
+		},
+		Now: now,
+	}
+	var res policy.Result
+	var err error
+	if r.Version == "" {
+		// rules which haven't been saved have no version to cache their policy under.
+		res, err = policy.Evaluate(r.Policy, in)
+	} else {
+		res, err = policies.Evaluate(r.ID+"/"+r.Version, r.Policy, in)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// requiresApproval returns true unless the policy approves the request automatically,
+// or the policy didn't decide and the rule has no approvers.
+func requiresApproval(r rule.AccessRule, res *policy.Result) bool {
+	if res != nil && res.Matched {
+		return res.Effect != rule.PolicyAutoApprove
+	}
+	return r.Approval.IsRequired()
+}
+
+// isUnreviewable returns true if the policy requires approval for the request, but nobody other than the requester
+// can review it, either as an approver or after the request is escalated.
+// Requests which need approval because the rule has approvers are left pending even if nobody can review them yet,
+// as the members of the approver groups can change.
+func (s *Service) isUnreviewable(ctx context.Context, r rule.AccessRule, req access.Request, res *policy.Result, autoApprove bool, reviewers []access.Reviewer) (bool, error) {
+	if autoApprove || len(reviewers) > 0 || res == nil || !res.Matched || res.Effect != rule.PolicyRequireApproval {
+		return false, nil
+	}
+	escalation, err := rulesvc.GetEscalationApprovers(ctx, s.DB, r)
+	if err != nil {
+		return false, err
+	}
+	for _, u := range escalation {
+		if u != req.RequestedBy {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// isDenied returns true if the policy denies the request.
+func isDenied(res *policy.Result) bool {
+	return res != nil && res.Matched && res.Effect == rule.PolicyDeny
+}
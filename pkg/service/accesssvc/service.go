@@ -10,6 +10,7 @@ import (
 	"github.com/common-fate/granted-approvals/pkg/cache"
 	"github.com/common-fate/granted-approvals/pkg/gevent"
 	"github.com/common-fate/granted-approvals/pkg/service/grantsvc"
+	"github.com/common-fate/granted-approvals/pkg/tickets"
 )
 
 // Service holds business logic relating to Access Requests.
@@ -19,6 +20,8 @@ type Service struct {
 	Granter     Granter
 	EventPutter EventPutter
 	Cache       CacheService
+	// Tickets looks up the tickets which access rules can require. Requests for those rules fail if it is nil.
+	Tickets TicketGetter
 }
 
 //go:generate go run github.com/golang/mock/mockgen -destination=mocks/granter.go -package=mocks . Granter
@@ -39,3 +42,10 @@ type CacheService interface {
 	RefreshCachedProviderArgOptions(ctx context.Context, providerId string, argId string) (bool, []cache.ProviderOption, error)
 	LoadCachedProviderArgOptions(ctx context.Context, providerId string, argId string) (bool, []cache.ProviderOption, error)
 }
+
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/tickets.go -package=mocks . TicketGetter
+
+// TicketGetter looks up tickets in the ticket systems configured for the deployment.
+type TicketGetter interface {
+	GetTicket(ctx context.Context, system string, id string) (*tickets.Ticket, error)
+}
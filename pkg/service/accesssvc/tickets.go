@@ -0,0 +1,83 @@
+package accesssvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/common-fate/apikit/apio"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/tickets"
+)
+
+// checkTicket looks up the ticket which the request references, if the rule requires a ticket.
+// It returns a field error if the ticket doesn't exist or isn't in one of the allowed states of the rule.
+func (s *Service) checkTicket(ctx context.Context, r rule.AccessRule, fields map[string]string) (*tickets.Ticket, error) {
+	t := r.Ticket
+	if !t.IsSet() {
+		return nil, nil
+	}
+	if s.Tickets == nil {
+		return nil, fmt.Errorf("%w: %s", tickets.ErrSystemNotConfigured, t.System)
+	}
+	id := fields[t.FieldID]
+	field := "fields." + t.FieldID
+
+	ticket, err := s.Tickets.GetTicket(ctx, t.System, id)
+	if errors.Is(err, tickets.ErrTicketNotFound) {
+		return nil, &apio.APIError{
+			Err:    errors.New("request validation failed"),
+			Status: http.StatusBadRequest,
+			Fields: []apio.FieldError{
+				{
+					Field: field,
+					Error: fmt.Sprintf("ticket %s was not found in %s", id, t.System),
+				},
+			},
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(t.AllowedStates) > 0 && !containsFold(t.AllowedStates, ticket.State) {
+		return nil, &apio.APIError{
+			Err:    errors.New("request validation failed"),
+			Status: http.StatusBadRequest,
+			Fields: []apio.FieldError{
+				{
+					Field: field,
+					Error: fmt.Sprintf("ticket %s is %s, it must be one of: %s", ticket.ID, ticket.State, strings.Join(t.AllowedStates, ", ")),
+				},
+			},
+		}
+	}
+	return ticket, nil
+}
+
+// ticketApproves returns true if the ticket is in one of the states of the rule which approve requests automatically,
+// and the requester is assigned to the ticket or reported or requested it.
+// Otherwise anyone could reference a colleague's approved change to get access without approval.
+func ticketApproves(r rule.AccessRule, ticket *tickets.Ticket, requesterEmail string) bool {
+	return ticket != nil && containsFold(r.Ticket.AutoApproveStates, ticket.State) && ticket.Involves(requesterEmail)
+}
+
+// ticketEvent records the ticket in the audit log of the request.
+func ticketEvent(r rule.AccessRule, ticket tickets.Ticket) map[string]string {
+	return map[string]string{
+		"ticketSystem": r.Ticket.System,
+		"ticketId":     ticket.ID,
+		"ticketState":  ticket.State,
+		"ticketUrl":    ticket.URL,
+	}
+}
+
+func containsFold(set []string, str string) bool {
+	for _, s := range set {
+		if strings.EqualFold(s, str) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package accesssvc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/common-fate/apikit/apio"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/service/accesssvc/mocks"
+	"github.com/common-fate/granted-approvals/pkg/tickets"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckTicket(t *testing.T) {
+	requirement := rule.TicketRequirement{
+		System:            tickets.TicketSystemJira,
+		FieldID:           "ticketId",
+		AllowedStates:     []string{"In Progress", "Approved"},
+		AutoApproveStates: []string{"Approved"},
+	}
+
+	type testcase struct {
+		name            string
+		ticket          rule.TicketRequirement
+		giveTicket      *tickets.Ticket
+		giveErr         error
+		want            *tickets.Ticket
+		wantErr         error
+		wantAutoApprove bool
+	}
+
+	testcases := []testcase{
+		{
+			name: "rule doesn't require a ticket",
+		},
+		{
+			name:       "ticket in an allowed state",
+			ticket:     requirement,
+			giveTicket: &tickets.Ticket{ID: "OPS-1", State: "in progress"},
+			want:       &tickets.Ticket{ID: "OPS-1", State: "in progress"},
+		},
+		{
+			name:            "approved change",
+			ticket:          requirement,
+			giveTicket:      &tickets.Ticket{ID: "OPS-1", State: "Approved", People: []string{"Alice@example.com"}},
+			want:            &tickets.Ticket{ID: "OPS-1", State: "Approved", People: []string{"Alice@example.com"}},
+			wantAutoApprove: true,
+		},
+		{
+			name:       "approved change which the requester isn't involved in",
+			ticket:     requirement,
+			giveTicket: &tickets.Ticket{ID: "OPS-1", State: "Approved", People: []string{"bob@example.com"}},
+			want:       &tickets.Ticket{ID: "OPS-1", State: "Approved", People: []string{"bob@example.com"}},
+		},
+		{
+			name:       "ticket in a state which isn't allowed",
+			ticket:     requirement,
+			giveTicket: &tickets.Ticket{ID: "OPS-1", State: "Done"},
+			wantErr: &apio.APIError{
+				Err:    errors.New("request validation failed"),
+				Status: http.StatusBadRequest,
+				Fields: []apio.FieldError{{Field: "fields.ticketId", Error: "ticket OPS-1 is Done, it must be one of: In Progress, Approved"}},
+			},
+		},
+		{
+			name:    "ticket not found",
+			ticket:  requirement,
+			giveErr: tickets.ErrTicketNotFound,
+			wantErr: &apio.APIError{
+				Err:    errors.New("request validation failed"),
+				Status: http.StatusBadRequest,
+				Fields: []apio.FieldError{{Field: "fields.ticketId", Error: "ticket OPS-1 was not found in jira"}},
+			},
+		},
+		{
+			name:    "ticket system unavailable",
+			ticket:  requirement,
+			giveErr: errors.New("jira returned 503 Service Unavailable"),
+			wantErr: errors.New("jira returned 503 Service Unavailable"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			tg := mocks.NewMockTicketGetter(ctrl)
+			if tc.ticket.IsSet() {
+				tg.EXPECT().GetTicket(gomock.Any(), tickets.TicketSystemJira, "OPS-1").Return(tc.giveTicket, tc.giveErr)
+			}
+			s := Service{Tickets: tg}
+			r := rule.AccessRule{Ticket: tc.ticket}
+
+			got, err := s.checkTicket(context.Background(), r, map[string]string{"ticketId": "OPS-1"})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantAutoApprove, ticketApproves(r, got, "alice@example.com"))
+		})
+	}
+}
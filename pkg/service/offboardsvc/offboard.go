@@ -0,0 +1,171 @@
+package offboardsvc
+
+import (
+	"context"
+	"time"
+
+	"github.com/common-fate/apikit/logger"
+	"github.com/common-fate/ddb"
+	ahTypes "github.com/common-fate/granted-approvals/accesshandler/pkg/types"
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/gevent"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/service/grantsvc"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/storage/dbupdate"
+)
+
+// RevokerID is recorded as the revoker of grants which are revoked when a user is offboarded.
+const RevokerID = "identity-sync"
+
+// Offboard cancels the pending requests of an archived user, revokes their active grants,
+// and removes them as a reviewer of other users' pending requests.
+// If dryRun is true, the changes which would be made are returned without making them.
+func (s *Service) Offboard(ctx context.Context, user identity.User, dryRun bool) (*identity.Offboarding, error) {
+	log := logger.Get(ctx).With("user.id", user.ID, "dryRun", dryRun)
+	now := s.Clock.Now()
+	res := identity.Offboarding{
+		UserID:            user.ID,
+		Email:             user.Email,
+		CancelledRequests: []string{},
+		RevokedGrants:     []string{},
+		RemovedReviews:    []string{},
+	}
+
+	pending, err := s.listRequestsForUser(ctx, user.ID, access.PENDING)
+	if err != nil {
+		return nil, err
+	}
+	for _, req := range pending {
+		if req.Status != access.PENDING {
+			continue
+		}
+		res.CancelledRequests = append(res.CancelledRequests, req.ID)
+		if dryRun {
+			continue
+		}
+		err = s.cancelRequest(ctx, req, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	approved, err := s.listRequestsForUser(ctx, user.ID, access.APPROVED)
+	if err != nil {
+		return nil, err
+	}
+	for _, req := range approved {
+		if !hasActiveGrant(req, now) {
+			continue
+		}
+		res.RevokedGrants = append(res.RevokedGrants, req.ID)
+		if dryRun {
+			continue
+		}
+		_, err = s.Granter.RevokeGrant(ctx, grantsvc.RevokeGrantOpts{Request: req, RevokerID: RevokerID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reviews, err := s.listRequestsForReviewer(ctx, user.ID, access.PENDING)
+	if err != nil {
+		return nil, err
+	}
+	reviewers := []ddb.Keyer{}
+	for _, req := range reviews {
+		// the user's own requests have already been cancelled.
+		if req.RequestedBy == user.ID || req.Status != access.PENDING {
+			continue
+		}
+		res.RemovedReviews = append(res.RemovedReviews, req.ID)
+		reviewers = append(reviewers, &access.Reviewer{ReviewerID: user.ID, Request: req})
+	}
+
+	if dryRun {
+		return &res, nil
+	}
+	if len(reviewers) > 0 {
+		err = s.DB.DeleteBatch(ctx, reviewers...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.EventPutter.Put(ctx, gevent.UserOffboarded{Offboarding: res})
+	if err != nil {
+		return nil, err
+	}
+	log.Infow("offboarded user", "requests.cancelled", len(res.CancelledRequests), "grants.revoked", len(res.RevokedGrants), "reviews.removed", len(res.RemovedReviews))
+	return &res, nil
+}
+
+// listRequestsForUser returns every page of the user's requests with the status.
+func (s *Service) listRequestsForUser(ctx context.Context, userID string, status access.Status) ([]access.Request, error) {
+	var res []access.Request
+	var next string
+	for {
+		q := storage.ListRequestsForUserAndStatus{UserId: userID, Status: status}
+		var opts []func(*ddb.QueryOpts)
+		if next != "" {
+			opts = append(opts, ddb.Page(next))
+		}
+		qr, err := s.DB.Query(ctx, &q, opts...)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, q.Result...)
+		if qr == nil || qr.NextPage == "" {
+			return res, nil
+		}
+		next = qr.NextPage
+	}
+}
+
+// listRequestsForReviewer returns every page of the requests with the status which the user is a reviewer of.
+func (s *Service) listRequestsForReviewer(ctx context.Context, reviewerID string, status access.Status) ([]access.Request, error) {
+	var res []access.Request
+	var next string
+	for {
+		q := storage.ListRequestsForReviewerAndStatus{ReviewerID: reviewerID, Status: status}
+		var opts []func(*ddb.QueryOpts)
+		if next != "" {
+			opts = append(opts, ddb.Page(next))
+		}
+		qr, err := s.DB.Query(ctx, &q, opts...)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, q.Result...)
+		if qr == nil || qr.NextPage == "" {
+			return res, nil
+		}
+		next = qr.NextPage
+	}
+}
+
+func (s *Service) cancelRequest(ctx context.Context, req access.Request, now time.Time) error {
+	originalStatus := req.Status
+	req.Status = access.CANCELLED
+	req.UpdatedAt = now
+	items, err := dbupdate.GetUpdateRequestItems(ctx, s.DB, req)
+	if err != nil {
+		return err
+	}
+	// the request is cancelled by the system rather than a user, so there is no actor.
+	reqEvent := access.NewStatusChangeEvent(req.ID, req.UpdatedAt, nil, originalStatus, req.Status)
+	items = append(items, &reqEvent)
+	err = s.DB.PutBatch(ctx, items...)
+	if err != nil {
+		return err
+	}
+	return s.EventPutter.Put(ctx, gevent.RequestCancelled{Request: req})
+}
+
+// hasActiveGrant returns true if the request has a grant which can still be revoked.
+func hasActiveGrant(req access.Request, now time.Time) bool {
+	if req.Grant == nil || req.Grant.End.Before(now) {
+		return false
+	}
+	return req.Grant.Status == ahTypes.GrantStatusACTIVE || req.Grant.Status == ahTypes.GrantStatusPENDING
+}
@@ -0,0 +1,180 @@
+package offboardsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/ddb/ddbmock"
+	ahTypes "github.com/common-fate/granted-approvals/accesshandler/pkg/types"
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/gevent"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/service/grantsvc"
+	"github.com/common-fate/granted-approvals/pkg/service/offboardsvc/mocks"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOffboard(t *testing.T) {
+	type testcase struct {
+		name string
+		// requests is returned for both pending and approved requests, as ddbmock
+		// returns the same result for every query of the same type.
+		requests    []access.Request
+		reviews     []access.Request
+		dryRun      bool
+		wantRevoked []string
+		want        identity.Offboarding
+	}
+
+	now := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	user := identity.User{ID: "usr_123", Email: "alice@example.com"}
+
+	pendingReq := access.Request{ID: "req_pending", RequestedBy: "usr_123", Status: access.PENDING}
+	activeReq := access.Request{ID: "req_active", RequestedBy: "usr_123", Status: access.APPROVED, Grant: &access.Grant{
+		Status: ahTypes.GrantStatusACTIVE,
+		End:    now.Add(time.Hour),
+	}}
+	expiredReq := access.Request{ID: "req_expired", RequestedBy: "usr_123", Status: access.APPROVED, Grant: &access.Grant{
+		Status: ahTypes.GrantStatusEXPIRED,
+		End:    now.Add(-time.Hour),
+	}}
+	otherReq := access.Request{ID: "req_other", RequestedBy: "usr_456", Status: access.PENDING}
+
+	testcases := []testcase{
+		{
+			name:        "ok",
+			requests:    []access.Request{pendingReq, activeReq, expiredReq},
+			reviews:     []access.Request{otherReq, pendingReq},
+			wantRevoked: []string{"req_active"},
+			want: identity.Offboarding{
+				UserID:            "usr_123",
+				Email:             "alice@example.com",
+				CancelledRequests: []string{"req_pending"},
+				RevokedGrants:     []string{"req_active"},
+				RemovedReviews:    []string{"req_other"},
+			},
+		},
+		{
+			name:     "dry run",
+			requests: []access.Request{pendingReq, activeReq},
+			reviews:  []access.Request{otherReq},
+			dryRun:   true,
+			want: identity.Offboarding{
+				UserID:            "usr_123",
+				Email:             "alice@example.com",
+				CancelledRequests: []string{"req_pending"},
+				RevokedGrants:     []string{"req_active"},
+				RemovedReviews:    []string{"req_other"},
+			},
+		},
+		{
+			name: "nothing to offboard",
+			want: identity.Offboarding{
+				UserID:            "usr_123",
+				Email:             "alice@example.com",
+				CancelledRequests: []string{},
+				RevokedGrants:     []string{},
+				RemovedReviews:    []string{},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			db := ddbmock.New(t)
+			db.MockQuery(&storage.ListRequestsForUserAndStatus{Result: tc.requests})
+			db.MockQuery(&storage.ListRequestsForReviewerAndStatus{Result: tc.reviews})
+			db.MockQuery(&storage.ListRequestReviewers{})
+
+			granter := mocks.NewMockGranter(ctrl)
+			for _, id := range tc.wantRevoked {
+				granter.EXPECT().RevokeGrant(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, opts grantsvc.RevokeGrantOpts) (*access.Request, error) {
+					assert.Equal(t, id, opts.Request.ID)
+					assert.Equal(t, RevokerID, opts.RevokerID)
+					return &opts.Request, nil
+				})
+			}
+
+			ep := mocks.NewMockEventPutter(ctrl)
+			if !tc.dryRun {
+				ep.EXPECT().Put(gomock.Any(), gomock.AssignableToTypeOf(gevent.RequestCancelled{})).Times(len(tc.want.CancelledRequests))
+				ep.EXPECT().Put(gomock.Any(), gevent.UserOffboarded{Offboarding: tc.want})
+			}
+
+			clk := clock.NewMock()
+			clk.Set(now)
+			s := Service{Clock: clk, DB: db, Granter: granter, EventPutter: ep}
+
+			got, err := s.Offboard(context.Background(), user, tc.dryRun)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.want, *got)
+		})
+	}
+}
+
+// pagedDB returns the pages of the user's requests and the requests they review, keyed by page token.
+type pagedDB struct {
+	*ddbmock.Client
+	requests map[string][]access.Request
+	reviews  map[string][]access.Request
+}
+
+func (p *pagedDB) Query(ctx context.Context, qb ddb.QueryBuilder, opts ...func(*ddb.QueryOpts)) (*ddb.QueryResult, error) {
+	var o ddb.QueryOpts
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var pages map[string][]access.Request
+	switch q := qb.(type) {
+	case *storage.ListRequestsForUserAndStatus:
+		if q.Status != access.PENDING {
+			return &ddb.QueryResult{}, nil
+		}
+		pages = p.requests
+		q.Result = pages[o.PageToken]
+	case *storage.ListRequestsForReviewerAndStatus:
+		pages = p.reviews
+		q.Result = pages[o.PageToken]
+	default:
+		return p.Client.Query(ctx, qb, opts...)
+	}
+	next := o.PageToken + "x"
+	if _, ok := pages[next]; !ok {
+		next = ""
+	}
+	return &ddb.QueryResult{NextPage: next}, nil
+}
+
+func TestOffboardReadsEveryPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := ddbmock.New(t)
+	db.MockQuery(&storage.ListRequestReviewers{})
+	paged := pagedDB{
+		Client: db,
+		requests: map[string][]access.Request{
+			"":  {{ID: "req_1", RequestedBy: "usr_123", Status: access.PENDING}},
+			"x": {{ID: "req_2", RequestedBy: "usr_123", Status: access.PENDING}},
+		},
+		reviews: map[string][]access.Request{
+			"":   {{ID: "req_3", RequestedBy: "usr_456", Status: access.PENDING}},
+			"x":  {},
+			"xx": {{ID: "req_4", RequestedBy: "usr_456", Status: access.PENDING}},
+		},
+	}
+
+	s := Service{Clock: clock.NewMock(), DB: &paged, Granter: mocks.NewMockGranter(ctrl), EventPutter: mocks.NewMockEventPutter(ctrl)}
+	got, err := s.Offboard(context.Background(), identity.User{ID: "usr_123", Email: "alice@example.com"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"req_1", "req_2"}, got.CancelledRequests)
+	assert.Equal(t, []string{"req_3", "req_4"}, got.RemovedReviews)
+}
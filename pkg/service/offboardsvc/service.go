@@ -0,0 +1,32 @@
+package offboardsvc
+
+import (
+	"context"
+
+	"github.com/benbjohnson/clock"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/gevent"
+	"github.com/common-fate/granted-approvals/pkg/service/grantsvc"
+)
+
+// Service offboards users who have been archived because they were removed from the identity provider,
+// cancelling their pending requests, revoking their grants and removing them as a reviewer.
+type Service struct {
+	Clock       clock.Clock
+	DB          ddb.Storage
+	Granter     Granter
+	EventPutter EventPutter
+}
+
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/granter.go -package=mocks . Granter
+
+// Granter revokes Grants in the Access Handler.
+type Granter interface {
+	RevokeGrant(ctx context.Context, opts grantsvc.RevokeGrantOpts) (*access.Request, error)
+}
+
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/eventputter.go -package=mocks . EventPutter
+type EventPutter interface {
+	Put(ctx context.Context, detail gevent.EventTyper) error
+}
@@ -0,0 +1,80 @@
+package remindersvc
+
+import (
+	"context"
+	"time"
+
+	"github.com/common-fate/ddb"
+	ac_types "github.com/common-fate/granted-approvals/accesshandler/pkg/types"
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/gevent"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/storage/dbupdate"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// SendGrantExpiryNotifications finds active grants which end within the GrantExpiryWarning interval
+// and emits a GrantExpiring event for each, which notifiers use to warn the user.
+//
+// The time the warning was sent is recorded on the grant, so that users are only warned once.
+//
+// The approved requests are read once per run, rather than querying the requests of each user by their end time.
+func (s *Service) SendGrantExpiryNotifications(ctx context.Context) error {
+	if s.GrantExpiryWarning == 0 {
+		return nil
+	}
+	log := zap.S()
+	now := s.Clock.Now()
+	warnFrom := now.Add(s.GrantExpiryWarning)
+
+	var opts []func(*ddb.QueryOpts)
+	for {
+		q := storage.ListRequestsForStatus{Status: access.APPROVED}
+		res, err := s.DB.Query(ctx, &q, opts...)
+		if err != nil && err != ddb.ErrNoItems {
+			return errors.Wrap(err, "listing approved requests")
+		}
+		for _, req := range q.Result {
+			if !isExpiring(req, now, warnFrom) {
+				continue
+			}
+			err = s.notifyGrantExpiring(ctx, req, now)
+			if err != nil {
+				log.Errorw("failed to send grant expiry notification", "request.id", req.ID, zap.Error(err))
+			}
+		}
+		if res == nil || res.NextPage == "" {
+			return nil
+		}
+		opts = []func(*ddb.QueryOpts){ddb.Page(res.NextPage)}
+	}
+}
+
+// isExpiring returns true if the request has an active grant which ends between now and warnFrom,
+// and the user hasn't been warned about it yet.
+func isExpiring(req access.Request, now, warnFrom time.Time) bool {
+	g := req.Grant
+	if g == nil || g.Status != ac_types.GrantStatusACTIVE || g.ExpiryNotificationSentAt != nil {
+		return false
+	}
+	return g.End.After(now) && !g.End.After(warnFrom)
+}
+
+func (s *Service) notifyGrantExpiring(ctx context.Context, req access.Request, now time.Time) error {
+	grant := *req.Grant
+	grant.ExpiryNotificationSentAt = &now
+	req.Grant = &grant
+
+	// The warning is recorded before the event is sent, so that a failure
+	// to send the event can't cause the user to be warned twice.
+	items, err := dbupdate.GetUpdateRequestItems(ctx, s.DB, req)
+	if err != nil {
+		return err
+	}
+	err = s.DB.PutBatch(ctx, items...)
+	if err != nil {
+		return errors.Wrap(err, "recording grant expiry notification")
+	}
+	return s.EventPutter.Put(ctx, gevent.GrantExpiring{Grant: grant.ToAHGrant(req.ID)})
+}
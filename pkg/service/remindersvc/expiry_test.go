@@ -0,0 +1,81 @@
+package remindersvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/common-fate/ddb/ddbmock"
+	ac_types "github.com/common-fate/granted-approvals/accesshandler/pkg/types"
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/gevent"
+	"github.com/common-fate/granted-approvals/pkg/service/remindersvc/mocks"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendGrantExpiryNotifications(t *testing.T) {
+	clk := clock.NewMock()
+	now := clk.Now()
+	sentAt := now.Add(-time.Minute)
+
+	type testcase struct {
+		name      string
+		grant     access.Grant
+		wantEvent bool
+	}
+
+	testcases := []testcase{
+		{
+			name:      "ok",
+			grant:     access.Grant{Status: ac_types.GrantStatusACTIVE, End: now.Add(10 * time.Minute), Subject: "user@example.com"},
+			wantEvent: true,
+		},
+		{
+			name:  "already notified",
+			grant: access.Grant{Status: ac_types.GrantStatusACTIVE, End: now.Add(10 * time.Minute), Subject: "user@example.com", ExpiryNotificationSentAt: &sentAt},
+		},
+		{
+			name:  "ends after the warning interval",
+			grant: access.Grant{Status: ac_types.GrantStatusACTIVE, End: now.Add(time.Hour), Subject: "user@example.com"},
+		},
+		{
+			name:  "already ended",
+			grant: access.Grant{Status: ac_types.GrantStatusACTIVE, End: now.Add(-time.Minute), Subject: "user@example.com"},
+		},
+		{
+			name:  "grant not active",
+			grant: access.Grant{Status: ac_types.GrantStatusPENDING, End: now.Add(10 * time.Minute), Subject: "user@example.com"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			grant := tc.grant
+			req := access.Request{ID: "req_123", RequestedBy: "user_1", Status: access.APPROVED, Grant: &grant}
+
+			db := ddbmock.New(t)
+			db.MockQuery(&storage.ListRequestsForStatus{Result: []access.Request{req}})
+			db.MockQuery(&storage.ListRequestReviewers{})
+
+			ctrl := gomock.NewController(t)
+			ep := mocks.NewMockEventPutter(ctrl)
+			if tc.wantEvent {
+				want := tc.grant
+				want.ExpiryNotificationSentAt = &now
+				ep.EXPECT().Put(gomock.Any(), gevent.GrantExpiring{Grant: want.ToAHGrant(req.ID)}).Times(1)
+			}
+
+			s := Service{
+				Clock:              clk,
+				DB:                 db,
+				EventPutter:        ep,
+				GrantExpiryWarning: 15 * time.Minute,
+			}
+			err := s.SendGrantExpiryNotifications(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
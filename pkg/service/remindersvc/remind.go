@@ -0,0 +1,205 @@
+package remindersvc
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/gevent"
+	"github.com/common-fate/granted-approvals/pkg/service/rulesvc"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/storage/dbcond"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// SendReminders checks all pending requests. Reviewers of requests which have been pending
+// for longer than the reminder interval are reminded once, and requests which have been pending for
+// longer than the escalation interval are escalated once to the escalation groups on the Access Rule.
+//
+// The time each reminder and escalation was sent is recorded on the Reviewer, so that running
+// SendReminders repeatedly never sends duplicate notifications. Requests which are reviewed or cancelled
+// while reminders are being sent are skipped.
+func (s *Service) SendReminders(ctx context.Context) error {
+	log := zap.S()
+
+	var pending []access.Request
+	var opts []func(*ddb.QueryOpts)
+	for {
+		q := storage.ListRequestsForStatus{Status: access.PENDING}
+		res, err := s.DB.Query(ctx, &q, opts...)
+		if err != nil {
+			return errors.Wrap(err, "listing pending requests")
+		}
+		pending = append(pending, q.Result...)
+		if res == nil || res.NextPage == "" {
+			break
+		}
+		opts = []func(*ddb.QueryOpts){ddb.Page(res.NextPage)}
+	}
+
+	log.Infow("checking pending requests", "count", len(pending))
+
+	for _, req := range pending {
+		// log and continue so that a single bad request doesn't prevent others from being processed.
+		err := s.processRequest(ctx, req)
+		if err != nil {
+			log.Errorw("failed to process reminders for request", "request.id", req.ID, zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (s *Service) processRequest(ctx context.Context, req access.Request) error {
+	now := s.Clock.Now()
+	pendingFor := now.Sub(req.CreatedAt)
+	if pendingFor < s.ReminderInterval {
+		return nil
+	}
+
+	reviewers := storage.ListRequestReviewers{RequestID: req.ID}
+	_, err := s.DB.Query(ctx, &reviewers)
+	if err != nil {
+		return errors.Wrap(err, "listing reviewers")
+	}
+
+	err = s.remind(ctx, req, reviewers.Result, now)
+	if err != nil {
+		return err
+	}
+
+	if pendingFor < s.EscalationInterval {
+		return nil
+	}
+	return s.escalate(ctx, req, reviewers.Result, now)
+}
+
+// remind notifies any reviewers of the request who haven't been reminded yet.
+func (s *Service) remind(ctx context.Context, req access.Request, reviewers []access.Reviewer, now time.Time) error {
+	var reviewerIDs []string
+	for _, r := range reviewers {
+		// reviewers who were added by an escalation were notified when the request was escalated.
+		if r.Notifications.ReminderSentAt != nil || r.Notifications.EscalatedAt != nil {
+			continue
+		}
+		// The reminder is recorded before the event is sent, so that a failure
+		// to send the event can't cause reviewers to be reminded twice.
+		err := s.setNotificationTime(ctx, r, "reminderSentAt", now)
+		if err == dbcond.ErrConditionFailed {
+			// the request was reviewed or cancelled after it was listed.
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "recording reminder")
+		}
+		reviewerIDs = append(reviewerIDs, r.ReviewerID)
+	}
+	if len(reviewerIDs) == 0 {
+		return nil
+	}
+	return s.EventPutter.Put(ctx, gevent.RequestReminder{Request: req, ReviewerIDs: reviewerIDs})
+}
+
+// escalate adds the members of the Access Rule's escalation groups as reviewers of the request.
+// Escalation only happens once per request.
+func (s *Service) escalate(ctx context.Context, req access.Request, reviewers []access.Reviewer, now time.Time) error {
+	for _, r := range reviewers {
+		if r.Notifications.EscalatedAt != nil {
+			return nil
+		}
+	}
+
+	rq := storage.GetAccessRuleVersion{ID: req.Rule, VersionID: req.RuleVersion}
+	_, err := s.DB.Query(ctx, &rq)
+	if err != nil {
+		return errors.Wrap(err, "getting access rule")
+	}
+	if len(rq.Result.Approval.EscalationGroups) == 0 {
+		return nil
+	}
+
+	approvers, err := rulesvc.GetEscalationApprovers(ctx, s.DB, *rq.Result)
+	if err != nil {
+		return errors.Wrap(err, "getting escalation approvers")
+	}
+
+	// the new reviewers are saved with a copy of the request, so the request is read again
+	// to make sure that it is still pending and that the copy is up to date.
+	gq := storage.GetRequest{ID: req.ID}
+	_, err = s.DB.Query(ctx, &gq)
+	if err != nil {
+		return errors.Wrap(err, "getting request")
+	}
+	req = *gq.Result
+	if req.Status != access.PENDING {
+		return nil
+	}
+
+	existing := make(map[string]access.Reviewer)
+	for _, r := range reviewers {
+		existing[r.ReviewerID] = r
+	}
+
+	var reviewerIDs []string
+	for _, u := range approvers {
+		// users cannot approve their own requests.
+		if u == req.RequestedBy {
+			continue
+		}
+		r, ok := existing[u]
+		if !ok {
+			r = access.Reviewer{
+				ReviewerID:    u,
+				Request:       req,
+				Notifications: access.Notifications{EscalatedAt: &now},
+			}
+			// the reviewer isn't written if it was added since the reviewers were listed.
+			err = s.Writer.Put(ctx, dbcond.Put{Item: &r, Condition: "attribute_not_exists(PK)"})
+			if err == dbcond.ErrConditionFailed {
+				err = s.setNotificationTime(ctx, r, "escalatedAt", now)
+			}
+		} else {
+			err = s.setNotificationTime(ctx, r, "escalatedAt", now)
+		}
+		if err == dbcond.ErrConditionFailed {
+			// the request was reviewed or cancelled after it was read.
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "recording escalation")
+		}
+		reviewerIDs = append(reviewerIDs, u)
+	}
+	if len(reviewerIDs) == 0 {
+		return nil
+	}
+	return s.EventPutter.Put(ctx, gevent.RequestEscalated{Request: req, ReviewerIDs: reviewerIDs})
+}
+
+// setNotificationTime records when the reviewer was notified, if the request is still pending.
+// Only the notification time is updated, as the copy of the request held by the reviewer may have changed since it was listed.
+// dbcond.ErrConditionFailed is returned if the request is no longer pending.
+func (s *Service) setNotificationTime(ctx context.Context, r access.Reviewer, attr string, t time.Time) error {
+	v, err := attributevalue.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return s.Writer.Update(ctx, dbcond.Update{
+		Item:       &r,
+		Expression: "SET #notifications.#attr = :t",
+		Condition:  "attribute_exists(PK) AND #request.#status = :pending",
+		Names: map[string]string{
+			"#notifications": "notifications",
+			"#attr":          attr,
+			"#request":       "request",
+			"#status":        "status",
+		},
+		Values: map[string]types.AttributeValue{
+			":t":       v,
+			":pending": &types.AttributeValueMemberS{Value: string(access.PENDING)},
+		},
+	})
+}
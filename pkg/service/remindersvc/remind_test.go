@@ -0,0 +1,181 @@
+package remindersvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/common-fate/ddb/ddbmock"
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/gevent"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/service/remindersvc/mocks"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/storage/dbcond"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendReminders(t *testing.T) {
+	clk := clock.NewMock()
+	now := clk.Now()
+	remindedAt := now.Add(-time.Hour)
+
+	type testcase struct {
+		name          string
+		request       access.Request
+		reviewers     []access.Reviewer
+		rule          rule.AccessRule
+		wantReminder  *gevent.RequestReminder
+		wantEscalated *gevent.RequestEscalated
+		wantWrites    []string
+		// reviewed is true if the request is approved after it is listed
+		reviewed bool
+	}
+
+	newRequest := func(pendingFor time.Duration) access.Request {
+		return access.Request{
+			ID:          "req_123",
+			RequestedBy: "requestor",
+			Rule:        "rule_1",
+			RuleVersion: "1",
+			Status:      access.PENDING,
+			CreatedAt:   now.Add(-pendingFor),
+		}
+	}
+
+	escalationRule := rule.AccessRule{
+		ID: "rule_1",
+		Approval: rule.Approval{
+			Users:            []string{"reviewer"},
+			EscalationGroups: []string{"admins"},
+		},
+	}
+
+	testcases := []testcase{
+		{
+			name:      "not pending long enough",
+			request:   newRequest(time.Minute),
+			reviewers: []access.Reviewer{{ReviewerID: "reviewer"}},
+			rule:      escalationRule,
+		},
+		{
+			name:         "reminds reviewers",
+			request:      newRequest(5 * time.Hour),
+			reviewers:    []access.Reviewer{{ReviewerID: "reviewer"}},
+			rule:         escalationRule,
+			wantReminder: &gevent.RequestReminder{Request: newRequest(5 * time.Hour), ReviewerIDs: []string{"reviewer"}},
+			wantWrites:   []string{"update reviewer reminderSentAt"},
+		},
+		{
+			name:      "requests reviewed after they are listed aren't reminded",
+			request:   newRequest(5 * time.Hour),
+			reviewers: []access.Reviewer{{ReviewerID: "reviewer"}},
+			rule:      escalationRule,
+			reviewed:  true,
+		},
+		{
+			name:      "reviewers are only reminded once",
+			request:   newRequest(5 * time.Hour),
+			reviewers: []access.Reviewer{{ReviewerID: "reviewer", Notifications: access.Notifications{ReminderSentAt: &remindedAt}}},
+			rule:      escalationRule,
+		},
+		{
+			name:          "escalates to escalation group members other than the requestor",
+			request:       newRequest(25 * time.Hour),
+			reviewers:     []access.Reviewer{{ReviewerID: "reviewer", Notifications: access.Notifications{ReminderSentAt: &remindedAt}}},
+			rule:          escalationRule,
+			wantEscalated: &gevent.RequestEscalated{Request: newRequest(25 * time.Hour), ReviewerIDs: []string{"admin"}},
+			wantWrites:    []string{"put admin"},
+		},
+		{
+			name:      "requests reviewed after they are listed aren't escalated",
+			request:   newRequest(25 * time.Hour),
+			reviewers: []access.Reviewer{{ReviewerID: "reviewer", Notifications: access.Notifications{ReminderSentAt: &remindedAt}}},
+			rule:      escalationRule,
+			reviewed:  true,
+		},
+		{
+			name:    "requests are only escalated once",
+			request: newRequest(25 * time.Hour),
+			reviewers: []access.Reviewer{
+				{ReviewerID: "reviewer", Notifications: access.Notifications{ReminderSentAt: &remindedAt}},
+				{ReviewerID: "admin", Notifications: access.Notifications{EscalatedAt: &remindedAt}},
+			},
+			rule: escalationRule,
+		},
+		{
+			name:      "no escalation groups on the rule",
+			request:   newRequest(25 * time.Hour),
+			reviewers: []access.Reviewer{{ReviewerID: "reviewer", Notifications: access.Notifications{ReminderSentAt: &remindedAt}}},
+			rule:      rule.AccessRule{ID: "rule_1", Approval: rule.Approval{Users: []string{"reviewer"}}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			db.MockQuery(&storage.ListRequestsForStatus{Result: []access.Request{tc.request}})
+			db.MockQuery(&storage.ListRequestReviewers{Result: tc.reviewers})
+			db.MockQuery(&storage.GetAccessRuleVersion{Result: &tc.rule})
+			db.MockQuery(&storage.GetGroup{Result: &identity.Group{ID: "admins", Users: []string{"admin", "requestor"}}})
+			current := tc.request
+			w := testWriter{}
+			if tc.reviewed {
+				current.Status = access.APPROVED
+				w.err = dbcond.ErrConditionFailed
+			}
+			db.MockQuery(&storage.GetRequest{Result: &current})
+
+			ctrl := gomock.NewController(t)
+			ep := mocks.NewMockEventPutter(ctrl)
+			if tc.wantReminder != nil {
+				ep.EXPECT().Put(gomock.Any(), *tc.wantReminder).Times(1)
+			}
+			if tc.wantEscalated != nil {
+				ep.EXPECT().Put(gomock.Any(), *tc.wantEscalated).Times(1)
+			}
+
+			s := Service{
+				Clock:              clk,
+				DB:                 db,
+				EventPutter:        ep,
+				Writer:             &w,
+				ReminderInterval:   4 * time.Hour,
+				EscalationInterval: 24 * time.Hour,
+			}
+			err := s.SendReminders(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantWrites, w.writes)
+		})
+	}
+}
+
+// testWriter records the writes which are made, unless err is set.
+type testWriter struct {
+	writes []string
+	err    error
+}
+
+func (w *testWriter) Put(ctx context.Context, put dbcond.Put) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.writes = append(w.writes, "put "+put.Item.(*access.Reviewer).ReviewerID)
+	return nil
+}
+
+func (w *testWriter) Update(ctx context.Context, update dbcond.Update) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.writes = append(w.writes, "update "+update.Item.(*access.Reviewer).ReviewerID+" "+update.Names["#attr"])
+	return nil
+}
+
+func (w *testWriter) TransactPut(ctx context.Context, puts ...dbcond.Put) error {
+	return errors.New("not implemented")
+}
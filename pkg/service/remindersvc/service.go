@@ -0,0 +1,34 @@
+package remindersvc
+
+import (
+	"context"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/gevent"
+	"github.com/common-fate/granted-approvals/pkg/storage/dbcond"
+)
+
+// Service reminds reviewers about pending Access Requests, escalates requests which
+// haven't been reviewed in time, and warns users when their grants are about to expire.
+type Service struct {
+	Clock       clock.Clock
+	DB          ddb.Storage
+	EventPutter EventPutter
+	// Writer records the notifications sent to reviewers without overwriting the rest of the reviewer.
+	Writer dbcond.Writer
+	// ReminderInterval is how long a request can be pending before its reviewers are reminded.
+	ReminderInterval time.Duration
+	// EscalationInterval is how long a request can be pending before
+	// it is escalated to the escalation groups on the Access Rule.
+	EscalationInterval time.Duration
+	// GrantExpiryWarning is how long before an active grant ends that the user is warned.
+	// Expiry warnings are disabled if this is zero.
+	GrantExpiryWarning time.Duration
+}
+
+//go:generate go run github.com/golang/mock/mockgen -destination=mocks/eventputter.go -package=mocks . EventPutter
+type EventPutter interface {
+	Put(ctx context.Context, detail gevent.EventTyper) error
+}
@@ -54,8 +54,33 @@ func GetApprovers(ctx context.Context, db ddb.Storage, rule rule.AccessRule) ([]
 		users.Add(u)
 	}
 
+	err := addGroupMembers(ctx, db, users, rule.Approval.Groups)
+	if err != nil {
+		return nil, err
+	}
+
+	res := users.All()
+	return res, nil
+}
+
+// GetEscalationApprovers gets all the members of the escalation groups for a rule.
+// Like GetApprovers, users are de-duplicated.
+func GetEscalationApprovers(ctx context.Context, db ddb.Storage, rule rule.AccessRule) ([]string, error) {
+	users := newUserMap()
+
+	err := addGroupMembers(ctx, db, users, rule.Approval.EscalationGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	res := users.All()
+	return res, nil
+}
+
+// addGroupMembers looks up each group concurrently and adds its members to users.
+func addGroupMembers(ctx context.Context, db ddb.Storage, users *userMap, groups []string) error {
 	wg, gctx := errgroup.WithContext(ctx)
-	for _, g := range rule.Approval.Groups {
+	for _, g := range groups {
 		id := g
 		wg.Go(func() error {
 			q := &storage.GetGroup{ID: id}
@@ -63,17 +88,12 @@ func GetApprovers(ctx context.Context, db ddb.Storage, rule rule.AccessRule) ([]
 			if err != nil {
 				return err
 			}
-			for _, u := range q.Result.Users {
+			// members of nested groups are approvers too.
+			for _, u := range q.Result.EffectiveUsers() {
 				users.Add(u)
 			}
 			return nil
 		})
 	}
-	err := wg.Wait()
-	if err != nil {
-		return nil, err
-	}
-
-	res := users.All()
-	return res, nil
+	return wg.Wait()
 }
@@ -67,6 +67,19 @@ func TestGetApprovers(t *testing.T) {
 			},
 			want: []string{"usr_2"},
 		},
+		{
+			name: "nested group members",
+			giveRule: rule.AccessRule{
+				Approval: rule.Approval{
+					Groups: []string{"grp_1"},
+				},
+			},
+			mockGetGroup: &identity.Group{
+				Users:          []string{"usr_2"},
+				InheritedUsers: []string{"usr_3"},
+			},
+			want: []string{"usr_2", "usr_3"},
+		},
 		// returning an empty array rather than nil ensures that our API endpoints
 		// that use this method don't return null when the frontend is expecting an array.
 		{
@@ -12,7 +12,12 @@ import (
 	"github.com/common-fate/granted-approvals/pkg/types"
 )
 
-func (s *Service) ArchiveAccessRule(ctx context.Context, user *identity.User, in rule.AccessRule) (*rule.AccessRule, error) {
+// ArchiveAccessRule archives the rule and cancels any pending requests for it.
+// If isAdmin is false, the user must be an owner of the rule.
+func (s *Service) ArchiveAccessRule(ctx context.Context, user *identity.User, in rule.AccessRule, isAdmin bool) (*rule.AccessRule, error) {
+	if !isAdmin && !in.IsOwner(user) {
+		return nil, ErrUserNotAuthorized
+	}
 	if in.Status == rule.ARCHIVED {
 		return nil, ErrAccessRuleAlreadyArchived
 	}
@@ -21,6 +21,7 @@ func TestArchiveAccessRule(t *testing.T) {
 		name      string
 		givenUser identity.User
 		givenRule rule.AccessRule
+		notAdmin  bool
 		wantErr   error
 		want      *rule.AccessRule
 	}
@@ -47,6 +48,11 @@ func TestArchiveAccessRule(t *testing.T) {
 	want.Metadata.UpdatedAt = now
 	want.Current = true
 
+	mockRuleWithOwner := mockRule
+	mockRuleWithOwner.Owners = rule.Owners{Users: []string{"owner"}}
+	wantOwner := want
+	wantOwner.Owners = mockRuleWithOwner.Owners
+
 	testcases := []testcase{
 		{
 			name:      "ok",
@@ -62,6 +68,20 @@ func TestArchiveAccessRule(t *testing.T) {
 			},
 			wantErr: ErrAccessRuleAlreadyArchived,
 		},
+		{
+			name:      "owner can archive the rule",
+			givenUser: identity.User{ID: "owner"},
+			givenRule: mockRuleWithOwner,
+			notAdmin:  true,
+			want:      &wantOwner,
+		},
+		{
+			name:      "user who isn't an owner can't archive the rule",
+			givenUser: mockUser,
+			givenRule: mockRuleWithOwner,
+			notAdmin:  true,
+			wantErr:   ErrUserNotAuthorized,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -77,7 +97,7 @@ func TestArchiveAccessRule(t *testing.T) {
 				DB:    db,
 			}
 
-			got, err := s.ArchiveAccessRule(context.Background(), &tc.givenUser, tc.givenRule)
+			got, err := s.ArchiveAccessRule(context.Background(), &tc.givenUser, tc.givenRule, !tc.notAdmin)
 
 			// This is the only thing from service layer that we can't mock yet, hence the override
 			if err == nil {
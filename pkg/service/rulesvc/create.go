@@ -2,61 +2,81 @@ package rulesvc
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/common-fate/apikit/logger"
 	ahTypes "github.com/common-fate/granted-approvals/accesshandler/pkg/types"
 	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/policy"
 	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/tickets"
 	"github.com/common-fate/granted-approvals/pkg/types"
 	"github.com/pkg/errors"
 )
 
 func (s *Service) CreateAccessRule(ctx context.Context, user *identity.User, in types.CreateAccessRuleRequest) (*rule.AccessRule, error) {
-	id := types.NewAccessRuleID()
+	return s.createAccessRule(ctx, user, types.NewAccessRuleID(), in, nil)
+}
 
+// createAccessRule saves a new access rule with the ID. The update message is recorded on the first version of the rule if it is provided.
+func (s *Service) createAccessRule(ctx context.Context, user *identity.User, id string, in types.CreateAccessRuleRequest, updateMessage *string) (*rule.AccessRule, error) {
 	log := logger.Get(ctx).With("user.id", user.ID, "access_rule.id", id)
 	now := s.Clock.Now()
 
-	// After verifying the provider, we can save the provider type to the rule for convenience
-	p, err := s.verifyRuleTarget(ctx, in.Target)
+	err := validateTimeConstraints(in.TimeConstraints)
 	if err != nil {
 		return nil, err
 	}
-
-	target := rule.Target{
-		ProviderID:     in.Target.ProviderId,
-		ProviderType:   p.Type,
-		With:           make(map[string]string),
-		WithSelectable: make(map[string][]string),
+	err = policy.Validate(rule.PolicyFromAPI(in.Policy))
+	if err != nil {
+		return nil, err
+	}
+	err = policy.ValidateApprovers(rule.PolicyFromAPI(in.Policy), rule.ApprovalFromAPI(in.Approval))
+	if err != nil {
+		return nil, err
+	}
+	err = rule.ValidateRequestFields(rule.RequestFieldsFromAPI(in.RequestFields))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRequestFields, err)
+	}
+	err = validateTicketRequirement(rule.TicketRequirementFromAPI(in.Ticket), rule.RequestFieldsFromAPI(in.RequestFields))
+	if err != nil {
+		return nil, err
 	}
 
-	for k, values := range in.Target.With.AdditionalProperties {
-		// min length 1 is configured in the api spec so len(0) is handled by builtin validation
-		if len(values) == 1 {
-			target.With[k] = values[0]
-		} else {
-			// store the selectables with value and label
-			target.WithSelectable[k] = values
-		}
+	// After verifying the provider, we can save the provider type to the rule for convenience
+	p, err := s.verifyRuleTarget(ctx, in.Target.ProviderId)
+	if err != nil {
+		return nil, err
 	}
 
+	target := targetFromAPI(in.Target)
+	target.ProviderType = p.Type
+
 	rul := rule.AccessRule{
 		ID:          id,
-		Approval:    rule.Approval(in.Approval),
+		Approval:    rule.ApprovalFromAPI(in.Approval),
 		Status:      rule.ACTIVE,
 		Description: in.Description,
 		Name:        in.Name,
 		Groups:      in.Groups,
 		Metadata: rule.AccessRuleMetadata{
-			CreatedAt: now,
-			CreatedBy: user.ID,
-			UpdatedAt: now,
-			UpdatedBy: user.ID,
+			CreatedAt:     now,
+			CreatedBy:     user.ID,
+			UpdatedAt:     now,
+			UpdatedBy:     user.ID,
+			UpdateMessage: updateMessage,
 		},
 		Target:          target,
 		TimeConstraints: in.TimeConstraints,
+		Notifications:   rule.NotificationsFromAPI(in.Notifications),
+		Owners:          rule.OwnersFromAPI(in.Owners),
+		Limits:          rule.LimitsFromAPI(in.Limits),
+		Policy:          rule.PolicyFromAPI(in.Policy),
+		RequestFields:   rule.RequestFieldsFromAPI(in.RequestFields),
+		Ticket:          rule.TicketRequirementFromAPI(in.Ticket),
 		Version:         types.NewVersionID(),
 		Current:         true,
 	}
@@ -72,9 +92,61 @@ func (s *Service) CreateAccessRule(ctx context.Context, user *identity.User, in
 	return &rul, nil
 }
 
+// validateTimeConstraints returns ErrInvalidTimeConstraints if the access window is invalid,
+// or if the minimum notice is longer than the scheduling horizon.
+func validateTimeConstraints(tc types.TimeConstraints) error {
+	if tc.AccessWindow != nil {
+		err := rule.ValidateAccessWindow(*tc.AccessWindow)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidTimeConstraints, err)
+		}
+	}
+	if tc.MinNoticeSeconds != nil && tc.MaxAdvanceSeconds != nil && *tc.MinNoticeSeconds > *tc.MaxAdvanceSeconds {
+		return fmt.Errorf("%w: minNoticeSeconds must not be greater than maxAdvanceSeconds", ErrInvalidTimeConstraints)
+	}
+	return nil
+}
+
+// validateTicketRequirement returns ErrInvalidTicketRequirement if the ticket system isn't supported,
+// or if the ticket ID isn't entered in a required TEXT request field of the rule.
+func validateTicketRequirement(t rule.TicketRequirement, fields []rule.RequestField) error {
+	if !t.IsSet() {
+		return nil
+	}
+	_, err := tickets.Registry().Lookup(t.System)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidTicketRequirement, err)
+	}
+	err = rule.ValidateTicketRequirement(t, fields)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidTicketRequirement, err)
+	}
+	return nil
+}
+
+// targetFromAPI converts the api representation of a rule target to the internal type, without the provider type.
+func targetFromAPI(in types.CreateAccessRuleTarget) rule.Target {
+	target := rule.Target{
+		ProviderID:     in.ProviderId,
+		With:           make(map[string]string),
+		WithSelectable: make(map[string][]string),
+	}
+
+	for k, values := range in.With.AdditionalProperties {
+		// min length 1 is configured in the api spec so len(0) is handled by builtin validation
+		if len(values) == 1 {
+			target.With[k] = values[0]
+		} else {
+			// store the selectables with value and label
+			target.WithSelectable[k] = values
+		}
+	}
+	return target
+}
+
 // verifyRuleTarget fetches the provider and returns it if it exists
-func (s *Service) verifyRuleTarget(ctx context.Context, target types.CreateAccessRuleTarget) (*ahTypes.Provider, error) {
-	p, err := s.AHClient.GetProviderWithResponse(ctx, target.ProviderId)
+func (s *Service) verifyRuleTarget(ctx context.Context, providerID string) (*ahTypes.Provider, error) {
+	p, err := s.AHClient.GetProviderWithResponse(ctx, providerID)
 	if err != nil {
 		return nil, err
 	}
@@ -37,7 +37,7 @@ func TestCreateAccessRule(t *testing.T) {
 	mockRule := rule.AccessRule{
 		ID:          ruleID,
 		Version:     versionID,
-		Approval:    rule.Approval(in.Approval),
+		Approval:    rule.ApprovalFromAPI(in.Approval),
 		Status:      rule.ACTIVE,
 		Description: in.Description,
 		Name:        in.Name,
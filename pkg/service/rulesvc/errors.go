@@ -18,4 +18,31 @@ var (
 
 	// ErrAccessRuleAlreadyArchived is returned if an archive request is made for a rule which is already archived
 	ErrAccessRuleAlreadyArchived = errors.New("access rule already archived")
+
+	// ErrAccessRuleArchived is returned if an upsert is made for a rule which is archived
+	ErrAccessRuleArchived = errors.New("access rule is archived and can't be updated")
+
+	// ErrInvalidSlug is returned if the slug for an upsert can't be used as an access rule id
+	ErrInvalidSlug = errors.New("slugs must be at most 63 lowercase letters, numbers and hyphens, starting with a letter or number")
+
+	// ErrVersionConflict is returned if the current version of a rule doesn't match the version expected by the caller
+	ErrVersionConflict = errors.New("the access rule has been modified since the expected version")
+
+	// ErrInvalidTargetOption is returned if a value in the target of a rule isn't one of the provider's options for the argument
+	ErrInvalidTargetOption = errors.New("invalid target option")
+
+	// ErrInvalidTimeConstraints is returned if the access window, notice or scheduling horizon of a rule is invalid
+	ErrInvalidTimeConstraints = errors.New("invalid time constraints")
+
+	// ErrInvalidRequestFields is returned if the request fields of a rule are invalid
+	ErrInvalidRequestFields = errors.New("invalid request fields")
+
+	// ErrInvalidTicketRequirement is returned if the ticket system of a rule isn't supported, or the ticket ID field isn't a required request field
+	ErrInvalidTicketRequirement = errors.New("invalid ticket requirement")
+
+	// ErrVersionIsCurrent is returned if a rule is rolled back to its current version
+	ErrVersionIsCurrent = errors.New("the version is already the current version of the access rule")
+
+	// ErrTooManyDeclinedRequests is returned if changing the target of a rule would decline more pending requests than can be saved along with the new version
+	ErrTooManyDeclinedRequests = errors.New("too many pending requests would be declined by changing the target of the access rule, review them before changing the target")
 )
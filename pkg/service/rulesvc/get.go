@@ -38,7 +38,8 @@ func canGet(user *identity.User, rule *rule.AccessRule, isAdmin bool) bool {
 		}
 	}
 	// DE = User can see a rule they're an approver of (via groups)
-	for _, group := range user.Groups {
+	userGroups := user.EffectiveGroups()
+	for _, group := range userGroups {
 		for _, g := range rule.Approval.Groups {
 			if g == group {
 				return true
@@ -46,7 +47,7 @@ func canGet(user *identity.User, rule *rule.AccessRule, isAdmin bool) bool {
 		}
 	}
 	// DE = User can see a rule they're assigned to (via the groups)
-	for _, group := range user.Groups {
+	for _, group := range userGroups {
 		for _, g := range rule.Groups {
 			if g == group {
 				return true
@@ -0,0 +1,89 @@
+package rulesvc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+type RollbackOpts struct {
+	User *identity.User
+	// Current is the current version of the rule.
+	Current rule.AccessRule
+	// Version is the earlier version of the rule which is restored.
+	Version rule.AccessRule
+	// IsAdmin is true if the user can manage the rule without being an owner of it.
+	IsAdmin bool
+}
+
+// RollbackAccessRule creates a new current version of the rule, copied from an earlier version.
+// The versions involved are recorded in the update message and metadata of the new version.
+// Pending requests which can't be granted with the target of the earlier version are declined.
+func (s *Service) RollbackAccessRule(ctx context.Context, in RollbackOpts) (*rule.AccessRule, error) {
+	if !in.IsAdmin && !in.Current.IsOwner(in.User) {
+		return nil, ErrUserNotAuthorized
+	}
+	if in.Current.Status == rule.ARCHIVED {
+		return nil, ErrAccessRuleArchived
+	}
+	if in.Version.Version == in.Current.Version {
+		return nil, ErrVersionIsCurrent
+	}
+
+	// makes a copy of the current version which will be mutated, so that the status and creation metadata are kept
+	newVersion := in.Current
+	newVersion.Name = in.Version.Name
+	newVersion.Description = in.Version.Description
+	newVersion.Groups = in.Version.Groups
+	newVersion.Approval = in.Version.Approval
+	newVersion.TimeConstraints = in.Version.TimeConstraints
+	newVersion.Notifications = in.Version.Notifications
+	newVersion.Owners = in.Version.Owners
+	newVersion.Limits = in.Version.Limits
+	newVersion.Policy = in.Version.Policy
+	newVersion.RequestFields = in.Version.RequestFields
+	newVersion.Ticket = in.Version.Ticket
+
+	msg := fmt.Sprintf("Rolled back to version %s", in.Version.Version)
+	meta := map[string]interface{}{
+		"rollbackFromVersion": in.Current.Version,
+		"rollbackToVersion":   in.Version.Version,
+	}
+	newVersion.Metadata.UpdatedBy = in.User.ID
+	newVersion.Metadata.UpdatedAt = s.Clock.Now()
+	newVersion.Metadata.UpdateMessage = &msg
+	newVersion.Metadata.UpdateMetadata = &meta
+	newVersion.Version = types.NewVersionID()
+	newVersion.Current = true
+
+	// the earlier target is validated in the same way as an updated target, as the provider's options may have changed since it was saved.
+	var declined []access.Request
+	if !sameTarget(in.Current.Target, in.Version.Target) {
+		var err error
+		newVersion.Target, declined, err = s.changeTarget(ctx, in.Current, in.Version.Target)
+		if err != nil {
+			return nil, err
+		}
+	}
+	declines, err := s.declineItems(ctx, in.User.ID, declined)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set the existing version to not current
+	in.Current.Current = false
+
+	err = s.putVersion(ctx, &newVersion, &in.Current, declines...)
+	if err != nil {
+		return nil, err
+	}
+	err = s.notifyDeclined(ctx, in.User.ID, declined)
+	if err != nil {
+		return nil, err
+	}
+	return &newVersion, nil
+}
@@ -0,0 +1,112 @@
+package rulesvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/common-fate/ddb/ddbmock"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackAccessRule(t *testing.T) {
+	type testcase struct {
+		name         string
+		givenUser    identity.User
+		givenCurrent rule.AccessRule
+		givenVersion rule.AccessRule
+		notAdmin     bool
+		wantErr      error
+		want         *rule.AccessRule
+	}
+
+	clk := clock.NewMock()
+	created := clk.Now().Add(-time.Hour)
+
+	old := rule.AccessRule{
+		ID:              "rule",
+		Version:         "ver_1",
+		Status:          rule.ACTIVE,
+		Name:            "Production",
+		Groups:          []string{"engineering"},
+		Approval:        rule.Approval{Groups: []string{"leads"}},
+		Target:          rule.Target{ProviderID: "aws", With: map[string]string{"accountId": "123"}},
+		TimeConstraints: types.TimeConstraints{MaxDurationSeconds: 3600},
+		Metadata:        rule.AccessRuleMetadata{CreatedAt: created, CreatedBy: "creator", UpdatedAt: created, UpdatedBy: "creator"},
+	}
+	current := old
+	current.Version = "ver_2"
+	current.Current = true
+	current.Name = "Production (no approval)"
+	current.Approval = rule.Approval{}
+	current.Owners = rule.Owners{Users: []string{"owner"}}
+
+	msg := "Rolled back to version ver_1"
+	want := old
+	want.Current = true
+	want.Metadata.UpdatedAt = clk.Now()
+	want.Metadata.UpdatedBy = "user1"
+	want.Metadata.UpdateMessage = &msg
+	want.Metadata.UpdateMetadata = &map[string]interface{}{
+		"rollbackFromVersion": "ver_2",
+		"rollbackToVersion":   "ver_1",
+	}
+
+	archived := current
+	archived.Status = rule.ARCHIVED
+
+	testcases := []testcase{
+		{
+			name:         "ok",
+			givenUser:    identity.User{ID: "user1"},
+			givenCurrent: current,
+			givenVersion: old,
+			want:         &want,
+		},
+		{
+			name:         "version is current",
+			givenUser:    identity.User{ID: "user1"},
+			givenCurrent: current,
+			givenVersion: current,
+			wantErr:      ErrVersionIsCurrent,
+		},
+		{
+			name:         "archived rule",
+			givenUser:    identity.User{ID: "user1"},
+			givenCurrent: archived,
+			givenVersion: old,
+			wantErr:      ErrAccessRuleArchived,
+		},
+		{
+			name:         "user who isn't an owner can't roll back the rule",
+			givenUser:    identity.User{ID: "user1"},
+			givenCurrent: current,
+			givenVersion: old,
+			notAdmin:     true,
+			wantErr:      ErrUserNotAuthorized,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := Service{Clock: clk, DB: &ddbmock.Client{}, Writer: &testWriter{}}
+			got, err := s.RollbackAccessRule(context.Background(), RollbackOpts{
+				User:    &tc.givenUser,
+				Current: tc.givenCurrent,
+				Version: tc.givenVersion,
+				IsAdmin: !tc.notAdmin,
+			})
+			if got != nil {
+				assert.NotEqual(t, tc.givenCurrent.Version, got.Version)
+				// the version ID is random, so it is overridden before comparing the rule
+				got.Version = tc.givenVersion.Version
+			}
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
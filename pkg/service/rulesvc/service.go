@@ -1,9 +1,16 @@
 package rulesvc
 
 import (
+	"context"
+
+	ddbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/benbjohnson/clock"
 	"github.com/common-fate/ddb"
 	"github.com/common-fate/granted-approvals/accesshandler/pkg/types"
+	"github.com/common-fate/granted-approvals/pkg/cache"
+	"github.com/common-fate/granted-approvals/pkg/gevent"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/storage/dbcond"
 )
 
 // Service holds business logic relating to Access Rules.
@@ -11,4 +18,47 @@ type Service struct {
 	Clock    clock.Clock
 	AHClient types.ClientWithResponsesInterface
 	DB       ddb.Storage
+	Cache    CacheService
+	// Writer saves new versions of rules, so that a version is only saved if the rule hasn't changed since it was read.
+	Writer dbcond.Writer
+	// EventPutter notifies requesters and reviewers of requests which are declined when the target of a rule changes.
+	EventPutter EventPutter
+}
+
+type EventPutter interface {
+	Put(ctx context.Context, detail gevent.EventTyper) error
+}
+
+// maxTransactItems is the number of items which DynamoDB allows in a transaction.
+const maxTransactItems = 100
+
+// CacheService loads the options for provider arguments, which are used to validate the targets of rules.
+type CacheService interface {
+	RefreshCachedProviderArgOptions(ctx context.Context, providerId string, argId string) (bool, []cache.ProviderOption, error)
+	LoadCachedProviderArgOptions(ctx context.Context, providerId string, argId string) (bool, []cache.ProviderOption, error)
+}
+
+// putVersion saves the new current version of a rule along with the previous version, which must have Current set to false,
+// and the items which decline the pending requests which can't be granted with the new version.
+// The write is conditional on the previous version still being the current version of the rule, and on the declined
+// requests still being pending, so ErrVersionConflict is returned if the rule or the requests were changed after they were read.
+func (s *Service) putVersion(ctx context.Context, newVersion *rule.AccessRule, previous *rule.AccessRule, declines ...dbcond.Put) error {
+	puts := []dbcond.Put{
+		{Item: newVersion, Condition: "attribute_not_exists(PK)"},
+		{
+			Item:      previous,
+			Condition: "#current = :current",
+			Names:     map[string]string{"#current": "current"},
+			Values:    map[string]ddbtypes.AttributeValue{":current": &ddbtypes.AttributeValueMemberBOOL{Value: true}},
+		},
+	}
+	puts = append(puts, declines...)
+	if len(puts) > maxTransactItems {
+		return ErrTooManyDeclinedRequests
+	}
+	err := s.Writer.TransactPut(ctx, puts...)
+	if err == dbcond.ErrConditionFailed {
+		return ErrVersionConflict
+	}
+	return err
 }
@@ -0,0 +1,213 @@
+package rulesvc
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/cache"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+// SimulateOpts filters the rules which are simulated. Empty filters match every rule.
+type SimulateOpts struct {
+	ProviderID string
+	// With matches rules which grant these argument values.
+	// Values may be the option value, such as a permission set ARN, or the label of the cached option, such as the permission set name.
+	With map[string]string
+	// UserID matches rules which the user can request.
+	UserID string
+}
+
+// ApprovalOutcome describes how requests made for a rule are approved.
+type ApprovalOutcome string
+
+const (
+	ApprovalAutomatic ApprovalOutcome = "AUTO_APPROVED"
+	ApprovalRequired  ApprovalOutcome = "REVIEW_REQUIRED"
+	// ApprovalConditional is used when the rule's policy or ticket requirement decides
+	// whether a request is approved automatically when it is made.
+	ApprovalConditional ApprovalOutcome = "CONDITIONAL"
+)
+
+// Simulation is who can request and approve access through a rule.
+type Simulation struct {
+	Rule rule.AccessRule
+	// RequestableUsers are the IDs of the active users in one of the rule's groups.
+	RequestableUsers []string
+	// Approvers are the IDs of the users who can approve requests, found with GetApprovers.
+	// If the simulation is for a user, the user isn't included because users can't approve their own requests.
+	Approvers []string
+	Approval  ApprovalOutcome
+}
+
+func (s Simulation) ToAPI() types.AccessSimulationResult {
+	return types.AccessSimulationResult{
+		AccessRule:       s.Rule.ToAPI(),
+		RequestableUsers: s.RequestableUsers,
+		Approvers:        s.Approvers,
+		Approval:         types.AccessSimulationResultApproval(s.Approval),
+	}
+}
+
+// Simulate evaluates the current active rules against the users and groups in the database,
+// returning who can request and approve each rule which matches the filters. Results are sorted by rule name.
+//
+// If opts.UserID is set and the user doesn't exist, ddb.ErrNoItems is returned.
+func Simulate(ctx context.Context, db ddb.Storage, opts SimulateOpts) ([]Simulation, error) {
+	rq := storage.ListAccessRulesForStatus{Status: rule.ACTIVE}
+	_, err := db.Query(ctx, &rq)
+	if err != nil && err != ddb.ErrNoItems {
+		return nil, err
+	}
+
+	var users []identity.User
+	if opts.UserID != "" {
+		uq := storage.GetUser{ID: opts.UserID}
+		_, err = db.Query(ctx, &uq)
+		if err != nil {
+			return nil, err
+		}
+		users = []identity.User{*uq.Result}
+	} else {
+		uq := storage.ListUsersForStatus{Status: types.IdpStatusACTIVE}
+		_, err = db.Query(ctx, &uq)
+		if err != nil && err != ddb.ErrNoItems {
+			return nil, err
+		}
+		users = uq.Result
+	}
+
+	options := &optionLabels{db: db, labels: make(map[string][]cache.ProviderOption)}
+	res := []Simulation{}
+	for _, r := range rq.Result {
+		if opts.ProviderID != "" && r.Target.ProviderID != opts.ProviderID {
+			continue
+		}
+		ok, err := options.matchesTarget(ctx, r.Target, opts.With)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		requestable := requestableUsers(r, users)
+		if opts.UserID != "" && len(requestable) == 0 {
+			continue
+		}
+
+		approvers, err := GetApprovers(ctx, db, r)
+		if err != nil {
+			return nil, err
+		}
+		if opts.UserID != "" {
+			approvers = without(approvers, opts.UserID)
+		}
+
+		res = append(res, Simulation{
+			Rule:             r,
+			RequestableUsers: requestable,
+			Approvers:        approvers,
+			Approval:         approvalOutcome(r),
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Rule.Name < res[j].Rule.Name
+	})
+	return res, nil
+}
+
+// requestableUsers returns the sorted IDs of the users who belong to one of the rule's groups, including through nested groups.
+func requestableUsers(r rule.AccessRule, users []identity.User) []string {
+	res := []string{}
+	for _, u := range users {
+		if u.Status != types.IdpStatusACTIVE {
+			continue
+		}
+		for _, g := range r.Groups {
+			if u.BelongsToGroup(g) {
+				res = append(res, u.ID)
+				break
+			}
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// approvalOutcome reports CONDITIONAL for rules with a policy or with ticket states which approve requests,
+// as these are only evaluated when a request is made.
+func approvalOutcome(r rule.AccessRule) ApprovalOutcome {
+	if r.Policy.IsSet() || (r.Ticket.IsSet() && len(r.Ticket.AutoApproveStates) > 0) {
+		return ApprovalConditional
+	}
+	if r.Approval.IsRequired() {
+		return ApprovalRequired
+	}
+	return ApprovalAutomatic
+}
+
+// optionLabels matches argument values against the labels of cached provider options.
+// The options for each provider argument are only loaded once.
+type optionLabels struct {
+	db     ddb.Storage
+	labels map[string][]cache.ProviderOption
+}
+
+// matchesTarget returns true if the target grants every value in with,
+// either as a fixed value or as one of the values which requesters can select.
+func (o *optionLabels) matchesTarget(ctx context.Context, t rule.Target, with map[string]string) (bool, error) {
+	for arg, want := range with {
+		values := t.WithSelectable[arg]
+		if v, ok := t.With[arg]; ok {
+			values = append([]string{v}, values...)
+		}
+		if contains(values, want) {
+			continue
+		}
+		opts, err := o.load(ctx, t.ProviderID, arg)
+		if err != nil {
+			return false, err
+		}
+		found := false
+		for _, opt := range opts {
+			if strings.EqualFold(opt.Label, want) && contains(values, opt.Value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (o *optionLabels) load(ctx context.Context, providerID, argID string) ([]cache.ProviderOption, error) {
+	key := providerID + "#" + argID
+	if opts, ok := o.labels[key]; ok {
+		return opts, nil
+	}
+	q := storage.GetProviderOptions{ProviderID: providerID, ArgID: argID}
+	_, err := o.db.Query(ctx, &q)
+	if err != nil && err != ddb.ErrNoItems {
+		return nil, err
+	}
+	o.labels[key] = q.Result
+	return q.Result, nil
+}
+
+func without(set []string, str string) []string {
+	res := []string{}
+	for _, s := range set {
+		if s != str {
+			res = append(res, s)
+		}
+	}
+	return res
+}
@@ -0,0 +1,131 @@
+package rulesvc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/ddb/ddbmock"
+	"github.com/common-fate/granted-approvals/pkg/cache"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulate(t *testing.T) {
+	admin := rule.AccessRule{
+		ID:     "rul_1",
+		Name:   "Admin",
+		Groups: []string{"grp_admins"},
+		Target: rule.Target{
+			ProviderID:     "aws-sso",
+			With:           map[string]string{"accountId": "123456789012"},
+			WithSelectable: map[string][]string{"permissionSetArn": {"arn:aws:sso:::permissionSet/ps-admin", "arn:aws:sso:::permissionSet/ps-read"}},
+		},
+		Approval: rule.Approval{Groups: []string{"grp_approvers"}},
+	}
+	readOnly := rule.AccessRule{
+		ID:     "rul_2",
+		Name:   "Read Only",
+		Groups: []string{"grp_everyone"},
+		Target: rule.Target{
+			ProviderID: "aws-sso",
+			With:       map[string]string{"accountId": "999999999999"},
+		},
+	}
+	okta := rule.AccessRule{
+		ID:     "rul_3",
+		Name:   "Okta",
+		Groups: []string{"grp_everyone"},
+		Target: rule.Target{
+			ProviderID: "okta",
+			With:       map[string]string{"groupId": "okta_admins"},
+		},
+		Policy: rule.Policy{Conditions: []rule.PolicyCondition{{Expression: "true", Effect: rule.PolicyAutoApprove}}},
+	}
+	users := []identity.User{
+		{ID: "usr_1", Status: types.IdpStatusACTIVE, Groups: []string{"grp_everyone", "grp_admins"}},
+		{ID: "usr_2", Status: types.IdpStatusACTIVE, Groups: []string{"grp_everyone"}, InheritedGroups: []string{"grp_admins"}},
+		{ID: "usr_3", Status: types.IdpStatusACTIVE, Groups: []string{"grp_everyone"}},
+	}
+
+	type testcase struct {
+		name        string
+		give        SimulateOpts
+		mockUser    *identity.User
+		mockOptions []cache.ProviderOption
+		want        []Simulation
+	}
+
+	testcases := []testcase{
+		{
+			name: "all rules",
+			want: []Simulation{
+				{Rule: admin, RequestableUsers: []string{"usr_1", "usr_2"}, Approvers: []string{"usr_1", "usr_3"}, Approval: ApprovalRequired},
+				{Rule: okta, RequestableUsers: []string{"usr_1", "usr_2", "usr_3"}, Approvers: []string{}, Approval: ApprovalConditional},
+				{Rule: readOnly, RequestableUsers: []string{"usr_1", "usr_2", "usr_3"}, Approvers: []string{}, Approval: ApprovalAutomatic},
+			},
+		},
+		{
+			name: "provider and value",
+			give: SimulateOpts{ProviderID: "aws-sso", With: map[string]string{"accountId": "123456789012", "permissionSetArn": "arn:aws:sso:::permissionSet/ps-admin"}},
+			want: []Simulation{
+				{Rule: admin, RequestableUsers: []string{"usr_1", "usr_2"}, Approvers: []string{"usr_1", "usr_3"}, Approval: ApprovalRequired},
+			},
+		},
+		{
+			name: "option label",
+			give: SimulateOpts{With: map[string]string{"permissionSetArn": "administratoraccess"}},
+			mockOptions: []cache.ProviderOption{
+				{Provider: "aws-sso", Arg: "permissionSetArn", Label: "AdministratorAccess", Value: "arn:aws:sso:::permissionSet/ps-admin"},
+			},
+			want: []Simulation{
+				{Rule: admin, RequestableUsers: []string{"usr_1", "usr_2"}, Approvers: []string{"usr_1", "usr_3"}, Approval: ApprovalRequired},
+			},
+		},
+		{
+			name: "no matching value",
+			give: SimulateOpts{With: map[string]string{"accountId": "000000000000"}},
+			want: []Simulation{},
+		},
+		{
+			name:     "user excluded from approvers",
+			give:     SimulateOpts{UserID: "usr_1", ProviderID: "aws-sso"},
+			mockUser: &users[0],
+			want: []Simulation{
+				{Rule: admin, RequestableUsers: []string{"usr_1"}, Approvers: []string{"usr_3"}, Approval: ApprovalRequired},
+				{Rule: readOnly, RequestableUsers: []string{"usr_1"}, Approvers: []string{}, Approval: ApprovalAutomatic},
+			},
+		},
+		{
+			name:     "user without access",
+			give:     SimulateOpts{UserID: "usr_3", With: map[string]string{"accountId": "123456789012"}},
+			mockUser: &users[2],
+			want:     []Simulation{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			db.MockQuery(&storage.ListAccessRulesForStatus{Result: []rule.AccessRule{admin, readOnly, okta}})
+			db.MockQuery(&storage.ListUsersForStatus{Result: users})
+			db.MockQuery(&storage.GetUser{Result: tc.mockUser})
+			// the approver group of the admin rule.
+			db.MockQuery(&storage.GetGroup{Result: &identity.Group{Users: []string{"usr_1", "usr_3"}}})
+			if tc.mockOptions != nil {
+				db.MockQuery(&storage.GetProviderOptions{Result: tc.mockOptions})
+			} else {
+				db.MockQueryWithErr(&storage.GetProviderOptions{}, ddb.ErrNoItems)
+			}
+
+			got, err := Simulate(context.Background(), db, tc.give)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
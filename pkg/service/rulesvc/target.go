@@ -0,0 +1,212 @@
+package rulesvc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	ddbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/cache"
+	"github.com/common-fate/granted-approvals/pkg/gevent"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/storage/dbcond"
+	"github.com/common-fate/granted-approvals/pkg/storage/dbupdate"
+)
+
+// TargetChangedDeclineReason is recorded on pending requests which are declined when the target of their rule is updated.
+const TargetChangedDeclineReason = "The target of the access rule was updated and the requested options are no longer available"
+
+// changeTarget validates the new target of a rule, returning it with the type of its provider,
+// along with the pending requests for the rule which can't be granted with it, which are declined.
+func (s *Service) changeTarget(ctx context.Context, current rule.AccessRule, target rule.Target) (rule.Target, []access.Request, error) {
+	p, err := s.verifyRuleTarget(ctx, target.ProviderID)
+	if err != nil {
+		return rule.Target{}, nil, err
+	}
+	target.ProviderType = p.Type
+	err = s.validateTargetOptions(ctx, target)
+	if err != nil {
+		return rule.Target{}, nil, err
+	}
+	declined, err := s.declineIncompatibleRequests(ctx, current, target)
+	if err != nil {
+		return rule.Target{}, nil, err
+	}
+	return target, declined, nil
+}
+
+// validateTargetOptions returns ErrInvalidTargetOption if any of the values in the target aren't options for the provider's arguments.
+// Arguments which don't have options accept any value.
+func (s *Service) validateTargetOptions(ctx context.Context, target rule.Target) error {
+	values := make(map[string][]string)
+	for k, v := range target.With {
+		values[k] = append(values[k], v)
+	}
+	for k, v := range target.WithSelectable {
+		values[k] = append(values[k], v...)
+	}
+	args := make([]string, 0, len(values))
+	for k := range values {
+		args = append(args, k)
+	}
+	sort.Strings(args)
+
+	for _, arg := range args {
+		hasOptions, opts, err := s.Cache.LoadCachedProviderArgOptions(ctx, target.ProviderID, arg)
+		if err != nil {
+			return err
+		}
+		invalid := invalidOptions(values[arg], opts)
+		if hasOptions && len(invalid) > 0 {
+			// the cached options may be stale, so they are refreshed before the target is rejected.
+			hasOptions, opts, err = s.Cache.RefreshCachedProviderArgOptions(ctx, target.ProviderID, arg)
+			if err != nil {
+				return err
+			}
+			invalid = invalidOptions(values[arg], opts)
+		}
+		if hasOptions && len(invalid) > 0 {
+			return fmt.Errorf("%w: %s is not an option for %s", ErrInvalidTargetOption, invalid[0], arg)
+		}
+	}
+	return nil
+}
+
+// invalidOptions returns the values which aren't in the options.
+func invalidOptions(values []string, opts []cache.ProviderOption) []string {
+	valid := make(map[string]bool)
+	for _, o := range opts {
+		valid[o.Value] = true
+	}
+	var invalid []string
+	for _, v := range values {
+		if !valid[v] {
+			invalid = append(invalid, v)
+		}
+	}
+	return invalid
+}
+
+// declineIncompatibleRequests returns the pending requests for the rule which can't be granted with the new target, with their status set to declined.
+// Pending requests which would be granted the same access with the new target are kept.
+func (s *Service) declineIncompatibleRequests(ctx context.Context, current rule.AccessRule, target rule.Target) ([]access.Request, error) {
+	var declined []access.Request
+	now := s.Clock.Now()
+
+	// pagination in case of many many pending requests
+	hasMore := true
+	var next string
+	for hasMore {
+		// We currently don't have an access pattern for this directly, so we can fetch all the pending requests and filter them in go
+		q := storage.ListRequestsForStatus{Status: access.PENDING}
+		var opts []func(*ddb.QueryOpts)
+		if next != "" {
+			opts = append(opts, ddb.Page(next))
+		}
+
+		res, err := s.DB.Query(ctx, &q, opts...)
+		if err != nil && err != ddb.ErrNoItems {
+			return nil, err
+		}
+		next = res.NextPage
+		hasMore = next != ""
+
+		for _, r := range q.Result {
+			if r.Rule != current.ID || requestFitsTarget(r, current.Target, target) {
+				continue
+			}
+			r.Status = access.DECLINED
+			r.UpdatedAt = now
+			declined = append(declined, r)
+		}
+	}
+	return declined, nil
+}
+
+// declineItems returns the items to save to decline the requests, along with their reviewers and an audit log event.
+// The requests are only saved if they are still pending, so that a request which was reviewed in the meantime isn't overwritten.
+func (s *Service) declineItems(ctx context.Context, actor string, declined []access.Request) ([]dbcond.Put, error) {
+	var puts []dbcond.Put
+	for _, r := range declined {
+		items, err := dbupdate.GetUpdateRequestItems(ctx, s.DB, r)
+		if err != nil {
+			return nil, err
+		}
+		puts = append(puts, dbcond.Put{
+			Item:      items[0],
+			Condition: "#status = :pending",
+			Names:     map[string]string{"#status": "status"},
+			Values:    map[string]ddbtypes.AttributeValue{":pending": &ddbtypes.AttributeValueMemberS{Value: string(access.PENDING)}},
+		})
+		for _, item := range items[1:] {
+			puts = append(puts, dbcond.Put{Item: item})
+		}
+		event := access.NewStatusChangeEvent(r.ID, r.UpdatedAt, &actor, access.PENDING, access.DECLINED)
+		event.RecordedEvent = &map[string]string{"reason": TargetChangedDeclineReason}
+		puts = append(puts, dbcond.Put{Item: &event})
+	}
+	return puts, nil
+}
+
+// notifyDeclined emits an event for each declined request, so that requesters and reviewers are told that it was declined.
+func (s *Service) notifyDeclined(ctx context.Context, actor string, declined []access.Request) error {
+	for _, r := range declined {
+		err := s.EventPutter.Put(ctx, gevent.RequestDeclined{Request: r, ReviewerID: actor})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requestFitsTarget returns true if the request would be granted the same access with the new target as with the previous target,
+// and its selected options are still available.
+// Requests are granted with the arguments of the current rule, combined with the options selected by the requester.
+func requestFitsTarget(req access.Request, previous, target rule.Target) bool {
+	if previous.ProviderID != target.ProviderID {
+		return false
+	}
+	before := grantArguments(req, previous)
+	after := grantArguments(req, target)
+	if len(before) != len(after) {
+		return false
+	}
+	for k, v := range before {
+		if after[k] != v {
+			return false
+		}
+	}
+	for k, selected := range req.SelectedWith {
+		if v, ok := target.With[k]; ok && v == selected.Value {
+			continue
+		}
+		if !contains(target.WithSelectable[k], selected.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// grantArguments returns the arguments which the request is granted with for the target.
+func grantArguments(req access.Request, target rule.Target) map[string]string {
+	args := make(map[string]string)
+	for k, v := range target.With {
+		args[k] = v
+	}
+	for k, v := range req.SelectedWith {
+		args[k] = v.Value
+	}
+	return args
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
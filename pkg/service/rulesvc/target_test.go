@@ -0,0 +1,293 @@
+package rulesvc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/ddb/ddbmock"
+	ahTypes "github.com/common-fate/granted-approvals/accesshandler/pkg/types"
+	"github.com/common-fate/granted-approvals/accesshandler/pkg/types/ahmocks"
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/cache"
+	"github.com/common-fate/granted-approvals/pkg/gevent"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// testCache returns the values as the options for each argument.
+type testCache map[string][]string
+
+func (c testCache) LoadCachedProviderArgOptions(ctx context.Context, providerId string, argId string) (bool, []cache.ProviderOption, error) {
+	values, ok := c[argId]
+	if !ok {
+		return false, nil, nil
+	}
+	var opts []cache.ProviderOption
+	for _, v := range values {
+		opts = append(opts, cache.ProviderOption{Provider: providerId, Arg: argId, Value: v, Label: v})
+	}
+	return true, opts, nil
+}
+
+func (c testCache) RefreshCachedProviderArgOptions(ctx context.Context, providerId string, argId string) (bool, []cache.ProviderOption, error) {
+	return c.LoadCachedProviderArgOptions(ctx, providerId, argId)
+}
+
+func TestRequestFitsTarget(t *testing.T) {
+	previous := rule.Target{
+		ProviderID:     "aws",
+		With:           map[string]string{"accountId": "123"},
+		WithSelectable: map[string][]string{"permissionSetArn": {"a", "b"}},
+	}
+	req := access.Request{SelectedWith: map[string]access.Option{"permissionSetArn": {Value: "a"}}}
+
+	testcases := []struct {
+		name   string
+		target rule.Target
+		want   bool
+	}{
+		{
+			name:   "option added",
+			target: rule.Target{ProviderID: "aws", With: map[string]string{"accountId": "123"}, WithSelectable: map[string][]string{"permissionSetArn": {"a", "b", "c"}}},
+			want:   true,
+		},
+		{
+			name:   "selected option removed",
+			target: rule.Target{ProviderID: "aws", With: map[string]string{"accountId": "123"}, WithSelectable: map[string][]string{"permissionSetArn": {"b", "c"}}},
+			want:   false,
+		},
+		{
+			name:   "selected option is now fixed",
+			target: rule.Target{ProviderID: "aws", With: map[string]string{"accountId": "123", "permissionSetArn": "a"}},
+			want:   true,
+		},
+		{
+			name:   "fixed value changed",
+			target: rule.Target{ProviderID: "aws", With: map[string]string{"accountId": "456"}, WithSelectable: map[string][]string{"permissionSetArn": {"a", "b"}}},
+			want:   false,
+		},
+		{
+			name:   "fixed value is now selectable",
+			target: rule.Target{ProviderID: "aws", WithSelectable: map[string][]string{"accountId": {"123", "456"}, "permissionSetArn": {"a", "b"}}},
+			want:   false,
+		},
+		{
+			name:   "provider changed",
+			target: rule.Target{ProviderID: "aws-2", With: map[string]string{"accountId": "123"}, WithSelectable: map[string][]string{"permissionSetArn": {"a", "b"}}},
+			want:   false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, requestFitsTarget(req, previous, tc.target))
+		})
+	}
+}
+
+func TestUpdateRuleTarget(t *testing.T) {
+	current := rule.AccessRule{
+		ID:      "rule1",
+		Version: "ver_1",
+		Current: true,
+		Status:  rule.ACTIVE,
+		Target: rule.Target{
+			ProviderID:     "aws",
+			ProviderType:   "aws-sso",
+			With:           map[string]string{"accountId": "123"},
+			WithSelectable: map[string][]string{"permissionSetArn": {"a", "b"}},
+		},
+	}
+	update := types.UpdateAccessRuleRequest{
+		Target: &types.CreateAccessRuleTarget{
+			ProviderId: "aws",
+			With: types.CreateAccessRuleTarget_With{AdditionalProperties: map[string][]string{
+				"accountId":        {"123"},
+				"permissionSetArn": {"b", "c"},
+			}},
+		},
+	}
+
+	type testcase struct {
+		name        string
+		cache       testCache
+		wantErr     error
+		wantDecline []string
+	}
+
+	testcases := []testcase{
+		{
+			name:        "requests for removed options are declined",
+			cache:       testCache{"accountId": {"123"}, "permissionSetArn": {"a", "b", "c"}},
+			wantDecline: []string{"req_a"},
+		},
+		{
+			name:    "options which the provider doesn't have are rejected",
+			cache:   testCache{"accountId": {"123"}, "permissionSetArn": {"a", "b"}},
+			wantErr: ErrInvalidTargetOption,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			db.MockQueryWithErrWithResult(&storage.ListRequestsForStatus{Result: []access.Request{
+				{ID: "req_a", Rule: "rule1", Status: access.PENDING, SelectedWith: map[string]access.Option{"permissionSetArn": {Value: "a"}}},
+				{ID: "req_b", Rule: "rule1", Status: access.PENDING, SelectedWith: map[string]access.Option{"permissionSetArn": {Value: "b"}}},
+				{ID: "req_other", Rule: "rule2", Status: access.PENDING, SelectedWith: map[string]access.Option{"permissionSetArn": {Value: "a"}}},
+			}}, &ddb.QueryResult{}, nil)
+			db.MockQuery(&storage.ListRequestReviewers{})
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := ahmocks.NewMockClientWithResponsesInterface(ctrl)
+			m.EXPECT().GetProviderWithResponse(gomock.Any(), gomock.Eq("aws")).Return(&ahTypes.GetProviderResponse{
+				JSON200:      &ahTypes.Provider{Id: "aws", Type: "aws-sso"},
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+			}, nil)
+
+			w := testWriter{}
+			e := testEventPutter{}
+			s := Service{Clock: clock.NewMock(), DB: db, AHClient: m, Cache: tc.cache, Writer: &w, EventPutter: &e}
+			got, err := s.UpdateRule(context.Background(), &UpdateOpts{
+				Updater:       &identity.User{ID: "admin"},
+				Rule:          current,
+				UpdateRequest: update,
+				IsAdmin:       true,
+			})
+			if tc.wantErr != nil {
+				assert.True(t, errors.Is(err, tc.wantErr), "got error %v", err)
+				assert.Empty(t, w.puts)
+				assert.Empty(t, e.declined)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, map[string][]string{"permissionSetArn": {"b", "c"}}, got.Target.WithSelectable)
+
+			var declined []string
+			for _, p := range w.puts {
+				switch v := p.Item.(type) {
+				case *access.Request:
+					assert.Equal(t, access.DECLINED, v.Status)
+					assert.Equal(t, "#status = :pending", p.Condition)
+					declined = append(declined, v.ID)
+				case *access.RequestEvent:
+					assert.Equal(t, TargetChangedDeclineReason, (*v.RecordedEvent)["reason"])
+				}
+			}
+			assert.Equal(t, tc.wantDecline, declined)
+			assert.Equal(t, tc.wantDecline, e.declined)
+		})
+	}
+}
+
+func TestRollbackRuleTarget(t *testing.T) {
+	current := rule.AccessRule{
+		ID:      "rule1",
+		Version: "ver_2",
+		Current: true,
+		Status:  rule.ACTIVE,
+		Target: rule.Target{
+			ProviderID:     "aws",
+			ProviderType:   "aws-sso",
+			With:           map[string]string{"accountId": "123"},
+			WithSelectable: map[string][]string{"permissionSetArn": {"a", "b"}},
+		},
+	}
+	version := current
+	version.Version = "ver_1"
+	version.Current = false
+	version.Target = rule.Target{
+		ProviderID:     "aws",
+		ProviderType:   "aws-sso",
+		With:           map[string]string{"accountId": "123"},
+		WithSelectable: map[string][]string{"permissionSetArn": {"b", "c"}},
+	}
+
+	type testcase struct {
+		name        string
+		cache       testCache
+		wantErr     error
+		wantDecline []string
+	}
+
+	testcases := []testcase{
+		{
+			name:        "requests for options which the earlier version doesn't have are declined",
+			cache:       testCache{"accountId": {"123"}, "permissionSetArn": {"a", "b", "c"}},
+			wantDecline: []string{"req_a"},
+		},
+		{
+			name:    "options which the provider no longer has are rejected",
+			cache:   testCache{"accountId": {"123"}, "permissionSetArn": {"a", "b"}},
+			wantErr: ErrInvalidTargetOption,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			db.MockQueryWithErrWithResult(&storage.ListRequestsForStatus{Result: []access.Request{
+				{ID: "req_a", Rule: "rule1", Status: access.PENDING, SelectedWith: map[string]access.Option{"permissionSetArn": {Value: "a"}}},
+				{ID: "req_b", Rule: "rule1", Status: access.PENDING, SelectedWith: map[string]access.Option{"permissionSetArn": {Value: "b"}}},
+			}}, &ddb.QueryResult{}, nil)
+			db.MockQuery(&storage.ListRequestReviewers{})
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := ahmocks.NewMockClientWithResponsesInterface(ctrl)
+			m.EXPECT().GetProviderWithResponse(gomock.Any(), gomock.Eq("aws")).Return(&ahTypes.GetProviderResponse{
+				JSON200:      &ahTypes.Provider{Id: "aws", Type: "aws-sso"},
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+			}, nil)
+
+			w := testWriter{}
+			e := testEventPutter{}
+			s := Service{Clock: clock.NewMock(), DB: db, AHClient: m, Cache: tc.cache, Writer: &w, EventPutter: &e}
+			got, err := s.RollbackAccessRule(context.Background(), RollbackOpts{
+				User:    &identity.User{ID: "admin"},
+				Current: current,
+				Version: version,
+				IsAdmin: true,
+			})
+			if tc.wantErr != nil {
+				assert.True(t, errors.Is(err, tc.wantErr), "got error %v", err)
+				assert.Empty(t, w.puts)
+				assert.Empty(t, e.declined)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, version.Target, got.Target)
+
+			var declined []string
+			for _, p := range w.puts {
+				if r, ok := p.Item.(*access.Request); ok {
+					assert.Equal(t, access.DECLINED, r.Status)
+					declined = append(declined, r.ID)
+				}
+			}
+			assert.Equal(t, tc.wantDecline, declined)
+			assert.Equal(t, tc.wantDecline, e.declined)
+		})
+	}
+}
+
+// testEventPutter records the IDs of the requests which are declined.
+type testEventPutter struct {
+	declined []string
+}
+
+func (e *testEventPutter) Put(ctx context.Context, detail gevent.EventTyper) error {
+	if d, ok := detail.(gevent.RequestDeclined); ok {
+		e.declined = append(e.declined, d.Request.ID)
+	}
+	return nil
+}
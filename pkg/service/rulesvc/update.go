@@ -2,42 +2,115 @@ package rulesvc
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/policy"
 	"github.com/common-fate/granted-approvals/pkg/rule"
 	"github.com/common-fate/granted-approvals/pkg/types"
 )
 
 type UpdateOpts struct {
-	UpdaterID      string
+	Updater        *identity.User
 	Rule           rule.AccessRule
 	UpdateRequest  types.UpdateAccessRuleRequest
 	ApprovalGroups []rule.Approval
+	// IsAdmin is true if the updater can manage the rule without being an owner of it.
+	IsAdmin bool
 }
 
 func (s *Service) UpdateRule(ctx context.Context, in *UpdateOpts) (*rule.AccessRule, error) {
-	clk := s.Clock
+	if !in.IsAdmin && !in.Rule.IsOwner(in.Updater) {
+		return nil, ErrUserNotAuthorized
+	}
+	err := validateTimeConstraints(in.UpdateRequest.TimeConstraints)
+	if err != nil {
+		return nil, err
+	}
+	err = policy.Validate(rule.PolicyFromAPI(in.UpdateRequest.Policy))
+	if err != nil {
+		return nil, err
+	}
+	err = rule.ValidateRequestFields(rule.RequestFieldsFromAPI(in.UpdateRequest.RequestFields))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRequestFields, err)
+	}
 	// makes a copy of the existing version which will be mutated
-	newVersion := in.Rule
-
-	// fields to be updated
-	newVersion.Description = in.UpdateRequest.Description
-	newVersion.Name = in.UpdateRequest.Name
-	newVersion.Approval.Users = in.UpdateRequest.Approval.Users
-	newVersion.Approval.Groups = in.UpdateRequest.Approval.Groups
-	newVersion.Groups = in.UpdateRequest.Groups
-	newVersion.Metadata.UpdatedBy = in.UpdaterID
-	newVersion.Metadata.UpdatedAt = clk.Now()
-	newVersion.TimeConstraints = in.UpdateRequest.TimeConstraints
+	newVersion := applyUpdate(in.Rule, in.UpdateRequest)
+	// the ticket requirement and policy are checked against the updated rule, as the request fields and policy may be kept from the existing version.
+	err = validateTicketRequirement(newVersion.Ticket, newVersion.RequestFields)
+	if err != nil {
+		return nil, err
+	}
+	err = policy.ValidateApprovers(newVersion.Policy, newVersion.Approval)
+	if err != nil {
+		return nil, err
+	}
+	newVersion.Metadata.UpdatedBy = in.Updater.ID
+	newVersion.Metadata.UpdatedAt = s.Clock.Now()
+	newVersion.Metadata.UpdateMessage = in.UpdateRequest.UpdateMessage
 	newVersion.Version = types.NewVersionID()
 
 	// Set the existing version to not current
 	in.Rule.Current = false
 
-	// updated the previous version to be a version and inserts the new one as current
-	err := s.DB.PutBatch(ctx, &newVersion, &in.Rule)
+	// requests which the new target can't grant are declined along with saving the new version
+	var declined []access.Request
+
+	if in.UpdateRequest.Target != nil {
+		target := targetFromAPI(*in.UpdateRequest.Target)
+		if !sameTarget(in.Rule.Target, target) {
+			newVersion.Target, declined, err = s.changeTarget(ctx, in.Rule, target)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	declines, err := s.declineItems(ctx, in.Updater.ID, declined)
+	if err != nil {
+		return nil, err
+	}
+
+	// updates the previous version to be a version and inserts the new one as current
+	err = s.putVersion(ctx, &newVersion, &in.Rule, declines...)
+	if err != nil {
+		return nil, err
+	}
+	err = s.notifyDeclined(ctx, in.Updater.ID, declined)
 	if err != nil {
 		return nil, err
 	}
 
 	return &newVersion, nil
 }
+
+// applyUpdate returns a copy of the rule with the fields from the update request.
+// Notifications, owners, limits, the policy, request fields and the ticket requirement are optional
+// in the update request, so the existing settings are kept if they are not provided.
+func applyUpdate(r rule.AccessRule, req types.UpdateAccessRuleRequest) rule.AccessRule {
+	r.Description = req.Description
+	r.Name = req.Name
+	r.Approval = rule.ApprovalFromAPI(req.Approval)
+	r.Groups = req.Groups
+	r.TimeConstraints = req.TimeConstraints
+	if req.Notifications != nil {
+		r.Notifications = rule.NotificationsFromAPI(req.Notifications)
+	}
+	if req.Owners != nil {
+		r.Owners = rule.OwnersFromAPI(req.Owners)
+	}
+	if req.Limits != nil {
+		r.Limits = rule.LimitsFromAPI(req.Limits)
+	}
+	if req.Policy != nil {
+		r.Policy = rule.PolicyFromAPI(req.Policy)
+	}
+	if req.RequestFields != nil {
+		r.RequestFields = rule.RequestFieldsFromAPI(req.RequestFields)
+	}
+	if req.Ticket != nil {
+		r.Ticket = rule.TicketRequirementFromAPI(req.Ticket)
+	}
+	return r
+}
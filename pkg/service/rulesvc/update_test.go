@@ -6,6 +6,7 @@ import (
 
 	"github.com/benbjohnson/clock"
 	"github.com/common-fate/ddb/ddbmock"
+	"github.com/common-fate/granted-approvals/pkg/identity"
 	"github.com/common-fate/granted-approvals/pkg/rule"
 	"github.com/common-fate/granted-approvals/pkg/types"
 	"github.com/stretchr/testify/assert"
@@ -16,6 +17,8 @@ func TestUpdateAccessRule(t *testing.T) {
 	type testcase struct {
 		name            string
 		givenUserID     string
+		givenGroups     []string
+		givenNotAdmin   bool
 		givenRule       rule.AccessRule
 		givenUpdateBody types.UpdateAccessRuleRequest
 		wantErr         error
@@ -37,7 +40,7 @@ func TestUpdateAccessRule(t *testing.T) {
 	*/
 	mockRule := rule.AccessRule{
 		ID:       ruleID,
-		Approval: rule.Approval(in.Approval),
+		Approval: rule.ApprovalFromAPI(in.Approval),
 		Status:   rule.ACTIVE,
 		Metadata: rule.AccessRuleMetadata{
 			CreatedAt: now,
@@ -80,6 +83,25 @@ func TestUpdateAccessRule(t *testing.T) {
 		Version: versionID,
 	}
 
+	mockRuleWithNotifications := mockRule
+	mockRuleWithNotifications.Notifications = rule.Notifications{SlackChannels: []string{"#approvals"}}
+
+	wantKeepNotifications := want
+	wantKeepNotifications.Notifications = mockRuleWithNotifications.Notifications
+
+	mockRuleUpdateNotificationsBody := mockRuleUpdateBody
+	mockRuleUpdateNotificationsBody.Notifications = &types.AccessRuleNotifications{SlackChannels: []string{"#security"}}
+
+	wantUpdatedNotifications := want
+	wantUpdatedNotifications.Notifications = rule.Notifications{SlackChannels: []string{"#security"}}
+
+	mockRuleWithOwners := mockRule
+	mockRuleWithOwners.Owners = rule.Owners{Groups: []string{"platform"}}
+
+	wantKeepOwners := want
+	wantKeepOwners.Owners = mockRuleWithOwners.Owners
+	wantKeepOwners.Metadata.UpdatedBy = "owner"
+
 	/**
 	Things to test:
 	- Control test case (pass) ✅
@@ -93,6 +115,38 @@ func TestUpdateAccessRule(t *testing.T) {
 			givenUpdateBody: mockRuleUpdateBody,
 			want:            &want,
 		},
+		{
+			name:            "notifications are kept if not provided",
+			givenUserID:     userID,
+			givenRule:       mockRuleWithNotifications,
+			givenUpdateBody: mockRuleUpdateBody,
+			want:            &wantKeepNotifications,
+		},
+		{
+			name:            "notifications updated",
+			givenUserID:     userID,
+			givenRule:       mockRuleWithNotifications,
+			givenUpdateBody: mockRuleUpdateNotificationsBody,
+			want:            &wantUpdatedNotifications,
+		},
+		{
+			name:            "owner can update the rule",
+			givenUserID:     "owner",
+			givenGroups:     []string{"platform"},
+			givenNotAdmin:   true,
+			givenRule:       mockRuleWithOwners,
+			givenUpdateBody: mockRuleUpdateBody,
+			want:            &wantKeepOwners,
+		},
+		{
+			name:            "user who isn't an owner can't update the rule",
+			givenUserID:     "other",
+			givenGroups:     []string{"engineering"},
+			givenNotAdmin:   true,
+			givenRule:       mockRuleWithOwners,
+			givenUpdateBody: mockRuleUpdateBody,
+			wantErr:         ErrUserNotAuthorized,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -103,12 +157,14 @@ func TestUpdateAccessRule(t *testing.T) {
 			}
 
 			s := Service{
-				Clock: clk,
-				DB:    &dbc,
+				Clock:  clk,
+				DB:     &dbc,
+				Writer: &testWriter{},
 			}
 
 			got, err := s.UpdateRule(context.Background(), &UpdateOpts{
-				UpdaterID:      tc.givenUserID,
+				Updater:        &identity.User{ID: tc.givenUserID, Groups: tc.givenGroups},
+				IsAdmin:        !tc.givenNotAdmin,
 				Rule:           tc.givenRule,
 				UpdateRequest:  tc.givenUpdateBody,
 				ApprovalGroups: []rule.Approval{},
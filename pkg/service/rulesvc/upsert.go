@@ -0,0 +1,171 @@
+package rulesvc
+
+import (
+	"context"
+	"reflect"
+	"regexp"
+
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+// slugPattern matches the slugs which can be used as access rule IDs.
+// Slugs can't contain underscores, so they never collide with generated IDs like rul_123.
+var slugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+type UpsertOpts struct {
+	// Slug is the stable ID of the rule, chosen by the caller.
+	Slug     string
+	Upserter *identity.User
+	Request  types.UpsertAccessRuleRequest
+	// IsAdmin is true if the user can manage rules for the target provider.
+	// Owners can update their existing rules, but can't create rules with an upsert.
+	IsAdmin bool
+}
+
+// UpsertResult describes what an upsert did to the rule.
+type UpsertResult string
+
+const (
+	UpsertCreated   UpsertResult = "created"
+	UpsertUpdated   UpsertResult = "updated"
+	UpsertUnchanged UpsertResult = "unchanged"
+)
+
+// UpsertAccessRule creates or updates the access rule with the slug as its ID.
+// A new version is only created if the rule has changed, so repeating an upsert doesn't modify the rule.
+//
+// If the request has a current version, ErrVersionConflict is returned unless it matches the current version of the rule.
+// ErrVersionConflict is also returned if the rule is updated by someone else between reading it and writing the new version.
+func (s *Service) UpsertAccessRule(ctx context.Context, in UpsertOpts) (*rule.AccessRule, UpsertResult, error) {
+	if !slugPattern.MatchString(in.Slug) {
+		return nil, "", ErrInvalidSlug
+	}
+	req := in.Request
+
+	q := storage.GetAccessRuleCurrent{ID: in.Slug}
+	_, err := s.DB.Query(ctx, &q)
+	if err == ddb.ErrNoItems {
+		if !in.IsAdmin {
+			return nil, "", ErrUserNotAuthorized
+		}
+		// the caller expected the rule to exist already
+		if req.CurrentVersion != nil {
+			return nil, "", ErrVersionConflict
+		}
+		r, err := s.createAccessRule(ctx, in.Upserter, in.Slug, types.CreateAccessRuleRequest{
+			Approval:        req.Approval,
+			Description:     req.Description,
+			Groups:          req.Groups,
+			Name:            req.Name,
+			Notifications:   req.Notifications,
+			Owners:          req.Owners,
+			Limits:          req.Limits,
+			Policy:          req.Policy,
+			RequestFields:   req.RequestFields,
+			Ticket:          req.Ticket,
+			Target:          req.Target,
+			TimeConstraints: req.TimeConstraints,
+		}, req.UpdateMessage)
+		if err != nil {
+			return nil, "", err
+		}
+		return r, UpsertCreated, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	current := *q.Result
+	if !in.IsAdmin && !current.IsOwner(in.Upserter) {
+		return nil, "", ErrUserNotAuthorized
+	}
+	if req.CurrentVersion != nil && *req.CurrentVersion != current.Version {
+		return nil, "", ErrVersionConflict
+	}
+	if current.Status == rule.ARCHIVED {
+		return nil, "", ErrAccessRuleArchived
+	}
+
+	update := types.UpdateAccessRuleRequest{
+		Approval:        req.Approval,
+		Description:     req.Description,
+		Groups:          req.Groups,
+		Name:            req.Name,
+		Notifications:   req.Notifications,
+		Owners:          req.Owners,
+		Limits:          req.Limits,
+		Policy:          req.Policy,
+		RequestFields:   req.RequestFields,
+		Ticket:          req.Ticket,
+		Target:          &req.Target,
+		TimeConstraints: req.TimeConstraints,
+		UpdateMessage:   req.UpdateMessage,
+	}
+	if !hasChanges(current, applyUpdate(current, update)) && sameTarget(current.Target, targetFromAPI(req.Target)) {
+		return &current, UpsertUnchanged, nil
+	}
+
+	updated, err := s.UpdateRule(ctx, &UpdateOpts{
+		Updater:       in.Upserter,
+		Rule:          current,
+		UpdateRequest: update,
+		IsAdmin:       in.IsAdmin,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return updated, UpsertUpdated, nil
+}
+
+// hasChanges returns true if the configurable fields of the rules differ.
+// Empty and missing lists are treated as equal, as they are stored differently depending on how the rule was created.
+func hasChanges(a, b rule.AccessRule) bool {
+	return a.Name != b.Name ||
+		a.Description != b.Description ||
+		!reflect.DeepEqual(a.TimeConstraints, b.TimeConstraints) ||
+		!equalStrings(a.Groups, b.Groups) ||
+		!equalStrings(a.Approval.Users, b.Approval.Users) ||
+		!equalStrings(a.Approval.Groups, b.Approval.Groups) ||
+		!equalStrings(a.Approval.EscalationGroups, b.Approval.EscalationGroups) ||
+		!equalStrings(a.Notifications.SlackChannels, b.Notifications.SlackChannels) ||
+		!equalStrings(a.Owners.Users, b.Owners.Users) ||
+		!equalStrings(a.Owners.Groups, b.Owners.Groups) ||
+		a.Limits != b.Limits ||
+		!reflect.DeepEqual(a.Policy, b.Policy) ||
+		!reflect.DeepEqual(a.RequestFields, b.RequestFields) ||
+		!reflect.DeepEqual(a.Ticket, b.Ticket)
+}
+
+// sameTarget returns true if the targets have the same provider and arguments. The provider type is ignored.
+func sameTarget(a, b rule.Target) bool {
+	if a.ProviderID != b.ProviderID || len(a.With) != len(b.With) || len(a.WithSelectable) != len(b.WithSelectable) {
+		return false
+	}
+	for k, v := range a.With {
+		if bv, ok := b.With[k]; !ok || bv != v {
+			return false
+		}
+	}
+	for k, v := range a.WithSelectable {
+		if bv, ok := b.WithSelectable[k]; !ok || !equalStrings(v, bv) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
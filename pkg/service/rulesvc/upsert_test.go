@@ -0,0 +1,229 @@
+package rulesvc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/ddb/ddbmock"
+	ahTypes "github.com/common-fate/granted-approvals/accesshandler/pkg/types"
+	"github.com/common-fate/granted-approvals/accesshandler/pkg/types/ahmocks"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/rule"
+	"github.com/common-fate/granted-approvals/pkg/storage"
+	"github.com/common-fate/granted-approvals/pkg/storage/dbcond"
+	"github.com/common-fate/granted-approvals/pkg/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpsertAccessRule(t *testing.T) {
+	type testcase struct {
+		name        string
+		slug        string
+		notAdmin    bool
+		givenRule   *rule.AccessRule
+		give        types.UpsertAccessRuleRequest
+		wantResult  UpsertResult
+		wantVersion string
+		// writeErr is returned by the conditional write of the new version
+		writeErr error
+		wantErr  error
+	}
+
+	clk := clock.NewMock()
+	user := identity.User{ID: "user1"}
+	current := "ver_1"
+	stale := "ver_0"
+
+	give := types.UpsertAccessRuleRequest{
+		Name:            "Production",
+		Description:     "Production access",
+		Groups:          []string{"engineering"},
+		Approval:        types.ApproverConfig{Users: []string{}, Groups: []string{"leads"}},
+		TimeConstraints: types.TimeConstraints{MaxDurationSeconds: 3600},
+		Target: types.CreateAccessRuleTarget{
+			ProviderId: "aws",
+			With:       types.CreateAccessRuleTarget_With{AdditionalProperties: map[string][]string{"accountId": {"123"}}},
+		},
+	}
+
+	existing := rule.AccessRule{
+		ID:          "production",
+		Version:     current,
+		Current:     true,
+		Status:      rule.ACTIVE,
+		Name:        "Production",
+		Description: "Production access",
+		Groups:      []string{"engineering"},
+		// the rule was stored without any approval users, rather than an empty list
+		Approval:        rule.Approval{Groups: []string{"leads"}},
+		TimeConstraints: types.TimeConstraints{MaxDurationSeconds: 3600},
+		Target: rule.Target{
+			ProviderID:     "aws",
+			ProviderType:   "aws-sso",
+			With:           map[string]string{"accountId": "123"},
+			WithSelectable: map[string][]string{},
+		},
+		Owners: rule.Owners{Users: []string{"owner"}},
+	}
+
+	changed := give
+	changed.Description = "Admin access to production"
+
+	withVersion := func(req types.UpsertAccessRuleRequest, version string) types.UpsertAccessRuleRequest {
+		req.CurrentVersion = &version
+		return req
+	}
+
+	retargeted := give
+	retargeted.Target.With = types.CreateAccessRuleTarget_With{AdditionalProperties: map[string][]string{"accountId": {"456"}}}
+
+	archived := existing
+	archived.Status = rule.ARCHIVED
+
+	testcases := []testcase{
+		{
+			name:       "creates the rule with the slug as its id",
+			slug:       "production",
+			give:       give,
+			wantResult: UpsertCreated,
+		},
+		{
+			name:        "unchanged rule doesn't create a version",
+			slug:        "production",
+			givenRule:   &existing,
+			give:        give,
+			wantResult:  UpsertUnchanged,
+			wantVersion: current,
+		},
+		{
+			name:       "changed rule creates a version",
+			slug:       "production",
+			givenRule:  &existing,
+			give:       withVersion(changed, current),
+			wantResult: UpsertUpdated,
+		},
+		{
+			name:      "stale version",
+			slug:      "production",
+			givenRule: &existing,
+			give:      withVersion(changed, stale),
+			wantErr:   ErrVersionConflict,
+		},
+		{
+			name:      "rule updated after it was read",
+			slug:      "production",
+			givenRule: &existing,
+			give:      withVersion(changed, current),
+			writeErr:  dbcond.ErrConditionFailed,
+			wantErr:   ErrVersionConflict,
+		},
+		{
+			name:    "version for a rule which doesn't exist",
+			slug:    "production",
+			give:    withVersion(give, current),
+			wantErr: ErrVersionConflict,
+		},
+		{
+			name:       "changed target creates a version",
+			slug:       "production",
+			givenRule:  &existing,
+			give:       retargeted,
+			wantResult: UpsertUpdated,
+		},
+		{
+			name:      "archived rule",
+			slug:      "production",
+			givenRule: &archived,
+			give:      give,
+			wantErr:   ErrAccessRuleArchived,
+		},
+		{
+			name:    "generated ids can't be used as slugs",
+			slug:    "rul_123",
+			give:    give,
+			wantErr: ErrInvalidSlug,
+		},
+		{
+			name:     "users who aren't admins can't create rules",
+			slug:     "production",
+			notAdmin: true,
+			give:     give,
+			wantErr:  ErrUserNotAuthorized,
+		},
+		{
+			name:      "users who aren't admins or owners can't update rules",
+			slug:      "production",
+			notAdmin:  true,
+			givenRule: &existing,
+			give:      changed,
+			wantErr:   ErrUserNotAuthorized,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := ddbmock.New(t)
+			if tc.givenRule != nil {
+				db.MockQuery(&storage.GetAccessRuleCurrent{Result: tc.givenRule})
+			} else {
+				db.MockQueryWithErr(&storage.GetAccessRuleCurrent{}, ddb.ErrNoItems)
+			}
+			db.MockQueryWithErrWithResult(&storage.ListRequestsForStatus{}, &ddb.QueryResult{}, nil)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := ahmocks.NewMockClientWithResponsesInterface(ctrl)
+			m.EXPECT().GetProviderWithResponse(gomock.Any(), gomock.Eq("aws")).Return(&ahTypes.GetProviderResponse{
+				JSON200:      &ahTypes.Provider{Id: "aws", Type: "aws-sso"},
+				HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+			}, nil).AnyTimes()
+
+			cache := testCache{"accountId": {"123", "456"}}
+			s := Service{Clock: clk, DB: db, AHClient: m, Cache: cache, Writer: &testWriter{err: tc.writeErr}}
+			got, result, err := s.UpsertAccessRule(context.Background(), UpsertOpts{
+				Slug:     tc.slug,
+				Upserter: &user,
+				Request:  tc.give,
+				IsAdmin:  !tc.notAdmin,
+			})
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantResult, result)
+			assert.Equal(t, tc.slug, got.ID)
+			assert.Equal(t, tc.give.Description, got.Description)
+			if tc.wantVersion != "" {
+				assert.Equal(t, tc.wantVersion, got.Version)
+			} else {
+				assert.NotEqual(t, current, got.Version)
+			}
+		})
+	}
+}
+
+// testWriter records the items which are written, unless err is set.
+type testWriter struct {
+	puts []dbcond.Put
+	err  error
+}
+
+func (w *testWriter) Put(ctx context.Context, put dbcond.Put) error {
+	return w.TransactPut(ctx, put)
+}
+
+func (w *testWriter) Update(ctx context.Context, update dbcond.Update) error {
+	return w.err
+}
+
+func (w *testWriter) TransactPut(ctx context.Context, puts ...dbcond.Put) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.puts = append(w.puts, puts...)
+	return nil
+}
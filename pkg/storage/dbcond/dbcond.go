@@ -0,0 +1,177 @@
+// Package dbcond makes conditional writes to the DynamoDB table.
+// The ddb client doesn't support condition expressions, so the DynamoDB client is used directly.
+// Items are marshalled the same way as the ddb client, with their keys added as attributes.
+package dbcond
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/common-fate/ddb"
+)
+
+// ErrConditionFailed is returned if the condition of a write isn't met, so nothing was written.
+var ErrConditionFailed = errors.New("condition check failed")
+
+// DynamoDBAPI is the part of the DynamoDB client which is used for conditional writes.
+type DynamoDBAPI interface {
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
+}
+
+// Writer makes conditional writes. It is met by Client.
+type Writer interface {
+	Put(ctx context.Context, put Put) error
+	Update(ctx context.Context, update Update) error
+	TransactPut(ctx context.Context, puts ...Put) error
+}
+
+// Put writes an item if its condition is met.
+type Put struct {
+	Item ddb.Keyer
+	// Condition is a DynamoDB condition expression which is checked against the existing item.
+	// If it is empty the item is always written.
+	Condition string
+	Names     map[string]string
+	Values    map[string]types.AttributeValue
+}
+
+// Update changes attributes of an existing item with an update expression, if its condition is met.
+// Attributes which aren't in the expression are left as they are.
+type Update struct {
+	// Item is the item to update. Only its keys are used.
+	Item       ddb.Keyer
+	Expression string
+	// Condition is a DynamoDB condition expression which is checked against the existing item.
+	// If it is empty the item is always updated, and is created if it doesn't exist.
+	Condition string
+	Names     map[string]string
+	Values    map[string]types.AttributeValue
+}
+
+type Client struct {
+	DB    DynamoDBAPI
+	Table string
+}
+
+// New creates a client for the table using the default AWS config.
+func New(ctx context.Context, table string) (*Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{DB: dynamodb.NewFromConfig(cfg), Table: table}, nil
+}
+
+// Put writes the item. ErrConditionFailed is returned if its condition isn't met.
+func (c *Client) Put(ctx context.Context, p Put) error {
+	item, err := marshalItem(p.Item)
+	if err != nil {
+		return err
+	}
+	in := dynamodb.PutItemInput{
+		Item:      item,
+		TableName: &c.Table,
+	}
+	if p.Condition != "" {
+		in.ConditionExpression = &p.Condition
+		in.ExpressionAttributeNames = p.Names
+		in.ExpressionAttributeValues = p.Values
+	}
+	_, err = c.DB.PutItem(ctx, &in)
+	return conditionErr(err)
+}
+
+// Update updates the item. ErrConditionFailed is returned if its condition isn't met.
+func (c *Client) Update(ctx context.Context, u Update) error {
+	keys, err := u.Item.DDBKeys()
+	if err != nil {
+		return err
+	}
+	in := dynamodb.UpdateItemInput{
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: keys.PK},
+			"SK": &types.AttributeValueMemberS{Value: keys.SK},
+		},
+		TableName:                 &c.Table,
+		UpdateExpression:          &u.Expression,
+		ExpressionAttributeNames:  u.Names,
+		ExpressionAttributeValues: u.Values,
+	}
+	if u.Condition != "" {
+		in.ConditionExpression = &u.Condition
+	}
+	_, err = c.DB.UpdateItem(ctx, &in)
+	return conditionErr(err)
+}
+
+// TransactPut writes the items in a transaction, so either all of the items are written or none of them are.
+// ErrConditionFailed is returned if the condition of any of the items isn't met.
+// DynamoDB transactions can contain at most 100 items.
+func (c *Client) TransactPut(ctx context.Context, puts ...Put) error {
+	in := dynamodb.TransactWriteItemsInput{
+		TransactItems: make([]types.TransactWriteItem, len(puts)),
+	}
+	for i, p := range puts {
+		item, err := marshalItem(p.Item)
+		if err != nil {
+			return err
+		}
+		put := types.Put{
+			Item:      item,
+			TableName: &c.Table,
+		}
+		if p.Condition != "" {
+			put.ConditionExpression = &p.Condition
+			put.ExpressionAttributeNames = p.Names
+			put.ExpressionAttributeValues = p.Values
+		}
+		in.TransactItems[i] = types.TransactWriteItem{Put: &put}
+	}
+
+	_, err := c.DB.TransactWriteItems(ctx, &in)
+	return conditionErr(err)
+}
+
+// conditionErr returns ErrConditionFailed if the error is caused by a condition which wasn't met.
+func conditionErr(err error) error {
+	var ccf *types.ConditionalCheckFailedException
+	if errors.As(err, &ccf) {
+		return ErrConditionFailed
+	}
+	var tce *types.TransactionCanceledException
+	if errors.As(err, &tce) {
+		for _, r := range tce.CancellationReasons {
+			if r.Code != nil && *r.Code == "ConditionalCheckFailed" {
+				return ErrConditionFailed
+			}
+		}
+	}
+	return err
+}
+
+// marshalItem marshals the item with its keys, in the same way as the ddb client.
+func marshalItem(item ddb.Keyer) (map[string]types.AttributeValue, error) {
+	keys, err := item.DDBKeys()
+	if err != nil {
+		return nil, err
+	}
+	attrs, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return nil, err
+	}
+	v := reflect.ValueOf(keys)
+	for i := 0; i < v.NumField(); i++ {
+		// empty keys aren't written, so that items aren't added to indexes they don't belong to.
+		if val := v.Field(i).String(); val != "" {
+			attrs[v.Type().Field(i).Name] = &types.AttributeValueMemberS{Value: val}
+		}
+	}
+	return attrs, nil
+}
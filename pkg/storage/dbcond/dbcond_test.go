@@ -0,0 +1,157 @@
+package dbcond
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/common-fate/ddb"
+	"github.com/stretchr/testify/assert"
+)
+
+type testItem struct {
+	ID   string `dynamodbav:"id"`
+	Name string `dynamodbav:"name"`
+}
+
+func (i *testItem) DDBKeys() (ddb.Keys, error) {
+	return ddb.Keys{PK: "ITEM#", SK: i.ID}, nil
+}
+
+type testDB struct {
+	in       *dynamodb.TransactWriteItemsInput
+	updateIn *dynamodb.UpdateItemInput
+	err      error
+}
+
+func (d *testDB) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	return &dynamodb.PutItemOutput{}, d.err
+}
+
+func (d *testDB) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	d.updateIn = params
+	return &dynamodb.UpdateItemOutput{}, d.err
+}
+
+func (d *testDB) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	d.in = params
+	return &dynamodb.TransactWriteItemsOutput{}, d.err
+}
+
+func TestTransactPut(t *testing.T) {
+	otherErr := errors.New("other error")
+	type testcase struct {
+		name    string
+		dbErr   error
+		wantErr error
+	}
+	testcases := []testcase{
+		{
+			name: "ok",
+		},
+		{
+			name: "condition failed",
+			dbErr: &types.TransactionCanceledException{CancellationReasons: []types.CancellationReason{
+				{Code: aws.String("None")},
+				{Code: aws.String("ConditionalCheckFailed")},
+			}},
+			wantErr: ErrConditionFailed,
+		},
+		{
+			name:    "other error",
+			dbErr:   otherErr,
+			wantErr: otherErr,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := testDB{err: tc.dbErr}
+			c := Client{DB: &db, Table: "test"}
+			err := c.TransactPut(context.Background(),
+				Put{Item: &testItem{ID: "a", Name: "A"}},
+				Put{
+					Item:      &testItem{ID: "b", Name: "B"},
+					Condition: "#name = :name",
+					Names:     map[string]string{"#name": "name"},
+					Values:    map[string]types.AttributeValue{":name": &types.AttributeValueMemberS{Value: "old"}},
+				},
+			)
+			assert.Equal(t, tc.wantErr, err)
+
+			want := []types.TransactWriteItem{
+				{Put: &types.Put{
+					TableName: aws.String("test"),
+					Item: map[string]types.AttributeValue{
+						"id":   &types.AttributeValueMemberS{Value: "a"},
+						"name": &types.AttributeValueMemberS{Value: "A"},
+						"PK":   &types.AttributeValueMemberS{Value: "ITEM#"},
+						"SK":   &types.AttributeValueMemberS{Value: "a"},
+					},
+				}},
+				{Put: &types.Put{
+					TableName: aws.String("test"),
+					Item: map[string]types.AttributeValue{
+						"id":   &types.AttributeValueMemberS{Value: "b"},
+						"name": &types.AttributeValueMemberS{Value: "B"},
+						"PK":   &types.AttributeValueMemberS{Value: "ITEM#"},
+						"SK":   &types.AttributeValueMemberS{Value: "b"},
+					},
+					ConditionExpression:       aws.String("#name = :name"),
+					ExpressionAttributeNames:  map[string]string{"#name": "name"},
+					ExpressionAttributeValues: map[string]types.AttributeValue{":name": &types.AttributeValueMemberS{Value: "old"}},
+				}},
+			}
+			assert.Equal(t, want, db.in.TransactItems)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type testcase struct {
+		name    string
+		dbErr   error
+		wantErr error
+	}
+	testcases := []testcase{
+		{
+			name: "ok",
+		},
+		{
+			name:    "condition failed",
+			dbErr:   &types.ConditionalCheckFailedException{},
+			wantErr: ErrConditionFailed,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := testDB{err: tc.dbErr}
+			c := Client{DB: &db, Table: "test"}
+			err := c.Update(context.Background(), Update{
+				Item:       &testItem{ID: "a", Name: "A"},
+				Expression: "SET #name = :name",
+				Condition:  "attribute_exists(PK)",
+				Names:      map[string]string{"#name": "name"},
+				Values:     map[string]types.AttributeValue{":name": &types.AttributeValueMemberS{Value: "B"}},
+			})
+			assert.Equal(t, tc.wantErr, err)
+
+			want := &dynamodb.UpdateItemInput{
+				TableName: aws.String("test"),
+				Key: map[string]types.AttributeValue{
+					"PK": &types.AttributeValueMemberS{Value: "ITEM#"},
+					"SK": &types.AttributeValueMemberS{Value: "a"},
+				},
+				UpdateExpression:          aws.String("SET #name = :name"),
+				ConditionExpression:       aws.String("attribute_exists(PK)"),
+				ExpressionAttributeNames:  map[string]string{"#name": "name"},
+				ExpressionAttributeValues: map[string]types.AttributeValue{":name": &types.AttributeValueMemberS{Value: "B"}},
+			}
+			assert.Equal(t, want, db.updateIn)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/storage/keys"
+)
+
+type GetAPIKey struct {
+	ID     string
+	Result *identity.APIKey
+}
+
+func (g *GetAPIKey) BuildQuery() (*dynamodb.QueryInput, error) {
+	qi := &dynamodb.QueryInput{
+		Limit:                  aws.Int32(1),
+		KeyConditionExpression: aws.String("PK = :pk1 and SK = :sk1"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk1": &types.AttributeValueMemberS{Value: keys.APIKeys.PK1},
+			":sk1": &types.AttributeValueMemberS{Value: keys.APIKeys.SK1(g.ID)},
+		},
+	}
+
+	return qi, nil
+}
+
+func (g *GetAPIKey) UnmarshalQueryOutput(out *dynamodb.QueryOutput) error {
+	if len(out.Items) != 1 {
+		return ddb.ErrNoItems
+	}
+
+	return attributevalue.UnmarshalMap(out.Items[0], &g.Result)
+}
@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/storage/keys"
+)
+
+// GetAPIKeyByHash looks up an API key by the hash of the key. Use identity.HashAPIKey to hash the key.
+type GetAPIKeyByHash struct {
+	Hash   string
+	Result *identity.APIKey
+}
+
+func (g *GetAPIKeyByHash) BuildQuery() (*dynamodb.QueryInput, error) {
+	qi := &dynamodb.QueryInput{
+		Limit:                  aws.Int32(1),
+		IndexName:              &keys.IndexNames.GSI1,
+		KeyConditionExpression: aws.String("GSI1PK = :pk1 and GSI1SK = :sk1"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk1": &types.AttributeValueMemberS{Value: keys.APIKeys.GSI1PK},
+			":sk1": &types.AttributeValueMemberS{Value: keys.APIKeys.GSI1SK(g.Hash)},
+		},
+	}
+
+	return qi, nil
+}
+
+func (g *GetAPIKeyByHash) UnmarshalQueryOutput(out *dynamodb.QueryOutput) error {
+	if len(out.Items) != 1 {
+		return ddb.ErrNoItems
+	}
+
+	return attributevalue.UnmarshalMap(out.Items[0], &g.Result)
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/ddb/ddbtest"
+	"github.com/common-fate/granted-approvals/pkg/identity"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+func TestGetAPIKey(t *testing.T) {
+	db := newTestingStorage(t)
+
+	k, _, err := identity.NewAPIKey("test", "", types.READONLY, types.NewUserID(), time.Now().UTC())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ddbtest.PutFixtures(t, db, &k)
+
+	tc := []ddbtest.QueryTestCase{
+		{
+			Name:  "ok",
+			Query: &GetAPIKey{ID: k.ID},
+			Want:  &GetAPIKey{ID: k.ID, Result: &k},
+		},
+		{
+			Name:  "by hash",
+			Query: &GetAPIKeyByHash{Hash: k.Hash},
+			Want:  &GetAPIKeyByHash{Hash: k.Hash, Result: &k},
+		},
+		{
+			Name:    "key not found",
+			Query:   &GetAPIKey{ID: types.NewAPIKeyID()},
+			WantErr: ddb.ErrNoItems,
+		},
+		{
+			Name:    "hash not found",
+			Query:   &GetAPIKeyByHash{Hash: identity.HashAPIKey("gak_other")},
+			WantErr: ddb.ErrNoItems,
+		},
+	}
+
+	ddbtest.RunQueryTests(t, db, tc)
+}
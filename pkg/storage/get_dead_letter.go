@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/eventlog"
+	"github.com/common-fate/granted-approvals/pkg/storage/keys"
+)
+
+type GetDeadLetter struct {
+	ID     string
+	Result *eventlog.DeadLetter
+}
+
+func (g *GetDeadLetter) BuildQuery() (*dynamodb.QueryInput, error) {
+	qi := &dynamodb.QueryInput{
+		Limit:                  aws.Int32(1),
+		KeyConditionExpression: aws.String("PK = :pk1 and SK = :sk1"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk1": &types.AttributeValueMemberS{Value: keys.DeadLetter.PK1},
+			":sk1": &types.AttributeValueMemberS{Value: keys.DeadLetter.SK1(g.ID)},
+		},
+	}
+	return qi, nil
+}
+
+func (g *GetDeadLetter) UnmarshalQueryOutput(out *dynamodb.QueryOutput) error {
+	if len(out.Items) != 1 {
+		return ddb.ErrNoItems
+	}
+
+	return attributevalue.UnmarshalMap(out.Items[0], &g.Result)
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/ddb/ddbtest"
+	"github.com/common-fate/granted-approvals/pkg/eventlog"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+func TestGetDeadLetter(t *testing.T) {
+	db := newTestingStorage(t)
+
+	now := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	ev := events.CloudWatchEvent{
+		ID:         types.NewRequestID(),
+		DetailType: "grant.activated",
+		Source:     "commonfate.io/granted",
+		Time:       now,
+		Detail:     []byte(`{"grant":{"id":"req_123"}}`),
+	}
+	dl := eventlog.NewDeadLetter(ev, errors.New("request: req_123 does not have a grant"), now)
+	ddbtest.PutFixtures(t, db, &dl)
+
+	tc := []ddbtest.QueryTestCase{
+		{
+			Name:  "ok",
+			Query: &GetDeadLetter{ID: dl.ID},
+			Want:  &GetDeadLetter{ID: dl.ID, Result: &dl},
+		},
+		{
+			Name:    "not found",
+			Query:   &GetDeadLetter{ID: types.NewRequestID()},
+			WantErr: ddb.ErrNoItems,
+		},
+	}
+
+	ddbtest.RunQueryTests(t, db, tc)
+}
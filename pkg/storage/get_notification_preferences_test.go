@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/ddb/ddbtest"
+	"github.com/common-fate/granted-approvals/pkg/notifications"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+func TestGetNotificationPreferences(t *testing.T) {
+	db := newTestingStorage(t)
+
+	p := notifications.Preferences{
+		UserID:      types.NewUserID(),
+		Channel:     notifications.ChannelSlack,
+		Delivery:    notifications.DeliveryDigest,
+		MutedEvents: []string{"request.approved"},
+	}
+	ddbtest.PutFixtures(t, db, &p)
+
+	tc := []ddbtest.QueryTestCase{
+		{
+			Name:  "ok",
+			Query: &GetNotificationPreferences{UserID: p.UserID},
+			Want:  &GetNotificationPreferences{UserID: p.UserID, Result: &p},
+		},
+		{
+			Name:    "no preferences",
+			Query:   &GetNotificationPreferences{UserID: types.NewUserID()},
+			WantErr: ddb.ErrNoItems,
+		},
+	}
+
+	ddbtest.RunQueryTests(t, db, tc)
+}
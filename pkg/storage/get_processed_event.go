@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/common-fate/ddb"
+	"github.com/common-fate/granted-approvals/pkg/eventlog"
+	"github.com/common-fate/granted-approvals/pkg/storage/keys"
+)
+
+// GetProcessedEvent returns the marker recorded when an event was applied by the event handler.
+// It returns ddb.ErrNoItems if the event hasn't been applied yet.
+type GetProcessedEvent struct {
+	ID     string
+	Result *eventlog.Processed
+}
+
+func (g *GetProcessedEvent) BuildQuery() (*dynamodb.QueryInput, error) {
+	qi := &dynamodb.QueryInput{
+		Limit:                  aws.Int32(1),
+		KeyConditionExpression: aws.String("PK = :pk1 and SK = :sk1"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk1": &types.AttributeValueMemberS{Value: keys.ProcessedEvent.PK1},
+			":sk1": &types.AttributeValueMemberS{Value: keys.ProcessedEvent.SK1(g.ID)},
+		},
+	}
+	return qi, nil
+}
+
+func (g *GetProcessedEvent) UnmarshalQueryOutput(out *dynamodb.QueryOutput) error {
+	if len(out.Items) != 1 {
+		return ddb.ErrNoItems
+	}
+
+	return attributevalue.UnmarshalMap(out.Items[0], &g.Result)
+}
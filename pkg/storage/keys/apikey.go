@@ -0,0 +1,17 @@
+package keys
+
+const APIKeyKey = "API_KEY#"
+
+type apiKeyKeys struct {
+	PK1    string
+	SK1    func(keyID string) string
+	GSI1PK string
+	GSI1SK func(hash string) string
+}
+
+var APIKeys = apiKeyKeys{
+	PK1:    APIKeyKey,
+	SK1:    func(keyID string) string { return keyID },
+	GSI1PK: APIKeyKey,
+	GSI1SK: func(hash string) string { return hash },
+}
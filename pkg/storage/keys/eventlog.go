@@ -0,0 +1,25 @@
+package keys
+
+const DeadLetterKey = "DEAD_LETTER_EVENT#"
+
+type deadLetterKeys struct {
+	PK1 string
+	SK1 func(eventID string) string
+}
+
+var DeadLetter = deadLetterKeys{
+	PK1: DeadLetterKey,
+	SK1: func(eventID string) string { return eventID },
+}
+
+const ProcessedEventKey = "PROCESSED_EVENT#"
+
+type processedEventKeys struct {
+	PK1 string
+	SK1 func(eventID string) string
+}
+
+var ProcessedEvent = processedEventKeys{
+	PK1: ProcessedEventKey,
+	SK1: func(eventID string) string { return eventID },
+}
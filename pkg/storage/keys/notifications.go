@@ -0,0 +1,29 @@
+package keys
+
+const NotificationPreferencesKey = "NOTIFICATION_PREFERENCES#"
+
+type notificationPreferencesKeys struct {
+	PK1 string
+	SK1 func(userID string) string
+}
+
+var NotificationPreferences = notificationPreferencesKeys{
+	PK1: NotificationPreferencesKey,
+	SK1: func(userID string) string { return userID },
+}
+
+const DigestMessageKey = "DIGEST_MESSAGE#"
+
+type digestMessageKeys struct {
+	PK1    string
+	SK1    func(userID string, messageID string) string
+	GSI1PK func(channel string) string
+	GSI1SK func(userID string, messageID string) string
+}
+
+var DigestMessage = digestMessageKeys{
+	PK1:    DigestMessageKey,
+	SK1:    func(userID string, messageID string) string { return userID + "#" + messageID },
+	GSI1PK: func(channel string) string { return DigestMessageKey + channel },
+	GSI1SK: func(userID string, messageID string) string { return userID + "#" + messageID },
+}
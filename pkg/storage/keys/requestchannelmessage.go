@@ -0,0 +1,15 @@
+package keys
+
+const RequestChannelMessageKey = "REQUEST_CHANNEL_MESSAGE#"
+
+type requestChannelMessageKeys struct {
+	PK1        string
+	SK1        func(requestID string, channel string) string
+	SK1Request func(requestID string) string
+}
+
+var RequestChannelMessage = requestChannelMessageKeys{
+	PK1:        RequestChannelMessageKey,
+	SK1:        func(requestID, channel string) string { return requestID + "#" + channel },
+	SK1Request: func(requestID string) string { return requestID + "#" },
+}
@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/common-fate/granted-approvals/pkg/eventlog"
+	"github.com/common-fate/granted-approvals/pkg/storage/keys"
+)
+
+// ListDeadLetters lists all events which the event handler failed to process.
+type ListDeadLetters struct {
+	Result []eventlog.DeadLetter `ddb:"result"`
+}
+
+func (l *ListDeadLetters) BuildQuery() (*dynamodb.QueryInput, error) {
+	qi := dynamodb.QueryInput{
+		KeyConditionExpression: aws.String("PK = :pk1"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk1": &types.AttributeValueMemberS{Value: keys.DeadLetter.PK1},
+		},
+	}
+	return &qi, nil
+}
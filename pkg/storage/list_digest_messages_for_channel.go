@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/common-fate/granted-approvals/pkg/notifications"
+	"github.com/common-fate/granted-approvals/pkg/storage/keys"
+)
+
+// ListDigestMessagesForChannel lists all digest messages waiting to be sent via a channel.
+// Results are ordered by user.
+type ListDigestMessagesForChannel struct {
+	Channel notifications.Channel
+	Result  []notifications.DigestMessage `ddb:"result"`
+}
+
+func (l *ListDigestMessagesForChannel) BuildQuery() (*dynamodb.QueryInput, error) {
+	qi := dynamodb.QueryInput{
+		IndexName:              aws.String(keys.IndexNames.GSI1),
+		KeyConditionExpression: aws.String("GSI1PK = :pk1"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk1": &types.AttributeValueMemberS{Value: keys.DigestMessage.GSI1PK(string(l.Channel))},
+		},
+	}
+	return &qi, nil
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/common-fate/ddb/ddbtest"
+	"github.com/common-fate/granted-approvals/pkg/notifications"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+func TestListDigestMessagesForChannel(t *testing.T) {
+	db := newTestingStorage(t)
+
+	// use a unique channel so that the test is isolated from other fixtures.
+	channel := notifications.Channel(types.NewDigestMessageID())
+	msg := notifications.DigestMessage{
+		ID:        types.NewDigestMessageID(),
+		UserID:    types.NewUserID(),
+		Channel:   channel,
+		EventType: "request.approved",
+		Message:   "Your request has been approved.",
+	}
+	ddbtest.PutFixtures(t, db, &msg)
+
+	tc := []ddbtest.QueryTestCase{
+		{
+			Name:  "ok",
+			Query: &ListDigestMessagesForChannel{Channel: channel},
+			Want:  &ListDigestMessagesForChannel{Channel: channel, Result: []notifications.DigestMessage{msg}},
+		},
+	}
+
+	ddbtest.RunQueryTests(t, db, tc)
+}
@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/storage/keys"
+)
+
+type ListRequestChannelMessages struct {
+	RequestID string
+	Result    []access.ChannelMessage `ddb:"result"`
+}
+
+func (g *ListRequestChannelMessages) BuildQuery() (*dynamodb.QueryInput, error) {
+	qi := dynamodb.QueryInput{
+		KeyConditionExpression: aws.String("PK = :pk AND begins_with(SK, :sk)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":pk": &types.AttributeValueMemberS{Value: keys.RequestChannelMessage.PK1},
+			":sk": &types.AttributeValueMemberS{Value: keys.RequestChannelMessage.SK1Request(g.RequestID)},
+		},
+	}
+	return &qi, nil
+}
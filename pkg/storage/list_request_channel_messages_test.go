@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/common-fate/ddb/ddbtest"
+	"github.com/common-fate/granted-approvals/pkg/access"
+	"github.com/common-fate/granted-approvals/pkg/types"
+)
+
+func TestListRequestChannelMessages(t *testing.T) {
+	s := newTestingStorage(t)
+
+	reqID := types.NewRequestID()
+	ts := "1660000000.000100"
+	cm1 := access.ChannelMessage{RequestID: reqID, Channel: "#approvals", Notifications: access.Notifications{SlackMessageID: &ts}}
+	cm2 := access.ChannelMessage{RequestID: reqID, Channel: "#security", Notifications: access.Notifications{SlackMessageID: &ts}}
+	ddbtest.PutFixtures(t, s, []*access.ChannelMessage{&cm1, &cm2})
+
+	tc := []ddbtest.QueryTestCase{
+		{
+			Name:  "ok",
+			Query: &ListRequestChannelMessages{RequestID: reqID},
+			Want:  &ListRequestChannelMessages{RequestID: reqID, Result: []access.ChannelMessage{cm1, cm2}},
+		},
+	}
+
+	ddbtest.RunQueryTests(t, s, tc)
+}
@@ -0,0 +1,113 @@
+package tickets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/common-fate/granted-approvals/pkg/gconfig"
+	"github.com/pkg/errors"
+)
+
+// JiraValidator looks up issues in Jira Cloud or Jira Server using the REST API.
+type JiraValidator struct {
+	client   *http.Client
+	baseURL  gconfig.StringValue
+	email    gconfig.StringValue
+	apiToken gconfig.SecretStringValue
+}
+
+func (j *JiraValidator) Config() gconfig.Config {
+	return gconfig.Config{
+		gconfig.StringField("baseUrl", &j.baseURL, "the Jira URL, such as https://example.atlassian.net"),
+		gconfig.StringField("email", &j.email, "the email address of the Jira user which looks up issues"),
+		gconfig.SecretStringField("apiToken", &j.apiToken, "the Jira API token", gconfig.WithNoArgs("/granted/secrets/tickets/jira/token")),
+	}
+}
+
+func (j *JiraValidator) Init(ctx context.Context) error {
+	j.client = &http.Client{Timeout: 10 * time.Second}
+	return nil
+}
+
+func (j *JiraValidator) TestConfig(ctx context.Context) error {
+	res, err := j.get(ctx, "/rest/api/2/myself")
+	if err != nil {
+		return errors.Wrap(err, "failed to get the current user while testing jira configuration")
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get the current user while testing jira configuration: jira returned %s", res.Status)
+	}
+	return nil
+}
+
+type jiraUser struct {
+	EmailAddress string `json:"emailAddress"`
+}
+
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Status struct {
+			Name string `json:"name"`
+		} `json:"status"`
+		Assignee *jiraUser `json:"assignee"`
+		Reporter *jiraUser `json:"reporter"`
+	} `json:"fields"`
+}
+
+// GetTicket returns the issue with the key, such as OPS-123. The state of the ticket is the name of the issue's status.
+// The people of the ticket are the issue's assignee and reporter, if Jira shares their emails.
+func (j *JiraValidator) GetTicket(ctx context.Context, id string) (*Ticket, error) {
+	err := checkID(id)
+	if err != nil {
+		return nil, err
+	}
+	res, err := j.get(ctx, "/rest/api/2/issue/"+url.PathEscape(id)+"?fields=status,assignee,reporter")
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrTicketNotFound, id)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("looking up jira issue %s: jira returned %s", id, res.Status)
+	}
+	var issue jiraIssue
+	err = json.NewDecoder(res.Body).Decode(&issue)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding jira issue %s", id)
+	}
+	t := Ticket{
+		ID:     issue.Key,
+		State:  issue.Fields.Status.Name,
+		URL:    j.base() + "/browse/" + url.PathEscape(issue.Key),
+		People: []string{},
+	}
+	for _, u := range []*jiraUser{issue.Fields.Assignee, issue.Fields.Reporter} {
+		if u != nil && u.EmailAddress != "" {
+			t.People = append(t.People, u.EmailAddress)
+		}
+	}
+	return &t, nil
+}
+
+func (j *JiraValidator) get(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.base()+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(j.email.Get(), j.apiToken.Get())
+	req.Header.Set("Accept", "application/json")
+	return j.client.Do(req)
+}
+
+func (j *JiraValidator) base() string {
+	return strings.TrimSuffix(j.baseURL.Get(), "/")
+}
@@ -0,0 +1,43 @@
+package tickets
+
+import (
+	"fmt"
+)
+
+const (
+	TicketSystemJira       = "jira"
+	TicketSystemServiceNow = "servicenow"
+)
+
+type RegisteredTicketValidator struct {
+	TicketValidator TicketValidator
+	Description     string
+}
+
+type TicketValidatorRegistry struct {
+	TicketValidators map[string]RegisteredTicketValidator
+}
+
+func Registry() TicketValidatorRegistry {
+	return TicketValidatorRegistry{
+		TicketValidators: map[string]RegisteredTicketValidator{
+			TicketSystemJira: {
+				TicketValidator: &JiraValidator{},
+				Description:     "Jira",
+			},
+			TicketSystemServiceNow: {
+				TicketValidator: &ServiceNowValidator{},
+				Description:     "ServiceNow",
+			},
+		},
+	}
+}
+
+// Lookup a ticket validator by the ticket system.
+func (r TicketValidatorRegistry) Lookup(system string) (*RegisteredTicketValidator, error) {
+	v, ok := r.TicketValidators[system]
+	if !ok {
+		return nil, fmt.Errorf("could not find ticket system %s", system)
+	}
+	return &v, nil
+}
@@ -0,0 +1,130 @@
+package tickets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/common-fate/granted-approvals/pkg/gconfig"
+	"github.com/pkg/errors"
+)
+
+// DefaultServiceNowTable is the table which tickets are looked up in if the table isn't configured.
+const DefaultServiceNowTable = "change_request"
+
+// ServiceNowValidator looks up records in ServiceNow using the Table API.
+type ServiceNowValidator struct {
+	client      *http.Client
+	instanceURL gconfig.StringValue
+	username    gconfig.StringValue
+	password    gconfig.SecretStringValue
+	table       gconfig.OptionalStringValue
+}
+
+func (s *ServiceNowValidator) Config() gconfig.Config {
+	return gconfig.Config{
+		gconfig.StringField("instanceUrl", &s.instanceURL, "the ServiceNow instance URL, such as https://example.service-now.com"),
+		gconfig.StringField("username", &s.username, "the username of the ServiceNow user which looks up tickets"),
+		gconfig.SecretStringField("password", &s.password, "the password of the ServiceNow user", gconfig.WithNoArgs("/granted/secrets/tickets/servicenow/password")),
+		gconfig.OptionalStringField("table", &s.table, "the table which tickets are looked up in (optional, defaults to change_request)"),
+	}
+}
+
+func (s *ServiceNowValidator) Init(ctx context.Context) error {
+	s.client = &http.Client{Timeout: 10 * time.Second}
+	return nil
+}
+
+func (s *ServiceNowValidator) TestConfig(ctx context.Context) error {
+	_, err := s.query(ctx, "sysparm_limit=1")
+	if err != nil {
+		return errors.Wrap(err, "failed to list tickets while testing servicenow configuration")
+	}
+	return nil
+}
+
+type serviceNowRecord struct {
+	Number           string `json:"number"`
+	State            string `json:"state"`
+	SysID            string `json:"sys_id"`
+	AssignedToEmail  string `json:"assigned_to.email"`
+	RequestedByEmail string `json:"requested_by.email"`
+	OpenedByEmail    string `json:"opened_by.email"`
+}
+
+// GetTicket returns the record with the number, such as CHG0030001.
+// The state of the ticket is the display value of the record's state, such as "Scheduled".
+// The people of the ticket are the users the record is assigned to, requested by and opened by.
+func (s *ServiceNowValidator) GetTicket(ctx context.Context, id string) (*Ticket, error) {
+	err := checkID(id)
+	if err != nil {
+		return nil, err
+	}
+	q := url.Values{
+		"sysparm_query":         {"number=" + id},
+		"sysparm_fields":        {"number,state,sys_id,assigned_to.email,requested_by.email,opened_by.email"},
+		"sysparm_display_value": {"true"},
+		"sysparm_limit":         {"1"},
+	}
+	records, err := s.query(ctx, q.Encode())
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up servicenow ticket %s", id)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTicketNotFound, id)
+	}
+	r := records[0]
+	t := Ticket{
+		ID:     r.Number,
+		State:  r.State,
+		URL:    fmt.Sprintf("%s/%s.do?sys_id=%s", s.base(), s.tableName(), url.QueryEscape(r.SysID)),
+		People: []string{},
+	}
+	for _, email := range []string{r.AssignedToEmail, r.RequestedByEmail, r.OpenedByEmail} {
+		if email != "" {
+			t.People = append(t.People, email)
+		}
+	}
+	return &t, nil
+}
+
+func (s *ServiceNowValidator) query(ctx context.Context, query string) ([]serviceNowRecord, error) {
+	u := fmt.Sprintf("%s/api/now/table/%s?%s", s.base(), url.PathEscape(s.tableName()), query)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(s.username.Get(), s.password.Get())
+	req.Header.Set("Accept", "application/json")
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("servicenow returned %s", res.Status)
+	}
+	var body struct {
+		Result []serviceNowRecord `json:"result"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&body)
+	if err != nil {
+		return nil, err
+	}
+	return body.Result, nil
+}
+
+func (s *ServiceNowValidator) tableName() string {
+	if s.table.Get() != "" {
+		return s.table.Get()
+	}
+	return DefaultServiceNowTable
+}
+
+func (s *ServiceNowValidator) base() string {
+	return strings.TrimSuffix(s.instanceURL.Get(), "/")
+}
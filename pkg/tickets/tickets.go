@@ -0,0 +1,102 @@
+// Package tickets looks up tickets in ticket systems such as Jira and ServiceNow,
+// so that requests for access rules which require a ticket can be validated.
+package tickets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/common-fate/granted-approvals/pkg/gconfig"
+)
+
+var (
+	// ErrTicketNotFound is returned if a ticket doesn't exist in the ticket system.
+	ErrTicketNotFound = errors.New("ticket not found")
+
+	// ErrSystemNotConfigured is returned if a ticket is looked up in a ticket system which isn't configured for the deployment.
+	ErrSystemNotConfigured = errors.New("ticket system is not configured")
+)
+
+// Ticket is a ticket in a ticket system.
+type Ticket struct {
+	ID string
+	// State is the status of the ticket as shown in the ticket system, such as "In Progress" or "Scheduled".
+	State string
+	// URL links to the ticket in the ticket system.
+	URL string
+	// People are the emails of the people the ticket is assigned to or was reported or requested by.
+	// Emails which the ticket system doesn't share are left out.
+	People []string
+}
+
+// Involves returns true if the person with the email is assigned to the ticket, or reported or requested it.
+func (t Ticket) Involves(email string) bool {
+	if email == "" {
+		return false
+	}
+	for _, p := range t.People {
+		if strings.EqualFold(p, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// TicketValidator looks up tickets in a ticket system.
+type TicketValidator interface {
+	// GetTicket returns ErrTicketNotFound if the ticket doesn't exist.
+	GetTicket(ctx context.Context, id string) (*Ticket, error)
+	gconfig.Configer
+	gconfig.Initer
+}
+
+// Validators are the ticket validators configured for the deployment, keyed by the ticket system.
+type Validators map[string]TicketValidator
+
+// GetTicket looks up the ticket in the ticket system.
+// It returns ErrSystemNotConfigured if the ticket system isn't configured.
+func (v Validators) GetTicket(ctx context.Context, system string, id string) (*Ticket, error) {
+	tv, ok := v[system]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSystemNotConfigured, system)
+	}
+	return tv.GetTicket(ctx, id)
+}
+
+// Load configures and initialises a ticket validator for each ticket system in the configuration.
+// It's configured in the same way as notifications, with the settings for each system keyed by the system.
+func Load(ctx context.Context, cfg deploy.FeatureMap) (Validators, error) {
+	res := make(Validators)
+	for system, values := range cfg {
+		r, err := Registry().Lookup(system)
+		if err != nil {
+			return nil, err
+		}
+		tv := r.TicketValidator
+		err = tv.Config().Load(ctx, &gconfig.MapLoader{Values: values})
+		if err != nil {
+			return nil, fmt.Errorf("loading %s ticket system configuration: %w", system, err)
+		}
+		err = tv.Init(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("initialising %s ticket system: %w", system, err)
+		}
+		res[system] = tv
+	}
+	return res, nil
+}
+
+// ticketID matches the IDs used by the supported ticket systems, such as OPS-123 and CHG0030001.
+// IDs are checked before they are used in URLs and queries.
+var ticketID = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,63}$`)
+
+func checkID(id string) error {
+	if !ticketID.MatchString(id) {
+		return fmt.Errorf("%w: %s is not a valid ticket ID", ErrTicketNotFound, id)
+	}
+	return nil
+}
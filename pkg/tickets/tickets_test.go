@@ -0,0 +1,129 @@
+package tickets
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/common-fate/granted-approvals/pkg/deploy"
+	"github.com/stretchr/testify/assert"
+)
+
+// newJiraServer stands in for the Jira REST API, serving the issues keyed by their key.
+func newJiraServer(t *testing.T, issues map[string]string) *httptest.Server {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "bot@example.com" || pass != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		key := r.URL.Path[len("/rest/api/2/issue/"):]
+		status, ok := issues[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// Jira doesn't share the emails of users who hide them, such as the reporter here.
+		_, _ = w.Write([]byte(`{"key":"` + key + `","fields":{"status":{"name":"` + status + `"},"assignee":{"emailAddress":"alice@example.com"},"reporter":{"displayName":"Bob"}}}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// newServiceNowServer stands in for the ServiceNow Table API, serving the change requests keyed by their number.
+func newServiceNowServer(t *testing.T, changes map[string]string) *httptest.Server {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "granted" || pass != "password" || r.URL.Path != "/api/now/table/change_request" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		number := r.URL.Query().Get("sysparm_query")[len("number="):]
+		state, ok := changes[number]
+		if !ok {
+			_, _ = w.Write([]byte(`{"result":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"result":[{"number":"` + number + `","state":"` + state + `","sys_id":"abc123","assigned_to.email":"","requested_by.email":"alice@example.com","opened_by.email":"bob@example.com"}]}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestGetTicket(t *testing.T) {
+	ctx := context.Background()
+	jira := newJiraServer(t, map[string]string{"OPS-123": "In Progress"})
+	snow := newServiceNowServer(t, map[string]string{"CHG0030001": "Scheduled"})
+
+	v, err := Load(ctx, deploy.FeatureMap{
+		TicketSystemJira:       {"baseUrl": jira.URL + "/", "email": "bot@example.com", "apiToken": "token"},
+		TicketSystemServiceNow: {"instanceUrl": snow.URL, "username": "granted", "password": "password"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		name    string
+		system  string
+		id      string
+		want    *Ticket
+		wantErr error
+	}{
+		{
+			name:   "jira issue",
+			system: TicketSystemJira,
+			id:     "OPS-123",
+			want:   &Ticket{ID: "OPS-123", State: "In Progress", URL: jira.URL + "/browse/OPS-123", People: []string{"alice@example.com"}},
+		},
+		{
+			name:    "jira issue not found",
+			system:  TicketSystemJira,
+			id:      "OPS-404",
+			wantErr: ErrTicketNotFound,
+		},
+		{
+			name:   "servicenow change request",
+			system: TicketSystemServiceNow,
+			id:     "CHG0030001",
+			want:   &Ticket{ID: "CHG0030001", State: "Scheduled", URL: snow.URL + "/change_request.do?sys_id=abc123", People: []string{"alice@example.com", "bob@example.com"}},
+		},
+		{
+			name:    "servicenow change request not found",
+			system:  TicketSystemServiceNow,
+			id:      "CHG0000000",
+			wantErr: ErrTicketNotFound,
+		},
+		{
+			name:    "ids which could change the query are rejected",
+			system:  TicketSystemServiceNow,
+			id:      "CHG0030001^ORnumber!=x",
+			wantErr: ErrTicketNotFound,
+		},
+		{
+			name:    "system not configured",
+			system:  "zendesk",
+			id:      "1",
+			wantErr: ErrSystemNotConfigured,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := v.GetTicket(ctx, tc.system, tc.id)
+			if tc.wantErr != nil {
+				assert.True(t, errors.Is(err, tc.wantErr), "got error %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestLoadUnknownSystem(t *testing.T) {
+	_, err := Load(context.Background(), deploy.FeatureMap{"zendesk": {}})
+	assert.EqualError(t, err, "could not find ticket system zendesk")
+}
@@ -22,12 +22,76 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
+// Defines values for APIKeyScope.
+const (
+	ADMIN           APIKeyScope = "ADMIN"
+	READONLY        APIKeyScope = "READ_ONLY"
+	REQUESTONBEHALF APIKeyScope = "REQUEST_ON_BEHALF"
+)
+
+// Defines values for APIKeyStatus.
+const (
+	APIKeyStatusACTIVE  APIKeyStatus = "ACTIVE"
+	APIKeyStatusREVOKED APIKeyStatus = "REVOKED"
+)
+
+// Defines values for AccessRulePolicyLanguage.
+const (
+	Cel AccessRulePolicyLanguage = "cel"
+)
+
+// Defines values for AccessRulePolicyConditionEffect.
+const (
+	AUTOAPPROVE     AccessRulePolicyConditionEffect = "AUTO_APPROVE"
+	DENY            AccessRulePolicyConditionEffect = "DENY"
+	REQUIREAPPROVAL AccessRulePolicyConditionEffect = "REQUIRE_APPROVAL"
+)
+
+// Defines values for AccessRuleRequestFieldType.
+const (
+	SELECT AccessRuleRequestFieldType = "SELECT"
+	TEXT   AccessRuleRequestFieldType = "TEXT"
+	URL    AccessRuleRequestFieldType = "URL"
+)
+
 // Defines values for AccessRuleStatus.
 const (
 	AccessRuleStatusACTIVE   AccessRuleStatus = "ACTIVE"
 	AccessRuleStatusARCHIVED AccessRuleStatus = "ARCHIVED"
 )
 
+// Defines values for AccessRuleTicketSystem.
+const (
+	Jira       AccessRuleTicketSystem = "jira"
+	Servicenow AccessRuleTicketSystem = "servicenow"
+)
+
+// Defines values for AccessSimulationResultApproval.
+const (
+	AUTOAPPROVED   AccessSimulationResultApproval = "AUTO_APPROVED"
+	CONDITIONAL    AccessSimulationResultApproval = "CONDITIONAL"
+	REVIEWREQUIRED AccessSimulationResultApproval = "REVIEW_REQUIRED"
+)
+
+// Defines values for AccessWindowDays.
+const (
+	Friday    AccessWindowDays = "friday"
+	Monday    AccessWindowDays = "monday"
+	Saturday  AccessWindowDays = "saturday"
+	Sunday    AccessWindowDays = "sunday"
+	Thursday  AccessWindowDays = "thursday"
+	Tuesday   AccessWindowDays = "tuesday"
+	Wednesday AccessWindowDays = "wednesday"
+)
+
+// Defines values for AdminRoleRole.
+const (
+	AUDITOR         AdminRoleRole = "AUDITOR"
+	IDENTITYMANAGER AdminRoleRole = "IDENTITY_MANAGER"
+	PROVIDERADMIN   AdminRoleRole = "PROVIDER_ADMIN"
+	RULEMANAGER     AdminRoleRole = "RULE_MANAGER"
+)
+
 // Defines values for ApprovalMethod.
 const (
 	AUTOMATIC ApprovalMethod = "AUTOMATIC"
@@ -49,6 +113,19 @@ const (
 	IdpStatusARCHIVED IdpStatus = "ARCHIVED"
 )
 
+// Defines values for NotificationPreferencesChannel.
+const (
+	EMAIL NotificationPreferencesChannel = "EMAIL"
+	SLACK NotificationPreferencesChannel = "SLACK"
+	TEAMS NotificationPreferencesChannel = "TEAMS"
+)
+
+// Defines values for NotificationPreferencesDelivery.
+const (
+	DIGEST    NotificationPreferencesDelivery = "DIGEST"
+	IMMEDIATE NotificationPreferencesDelivery = "IMMEDIATE"
+)
+
 // Defines values for ProviderSetupStatus.
 const (
 	COMPLETE                       ProviderSetupStatus = "COMPLETE"
@@ -101,10 +178,42 @@ const (
 
 // Defines values for ReviewDecision.
 const (
-	APPROVED ReviewDecision = "APPROVED"
-	DECLINED ReviewDecision = "DECLINED"
+	ReviewDecisionAPPROVED ReviewDecision = "APPROVED"
+	ReviewDecisionDECLINED ReviewDecision = "DECLINED"
 )
 
+// An API key which provides programmatic access to the Approvals API as a service account.
+type APIKey struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// The ID of the user who created the key.
+	CreatedBy   string  `json:"createdBy"`
+	Description *string `json:"description,omitempty"`
+	Id          string  `json:"id"`
+	Name        string  `json:"name"`
+
+	// The first characters of the key, used to identify it.
+	Prefix string `json:"prefix"`
+
+	// The permissions of an API key.
+	//
+	// READ_ONLY keys can make GET requests to any endpoint, including administrative endpoints.
+	// REQUEST_ON_BEHALF keys can create requests on behalf of users.
+	// ADMIN keys have the same permissions as a Granted administrator.
+	Scope  APIKeyScope  `json:"scope"`
+	Status APIKeyStatus `json:"status"`
+}
+
+// The permissions of an API key.
+//
+// READ_ONLY keys can make GET requests to any endpoint, including administrative endpoints.
+// REQUEST_ON_BEHALF keys can create requests on behalf of users.
+// ADMIN keys have the same permissions as a Granted administrator.
+type APIKeyScope string
+
+// APIKeyStatus defines model for APIKeyStatus.
+type APIKeyStatus string
+
 // Access Rule contains information for an end user to make a request for access.
 type AccessRule struct {
 	Description string `json:"description"`
@@ -112,6 +221,9 @@ type AccessRule struct {
 	IsCurrent   bool   `json:"isCurrent"`
 	Name        string `json:"name"`
 
+	// The fields which requesters fill in when making a request.
+	RequestFields *[]AccessRuleRequestField `json:"requestFields,omitempty"`
+
 	// A target for an access rule
 	Target AccessRuleTarget `json:"target"`
 
@@ -130,11 +242,28 @@ type AccessRuleDetail struct {
 	Description string         `json:"description"`
 
 	// The group IDs that the access rule applies to.
-	Groups    []string           `json:"groups"`
-	ID        string             `json:"id"`
-	IsCurrent bool               `json:"isCurrent"`
-	Metadata  AccessRuleMetadata `json:"metadata"`
-	Name      string             `json:"name"`
+	Groups    []string `json:"groups"`
+	ID        string   `json:"id"`
+	IsCurrent bool     `json:"isCurrent"`
+
+	// Limits on the requests which each user can make for an Access Rule. Limits which aren't set aren't enforced.
+	Limits   *AccessRuleLimits  `json:"limits,omitempty"`
+	Metadata AccessRuleMetadata `json:"metadata"`
+	Name     string             `json:"name"`
+
+	// Notification settings for an Access Rule.
+	Notifications *AccessRuleNotifications `json:"notifications,omitempty"`
+
+	// The users and groups who own an Access Rule. Owners can update and archive the rule and view requests made for it, without being administrators.
+	Owners *AccessRuleOwners `json:"owners,omitempty"`
+
+	// Conditions which decide whether requests for an Access Rule are approved automatically, require approval or are denied.
+	// The conditions are evaluated in order when a request is made, and the effect of the first condition which is true is applied.
+	// If no conditions are true, the request is approved automatically if the rule has no approvers, otherwise it requires approval.
+	Policy *AccessRulePolicy `json:"policy,omitempty"`
+
+	// The fields which requesters fill in when making a request.
+	RequestFields *[]AccessRuleRequestField `json:"requestFields,omitempty"`
 
 	// The status of an Access Rule.
 	Status AccessRuleStatus `json:"status"`
@@ -142,6 +271,10 @@ type AccessRuleDetail struct {
 	// A target for an access rule
 	Target AccessRuleTarget `json:"target"`
 
+	// Requires requests for an Access Rule to reference a ticket in a ticket system, such as a Jira issue or a ServiceNow change request.
+	// The ticket is looked up when a request is made, and the request is rejected if the ticket doesn't exist or isn't in an allowed state.
+	Ticket *AccessRuleTicket `json:"ticket,omitempty"`
+
 	// Time configuration for an Access Rule.
 	TimeConstraints TimeConstraints `json:"timeConstraints"`
 
@@ -150,15 +283,114 @@ type AccessRuleDetail struct {
 	Version string `json:"version"`
 }
 
+// The fields which changed between two versions of an Access Rule.
+type AccessRuleDiff struct {
+	Changes []AccessRuleFieldChange `json:"changes"`
+
+	// The earlier version.
+	From string `json:"from"`
+
+	// The later version.
+	To string `json:"to"`
+}
+
+// A change to a field of an Access Rule.
+// Changes to single values set from and to, and changes to lists set added and removed.
+type AccessRuleFieldChange struct {
+	Added *[]string `json:"added,omitempty"`
+
+	// The path of the field, such as approval.groups or target.with.accountId.
+	Field   string    `json:"field"`
+	From    *string   `json:"from,omitempty"`
+	Removed *[]string `json:"removed,omitempty"`
+	To      *string   `json:"to,omitempty"`
+}
+
+// Limits on the requests which each user can make for an Access Rule. Limits which aren't set aren't enforced.
+type AccessRuleLimits struct {
+	// The minimum time in seconds between a user's grant for the rule ending and their next grant starting.
+	CooldownSeconds *int `json:"cooldownSeconds,omitempty"`
+
+	// The maximum number of grants a user can have for the rule which haven't ended. Pending requests count towards the limit.
+	MaxActiveGrants *int `json:"maxActiveGrants,omitempty"`
+
+	// The maximum number of requests a user can make for the rule in 24 hours.
+	MaxRequestsPerDay *int `json:"maxRequestsPerDay,omitempty"`
+}
+
 // AccessRuleMetadata defines model for AccessRuleMetadata.
 type AccessRuleMetadata struct {
 	CreatedAt     time.Time `json:"createdAt"`
 	CreatedBy     string    `json:"createdBy"`
 	UpdateMessage *string   `json:"updateMessage,omitempty"`
-	UpdatedAt     time.Time `json:"updatedAt"`
-	UpdatedBy     string    `json:"updatedBy"`
+
+	// Additional information about the update, such as the versions involved in a rollback.
+	UpdateMetadata *map[string]interface{} `json:"updateMetadata,omitempty"`
+	UpdatedAt      time.Time               `json:"updatedAt"`
+	UpdatedBy      string                  `json:"updatedBy"`
+}
+
+// Notification settings for an Access Rule.
+type AccessRuleNotifications struct {
+	// Slack channels which receive a shared feed of the requests made for this Access Rule.
+	SlackChannels []string `json:"slackChannels"`
+}
+
+// The users and groups who own an Access Rule. Owners can update and archive the rule and view requests made for it, without being administrators.
+type AccessRuleOwners struct {
+	// The group IDs whose members own the rule.
+	Groups []string `json:"groups"`
+
+	// The user IDs of the owners of the rule.
+	Users []string `json:"users"`
+}
+
+// Conditions which decide whether requests for an Access Rule are approved automatically, require approval or are denied.
+// The conditions are evaluated in order when a request is made, and the effect of the first condition which is true is applied.
+// If no conditions are true, the request is approved automatically if the rule has no approvers, otherwise it requires approval.
+type AccessRulePolicy struct {
+	Conditions []AccessRulePolicyCondition `json:"conditions"`
+
+	// The language of the condition expressions.
+	Language AccessRulePolicyLanguage `json:"language"`
+}
+
+// The language of the condition expressions.
+type AccessRulePolicyLanguage string
+
+// A CEL expression which returns a bool. The expression can use the variables `requester` (id, email and groups),
+// `request` (reason, with and timing, which has durationSeconds and startTime for scheduled requests) and `now`.
+type AccessRulePolicyCondition struct {
+	Description *string                         `json:"description,omitempty"`
+	Effect      AccessRulePolicyConditionEffect `json:"effect"`
+	Expression  string                          `json:"expression"`
 }
 
+// AccessRulePolicyConditionEffect defines model for AccessRulePolicyCondition.Effect.
+type AccessRulePolicyConditionEffect string
+
+// A field which requesters fill in when making a request, such as a ticket ID or a change category.
+type AccessRuleRequestField struct {
+	Description *string `json:"description,omitempty"`
+
+	// The ID of the field, which the values are keyed by on requests.
+	Id    string `json:"id"`
+	Label string `json:"label"`
+
+	// The options for a SELECT field.
+	Options *[]string `json:"options,omitempty"`
+
+	// A regular expression which the whole value of a TEXT field must match.
+	Pattern  *string `json:"pattern,omitempty"`
+	Required bool    `json:"required"`
+
+	// TEXT fields accept any text which matches the pattern, URL fields accept http and https links, and SELECT fields accept one of the options.
+	Type AccessRuleRequestFieldType `json:"type"`
+}
+
+// TEXT fields accept any text which matches the pattern, URL fields accept http and https links, and SELECT fields accept one of the options.
+type AccessRuleRequestFieldType string
+
 // The status of an Access Rule.
 type AccessRuleStatus string
 
@@ -198,6 +430,25 @@ type AccessRuleTargetDetail_WithSelectable struct {
 	AdditionalProperties map[string][]Selectable `json:"-"`
 }
 
+// Requires requests for an Access Rule to reference a ticket in a ticket system, such as a Jira issue or a ServiceNow change request.
+// The ticket is looked up when a request is made, and the request is rejected if the ticket doesn't exist or isn't in an allowed state.
+type AccessRuleTicket struct {
+	// The states which the ticket must be in, ignoring case. If it is empty, tickets in any state are allowed.
+	AllowedStates []string `json:"allowedStates"`
+
+	// Requests are approved automatically if the ticket is in one of these states, such as an approved change, and the requester is assigned to the ticket or reported or requested it. The policy of the rule takes precedence.
+	AutoApproveStates *[]string `json:"autoApproveStates,omitempty"`
+
+	// The ID of the required TEXT request field which requesters enter the ticket ID in. Updating a rule with an empty fieldId removes the ticket requirement.
+	FieldId string `json:"fieldId"`
+
+	// The ticket system which the ticket is in. The ticket system must be configured for the deployment.
+	System AccessRuleTicketSystem `json:"system"`
+}
+
+// The ticket system which the ticket is in. The ticket system must be configured for the deployment.
+type AccessRuleTicketSystem string
+
 // Access Rule contains information for an end user to make a request for access. `AccessRuleWithSelectables` contains a more detailed `target` field with the specific options that can be selected.
 type AccessRuleWithSelectables struct {
 	Description string `json:"description"`
@@ -205,6 +456,9 @@ type AccessRuleWithSelectables struct {
 	IsCurrent   bool   `json:"isCurrent"`
 	Name        string `json:"name"`
 
+	// The fields which requesters fill in when making a request.
+	RequestFields *[]AccessRuleRequestField `json:"requestFields,omitempty"`
+
 	// A target for an access rule
 	Target AccessRuleTargetDetail `json:"target"`
 
@@ -216,15 +470,89 @@ type AccessRuleWithSelectables struct {
 	Version string `json:"version"`
 }
 
+// AccessSimulation defines model for AccessSimulation.
+type AccessSimulation struct {
+	Results []AccessSimulationResult `json:"results"`
+}
+
+// Filters for an access simulation. Omitted filters match every rule.
+type AccessSimulationRequest struct {
+	// Only include rules for this provider.
+	ProviderId *string `json:"providerId,omitempty"`
+
+	// Only include rules which this user can request.
+	UserId *string `json:"userId,omitempty"`
+
+	// Only include rules which grant these argument values, such as {"accountId": "123456789012"}. Values may be the option value or its label, such as the name of a permission set.
+	With *AccessSimulationRequest_With `json:"with,omitempty"`
+}
+
+// Only include rules which grant these argument values, such as {"accountId": "123456789012"}. Values may be the option value or its label, such as the name of a permission set.
+type AccessSimulationRequest_With struct {
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+// Who can request and approve access through an Access Rule.
+type AccessSimulationResult struct {
+	// Access Rule contains information for an end user to make a request for access.
+	AccessRule AccessRule `json:"accessRule"`
+
+	// AUTO_APPROVED if requests are approved without a review, REVIEW_REQUIRED if they must be approved by an approver,
+	// or CONDITIONAL if the rule's policy or ticket requirement decides when each request is made.
+	Approval AccessSimulationResultApproval `json:"approval"`
+
+	// The IDs of the users who can approve requests. Users can't approve their own requests.
+	Approvers []string `json:"approvers"`
+
+	// The IDs of the active users who can request the rule.
+	RequestableUsers []string `json:"requestableUsers"`
+}
+
+// AUTO_APPROVED if requests are approved without a review, REVIEW_REQUIRED if they must be approved by an approver,
+// or CONDITIONAL if the rule's policy or ticket requirement decides when each request is made.
+type AccessSimulationResultApproval string
+
 // AccessToken defines model for AccessToken.
 type AccessToken = string
 
+// Restricts access to the given days and times. Access must start and run entirely within the window, for example business hours on weekdays.
+type AccessWindow struct {
+	// The days of the week which access is allowed on. If empty, access is allowed on every day.
+	Days *[]AccessWindowDays `json:"days,omitempty"`
+
+	// The time of day in HH:MM format which access must end by. If empty, access may run until midnight.
+	EndTime *string `json:"endTime,omitempty"`
+
+	// The time of day in HH:MM format which access may start from. If empty, access may start from midnight.
+	StartTime *string `json:"startTime,omitempty"`
+
+	// The IANA timezone which the days and times are in.
+	Timezone string `json:"timezone"`
+}
+
+// AccessWindowDays defines model for AccessWindow.Days.
+type AccessWindowDays string
+
+// An admin role assigned to a group which the user belongs to.
+type AdminRole struct {
+	Group string `json:"group"`
+
+	// The providers which a RULE_MANAGER can manage Access Rules for. If it is empty, the role applies to every provider.
+	Providers *[]string     `json:"providers,omitempty"`
+	Role      AdminRoleRole `json:"role"`
+}
+
+// AdminRoleRole defines model for AdminRole.Role.
+type AdminRoleRole string
+
 // Describes whether a request has been approved automatically or from a review
 type ApprovalMethod string
 
 // Approver config for access rules
 type ApproverConfig struct {
-	Groups []string `json:"groups"`
+	// The group IDs which pending requests are escalated to if they have not been reviewed after the escalation interval.
+	EscalationGroups *[]string `json:"escalationGroups,omitempty"`
+	Groups           []string  `json:"groups"`
 
 	// The user IDs of the approvers for the request.
 	Users []string `json:"users"`
@@ -248,6 +576,22 @@ type CreateRequestWith struct {
 	AdditionalProperties map[string]string `json:"-"`
 }
 
+// An event which the event handler failed to process.
+type FailedEvent struct {
+	// The number of times the event has failed to be processed.
+	Attempts   int    `json:"attempts"`
+	DetailType string `json:"detailType"`
+
+	// The error returned by the most recent attempt to process the event.
+	Error         string    `json:"error"`
+	FirstFailedAt time.Time `json:"firstFailedAt"`
+
+	// The EventBridge event ID.
+	Id           string    `json:"id"`
+	LastFailedAt time.Time `json:"lastFailedAt"`
+	Source       string    `json:"source"`
+}
+
 // A temporary assignment of a user to a principal.
 type Grant struct {
 	// The end time of the grant.
@@ -277,9 +621,84 @@ type Group struct {
 	Name        string `json:"name"`
 }
 
+// The changes an identity sync makes to users and groups.
+type IdentitySyncDiff struct {
+	// The number of active users before the sync.
+	ActiveUsers int `json:"activeUsers"`
+
+	// The IDs of groups which are created or reactivated.
+	GroupsAdded []string `json:"groupsAdded"`
+
+	// The IDs of groups which are archived.
+	GroupsRemoved []string `json:"groupsRemoved"`
+
+	// The emails of users who are created or reactivated.
+	UsersAdded []string `json:"usersAdded"`
+
+	// The emails of users who are archived.
+	UsersArchived []string `json:"usersArchived"`
+
+	// Users whose group memberships change.
+	UsersChanged []IdentitySyncUserChange `json:"usersChanged"`
+}
+
+// The identity provider groups which are mapped onto a Granted group by an identity sync.
+type IdentitySyncMappedGroup struct {
+	Id string `json:"id"`
+
+	// The names of the identity provider groups matched by the group mapping.
+	IdentityProviderGroups []string `json:"identityProviderGroups"`
+}
+
+// A user who was archived by an identity sync, and the access which was removed from them.
+type IdentitySyncOffboarding struct {
+	// The IDs of the user's pending requests which were cancelled.
+	CancelledRequests []string `json:"cancelledRequests"`
+	Email             string   `json:"email"`
+
+	// The IDs of the pending requests which the user was removed as a reviewer from.
+	RemovedReviews []string `json:"removedReviews"`
+
+	// The IDs of the requests whose active grants were revoked.
+	RevokedGrants []string `json:"revokedGrants"`
+	UserId        string   `json:"userId"`
+}
+
+// A user who should have been archived by an identity sync, but couldn't be offboarded. They are kept active until a later sync offboards them.
+type IdentitySyncOffboardingFailure struct {
+	Email  string `json:"email"`
+	Error  string `json:"error"`
+	UserId string `json:"userId"`
+}
+
+// The groups a user is added to or removed from by an identity sync.
+type IdentitySyncUserChange struct {
+	Email         string   `json:"email"`
+	GroupsAdded   []string `json:"groupsAdded"`
+	GroupsRemoved []string `json:"groupsRemoved"`
+}
+
 // IdpStatus defines model for IdpStatus.
 type IdpStatus string
 
+// A user's notification preferences. Users who haven't set any preferences are notified immediately via Slack about all events.
+type NotificationPreferences struct {
+	// The channel used to send notifications to the user. Notifications are sent via Slack until the channel has a notifier.
+	Channel NotificationPreferencesChannel `json:"channel"`
+
+	// Whether notifications are sent immediately, or batched into a daily digest.
+	Delivery NotificationPreferencesDelivery `json:"delivery"`
+
+	// Event types which the user doesn't want to be notified about, such as request.approved or grant.expiring.
+	MutedEvents []string `json:"mutedEvents"`
+}
+
+// The channel used to send notifications to the user. Notifications are sent via Slack until the channel has a notifier.
+type NotificationPreferencesChannel string
+
+// Whether notifications are sent immediately, or batched into a daily digest.
+type NotificationPreferencesDelivery string
+
 // Provider
 type Provider struct {
 	Id   string `json:"id"`
@@ -390,6 +809,9 @@ type Request struct {
 	// Describes whether a request has been approved automatically or from a review
 	ApprovalMethod *ApprovalMethod `json:"approvalMethod,omitempty"`
 
+	// The values of the request fields of the Access Rule, keyed by the field ID.
+	Fields *map[string]string `json:"fields,omitempty"`
+
 	// A temporary assignment of a user to a principal.
 	Grant        *Grant               `json:"grant,omitempty"`
 	ID           string               `json:"id"`
@@ -426,6 +848,9 @@ type RequestDetail struct {
 	// true if the requesting user is a reviewer of this request.
 	CanReview bool `json:"canReview"`
 
+	// The values of the request fields of the Access Rule, keyed by the field ID.
+	Fields *map[string]string `json:"fields,omitempty"`
+
 	// A temporary assignment of a user to a principal.
 	Grant        *Grant                      `json:"grant,omitempty"`
 	ID           string                      `json:"id"`
@@ -501,8 +926,17 @@ type Selectable struct {
 
 // Time configuration for an Access Rule.
 type TimeConstraints struct {
+	// Restricts access to the given days and times. Access must start and run entirely within the window, for example business hours on weekdays.
+	AccessWindow *AccessWindow `json:"accessWindow,omitempty"`
+
+	// The maximum time in seconds in advance that access can be scheduled.
+	MaxAdvanceSeconds *int `json:"maxAdvanceSeconds,omitempty"`
+
 	// The maximum duration in seconds the access is allowed for.
 	MaxDurationSeconds int `json:"maxDurationSeconds"`
+
+	// The minimum time in seconds between a scheduled request being made and the access starting.
+	MinNoticeSeconds *int `json:"minNoticeSeconds,omitempty"`
 }
 
 // User defines model for User.
@@ -534,9 +968,15 @@ type ArgOptionsResponse struct {
 
 // AuthUserResponse defines model for AuthUserResponse.
 type AuthUserResponse struct {
+	// The admin roles of the user, which grant a subset of the permissions of an administrator.
+	AdminRoles *[]AdminRole `json:"adminRoles,omitempty"`
+
 	// Whether the user is an administrator of Granted.
 	IsAdmin bool `json:"isAdmin"`
-	User    User `json:"user"`
+
+	// A user's notification preferences. Users who haven't set any preferences are notified immediately via Slack about all events.
+	NotificationPreferences NotificationPreferences `json:"notificationPreferences"`
+	User                    User                    `json:"user"`
 }
 
 // CompleteProviderSetupResponse defines model for CompleteProviderSetupResponse.
@@ -545,6 +985,15 @@ type CompleteProviderSetupResponse struct {
 	DeploymentConfigUpdateRequired bool `json:"deploymentConfigUpdateRequired"`
 }
 
+// CreateAPIKeyResponse defines model for CreateAPIKeyResponse.
+type CreateAPIKeyResponse struct {
+	// An API key which provides programmatic access to the Approvals API as a service account.
+	ApiKey APIKey `json:"apiKey"`
+
+	// The secret API key. It isn't stored, so it can't be retrieved again.
+	Key string `json:"key"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error string `json:"error"`
@@ -556,6 +1005,31 @@ type IdentityConfigurationResponse struct {
 	IdentityProvider     string `json:"identityProvider"`
 }
 
+// IdentitySyncResponse defines model for IdentitySyncResponse.
+type IdentitySyncResponse struct {
+	// True if the sync archives more than the archive threshold of active users. Only returned for dry runs and forced syncs, as otherwise the sync is aborted.
+	ArchiveThresholdExceeded bool `json:"archiveThresholdExceeded"`
+
+	// The changes an identity sync makes to users and groups.
+	Diff   IdentitySyncDiff `json:"diff"`
+	DryRun bool             `json:"dryRun"`
+
+	// The identity provider groups matched by each group mapping. Only returned if group mappings are configured.
+	MappedGroups *[]IdentitySyncMappedGroup `json:"mappedGroups,omitempty"`
+
+	// The users who were archived by the sync. Empty for dry runs.
+	Offboarded []IdentitySyncOffboarding `json:"offboarded"`
+
+	// The users who couldn't be offboarded, and were kept active. Only returned if offboarding any users failed.
+	OffboardingFailures *[]IdentitySyncOffboardingFailure `json:"offboardingFailures,omitempty"`
+}
+
+// ListAPIKeysResponse defines model for ListAPIKeysResponse.
+type ListAPIKeysResponse struct {
+	ApiKeys []APIKey `json:"apiKeys"`
+	Next    *string  `json:"next"`
+}
+
 // ListAccessRuleApproversResponse defines model for ListAccessRuleApproversResponse.
 type ListAccessRuleApproversResponse struct {
 	Next  *string  `json:"next"`
@@ -574,6 +1048,12 @@ type ListAccessRulesResponse struct {
 	Next        *string      `json:"next"`
 }
 
+// ListFailedEventsResponse defines model for ListFailedEventsResponse.
+type ListFailedEventsResponse struct {
+	Events []FailedEvent `json:"events"`
+	Next   *string       `json:"next"`
+}
+
 // ListGroupsResponse defines model for ListGroupsResponse.
 type ListGroupsResponse struct {
 	Groups []Group `json:"groups"`
@@ -612,6 +1092,19 @@ type ReviewResponse struct {
 	Request *Request `json:"request,omitempty"`
 }
 
+// CreateAPIKeyRequest defines model for CreateAPIKeyRequest.
+type CreateAPIKeyRequest struct {
+	Description *string `json:"description,omitempty"`
+	Name        string  `json:"name"`
+
+	// The permissions of an API key.
+	//
+	// READ_ONLY keys can make GET requests to any endpoint, including administrative endpoints.
+	// REQUEST_ON_BEHALF keys can create requests on behalf of users.
+	// ADMIN keys have the same permissions as a Granted administrator.
+	Scope APIKeyScope `json:"scope"`
+}
+
 // CreateAccessRuleRequest defines model for CreateAccessRuleRequest.
 type CreateAccessRuleRequest struct {
 	// Approver config for access rules
@@ -620,11 +1113,30 @@ type CreateAccessRuleRequest struct {
 
 	// The group IDs that the access rule applies to.
 	Groups []string `json:"groups"`
-	Name   string   `json:"name"`
+
+	// Limits on the requests which each user can make for an Access Rule. Limits which aren't set aren't enforced.
+	Limits *AccessRuleLimits `json:"limits,omitempty"`
+	Name   string            `json:"name"`
+
+	// Notification settings for an Access Rule.
+	Notifications *AccessRuleNotifications `json:"notifications,omitempty"`
+
+	// The users and groups who own an Access Rule. Owners can update and archive the rule and view requests made for it, without being administrators.
+	Owners *AccessRuleOwners `json:"owners,omitempty"`
+
+	// Conditions which decide whether requests for an Access Rule are approved automatically, require approval or are denied.
+	// The conditions are evaluated in order when a request is made, and the effect of the first condition which is true is applied.
+	// If no conditions are true, the request is approved automatically if the rule has no approvers, otherwise it requires approval.
+	Policy        *AccessRulePolicy         `json:"policy,omitempty"`
+	RequestFields *[]AccessRuleRequestField `json:"requestFields,omitempty"`
 
 	// A target for an access rule
 	Target CreateAccessRuleTarget `json:"target"`
 
+	// Requires requests for an Access Rule to reference a ticket in a ticket system, such as a Jira issue or a ServiceNow change request.
+	// The ticket is looked up when a request is made, and the request is rejected if the ticket doesn't exist or isn't in an allowed state.
+	Ticket *AccessRuleTicket `json:"ticket,omitempty"`
+
 	// Time configuration for an Access Rule.
 	TimeConstraints TimeConstraints `json:"timeConstraints"`
 }
@@ -643,10 +1155,13 @@ type CreateProviderSetupRequest struct {
 
 // CreateRequestRequest defines model for CreateRequestRequest.
 type CreateRequestRequest struct {
-	AccessRuleId string             `json:"accessRuleId"`
-	Reason       *string            `json:"reason,omitempty"`
-	Timing       RequestTiming      `json:"timing"`
-	With         *CreateRequestWith `json:"with,omitempty"`
+	AccessRuleId string `json:"accessRuleId"`
+
+	// The values of the request fields of the Access Rule, keyed by the field ID.
+	Fields *map[string]string `json:"fields,omitempty"`
+	Reason *string            `json:"reason,omitempty"`
+	Timing RequestTiming      `json:"timing"`
+	With   *CreateRequestWith `json:"with,omitempty"`
 }
 
 // CreateUserRequest defines model for CreateUserRequest.
@@ -681,7 +1196,69 @@ type UpdateAccessRuleRequest struct {
 	Approval    ApproverConfig `json:"approval"`
 	Description string         `json:"description"`
 	Groups      []string       `json:"groups"`
-	Name        string         `json:"name"`
+
+	// Limits on the requests which each user can make for an Access Rule. Limits which aren't set aren't enforced.
+	Limits *AccessRuleLimits `json:"limits,omitempty"`
+	Name   string            `json:"name"`
+
+	// Notification settings for an Access Rule.
+	Notifications *AccessRuleNotifications `json:"notifications,omitempty"`
+
+	// The users and groups who own an Access Rule. Owners can update and archive the rule and view requests made for it, without being administrators.
+	Owners *AccessRuleOwners `json:"owners,omitempty"`
+
+	// Conditions which decide whether requests for an Access Rule are approved automatically, require approval or are denied.
+	// The conditions are evaluated in order when a request is made, and the effect of the first condition which is true is applied.
+	// If no conditions are true, the request is approved automatically if the rule has no approvers, otherwise it requires approval.
+	Policy        *AccessRulePolicy         `json:"policy,omitempty"`
+	RequestFields *[]AccessRuleRequestField `json:"requestFields,omitempty"`
+
+	// A target for an access rule
+	Target *CreateAccessRuleTarget `json:"target,omitempty"`
+
+	// Requires requests for an Access Rule to reference a ticket in a ticket system, such as a Jira issue or a ServiceNow change request.
+	// The ticket is looked up when a request is made, and the request is rejected if the ticket doesn't exist or isn't in an allowed state.
+	Ticket *AccessRuleTicket `json:"ticket,omitempty"`
+
+	// Time configuration for an Access Rule.
+	TimeConstraints TimeConstraints `json:"timeConstraints"`
+	UpdateMessage   *string         `json:"updateMessage,omitempty"`
+}
+
+// UpsertAccessRuleRequest defines model for UpsertAccessRuleRequest.
+type UpsertAccessRuleRequest struct {
+	// Approver config for access rules
+	Approval ApproverConfig `json:"approval"`
+
+	// The version which the caller expects to be current. The rule isn't changed if it has been modified since this version.
+	CurrentVersion *string `json:"currentVersion,omitempty"`
+	Description    string  `json:"description"`
+
+	// The group IDs that the access rule applies to.
+	Groups []string `json:"groups"`
+
+	// Limits on the requests which each user can make for an Access Rule. Limits which aren't set aren't enforced.
+	Limits *AccessRuleLimits `json:"limits,omitempty"`
+	Name   string            `json:"name"`
+
+	// Notification settings for an Access Rule.
+	Notifications *AccessRuleNotifications `json:"notifications,omitempty"`
+
+	// The users and groups who own an Access Rule. Owners can update and archive the rule and view requests made for it, without being administrators.
+	Owners *AccessRuleOwners `json:"owners,omitempty"`
+
+	// Conditions which decide whether requests for an Access Rule are approved automatically, require approval or are denied.
+	// The conditions are evaluated in order when a request is made, and the effect of the first condition which is true is applied.
+	// If no conditions are true, the request is approved automatically if the rule has no approvers, otherwise it requires approval.
+	Policy        *AccessRulePolicy         `json:"policy,omitempty"`
+	RequestFields *[]AccessRuleRequestField `json:"requestFields,omitempty"`
+
+	// A target for an access rule
+	Target CreateAccessRuleTarget `json:"target"`
+
+	// Requires requests for an Access Rule to reference a ticket in a ticket system, such as a Jira issue or a ServiceNow change request.
+	// The ticket is looked up when a request is made, and the request is rejected if the ticket doesn't exist or isn't in an allowed state.
+	Ticket *AccessRuleTicket `json:"ticket,omitempty"`
 
 	// Time configuration for an Access Rule.
 	TimeConstraints TimeConstraints `json:"timeConstraints"`
@@ -700,12 +1277,45 @@ type AdminListAccessRulesParams struct {
 // AdminListAccessRulesParamsStatus defines parameters for AdminListAccessRules.
 type AdminListAccessRulesParamsStatus string
 
+// AdminDiffAccessRuleVersionsParams defines parameters for AdminDiffAccessRuleVersions.
+type AdminDiffAccessRuleVersionsParams struct {
+	// The earlier version to compare.
+	From string `form:"from" json:"from"`
+
+	// The later version to compare.
+	To string `form:"to" json:"to"`
+}
+
+// AdminSimulateAccessJSONBody defines parameters for AdminSimulateAccess.
+type AdminSimulateAccessJSONBody = AccessSimulationRequest
+
+// AdminListApiKeysParams defines parameters for AdminListApiKeys.
+type AdminListApiKeysParams struct {
+	// encrypted token containing pagination info
+	NextToken *string `form:"nextToken,omitempty" json:"nextToken,omitempty"`
+}
+
+// AdminListFailedEventsParams defines parameters for AdminListFailedEvents.
+type AdminListFailedEventsParams struct {
+	// encrypted token containing pagination info
+	NextToken *string `form:"nextToken,omitempty" json:"nextToken,omitempty"`
+}
+
 // GetGroupsParams defines parameters for GetGroups.
 type GetGroupsParams struct {
 	// encrypted token containing pagination info
 	NextToken *string `form:"nextToken,omitempty" json:"nextToken,omitempty"`
 }
 
+// IdentitySyncParams defines parameters for IdentitySync.
+type IdentitySyncParams struct {
+	// return the changes which the sync would make without making them
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+
+	// run the sync even if it would archive more than the archive threshold of active users
+	Force *bool `form:"force,omitempty" json:"force,omitempty"`
+}
+
 // ListProviderArgOptionsParams defines parameters for ListProviderArgOptions.
 type ListProviderArgOptionsParams struct {
 	// invalidate the cache and refresh the provider's options.
@@ -719,6 +1329,12 @@ type AdminListRequestsParams struct {
 
 	// encrypted token containing pagination info
 	NextToken *string `form:"nextToken,omitempty" json:"nextToken,omitempty"`
+
+	// only return requests where this request field matches fieldValue. If it is omitted, every field is searched.
+	FieldId *string `form:"fieldId,omitempty" json:"fieldId,omitempty"`
+
+	// only return requests with a request field containing this value, ignoring case.
+	FieldValue *string `form:"fieldValue,omitempty" json:"fieldValue,omitempty"`
 }
 
 // AdminListRequestsParamsStatus defines parameters for AdminListRequests.
@@ -762,12 +1378,24 @@ type UserListRequestsUpcomingParams struct {
 	NextToken *string `form:"nextToken,omitempty" json:"nextToken,omitempty"`
 }
 
+// UpdateMyNotificationPreferencesJSONBody defines parameters for UpdateMyNotificationPreferences.
+type UpdateMyNotificationPreferencesJSONBody = NotificationPreferences
+
+// AdminUpsertAccessRuleJSONRequestBody defines body for AdminUpsertAccessRule for application/json ContentType.
+type AdminUpsertAccessRuleJSONRequestBody UpsertAccessRuleRequest
+
 // AdminCreateAccessRuleJSONRequestBody defines body for AdminCreateAccessRule for application/json ContentType.
 type AdminCreateAccessRuleJSONRequestBody CreateAccessRuleRequest
 
 // AdminUpdateAccessRuleJSONRequestBody defines body for AdminUpdateAccessRule for application/json ContentType.
 type AdminUpdateAccessRuleJSONRequestBody UpdateAccessRuleRequest
 
+// AdminSimulateAccessJSONRequestBody defines body for AdminSimulateAccess for application/json ContentType.
+type AdminSimulateAccessJSONRequestBody = AdminSimulateAccessJSONBody
+
+// AdminCreateApiKeyJSONRequestBody defines body for AdminCreateApiKey for application/json ContentType.
+type AdminCreateApiKeyJSONRequestBody CreateAPIKeyRequest
+
 // CreateGroupJSONRequestBody defines body for CreateGroup for application/json ContentType.
 type CreateGroupJSONRequestBody CreateGroupRequest
 
@@ -789,6 +1417,9 @@ type UserCreateRequestJSONRequestBody CreateRequestRequest
 // ReviewRequestJSONRequestBody defines body for ReviewRequest for application/json ContentType.
 type ReviewRequestJSONRequestBody ReviewRequest
 
+// UpdateMyNotificationPreferencesJSONRequestBody defines body for UpdateMyNotificationPreferences for application/json ContentType.
+type UpdateMyNotificationPreferencesJSONRequestBody = UpdateMyNotificationPreferencesJSONBody
+
 // Getter for additional properties for AccessRuleTarget_With. Returns the specified
 // element and whether it was found
 func (a AccessRuleTarget_With) Get(fieldName string) (value string, found bool) {
@@ -1001,6 +1632,59 @@ func (a AccessRuleTargetDetail_WithSelectable) MarshalJSON() ([]byte, error) {
 	return json.Marshal(object)
 }
 
+// Getter for additional properties for AccessSimulationRequest_With. Returns the specified
+// element and whether it was found
+func (a AccessSimulationRequest_With) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for AccessSimulationRequest_With
+func (a *AccessSimulationRequest_With) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for AccessSimulationRequest_With to handle AdditionalProperties
+func (a *AccessSimulationRequest_With) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for AccessSimulationRequest_With to handle AdditionalProperties
+func (a AccessSimulationRequest_With) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
 // Getter for additional properties for CreateAccessRuleTarget_With. Returns the specified
 // element and whether it was found
 func (a CreateAccessRuleTarget_With) Get(fieldName string) (value []string, found bool) {
@@ -1224,6 +1908,9 @@ type ServerInterface interface {
 	// List Access Rule approvers
 	// (GET /api/v1/access-rules/{ruleId}/approvers)
 	UserGetAccessRuleApprovers(w http.ResponseWriter, r *http.Request, ruleId string)
+	// Upsert Access Rule
+	// (PUT /api/v1/admin/access-rule-slugs/{slug})
+	AdminUpsertAccessRule(w http.ResponseWriter, r *http.Request, slug string)
 	// List Access Rules
 	// (GET /api/v1/admin/access-rules)
 	AdminListAccessRules(w http.ResponseWriter, r *http.Request, params AdminListAccessRulesParams)
@@ -1239,12 +1926,36 @@ type ServerInterface interface {
 	// Archive Access Rule
 	// (POST /api/v1/admin/access-rules/{ruleId}/archive)
 	AdminArchiveAccessRule(w http.ResponseWriter, r *http.Request, ruleId string)
+	// Diff Access Rule Versions
+	// (GET /api/v1/admin/access-rules/{ruleId}/diff)
+	AdminDiffAccessRuleVersions(w http.ResponseWriter, r *http.Request, ruleId string, params AdminDiffAccessRuleVersionsParams)
 	// Get Access Rule version history
 	// (GET /api/v1/admin/access-rules/{ruleId}/versions)
 	AdminGetAccessRuleVersions(w http.ResponseWriter, r *http.Request, ruleId string)
 	// Get Access Rule Version
 	// (GET /api/v1/admin/access-rules/{ruleId}/versions/{version})
 	AdminGetAccessRuleVersion(w http.ResponseWriter, r *http.Request, ruleId string, version string)
+	// Rollback Access Rule
+	// (POST /api/v1/admin/access-rules/{ruleId}/versions/{version}/rollback)
+	AdminRollbackAccessRule(w http.ResponseWriter, r *http.Request, ruleId string, version string)
+	// Simulate Access
+	// (POST /api/v1/admin/access-simulation)
+	AdminSimulateAccess(w http.ResponseWriter, r *http.Request)
+	// List API keys
+	// (GET /api/v1/admin/api-keys)
+	AdminListApiKeys(w http.ResponseWriter, r *http.Request, params AdminListApiKeysParams)
+	// Create an API key
+	// (POST /api/v1/admin/api-keys)
+	AdminCreateApiKey(w http.ResponseWriter, r *http.Request)
+	// Revoke an API key
+	// (POST /api/v1/admin/api-keys/{keyId}/revoke)
+	AdminRevokeApiKey(w http.ResponseWriter, r *http.Request, keyId string)
+	// List failed events
+	// (GET /api/v1/admin/events/failed)
+	AdminListFailedEvents(w http.ResponseWriter, r *http.Request, params AdminListFailedEventsParams)
+	// Replay a failed event
+	// (POST /api/v1/admin/events/failed/{eventId}/replay)
+	AdminReplayFailedEvent(w http.ResponseWriter, r *http.Request, eventId string)
 	// List groups
 	// (GET /api/v1/admin/groups)
 	GetGroups(w http.ResponseWriter, r *http.Request, params GetGroupsParams)
@@ -1259,7 +1970,7 @@ type ServerInterface interface {
 	IdentityConfiguration(w http.ResponseWriter, r *http.Request)
 	// Sync Identity
 	// (POST /api/v1/admin/identity/sync)
-	IdentitySync(w http.ResponseWriter, r *http.Request)
+	IdentitySync(w http.ResponseWriter, r *http.Request, params IdentitySyncParams)
 	// List providers
 	// (GET /api/v1/admin/providers)
 	ListProviders(w http.ResponseWriter, r *http.Request)
@@ -1347,6 +2058,9 @@ type ServerInterface interface {
 	// Get details for the current user
 	// (GET /api/v1/users/me)
 	GetMe(w http.ResponseWriter, r *http.Request)
+	// Update notification preferences for the current user
+	// (PUT /api/v1/users/me/notification-preferences)
+	UpdateMyNotificationPreferences(w http.ResponseWriter, r *http.Request)
 	// Get a user
 	// (GET /api/v1/users/{userId})
 	GetUser(w http.ResponseWriter, r *http.Request, userId string)
@@ -1411,14 +2125,40 @@ func (siw *ServerInterfaceWrapper) UserGetAccessRuleApprovers(w http.ResponseWri
 	// ------------- Path parameter "ruleId" -------------
 	var ruleId string
 
-	err = runtime.BindStyledParameter("simple", false, "ruleId", chi.URLParam(r, "ruleId"), &ruleId)
+	err = runtime.BindStyledParameter("simple", false, "ruleId", chi.URLParam(r, "ruleId"), &ruleId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ruleId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UserGetAccessRuleApprovers(w, r, ruleId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// AdminUpsertAccessRule operation middleware
+func (siw *ServerInterfaceWrapper) AdminUpsertAccessRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameter("simple", false, "slug", chi.URLParam(r, "slug"), &slug)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ruleId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
 		return
 	}
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UserGetAccessRuleApprovers(w, r, ruleId)
+		siw.Handler.AdminUpsertAccessRule(w, r, slug)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1563,6 +2303,63 @@ func (siw *ServerInterfaceWrapper) AdminArchiveAccessRule(w http.ResponseWriter,
 	handler(w, r.WithContext(ctx))
 }
 
+// AdminDiffAccessRuleVersions operation middleware
+func (siw *ServerInterfaceWrapper) AdminDiffAccessRuleVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "ruleId" -------------
+	var ruleId string
+
+	err = runtime.BindStyledParameter("simple", false, "ruleId", chi.URLParam(r, "ruleId"), &ruleId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ruleId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminDiffAccessRuleVersionsParams
+
+	// ------------- Required query parameter "from" -------------
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "from"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+	if paramValue := r.URL.Query().Get("to"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "to"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminDiffAccessRuleVersions(w, r, ruleId, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // AdminGetAccessRuleVersions operation middleware
 func (siw *ServerInterfaceWrapper) AdminGetAccessRuleVersions(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -1624,6 +2421,185 @@ func (siw *ServerInterfaceWrapper) AdminGetAccessRuleVersion(w http.ResponseWrit
 	handler(w, r.WithContext(ctx))
 }
 
+// AdminRollbackAccessRule operation middleware
+func (siw *ServerInterfaceWrapper) AdminRollbackAccessRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "ruleId" -------------
+	var ruleId string
+
+	err = runtime.BindStyledParameter("simple", false, "ruleId", chi.URLParam(r, "ruleId"), &ruleId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ruleId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version string
+
+	err = runtime.BindStyledParameter("simple", false, "version", chi.URLParam(r, "version"), &version)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "version", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminRollbackAccessRule(w, r, ruleId, version)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// AdminSimulateAccess operation middleware
+func (siw *ServerInterfaceWrapper) AdminSimulateAccess(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminSimulateAccess(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// AdminListApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) AdminListApiKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminListApiKeysParams
+
+	// ------------- Optional query parameter "nextToken" -------------
+	if paramValue := r.URL.Query().Get("nextToken"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "nextToken", r.URL.Query(), &params.NextToken)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "nextToken", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminListApiKeys(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// AdminCreateApiKey operation middleware
+func (siw *ServerInterfaceWrapper) AdminCreateApiKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminCreateApiKey(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// AdminRevokeApiKey operation middleware
+func (siw *ServerInterfaceWrapper) AdminRevokeApiKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "keyId" -------------
+	var keyId string
+
+	err = runtime.BindStyledParameter("simple", false, "keyId", chi.URLParam(r, "keyId"), &keyId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "keyId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminRevokeApiKey(w, r, keyId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// AdminListFailedEvents operation middleware
+func (siw *ServerInterfaceWrapper) AdminListFailedEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AdminListFailedEventsParams
+
+	// ------------- Optional query parameter "nextToken" -------------
+	if paramValue := r.URL.Query().Get("nextToken"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "nextToken", r.URL.Query(), &params.NextToken)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "nextToken", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminListFailedEvents(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// AdminReplayFailedEvent operation middleware
+func (siw *ServerInterfaceWrapper) AdminReplayFailedEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "eventId" -------------
+	var eventId string
+
+	err = runtime.BindStyledParameter("simple", false, "eventId", chi.URLParam(r, "eventId"), &eventId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AdminReplayFailedEvent(w, r, eventId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // GetGroups operation middleware
 func (siw *ServerInterfaceWrapper) GetGroups(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -1715,8 +2691,35 @@ func (siw *ServerInterfaceWrapper) IdentityConfiguration(w http.ResponseWriter,
 func (siw *ServerInterfaceWrapper) IdentitySync(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params IdentitySyncParams
+
+	// ------------- Optional query parameter "dryRun" -------------
+	if paramValue := r.URL.Query().Get("dryRun"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "dryRun", r.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dryRun", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "force" -------------
+	if paramValue := r.URL.Query().Get("force"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "force", r.URL.Query(), &params.Force)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "force", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.IdentitySync(w, r)
+		siw.Handler.IdentitySync(w, r, params)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2068,6 +3071,28 @@ func (siw *ServerInterfaceWrapper) AdminListRequests(w http.ResponseWriter, r *h
 		return
 	}
 
+	// ------------- Optional query parameter "fieldId" -------------
+	if paramValue := r.URL.Query().Get("fieldId"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "fieldId", r.URL.Query(), &params.FieldId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fieldId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "fieldValue" -------------
+	if paramValue := r.URL.Query().Get("fieldValue"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "fieldValue", r.URL.Query(), &params.FieldValue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fieldValue", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.AdminListRequests(w, r, params)
 	}
@@ -2504,6 +3529,21 @@ func (siw *ServerInterfaceWrapper) GetMe(w http.ResponseWriter, r *http.Request)
 	handler(w, r.WithContext(ctx))
 }
 
+// UpdateMyNotificationPreferences operation middleware
+func (siw *ServerInterfaceWrapper) UpdateMyNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateMyNotificationPreferences(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // GetUser operation middleware
 func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -2652,6 +3692,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/access-rules/{ruleId}/approvers", wrapper.UserGetAccessRuleApprovers)
 	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/admin/access-rule-slugs/{slug}", wrapper.AdminUpsertAccessRule)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/admin/access-rules", wrapper.AdminListAccessRules)
 	})
@@ -2667,12 +3710,36 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/api/v1/admin/access-rules/{ruleId}/archive", wrapper.AdminArchiveAccessRule)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/admin/access-rules/{ruleId}/diff", wrapper.AdminDiffAccessRuleVersions)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/admin/access-rules/{ruleId}/versions", wrapper.AdminGetAccessRuleVersions)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/admin/access-rules/{ruleId}/versions/{version}", wrapper.AdminGetAccessRuleVersion)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/admin/access-rules/{ruleId}/versions/{version}/rollback", wrapper.AdminRollbackAccessRule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/admin/access-simulation", wrapper.AdminSimulateAccess)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/admin/api-keys", wrapper.AdminListApiKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/admin/api-keys", wrapper.AdminCreateApiKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/admin/api-keys/{keyId}/revoke", wrapper.AdminRevokeApiKey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/admin/events/failed", wrapper.AdminListFailedEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/admin/events/failed/{eventId}/replay", wrapper.AdminReplayFailedEvent)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/admin/groups", wrapper.GetGroups)
 	})
@@ -2775,6 +3842,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/users/me", wrapper.GetMe)
 	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/users/me/notification-preferences", wrapper.UpdateMyNotificationPreferences)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/users/{userId}", wrapper.GetUser)
 	})
@@ -2785,132 +3855,229 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+x9a3PbOLLoX0Hx3qp5lCzJjjOTuOrWPR7byWonib22nOzZzZwJREISJiTAAKBsTcr/",
-	"/RReJEiCFPVw7Mzm02RkAmh0N/qF7sbnIKRJSgkiggdHnwOGPmWIi19ohJH64YQhKNBxGCLOL7MYXeoP",
-	"5J9CSgQi6p8wTWMcQoEpGfzBKZG/8XCOEij/lTKaIibMjDBNGV3AWP77/zI0DY6C/zMooBjocXxwrL5D",
-	"7ISSKZ4Fd70gQjxkOJWryMHoFiZpjIKj4DhKMAFQAQkEBecfBQx6gVim8q9cMEzUBDNGs1QBUZoqGM8R",
-	"UH8Do1MOxBwKIObITsiyGAG1QyRn7we9AAuUqHlqS5gfIGNwKf+fwASVgZXAASgh9oEoIJshsQo3VaqM",
-	"9Sg5HifohBIuGMSGpm0TjSuf3931FA9ghqLg6N8WY72CamZLZWrkcNcB+C3fJJ38gUIR3N3JRfQOXsrp",
-	"t2epCmPUkGqJkMDbV4jMxDw4OhgePusFCSb2h/1ekEIhEJMM8T//hnt/Hu/9a7j3/Pf+3m91QlXQpBZo",
-	"3ekFowscIXaFxC52nJrpxmpBHz9LUACdKka2X8uzwZEAWdpfuaXSCr6tVc5j8AuaYaKWm2U4QpFcKUvl",
-	"2uoUTSkDEBB0AzTbAouRfpAjyeBlBzImPxmjyMsRDEHewCwCJ/JfKw6OgXGsP77rBTdYclGXY2uGvpMD",
-	"qlgvAZ7D0spZ1xyx7TGGEoiVSJ5SlkARHJlfeqsOTQ1/U8y4eNPtxNUGY65kuUOYCaUxgkT+MYabTlzB",
-	"st1aAaozeQFEA9pLR/lKoPSEStEudqAbQzNT/Ui/myMxlyd4jgAXKAWYA/s1oAwQKpwz7SAtVOrzLYwz",
-	"czSiCMs5YXxRWrpGirpI0VOBhZoLICIQQxGYLBVQGUcM3MxxOAchZQzxlJJIChwFsRIFEm4HSIPUXnC7",
-	"N6N75scEpv/WMPzWQLwcR5W9NVDrEi0wutkJaRIzzIOqEHOjfNqFhoTl1H591wukgcNwhMabCJ0KYnIo",
-	"ukjrYwKgMbC+44ApwKS6gMTKZ7NY/z0ZO7aQ/hFowQRCSMAEAbsLIpkBkzDOIvlX+7P92qgHO8eERsv+",
-	"ezKaAiwkO9MEC4GinvqIMjzDBMbVFW9wHMslM44ipTmu0+ixGqgt9uf6BqRPT21l6fWCTKHuNeIcznxr",
-	"VPirumCvq3XoPZlqcikkuMb6MZudq8/5pfl5CxLOITeTNUtSSJaA6o/AHC4QmCBEAM9mM8QFipTBorwA",
-	"NsvkyfeLV9q0jDw0xWTms5LvYKjVH+izNYckihEb0BQRmOL+Mom9hNQbq7NKhVoOCgoou0gGM0obbAS8",
-	"ZJAIBwl3veA4E3NtdWxNKEffN+s7pVowl9AovwlLFhSUSXmlwJOiwEccOXDVuZAbqSFPDWw3BKpoO0UC",
-	"4pgDOKGZcR8zMUdESFSgSG1CWbpGfVUcgq0xGaE0pktJIy2PtFy8zDfVeAxAAkkGY6ClgcSzxYTV3gbH",
-	"4Niccg6KxYxRkDEFJfj+w0x/vFd8Ijn5ww9yMhgKvJCLuE6Jj3Q1zda6ty7kGSvFo7EMbuaIWOtJKpVC",
-	"6VmqlHwYxfRnjNFdcDyS86wWtvqzjppcfQwYEhkjUnIxmhiziy1wiBT8o0jyolieuPTawX5KJ1L58w0u",
-	"FzYAWBSvxkFtRM+/WhcsXSrkcICJdnAksxZH1a5U4WblPmOX3RUqX2EuCoPDmgG70FsE3aoxJItjOIlR",
-	"cCRYhjx+kpQmaxkSHgEnFYNasBOXgRhzITGipXHEwc2cKgPQ2pFKPjshM2O51THGtazcBfMVc5aQ0Wq2",
-	"5WM0GF6jqxsdGp33tVB7pkODuXjyIOzBUfXgSCr4z7oncob8OCpRsAs0eUz0NgypdXeHnNyg3pp5SubF",
-	"LhCTlibsjKASHCvFUmWR9RgDk72U0RmT3FFR5xxMkFT0OvhpQxbWrikplYKnjCd5tpAb2oXmX9hblk6Y",
-	"c5ffHYcZIHbAYQa+L6r2jEpZG4krGS+feAeI2ZFrtIUtsNrf2bV5cAFnmCg/xzUTVJRhe0dnDfnSbPhB",
-	"UPoU6L2ow25DhFuTjBWxp05MedfJcDVOy1SZ+BJS5bJY86qvpjFTq0BKobRrTp+jO6WpKyCumMTG6UdE",
-	"O6zSaUvgR1Qsp79Q00jHrfUebv0LWhyVx7Es/v3g2c3BGZqIg388Iy/+8feD6Fe4/2J89vyfw7/XpjCx",
-	"ZB0zC0an+krhJGOsHLp1YgT3c0F7H1ezvUB6GQa3VSWYEfwpQ8B8YRyaKUYsj2M5tO8D5UEbPlLMoK6S",
-	"uLmhM7P0wXvyTrrK5iPMTZAg6gEsvuNgdAoYShQThZRwzOWh6b8nKy8XcRQUu1n3RtklqZRNWGgeK/i+",
-	"dqx6Qc3qbzgbxRfFAYnU/6PI4zwazEASKexw9ZFrUOAFAjDFnsPyn5UH8QAnO0ECRlDA7mf1tR2xgVzg",
-	"AopsDY/qSn//TaLci0Qx1Oh1T2DJuWUbyWNkS6v8ee2wZeWKUaEsOvZcMpYvSSVcfUlNdcGrR/2y9J7D",
-	"VXc89guzan77L5doOoQ+KMwsXiiqF7j5Nl3gXUDc6bx4fu0QqxnTV/mR9FzNqL9VbjwlIwe9AJEskYAe",
-	"n4xHb8+CXnB8efK30duzUz8wV5bXaqitnVnPMdPMZg0vR9TWFEbqBE67mMRuVkrnW/8aOuUMVyhGodBO",
-	"SPNc3ZVB1ej1BwECA34NBi8VxvmhbWYI/U2jAfBXpEUbcM5UX5RIXaTku9Js/L5dGfChcekPxfQQJJSh",
-	"wh78oBnmA5hiFEdAYkDfu6QoxFMc5vfLypQyyRJcTa2vLL+5T92MHSdS/83k+TJOVPUANp7WMf2IdLpL",
-	"aQ79s4/DuKBpjGdzxQWSZYPlbQKfRR/nfxwOf/qk9mmvml8jMaee2+tT9X8TxMFNfo9tj/Uccp3LYS6m",
-	"IgAzQaVUCGEcLwFl+oYU2rQnV+Vfj89fH49HJ0EvuDx7Ozp7V9H6Zbi6be+nZ8+TWDyDn27J7aGzvdyn",
-	"q7OivVIzKXeFpFJMxGuiY5PMojxuWLeOlMCUPqBJILZXfDw/CHn0qbftFaQBvYbkHDsezmvIf9+JSm+4",
-	"vl6lvbvjvY5upTi0DsFKy8BUYp46mTgS5+qLHsBTrYbEHBJACTLfyaFG8dgkOkMkKaJ0eqZJnpMnWueC",
-	"y/Ot59CEtkuqDAlnuLoGZ8Y+7mYZqARmhbU17bV6lvRaRpNZqT6LL/G0flifUfgkPLz5OYl/Frdqc+qa",
-	"yMtbKEkpg2wJIOd4RlQ+jPQocoMDgpRhEuIUxnV1j0jkP33SZJHy2hJFpdQoxyRXsgfDg4O94U97+0/G",
-	"wydHT54fPRn2nx/s/yvoFS6cVDJ76/pxrmFbh2x06ispmOkUMWuelCGl2lZZkffNBWSi0VNj4sHwwVt8",
-	"yFDrT+VLeoAzKuXi7M3p6M1L6UZaf/Ls8vL8UmuY81/PTuUv/7wYXRpVU8NNpvnVzysJxDGAUaSuPQ0M",
-	"lv08hKmn96+RN59HVSxIPdcP0DTsKb52jrw+Pp5zru/O1y7mwX75nKBkIlVGVrJUMRFopt2vhkRaXyVP",
-	"1YpSlpa7QGl7chee7Y2itAhA5PaFjSTk3OBMVYzoZlfAJ9OI7f88C+fDQxi4pQl1XskT22piqAGfwlQW",
-	"dbBBhS0RMvtw8rXqEtecWX1V9e4q30y+U+N95P9v1eeNst6V69N1jJI9pZoNbVK8kIp0Z5yHTaItjNvT",
-	"WN3yCZXvbkb5c1cxv0Ih8xk1dk5d9eFO7ZgQXA0G38dYurwEHF+MwEekjF8IUsj5DWXRD96VGzPO9ZwX",
-	"UGvkMlBKJ0Dp/1JpPDBkEhAVFNbu4IIy5feQHEIOEkjgDDGgID1+dwWurl6DC8hgggRi4EqO6XdzhvyH",
-	"tyCPg1UPu7q80c1WuHkKFzd/InpzMPnjeVDnM1WZ4kl6jlZpV5eefZ8bvbAz12fR/OWpzumIRD11I370",
-	"nrrhZ7o4ZPNJdJNOP+IyfvR1vcemyu0KUyxiywfptJzCI+aMZrN5vd7whrKP05jeyAlscjgYzxEvbBYO",
-	"IEPgxx8JFT/+CJZI6MRkVDfR8gIjHEErFrbN36+h087tcRW6FW9NYcxRr8VAKWe16vqtDQqxel7OzcMm",
-	"o9PcU8mpqPOnwVi6D0ouMUgimoBfr65Hp8pCXlAcgZQKRASG6tJ1GuNQcO0USb7dy72aYl7pmxoOaco4",
-	"B1Mco377VV3bvUBRt2Z40DXqTs5fX7w6G0tj7u3xq9Hp8Xh0/ub3F8ejV0q329+U2Td6MxqPjl/9fnL+",
-	"5sXo5fWl/nb05veLy/OXl2dXV+VJrq5Pzs5Om2xBgXwXt8dEFVvZIi5bJChxFKnkFTLLAyVijpbqANhC",
-	"Ol9RyupcmyuB0nOzZnP4eFVlcjXt3j3jfsHXFL1Tos8E7io+SkfBpz7xXiJqrFeOY68uHTxCUwu6buJy",
-	"nyRPGFo8/4T+fD6pi8tTDGeEcoHDV9QXMwIxnUm5z5aAoVglYxkX1D2M8vgbeOvyLkYLFPtxKydXf3aP",
-	"wejNi/OgF7w7vnyjeV17Nj7OTfiseeJE30+uJpQGUM/WhO0ynnaC+hHhgmVhXupVxppkD1P+s1le7JUz",
-	"waqwmbtYEwZK4G5rytQg9JTG5obT+ghwrS5f3kgF803xntXFi0LF/HAZNSXQm9Dpbn5n2MxlZ+1QjLTM",
-	"LsW2iwLqhsLvjevI82i5HRN1KMjK529DWb7DnRzBshFWFX2FUANwBiWRHTu6bPg0qJ46EpX1zc26qMFm",
-	"TyETOMxiyEpGO7cQIR2whWTpqtnGBKk2p6DYYw/wLJwDyMGHGHOxxzndU+H0D16dGdPZhoKpLEo9UHc3",
-	"pcpqp1AgrhV0dX1yov9VBM2aNIpPg+cKu0q6JjZ1mGpTJnXqzatMmZeuUxuD4zRBYi5NnARGSDpn7g21",
-	"47HUswVLWb0dsovLtTuwdp+2OuUw/1plDJoYeHsxDNSlCr5Aie9uuaULi8Hd1nlJZh5vyWUvsHfxq24Z",
-	"2nYtx9ZqsoswYLe8QEM0Jylwsy40u0jm8p2sAo3OKTMwlonVc1m1nNRVwrZzJO0R8mCvzswN0ZQ2R2GN",
-	"a/Q6VO1pxeaj5pSih5EBuz38ISS6RMIT9mMZkvrNd9uo/X01ELG8lta5OK6HHr+JmW9iZmsxU7DrWjJG",
-	"F9p56mabqFpK192Yc6aMJoqjrx7+plHCcrUZI8mh482YSe1DX9dH/iQz9cULiOOMocvmw4yber2FlEUo",
-	"yglc758g/2KaV91AKaP0CB1HUfkPtIzytSOnhndbt2m+aUhAEfSxsImgGzKJoLvoc+VKCpVkUhzE+oHX",
-	"RO9m3N/uP/3z6acwRjz69Nw17tdOJM9bZ7mpZRcXl+f66regwMnxm5OzVzpofHp28mr0ppxvVgbAQ4sy",
-	"qupXmsb3vUIhJRH334yri3slj2o7xJw++2m4r9IvuIBJKg2U6/GJ+uFPSpCbUrCV/K9CWkfC2OqBLrQ8",
-	"pHT5KZ4+u53Ap9ZRKzVf89hqtoGaNswo8VDUT08/5UrLeUhXzuQu043mt9DdbQLlYfskSwXT1N6M6gEO",
-	"zA5E3bAMJ/vPbqPbG0w+zTWWx/X83MqZwUk1LmOS8ypVGGWEJPD2tM7L9eOYwFucZAmw7CT5lesBbi2b",
-	"tE3jmN7oPl99nQcjBwZHPw17tTNSwaAHGAeL41qqbc3kuDaNqRp6cLb31NxJW7kGXem22Kz9McWhyFhD",
-	"bkAnjVBkuNyjEenr7mlBd8xKp+Gnaz3W01GveYdUlpM5wy4Rg1D+8F/oVqMghhPex1TnEtUTV9Ro8Ebi",
-	"gDjQHgVzIVJ+NBjABRSQ8f4Mi3k2ke6VKVHvhzQZZIP9w4P9w4Ph8P8v/t+hxO3fKZ+70OQLtufNbLDw",
-	"z4cHwyc/PdcLS3o4QqnG4TGcID+H5wkN7c76wmQf6IkcIjmrdlT2FP2Bs6chHj6NMtMrFJMpteX/UGfc",
-	"WQLRJKEEvICq72jGYgdFofrbFAokKVxL8K13Tzu+GAX1lHLuhCGOgv3+ULcXVMkEwVHwpD/sDwPVpHqu",
-	"cDmAKR4s9k32wR6znX28idAvkZACr5REDiB3Qw991ScQabEmbdC8mcVxqWVPqWvjwXDYdObz7wZNzYxU",
-	"44IsSSBbmtVKzX1U+cKMS7KfkQioY/ibHOPb+eAzU02T71pREJkmfR6N8568J2cGFTpBhJJ4madKq6t1",
-	"FzoT9dBX6RDo7MDC5qYqRwXZTlixus8RtAcoK42MEMcz3TlDkyPvpuUtORnl2cURRZx8J0CCkLrr4Eqr",
-	"6rgC7wEI/jYeXxwO90FGYCbmlOE/UWQ6xakwjG4WV6e6xPNLVI57+Wi+k/YdzfUmvs6Uv8ojcTjcX81y",
-	"5W59atTh2qNK7CnZxyGFnznl8TSJa/JPnwMs4ZZHthC2zDb3LuSabuxSYKwqA39bxfSDvECjlf3rpRzl",
-	"mog+eE/G85w7OIDlrm+jU/7tnDSek7wR4A6EZL2p4MNxflUwFyz0cIcgSjDppvkU9FXVVyOmqrCs6Kmg",
-	"tpHyzC9wLBArM/tkqdJr8zthbW2qq1855FOG2NK1u2yCUb7r9trzqm1UBQmRkC1TnfnzUd3pq8pVTGYg",
-	"1R2atEs0pQ0QEXQrbOVeCyl2YAVUekB2twVMS17JZtR376pDbPVqLw/Bq2VkRVzpFxotm7fkvJEzaHog",
-	"566Go/170Jq2MLauLG2kUUmA4UZyY387uWEI4Vealoqth7qbUVcPHXhI/QAWTTNtHqkh45ysexHgvSDN",
-	"PDTU3Zx5lY4dy6v95K6+CrDJyW56WeDukXDPsI7KX2AEHDANh1XQ7dg5DkOVP3pDBXhBM6K+eOpbakQE",
-	"YgTG4AoxaYYplquwmsbgTiTAALJwjhc6VHpf3OnVJ68h+8irvY2lDaoBivrvyTFZghQR9eiF7SRp7FLM",
-	"S+NsJUwISYji2GdXKrwc68n/c0VWznWbCzqDwxL7deU2I12azcqiraRNOp9jLihbmoeuHBtwTeX01i59",
-	"D0bWjkRCmz6p4uML6pc1aTv4bP5114HKeUsXuz3/pUVH4n4zQByGKXDyhRil551o4ZBmc5Yr7mKa7FV1",
-	"8wTyvnRljnmJTCvzVc7nY/f0Kg3ZPe5djoE1HTtp/Om4kSkAO6EzggXV4Z6U0hhg+6QUInDiVbLO85Ob",
-	"O32l1yvv098zXeYfiZO3A9VsSJnXzq8yCTWvDD7P9IsfqwV2rRGYZpl+44G7T4ncSD4phmuiUX0NnHqT",
-	"DYSiwdOWssy+itLqfa/zoEoN+95HaTYyfNqft3k4TlcoakLGSr63Iwd8SULl+ngl42VGyliXn4Mc1YAS",
-	"EKEEkqiRAFdyfj/ed2c5bI1MCSWwIHfBX17x3R4eLj7zXYFeOH/dSkasVX/jKQTcqRm3NTFqmOtOjcHn",
-	"oklUe2wvr12dLAGOfNLbaTZybwK8oMnXRoMuyqLUsGsbfeGn7wCyWfP5m0mDWMouSRG9GNBfTHTfMfUA",
-	"oxNNcRoONfLCsVxxS35Y/U7irw+sVvKzAdkMGMAfLwcMPkM2k//jvJjZasK57eiaYioXDgr0+5Rg7AxL",
-	"4FIH28K56gJCAUNThrhuG6J+7qlmOLqRhPnjB6BcJZDjrd+qFoqHS1f5bJjYotBiffV0gAuVxdt3vPRo",
-	"qMeDM6N8/luR+rmRA+d5i/WRyDjF6zRH9xdkdn/QQPH0rg5N/rJWB0vFVCtAhgAXOI5X9MtpZ2Gz8qa+",
-	"fsNbYx4y1nvDqDYu9tmuNYIBv6AZNkKi1P7H7l9LDFIko7h13r5YQAkXm8cEKq8dtcYG2jHrfzdpQ0+/",
-	"RAqFu3p/m2acBXcrmLYQ9+r/jVUXIX8PgkuU0IW+8HPfbKv0KZGCUc+gI2YRFLBUkIOFSkZy9YX+Pip1",
-	"fikT+xS5j+8WxF6X7xuoU8KzXqt9myp/uy1fSyPDbVtRCT03M7ZjEd3vRs0tfMsutxPVhql2KmfrLDtw",
-	"u2bcM3BNYm00BZcZUc1OS5EMJ5jZ03awukm8YdgYE1WDyBSslQstuKAMzrTNkb/FjIVKl2tcNsLcXReR",
-	"KKWYCJVYBwhVpcweoVp55npzBmx/MLsSV7SNUSqvva3lnTaxR7UVzZc6tpVuPvfu5dZbCHUNXa618a9D",
-	"JqjWX4PP8j8jEqHbVinhqw5EEhkRupUn0jyxrg+mfn1cnUpVQWjqkDxbzhfvslmnZulexFbW0IjY1NBU",
-	"W//VuPsqmyS4zOBXAm1kcdU6DtnjvyJTZ3uFd72CkI78cVo37UQKWRfyAZWU7avDm3sslXPL01p7vyxV",
-	"tts7qcR0irXJxD4YDsH5r8VT/WiBiEkEZ0i5O06rJ5WlzbXPr/9tH1mZ0oyo5qeY8BSFwsaSnMFR3tuo",
-	"aCVZ7ehnXnZpgPVwOCwAxZW+6CEkhAoJS94JCnwv0WKqxnq1nsC83j5T7hcTK3F+qJ8mS4ovY+e9LYUv",
-	"6jWcBdN31rrui74t4SCnEgEqMuTP9TbkcV8WX7QKaZpgYQKL8rO8hEGvwrNYv5WyZvK2pzS4XOttK8D/",
-	"CkndtYegy0zz3zRj4OXZOLcc12GLwee80L9Dlk6Ro1eUa/szcop2IPdmTpVbA7UEkA8fKoCcP5mzRR2H",
-	"04ZhGzssf4vGS+AXSITzSjGSN3fm2vzhq06dKb0fXqbapbcua8MkGttvarscGlMdvWG4TO/1/jNo9Kvn",
-	"f7kEGluavkqcKi4ZfJb/MYJ09dHWH+/GYjT2MrQ8F8aZSpbWeVy6JpDPcVovslMD/Ty24SPt63dLqNSg",
-	"Ox0CqneE91ka0MTCpXKAr4N7DTus5t6V1qG6VbBf9d+TsepDZ1rOOdd4utsHB0uaSRNvqhRKnqqv71Lk",
-	"36T7oMf7Cz7/YqYln9ObAg35g9+e9n1TynqAQdM7GJKmUXPIdfscMUcJR/EC8cbrSz11+/3lX80aVgyb",
-	"LF0Pxm96NdSilB4w9TSVVK6qMoNAknFh8taXlVR1/b5bAj+a8ipjLoPrvKjbqYWuP5zqFmart1xsHbfh",
-	"BHclhqaIIRIi3gfnkn1uMEe27hocDg8LF9oWx7TXXJcedtvc8jATrDA+GqwFny5eYU17pNoghZrIXtEW",
-	"YZ7GcAnUGc3rhXoA3aZYvaKjMwcW9KNqVJeLwJVy6wIqGL9qG3ltn9OL/ywNqe2b1kqDWnGXukGxz4uW",
-	"RaF6WkM34ouX5mUZQJnK0ImyWB+oibqCladWv2yHCZhmImNotdq5tkB/I+G68YG810M1amcVnn1PK+9D",
-	"qt/LsqpKCVclXsHqcIXtUyE/wFwwKKezNkA1Pz1nIQmB7QssnJaOCsLvCRXoCBhj1Kuwbbeh0rI/NHav",
-	"+BYHeSxxEB8L2QK2zjePtrtd7fYtV/FuxoTLhJQAqWcKi0IdAxor0cUQpxkLkfeuUiv7e7ikXPdhLQ8g",
-	"XS8ujcFSfTflMfKCsM9tr2ICLfW/DPWtmrjnoka9zGMUIYaB8kfPHxXnaOOxY+X8ZiA0Bn6UQyG9ag1E",
-	"PUafK7ElmMOF7fIQSZ88RiYEaYKUxrPV7Y098Ui1wo40Wuf07+HXUxB/YkiwvqPicpNqls2/iLHlTVp1",
-	"GztvnrNamuWxZDnbI4Hs3h6XHGH5IxBfXI7obsoe6ZG/oaF7WIIiAJi/Q6HiIBNUaxpXi3uYK3s9XLIm",
-	"ZoDeFKGunpuNYBrarWhEV+NgvZEtohflCTbKcLFTNFwxaUxvLiSwZhX6Ea3FKnhHrKLCyCUP3WggDVOR",
-	"k4IWmGZc+ujGke+Ds+kUaYcdJwmKMBQoXgIfEelH1K5pvnptcWnQRWwMoytD6Msm3UO6c5F2UTpcxE5i",
-	"OpuhSGp/f3/al0i8RhtpgONMzMvXrJ06o9TMPrefbNVb74gn91JuhUK1vn0jNvJ7sge6grqP1jKwBZm9",
-	"e7rGVFCotlZ62qLf89FgENMQxnPKxdGz4bNhIAWRAS3vFp2DeNfLf9O3W3e/3f1vAAAA///nvie5c7cA",
-	"AA==",
+	"H4sIAAAAAAAC/+y9e3MbN7I4+lVweU9VdvdQlOw4WdtVp+5lJNrLjS1p9Uh2z9o/G5oBSURDgAEwkhiX",
+	"vvuv0HgMZgYzHD5ky9n8k8gcPBqN7kaju9H9qZfw+YIzwpTsvfzUE+TXnEj1A08pgR8OBcGKDE/HP5Ll",
+	"mfmof044U4TBn3ixyGiCFeVs/xfJmf5NJjMyx/qvheALIpQdLSUyEXSh2+p/quWC9F72pBKUTXv3/R7D",
+	"c6I/zPHdG8KmatZ7+eTp835vTpn/d7/eTSZ8Af3+S5BJ72Xv/90vVrVvYJH7Zg3n0PT+vg9LpYKkvZf/",
+	"NvO6cd77GfjVLyRRvft73d5iIkmIlGd5RrbHBl4sBL/B2UrIoR0Rh5xNKCy4gkdyh+eLTEM8TOeUIQxA",
+	"IsXRybXCvQjGpoLni/qW9C5mBME3ND6SSM2wQmpG3IAizwiCFRI9+qDX71FF5jK6l/YHLARe6n9ndE6V",
+	"XLlWj983pn1AFcUy9bIQ1muNLY5xRSd2E9aY8LjU7b7f47eMiDUGODHt7/u9Bc9osuze89S0t1RJpHpF",
+	"SZbC1B7B3UY6C/rHdkFhMSVq1XhVWr8wvXR/mlyv7h/0NO2h55wcciaVwJStJoSLSvMqy1oK7hdc1Hds",
+	"HJK0X3EdgFY+f62H/yIC7+nBs5rEW2CliNAM+n/+jfd+G+7978Heiw+Dvfd18o9JttaVngp+Q1Mizona",
+	"xYoXdriL5YLUMADyRYOC+AQEi2utZZUkCuWLwcollWaILa0iH3s/kCllMN00pylJ9Uz5Qs8NUm3CBcKI",
+	"kVtkyBY5jAx6HkkWLzuQ+Z4zxmmUIiae8+uYu8FZTqTDnRUVyPRwv9o16Bn66JosSYqulvAF2qHx0aBX",
+	"xVm/d7c35Xv2xzle/NvA874ASxAsG2hY0bn+awU/W9RdmMb3/d4tVbNVnUrI/1l3qBJDCZ8ellaCv5RE",
+	"bL+RZI4pnNwTLuZY9V7aX/qreDmy40Kq426CoNaZSjjyg4254jwjmOmPGd504AqW3dIKUIPBCyAa0F6S",
+	"MOeKLA65PsfVDlSoxI5U55efZ0TNiADKl4osEJXItUZcIMZVwAgB0hLQsn4CVgNw0pTqMXF2Wpq6thV1",
+	"fjVDObYlTBFRsGMuiUC3M5rMUMKFIHLBWarlIEAMEkrDvQG3VjbP46iytobdOiM3lNzuZGvmtlsEVQmV",
+	"9kxsFxoaliPXWutkN0QImpKLTYROBTEeii6HyJAhbPXwbyQSAJiWuZh5kWsmG7xjF4HKbH5ERjChBDN0",
+	"RZBbBdPEQFmS5an+6n52re2p5ca44uly8I6NJ4gqTc58TpUiaR8acUGnlOGsOuMtzTI9ZS5JCgfa5SJ9",
+	"rPeYlmvK57xn/HGh+I+4UPR7ObDCWyIlnsZ2viIvqhP2u15CGiTt5UISoR4HJya5EISpn4hwcjmifpqP",
+	"9tDSQifBWUYEIncLkigwOFwRZIcaIN0HTAZUsm8USmaYTUmKKMivGZboihCG5jylE6q1csoSgtSMSjfT",
+	"IHa77y4zviLTxh8i5w+RExM5D2TlgFm0wimN3BiK6QmMJM/sz1sIoRmWdrBmrRyzJeKmEZrhG2JEgcyn",
+	"UyIVSeFODtwpprnWIuOqOm+aRvN6MZhtVuJpu22DfcP/M8zSjIh9viAML+hgOc+iO2oWVifKyrYFKCig",
+	"7KJl2l7GJsHQa4GZCpBw3+8NczUzN9itNwoMqGc8Iw0YhO9I6AbOupBLIvr2AJga4JDMryRRrsWCiDmV",
+	"ElZh1GMYhmqCVFzENiHOfg64mAQIbr3Ntz64YFFZA0GDBYjVCnGMrELZeyrIhAjCErIS4uOGbprrJRGr",
+	"uutNrRESdCzW2wxbF+I6IgrTTCJ8xXN7+OVqRpjS45EUEAYmL3thrFgGt6a3lCwyvtSUbPQOcxM588tt",
+	"FBZojlmOM2SEp95ThyN3X7b7iYZWTEpUTGav4bkAKNGfPk5N472iieb3j3/Wg+FE0Rs9SWidjJFJ7S7Z",
+	"urYu2wPqksUyup0R5uwV+hpXXDPdrpSMmYGp0vnqthcPC/ojWXZzq+npr03j+qIkSQRRaHg61gbJARor",
+	"qxFKxYW+v0quNcIE69+uNBKUoOSGpAhPMWWrLcIWUgNCV1QngK3UgaVXMBKC70KwEj3O6sPdNOtofIDG",
+	"GjW5YPqAFHxuLUXihiYECGCcamZWy8OQ4Hd1UDjxCZ6RBuM1tQA4Gl2Ng1qPfny2Llg6A+RIRJmxyWpu",
+	"L2Sdm6kiDuDYoqG8KKHyfMmSXWBQJDN6Qy5mgsgZz9LRXUJIGhN6FyIn+ooEe7tkCbJdJZpzocUSZlYz",
+	"gp+RckPCaaullzn55ACdsGwZUAwXKBVLJHKmz0T4IdHyY8kS2UdYIq6l7S2VpJicwmkhGo/KlE4mqyRE",
+	"iMoj3V73E8uzvMFqPceLBUlft1zj/FZ695HRk9Ecq2RmTKwEJzN739MDUjatIoROyt8lwoJ46iBpZ1Ul",
+	"XODbAviY4sInkyuORXznrc4i0e2Mo1si/CZ7m7HekwEazRdqWdrPjUA9sbA0XGp58fkVplkuiFwFc8Lz",
+	"LLVCvFhpH6gN1nNNFsoSaWQ3ghnhgmAGnmCakXTbFdolrNTcLWFayu43M25pL9c43vNMWb3YU7HeVQ3Y",
+	"GyqVOU7lzo7vNe7f/iCvUgIjdzA7y7MMX2Wk91KJnHQ7lGXP9u90zKGMSkCPPZSlR4u/jDvT1S5Q1HFh",
+	"Rn9fywwdUeM3xIS5xaSWwXDhhYB7TWC8slaXOsak0ft3QVLFmBuYdQwYOySwAJo1UDsyUURe1Y4g7Iuj",
+	"6osjKeDEIp5ADhyuXoFMHt1o8HeALHLj4g874SmYfXeIsjBshCNzRCE7hMWRUWB2gJ2IE6wNO426x2Z4",
+	"8RbIrRmsZE7YBWIWpQE7I6gEx0rRXZlkPcKgbG8h+FRoDqpc37X3Qys6JurJKXjOjlG6AxV8Zy3jX4rx",
+	"wum/JOc1UZiF77OqBvbYXRuJKwnPD7wDxOzIYLyFvrTa8rlrFeoU63gIzUyhKgXieXvD5hrypdlOgVGp",
+	"KTJrAWZ3QThbb5kofMqdiPK+k53FgAVXUBMJA1c2p4IOYBg7NLiXzNWidnscMqfoW5eCFbYSgdTEc23E",
+	"SYJwdghw9CZe3RdrNFobmG7Ic+MsKmPB2vqGqhSwl2JF9hQFb1qNhG2XHxpsmuOj0CVilHPTA37Uds5N",
+	"nNc0bQ0Trn1YCDKhd3EQIVhPe90FThQR3odzTZZ9DTUYz80ldLJEVEUBXv9pRb8nFVZ5x/vmuWlbNwsW",
+	"jk4Dgl+rHz/co36wxVo4UGWeQ8ActQi6+779cu5WV8de3Y/l7Nfv2Dt2NhoefTg5fvMv/YuEe9kcXxP0",
+	"enThuAAIVpsxCEsXnDLVDyK9gvNdG+xcEznQQ//jcnR+8eHk+MMPo78N37wqpjBrLCbgOphshrOJl26D",
+	"d2x49HZ8bPqAZ1VvucTz8oqAbaLKhqYCwvK53gW/yl6/VwOr1+/BVHV8n9sNqxFTactffvLzDA8vxj+N",
+	"YJKfTn4cHUWGdFteH7O4NNXlS3F30XY9hWnFOmzdrIQZ55feMdhGXARX6xYwTF2qbP0Kh6blfiLPPjx9",
+	"fvt0RK7U0388Z6/+8fen6Y/4yauL0Yt/Hvy9NoSNBDXSoTc+gjHloYm/iRtX131LU4vliMkZ/c1KcNse",
+	"DHc68pAy48qa4+vKGdH/MmEh8YCQbcM6bpripoYoZ/TXvAiesgKXEuGDHAIyHSBwHFpUAd0Cz0v7QsEF",
+	"RqF37GeNVtuISusbTfuIqm+kPp4EmQO9J5xJKpV2bLxjK11pIHjdataNNQmpL2ThgkVjkrhqIGpg46JF",
+	"wcsp/JukEZePxYw2PVNpjjvKqnIXL2iEr/+z3uV9ASG0ebzcnCicYoW7933revynPyR8vIK7o77ox3M6",
+	"43Yi/8vFAP5eDwuvl3eOWvTsvM2hYo+N9qPF+qlXMIELUb4i6pYQhtQtd1iUlcceGv/1eyb038TiD8xx",
+	"CN1jLKLDPeLwEywyTR9tIdOKx/tmWLX2rGw3AAGj9f1K4ztifKct+xEuN8IGZnS4QJkNiiD/HTP94TyV",
+	"lE0z/0BSEmUCZDC8qDIO6KRonVGpTCuc6hAq/VmQOb8haX1Pocl6T1AA5IZrJVYzdxGHZn0k82QGFzLL",
+	"LAMbzKAZHphioJ9KDqxxYwwgFidYpVds+x3x1D7YNa+3OENMKygFEBCljXDnW0nkjVcSylg0v+u7b/A2",
+	"yjEwRH3AVc5fye0dryQ37RimDxYEgtGIcn8SZmJjIgzOeZbyW3ZOEs6azlWtYs7zOdKSTJ+j0jT2UgUD",
+	"hN9IG0HrJDsIZ8Js+APYkahA2t5pG0qFhZb2Giw7R/h2kzJFpsaSOsd3Q4iygCt+E5j4DsBk+fyKQFQs",
+	"TCMRLjAIFoQSgAZn+neDqZSkA3Rqwfa7AaSKFL/FIpXQGXS+TpA7K/4pEUd42RV2PzWO7L+HnjL09Bma",
+	"8VzIVbDcx6jXEmUr4b4NdNQWM2RUi7Y/asoZaM2hZomsm9pXPCgoWhRQVcStf+DaEDlnBigElSreAklE",
+	"2Q3PbuztSgeLZ1c4uV7j1apGuJjghHy6L6CtmmqjVtoWnNlRfliullXFppQNigUg4XBRmfY2UGOa6eK4",
+	"epsob0P4GUmiFISkRaRXTSjJDCfXWqQykkUGPtefUWK/e3U/IXADRnKGhQ4PJCStZBbQ8XSpYyAqq0Bs",
+	"GAtThjaKzzKmWpF64q9WTYFpWpTaE1Vb6fktqx0HZhAQGWanoVMRYUkKWwI8+63jh6o+0qe0Zhnj1616",
+	"cPuNXv22m/7tjEuC5kRLOQmwO2jWu+R7h1wcSzCX3XxzWXX/EttttvPa2dVGd9tuYes2n/p7cHkBh5wZ",
+	"4eXoOiUJTfUpZZ4O+J2q8xFEe9pgqlS/hODgbcJZtuy79wVeJ9PKmG6fEkZJap94J8Xk+hvR2id4gChD",
+	"XKTgFjLXLgMFooZi+u54R2QyIYkq9EFw2LhR7YqoRApigqU18aTmDTjjVQB0s37pxTiVDStEtNheeIrJ",
+	"uGspZD+IBKbK4SJQUN+xGjkXoGxw+TG76/cy+sQSs2mOp6TpImO+OkwWOCR3C0GM0yP0bCQkC5zIDSeD",
+	"n7MfLi9Kw5Y+O9BwscrIvedw9CaA2Itq5y7WJjTzrDZoBFLLxmzfYEG1Y16ij96e8xH9iaZ9BCk8AmH4",
+	"5/475hp9RH8y+V2MEINWJn1A3+t7EqU2ZN7qvtAKVFJ96AKD6Z1Nc22PdXz3Z2j1kfHbjzGqabaX5orv",
+	"WYJEjEMYTZonRjExFyHD0+dno+idx3BWycl0eXHyYXh6enZiXU3/uByfjewvwze9fu9odPyvCFX0ewW2",
+	"y1C+60lB3vU0v3t0u9vbu/zg4On35r/ua+Uih/6f/0Hvek+efvvsu+//+vzFwZOn73orL+EBLH6VLSRZ",
+	"kFsrbZZMdBHCNJfw9SyFwc0WGXMbeM0Fwu5+n2BFplwsV7rWGjxnbW55e7ku3qpb2wAWpEiLxP3GyfKt",
+	"2sALKYXqubfeBzm49t5/Ouh//+39f8WIMMNXJCuTzIVDRKx963tWHj4NReejN6PDC7PK9VQBv576Lgsy",
+	"zTMs6iJI4+92xp2FBawx6GL0TwsAmudSmccXZTT+W2Prv/f+fbD34v1/N7kX3eO/up9AxXOI+XklOEAW",
+	"CtzsSl+UDbgACTEXFbvcPro8e1PpNVNqAfJJ/yFRRtm1NGdziF3fnDN/vgQvip140VD1+r3LMy1JTP/e",
+	"+04GVEMltmnwPcrVJUZtZenCzV6nJWOpjVs0a0754dnh38Y/Vbzy1WlaPfMX3kxfpThj4HLaWeDOqkmE",
+	"RfCkrEv0VZhirHMKpxo69QjnJCOJMvFuzWOtYUArT9IQb9qz4NdgiO7ChbeeNxOEadPoZP097kUbcMFQ",
+	"n3WTurgrLrx3qhp5Z1XxtnuN4sg/DC+OXsqKv+VSKjIPz+e/U4ERlTIn5oA+NxF1x/zWndWilN3KDSpR",
+	"xvm1fju+WHnZCT4IotdsXn2pYriUE3gXTO4gYlPYZ8IUbuw4y/gtAZVTkZgyaRtogURa5B6RwaFmJ4bz",
+	"64ogyvqIThkXJleXJAPks20R/equb3tIA9TSjGiukmb60gH4796YoVMbAa6JoruU0Nc1G2DQtKIzb/Js",
+	"vMlWEEwB7uIgkw4lAS2wYiiz9bUdtGkVpKRTVjzBt1NASOgCnoyav02XVAcYwtXFuLRD+wJS+BpiPklC",
+	"Uk21FRyeu2vFehiE03u8UlF0fG30mVJSzbrCS5giIlzu+AhRVnfE2muUIRpkIbE+Jhn2t7O7HCdRDbS2",
+	"UsO/8YWVWLxO6UABZiPKLR0LFO9fveU8fBpd6Ae/UIF7/Z6NvWX8drW6Y+EutqZfYdq42AQ42wXmzyXx",
+	"Kx86FBB9bJz6YzE8Nk+2fZDSR3PCfnTEpWlE41cuSKLtnV7Fh/gemypQwtAxX9Qf4Ye/g/DD4FHkH3El",
+	"nycIsSorGgXLOZ3nGXb8VX1XoV9yr2vsLEY8g/4dHgGZaWoLCWDrAH+QWbBMGa9oZpilpPRL33GATkyS",
+	"UTSxLeFujcgNgdQDMXeUU45jRy+8+Tdh8Obsl4V7KZJtp/yiqOOA7syjsvAEB2xfG3rtm0lHCIzT3mhZ",
+	"LoWXtUIV6tandz1vD3zXe1mzB94PkEnXi+Z4qc+DwvzgbDECUSURWBHKPlrNN8ZUU4T+I0lU3Tlbd3bX",
+	"aacTmQFRRxI58XAXjHfNGnfdMTUTPJ/OVvo6cSncv/tr6jCmtyIcA7vwkdaXRVStdt49bF889dHZ6Kfx",
+	"6OcP1pB8ZFXtpdekfNerZaBVi/47xgU6PDk+Gl+MT46Hb0JfzDfS68cioiJa95Y0hxoEu1SuWyULTrgy",
+	"87oiBLjX7wVQRA3fDmbZpESXEsPVchQUxlV0Ka2H9Rvlv5oAF+3UDI2w3RV820tL8UvZBcgwPY6H1SFw",
+	"B95OHAa616AL0RkQ5Ps21gN+auS8C35NjH28NID5OaatScUXGZ3OgEe1+tdb3s3x8/R69suzg+9/hQWZ",
+	"MX6mLOW3sTunHi5RsvIqb0pvCEMpXkrnPSJy4FgZOAJcRfBR5FrPVlSQbAl8ZfNc38KcfTgRrIKIrnJJ",
+	"mR4DYna0wf6WkGs9T0QhxssGGgC4LBHo/i7yy4CnL7PWuKBPvPHE3fVj3+3Rl+JliVAcz805SzE4AnMi",
+	"zV+3JGXubzXLhf1zIqj5Q2KVC/tnDr1jrFglfsJSCHZpuAQayZ9ifTShv/3t5du3yNx1ykuHndF3nqtl",
+	"ZOH6yNGblTNFMzSnKdO0U76nPvnry4OD6CXVuQa3BREvLe3oOMYGKIsGDWAevGgAU8PxG2cNUI6Hx0Pk",
+	"mgS36TKhw0lBWXnGYS6VwBnF++fLlJFlffJIemmApCYRLDPG5IDPkhl7XFsk8CxZa7ANMCmWA3rSFck4",
+	"m7oHJJFolYYXqDafQRyB/rPbVHR2+Wb04e3wePh6dGaj9Jh23QfHPiiFEdPbjNjF+KculhtD1XGN88Pi",
+	"zT93DCDr9XvDy6PxxYn+a3w0Or4YX/wr+KgP1fHR6OxD8QaydXNhLhsHU9pgv4Gx3bVnxFuiZjyi/B7B",
+	"v66MPmBTZbrjzOfXbjAMcmFDpF0tg4ri8HZ4MT70SkPF+1OGq9tJ8/3zF/NMPce/3rG7Z+akKb+fqlOw",
+	"/e7qaBQGGKNn16iUyASbk/N1x+gqeHReDV7V3GyHMgzjNDuIhmVcGcQavGnETpxRsAAAQVijDpZZiyQ3",
+	"KXiwRmyXV0CKqNiIPWRnIV7l/Y0QeEOO7p04pxpSVK667XXHex3demxr3KNg/sMLjfnQcx+EJtBJkNIR",
+	"bPILQznSWQRdbQ+7SZpITbYBW9ND65amclYGYWQ1B7W5JwTd4VbstNxuPi4w0wLW1vQ81os3reX+szPV",
+	"R4lFFtfFzXOOv02e3f51nv1V3cHiwrRRsdMS8tAEh6L5t80M7nI8Ke7qhkXupkrpk6qBF4tQdaMzhFPI",
+	"YPgr4mYoJd0MYuSNQdkVWCs0DjA4DFwG46jnwGemrcNXSTBrExLNuVQQLMwUsusLcFAsImpZgYBGg/Z1",
+	"Um80BffA1v0gaDp1mCsVMwvjbjaZVvJcJKRLytpeaQ98T4fefkEJVRxUYAs4KqTOCDPBQ46oYCTzBRdY",
+	"LK2KB2YCMPo4NwZGC0FZQhc4q9MsYQ3IJla5dRLFEFdJw3168PTp3sH3e0++vTj49uW3L15+ezB48fTJ",
+	"//b6XTDeEr0fxhe0ue7C6oE2G727lJYh5cYDsqqMrMJCNTqOhfpi+JAtoTy22op1RNeAsxrd6ej4aHz8",
+	"utcvwnpGZ2cnZ0HOjX5v9M9TsAvFrp8yN6QYpxUTW5qmQiM/zIsT35h6ybw1atH5V6YOpH4YjmH2EK7H",
+	"IXcZ9onylb3YbBJ/WPvZBOofanNu8D0Q3Q2JfOLliMsODxA74QSl5elVRJZXy70cpyH7NLKalBY8oHDN",
+	"qj6liBlmFb1ps8MV51/JDHdFJkYJstmNoyeemXSYNuZNtjquf+dh3/X5nExwsvmTcROd/Kx4K9l9epe/",
+	"eYPHGi2LBa6RPucPWDJ3tVgztwV7vem3WKx5DxqZ79LNIN29zXCAnNGFtHS7UWpoPXDTc+vYBcdsRxU/",
+	"FfDLhFqlnH6JRwLurTHpCkYOc4yvlx+9oEyTZB1xBgqCywRlUGz8FSVJUOd32q0CQNtFXIs5f1x0yemu",
+	"/O3dpXTf/NoK4rQB2oa9CfG+YovC3Ooxd7zLGXeLZSnJexXvReyVPUDNDupu9vG2rwQxr+9RgllCsoyk",
+	"Z0GSypVunG9k3SRipyVayrhB1+NzXy+36RW6SXm4GsQG2IpUfAFuNHadncbYu9b1MN3o6Ma2V9QBZAFE",
+	"Wl7ZY84+qgbc2fHWl5BRY0ZETsFl3WlV9e2vrqiG/AbKD8m5O+W7nPttDCBnumZAUAOsnRuuctVQZQBi",
+	"2pb2eYevMmBdF9imutCD+D6ygWuaKbWprssWe1TUgGlHu8PlCuwHx1qz7dO/kafSJsBQ3CgNgUTpdAg0",
+	"o6qisW2hbW0o3x2C207kBrQHSIyiexHJYOjeSPgLVmnsRcMribjpCn87ScWTv06T2cEzDAs7bq5IFuOs",
+	"byQKU1ehRdHFeeI177kkDpD5gi3DZsBHZgySIjqfk5Ripf21NxQj86Lc5lnLMpv4PJ4Oh5Gs+dLBSOYz",
+	"kUptcQjB9r5lvaQBOi590vBJCKjx8BhWV8HQM5D/dhmlzJbnb4aHP+or79vhWD/YuRgN355HL74pyaj2",
+	"MTWXJ2NxwAKk9TV3XVk9hhqVK8U0W6KUTq313UE2fvt2dDQeXugr+tH49ej8IgrWPFcuAX8dMvgd6T61",
+	"09GF2N9CbBJYG/02w4YWIUTONeAdSFxYwwK5W1CxnfrlSCNAcHlRAf80UX+EOU8bLUfuC+qqySprX+1g",
+	"DIQmAcCnhT2ibqq29iKYevjzued6LxJsPK3/t8PjLQR5QjBv1z5g9yrV4De+GP/OcydWD2qLXeKsmUlU",
+	"pRw+1C+3veKlpag8J4kgqnlMU8U/HDrwvdiic3/K6DUJsvea5y0LLOUtF+mfozM3Zls2Y55iNasDpVxy",
+	"J8W118WZNAwUzmHjCt7BYxzzTVr/t0AA6fDnc3R+/hadYoHnRBGBznWfQbeY2bjhqNieAKsRcg1po5uT",
+	"5fY7fHP7G+G3T69+edGr0xmELtbpbPXr3XA/oyb+GzdyfRT4ZF5qFNdGOEM6Bh7D0I34MWvqhp/JzTMx",
+	"u0pvF5NrWsaPSQ4fOb/99dcGRTlPC5+UC0a4gEndJqwEiW65uJ5k/FYP4Mqcat1YhsEY+pT6y18YV3/5",
+	"C1oSX5MrltbBLJmmPhB62xq6NXS6sSPaYFKgXDa7Dyc4k6TfYhwvl/yDDZZrpChqd0356PrxkXfx+l00",
+	"1TnRhfa7glwSmKV8jn48vxwfgXfmhtMULbgiTFEMuW0nGQTYgTdZ0+2edwcX4+prp6WQpnqmOmSbDBpC",
+	"szo8DTbyS5NU4PF0asrhydvTNyPQUn4avhkfDXUU6YdXw/Gb0VHwG7gcxsfji/HwzYfDk+NX49eXZ6bt",
+	"+PjD6dnJ67PR+Xl5kPPLw9HoqMkPoUjMnDRkiN8QAYEsDnTdElGWgubApj5GRrl7oi2v2t2AWGLec0UW",
+	"J3bO5hekMRTrLw7KalHXkMfjgq9Lhf6Kf6yj4FPGmxlJ6GmwXmHHfl06RISmEXTdxOUTNv9WkJsXv5Lf",
+	"XlzVxeURxVPGpaLJGx41q2V8quW+WCJBfPAOrjAjuvHw1uVdRm6a7it6cPhc0taPX530+r2fh2fHhtaN",
+	"Vy2qsctp88Bzk79t9UYZAM1oTdgu42knqB8zqYTJvyLr57kmD1tcerMqTOfBACvzhwVtmzBQAndbVaYG",
+	"YW39SaE4rY+AUOuKiBFawXxToMynldGlCoKlaBk1JdCb0BkufmfY9LKzxhRjI7NLYY2GdfXe62PUSW57",
+	"BYjpLaZB+4UEhvOBkq5P2qHctx+/DWV+hTthwbISVhV9hVAzRbNleC8qKz4NR08dieZlpZ2XNCa0FYom",
+	"kDYmVNqlg4iYSDfMSvHyjXno2y4FxRoLI8XHjEq1JyXfA0/cx3g8EJ9uKJjKorQ1d/kqVap87BQHSKgF",
+	"nV8eHpq/ioCNphMldoL7A7u6dU1kGhDVpkTa+LZwWDyt4f5lIZ8TNdMqDqRONEZm/+Y6uLFs8fDLAhR/",
+	"/1WEUq+u7OBbu2QCsuXCWfUCOTYoM5sGp1/kn/LRoOVItu73kakLC2uvCIlNvb6Y/Sb2iNskg2tw1tl8",
+	"DlsmaLXjNLhT3KP3VVGjbavWfY3hI5pNplvRAUtLQcUBSIvXsdsFnTv/0A6y2sYYvkBjwPwWxvJm9cuv",
+	"0wqAKtgOJEXLy886jzUYedruL2s8Aq9D1V5UxjZqTnb0ZUTTbmVSgpnx29YXaDKGTqLR47TkFOcTGxRe",
+	"eyEdWET/kH5/SL/fq/QruGgt0effEdTCIBt2NV6Dcl3K0b55oOjzLx8TrGE534yQdNeLzYgJ1mFehTSk",
+	"jYQWNmDirJmZGw4oQRIu0q4PRUy0kelhrE7wzIaXUb62ZLO027pM26bhnZPij4VMFN+QSBTfiERaJAWE",
+	"3kQrhZa4uttV6O7Jd79992uSEZn++iK8Cq2dedOn1gvfYBZ5G4odOBweH47eGBP70ejwzfi4/DCzDEBk",
+	"L8qoqjuAyyme4zHsLQ+7qeTPvz94Yl44KTxfaL3p8uLQP6EOg/+3kv9VSOtIuHDnQJe9fMb58tds8vzu",
+	"Cn/nrrX6TDgiCW3KqZTab0Zf5Cyyo/H9jO9cabrI1pVTX5b3jXuffXedAOwRMclSwTR3fmTTIYA5gKgb",
+	"lvHVk+d36d0tZb/ODJYv6kmvKjxD51UrVpeSGLiSxGK1Um7b2uI56Q1mCWmv8mNL0FSr/ECVSehu8rjZ",
+	"64VL5+ZyGZbKz3x/0FAL56jOkM2QOJ4IoQlCh4M0FhMuusxPmQ69WYWHldWOamnhrZcL+KYS3xytcXQQ",
+	"rc4TEmkEVQGhXtRShNW0OqhBX+Or5vhGeNZ33BQosskz7gZ1JMMt8yxoonIR/9ZNxS9iEx9QT3exmAXS",
+	"CtADzd0vtayg1x+Ww2atiq06nAkabmIv0T/8/+TOoCDDV3JAuXlYVY+kgt7oWOOABdC+7EGu8Jf7+/gG",
+	"KyzkYErVLL/KJRG2Qv8g4fP9fP/Js6dPnj09OPj/bv7nmcbt37mchdD4CdsDuTaY+K/Pnh58+/0LM7He",
+	"j0Du1yjcp6hvjrBpN9OYZi6LebBJwawd9SlOfqH5dwk9+C7NNeTg/Zpw62FT2Dw/dBvE53PO0CusgF5E",
+	"FqAogW8TrIje4dpTfffaxVlUpA4O69XTW8jAAPWy92RwYHL0Q3RL72Xv28HB4MAUCJgBLvfxgu7fPLHh",
+	"MHsmR8XLT71oSoPXxMTOhgktbP5dZ3TSMlDvFYg1reb33lCpNPEXdiQTwy8XnEkz2dODgyae9+329TjB",
+	"GGf2A2yuzOdzLJZ2tvCY1XMpPJV620cshUji3nvdJ7by/U/6f+P0vhUF5h11tM6VrrM/sqgwEUtcp9pz",
+	"SQ8g1iOEzqfp0E2xfShWXGs4BE3ZjMMmPkBCcUguSj1TIumUQfRAKR1yQ6rMsX9q7UJr54S4tH9F2Zg+",
+	"wuhvFxenzw6eoJzhXM24oL+R1D67p9K/vK/vusbza1K2eMb23MoBW8I6sxGz+7/YC7AR+d1NlNU8mcCQ",
+	"ldyHP2qWeHbwZDXJjfQ6C0LTvZ6t3atEnpp8gq2IE6dmTxtJqT996lENt2bZQtgaOg1LL7yEUkoBxqoy",
+	"8P0qot8vpc5rJP96UpZydhOdY/Vi5qlDW24zzZX28aUOA/uDTxr5ZBjk29tWSPqxyjT8RSi/KphRmFjw",
+	"CzGBzmMVssKezPKp3P+k/wdHQAWE6m3aZEoMMxMnMy4Jc44CnbWKiH5RFA3ejdhMp0WMQMg7vX5soRqg",
+	"1mVWCv7o2jX2/6bYz9NYsZ/79/3eIo9w+aFNncyFzY4sq3AGicCzfKqXRJVOntxHktsrpM3cZat92YRp",
+	"RmWgKZmb6NFsOXjHhmGGZo0mkAXuZXi16putd20Kyllz4E9FXxs4V85CBKlqbHQqRs8OXqCcZXotVJUK",
+	"79jhqjGJwhztJm1QkXlAEJtpJ3VhrRLPCbrFS42Q3OWjDpN0lpkfEqldLiQR1YMSoP6Bp8tmpnNNKJH7",
+	"1TGcK+D+sxy6Lh/4fTTBlCEXHCTa5ubBa87sVuot1uTjsB7uyEKQG8pzWVT8vu/3nh48+XLLsHQ5MFLx",
+	"YCNZupkEfrGdBDZE0qB+AClWFOOqeGxWC0C4V28GcXKvqPG9fruQNcm+y7qAdnOHMVzmMu5F56+5eZnl",
+	"r6UuINjTQ3u5qOrVsQoSYYlYLkyk7jVhrnaCZvUFnlLm7FkT3gCRLo/t8t22nFQ7uCQZkt7gquTIod9b",
+	"cNl4QtTT2kU2vJovbxP5Vh2jWb59XsHgfF2fVRSUNtFuxBZM3e3OWzdeR7b6C1z4mvfmkd7zAs56EP22",
+	"QaW7tHocZptUzQBdSx/HNqUl9WqYUbN0W5e+EQtSxNGWAnvdm4b+O6Yt6LWkGPoOViSBdFlfGEc6xy8R",
+	"CN9gmoHGbe5ySUajdyqrVqVbi53qGI9FrXKkfVDf5x9wigIwLflXaCG4owbUXivtjl7xnKWB7rFaudNn",
+	"s+RzwhlBJJOQflpf6We+0Aq0LnwpoEZ9F1vJmCkidIF/XdeNCATsVlNp0l1Jv32bxCNy7dsdZ0bP0rdY",
+	"XMvKUYqCHDv6hsSWcXYBxJbvZebVbinjTYQ9bDqo/1xx7alucyFvcVgiv67UltrEdtN4yURjOFPVUlHu",
+	"wuT8lOqWOxndUBk1svU6XVexI/bqvFIR19xOsMgoEW5KbanTSMOCNCnfOmZqLS7px0ulq7VmVXxdzvwc",
+	"pK93/LHpKRqoklUuIIfPpbF05BhH5yu5BntKmVGpuFja8tPBjXFNVTZAys6vZDs6o9u0zyo+Hu/e7n+y",
+	"f9132GVfgtAtLx5k03Fz/7iu1KXA5yOUfnSgm2BrHp7k9gXPsiucXD+YCrjDVbYbZtz9LWLPLjNIHyV8",
+	"QV3+NMyqR7wtnewwg2gQOWxt3latt6/BITZqThROscLOfh5eJeMceWYneHz66Oc08D77EmZhh/oNb1Gy",
+	"XHAySpQjHXDj3UiOKG22w5JpNzQXVPM3Qz6/IHloPf3q4B17xYWtMaflGHgSnA/GVYOkynum61XgfLk/",
+	"gKrfoU4cQOgefYvV5a0H79g5UchkVTSFzzUwxunlfEVFDjSsohUhIzxka7DZO13d3rFD1qlXWjShTw/M",
+	"scW0Xy/HlnjPbRnye7aa7RZ075os230xhrJt+jBHS5ZL9P+nAs+BICsV8UrRZYMWB86C/qhhWHFnfPSe",
+	"ktOxXsb2hLC1KcH4Yux+re2HkWGyOHPZsSXOkS3Uaqqn68/Ove6LpsAxDue6gayPsG2Ctb99hrgraWYy",
+	"wUHsjietInWk0QaG9tZitnZGcJAWzFbK+/gDwYII9C4/OPg2uSZL+IN8HDjDpdQCc0aYFpjKljENV+iK",
+	"lUIstqPc0zG6PHtj26KP+xYBHyFDKL0rV2j0nx1vObn9EVb3IzCNi3DQtUdH5xcfTo4//DD62/DNKyQT",
+	"vgATmzWXl21yNmRIW0KJ8hEAH/+5Z2M4907Y3g9khrPJ3snko8NRkDf0G1muTjFodawBK27hVAMeWOFQ",
+	"ayfs8kAhL30+Ubw1BxYeTUtm6wjj/U/XZKlvFSZVdbcLBHTZjdZ/BtOGYmCAzG+pYVNNq4Uj5Yr4DLYh",
+	"pzXp5jBQQGcPdcYDAf1ObMMGaWuSk8lJvG/qaq044E3bNUp/IVOtyXW0b3sEWWR4CVUVEpP+M2cpEdlS",
+	"yy0qZU6KZEOCSJ7dNLoUNFxBRaivXjsI1/JYVIRJuIdrk9T+J/inEVR637sJKttpV6JqLxX0BkwU4WpK",
+	"yUFLtDxAo5DWIe0+zgTB6dJXC80gF7QgSF7TxUK7pSW3tA3ObfcAmhHtSjQd4CaobmnSLPh0/3KRsxgp",
+	"/Q6ElV5oZUO6UFfxZqwpcATe79kLfA3Lr4l67b581aLitU3R38y4HgNrRlhp0xn0dUr0IZ8yaqtboQXn",
+	"GaJOPydMB0dExLMZyxWB2VBRhO6fI/DKwPlYoq12p1o6/Hfkqv1P8P9xutoXQpm5WnkniCGZQSPDPaQa",
+	"17h9Jz9W8KK9DtAaBYkaN/A3WDxt6SZw1sTWMLgQ06aWRMkQWXr0XcO+q9dxWGm1vsyJjvQIKB1Q1ISM",
+	"lXTveu5rQ2+zNfksZ2Wsmwo5DtWIM5SSOWYmhbj5SqXeLqFcsDm6hRI+NsQmqGmsB3a/qpkgcsaztFoA",
+	"sO+eD0y4SKAsgCQRq2xYn2XVCWeNwCqobFio9rAEA7GubgimCU17c3xtzQvzhnMvFcuzPHroBakTarDk",
+	"rJhW6wK7wFlTgAg3VWhb4Hu/DYdo3H+1NgkNPHIr6cJBPll+e6R+0Sz2WPc0+LrVKbFW6tJIDuWd2gF2",
+	"c/8KMdd9N/Y/FYXJ28OsFz6n+hLRtLY9r4kK6rQ82BFe7MnXtgdd1IVSkfhtNIb4/u5jMW3mvymxzhm9",
+	"I2YyZFpcOUux7l8EdwZ1ghtpYahn3JIeqoX1H9fGl3gDiymygD9eCtj/hMVU/8NGtHcK9rRtGwPWTgMU",
+	"5FA0A10E3eZ4aWJ/kxkUUOFIkIk+j+1r1GRG+lBHyHgk7MePCM5k5PE2aD0WhmJ6slBdQkYpc+8AivnB",
+	"Wx5C5fD2jaut1PiQy/Z6AGWhWNJjsfOVaJ17dH9GYo/HKgFN74ppiGozHpVlffAgRCod495eaqidhO3M",
+	"m1p7StnIW60+9bI6kGsLPPBErmMO+oFMKZP1yklu/UZisCJtQpgiP2YNKuFic6tQCRdbeRErI22nspe2",
+	"AnBXLw3UjLPe/Qqi3f9U+rfV6lISL99gSneaUvVsz21+hTBAMJoRjM0UAujC7JxUgXM6PC9M+xQtGjf7",
+	"CFrUN3tdum/YnXIwOczVvky9oa2ZRQwywooflbjeZsIONKKHXah9ENmyyu1EtSWqncrZOsnuhwVHHhi4",
+	"JrE2nqCznEEavpItKzBn940eDA+bbgW1ykRVIbLhE+Wsi1Jx4WJSwRaBFdFshNqmTakM5yUsXXDKFKSA",
+	"QYxDuvWIULW43J4AqyO1EaJrizCq1a7qfDttIo9qFZ/PxbaVQkgPfsutV1/qarxea+Ffh0yQiujf9f/G",
+	"LCV3rVIiliqYaGSk5E5zpA0NN4wJoxiuhHTCNp9nZMl+8i6LDbJrPojYij2mvvBh72m1amKNus/zqzkt",
+	"E7ius7SJxlUr1uTYf8W75O0PvMsVGxnIn6Dq1U6kkLtCfsFDypUkks3lqcpZ0Ba1yoj5AnS3n/UhZk39",
+	"JmfY04MDdPKjD7705nYF5XiDt/R6MiIEF9Lc+c3fLoxnol+BgdGQyQVJfNRh0Dn1ZaGKKpzVYogfbfXa",
+	"OKzPDg4KQGm5XKMGhHEIyywe//8piLns18opy3rlUb1eypzE+XOdm9xWfB4976eS+aKe0Lkg+s6nruXo",
+	"VeagIGcehm2wvRoDr86KFq1Cms+psoZF3cwn2zOzyDxTcoM8OpE84eXE7y4d/CPKr1ObNwiL9gj3ZbGL",
+	"ajOGRXySKvgXVBcFtdJEYmgsQ6oroqvEFyW+JdF+MhOiEfWG6YbjdBdwmzjoMswBJmFFwIZ9RKeM68FR",
+	"giVpBc3VkX6AEBpHwA2s+C+eC/R6dOH18XWYbf+Tr6XQ4WGpF+Ptz21eE1VUXHkwJbVcFKrFLP/sS5nl",
+	"PZFtkccxqHSxjXZrHM1NG/yKqGQWCFbnlq7dRi7th686JE0vojHcL5aXdcPgNFdpbLvYNJsdfUMjpFnr",
+	"w0emAZS/v8A0i/yV4hSoZP+Tebl4300hN413o4fbWwh2NJdkOWTEMfGRJiewnNFFPckudIzTWGfCKOeg",
+	"X79aQiUHfVAhoOp5fciHlE0kfPLjV0e9lhxWU+9KnRt8Na6Ve/Luig0GzlFTi0SiJc+14jyBAyVQFbWH",
+	"Sn8zb3V1/3jC59+Zwi5n/LZAAzxZdk/ZKoUbJ1z0kcC2mDVmTb30kxPzum9G5pJkN0Q2OoXN0GuGuD32",
+	"03uFNgwEO1+G98K46tWQcEy/TGorJwoGgEt49g6PLU2qlWUleYS+HDEXhOjHG6BLn9Q9yIWuOJqX5w0T",
+	"s1PmgpYLSghnEmRCBGEJkQN0osnnlsJLVbBLoGcHzwrDhMuA1p5z3Rx9oQK/keZhB1ihfDRoC9H3h+3a",
+	"dESq7S+wVI2iLaUS3nYAj/qkcH1E7hb6JOrbeAzzVDAQgSvl1ikGGL9qHXntO2cU//ki4a40Xese1DL4",
+	"gV/KJYkoi0IsfKKMbGmcVwRxEdSlUhxdgWNbc62vZDXJVS7I6mPn0gH9xxauax/wtR6qtlB34DGuwuTz",
+	"XCAwFrqjCoQriFe02lzh6lToBlQqgfVwTgeovvvwJKQhcBWhw5QrAOGfGFfkJbLKaPTAdtWGStP+ubF6",
+	"xR92kMdiB4mRkMvU09mf62rv1Xya/ogP41BCIuQM6XOm0CiUSR4FoksQyXMRe23p87E9gOt3YJdvH5Pu",
+	"21pYg+U8a8k0s5E72HRFlUU8SloAYd6FCKDhZ9p9d0w8cBYhM81jFCGWgBweHhflGOWxY268zUBoNPzA",
+	"hQLyrQAQdRu9P8SW5n24Kx5j0k8bE6Q1UtqbrakgHbFHwgw7OtE6B9UffD2PxQ/tFqx/UQmpyWYw+BzK",
+	"VjQUOKydvXkkcGmUxxI77liiliTiccgRszNfRI6YgtUR6eF0ZWNaNgEWBk590dG/+aRT1aJxNbuHDYQw",
+	"3TVpUoH4bWHq6ocxHrag3YpCdDUKNgvZwnpRHmCjuCE3RFP6G8D05kKCrplNyXfbDamAGbl0Q7cnkLCJ",
+	"fVykjylPlS1ds3SARpMJMRd2Op+TlGJFsiWKbSK/Ju0nzVd/WhR5kKwNoytBGGfTnKw8IuJP8gvbScan",
+	"U5PmLl6f9jVRb8lGJ4DOdVd2s3ZK5l1T+8J6stXbemc87TOuaxAaVWRvURhMgX3aSsKAzt6CMnvK0gl1",
+	"9kLj6bCJpLwUc23MLgwavHBvl8cBoKcBnA+TsLRptgf2s7VM+wCut87kZn1mIa2ggFa2IcHQL7xCp3Pm",
+	"pUaG9K7aL+QFfYiE/LgFmf0H8qQDFFA+xwxblBx/ub+f8QRnMy7Vy+cHzw969+89aL5guQfxvu9/Mw7W",
+	"+/f3/3cA3qcBMYgtAQA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
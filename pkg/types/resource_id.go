@@ -39,3 +39,15 @@ func NewHistoryID() string {
 func NewProviderSetupID() string {
 	return newResourceID("pse")
 }
+
+func NewDigestMessageID() string {
+	return newResourceID("dig")
+}
+
+func NewGroupID() string {
+	return newResourceID("grp")
+}
+
+func NewAPIKeyID() string {
+	return newResourceID("key")
+}
@@ -199,6 +199,54 @@ export const RequestSelectedWithDisplay: React.FC<{
   }
   return null;
 };
+
+/**
+ * Displays the values of the Access Rule's request fields, using the field labels
+ * from the version of the rule which the request was made for.
+ */
+export const RequestFieldsDisplay: React.FC<{
+  request: RequestDetail | undefined;
+}> = ({ request }) => {
+  const values = request?.fields;
+  if (values === undefined || Object.keys(values).length === 0) {
+    return null;
+  }
+  const fields = request?.accessRule.requestFields ?? [];
+  // show the fields in the order the rule defines them, followed by any the rule no longer has
+  const ids = [
+    ...fields.map((f) => f.id).filter((id) => values[id]),
+    ...Object.keys(values).filter((id) => !fields.some((f) => f.id === id)),
+  ];
+
+  return (
+    <Stack spacing={2}>
+      {ids.map((id) => {
+        const field = fields.find((f) => f.id === id);
+        const value: string = values[id];
+        return (
+          <VStack align={"left"} key={id}>
+            <Text textStyle="Body/Medium">{field?.label ?? id}</Text>
+            {field?.type === "URL" ? (
+              <Link
+                href={value}
+                isExternal
+                textStyle="Body/Small"
+                wordBreak="break-all"
+              >
+                {value}
+              </Link>
+            ) : (
+              <Text color="neutrals.600" textStyle="Body/Small">
+                {value}
+              </Text>
+            )}
+          </VStack>
+        );
+      })}
+    </Stack>
+  );
+};
+
 export const RequestDetails: React.FC<RequestDetailProps> = ({ children }) => {
   const { request } = useContext(Context);
 
@@ -252,6 +300,7 @@ export const RequestDetails: React.FC<RequestDetailProps> = ({ children }) => {
           </HStack>
         </Skeleton>
         <RequestSelectedWithDisplay request={request} />
+        <RequestFieldsDisplay request={request} />
         <Skeleton isLoaded={request !== undefined}>
           {request?.reason && (
             <VStack align={"left"}>
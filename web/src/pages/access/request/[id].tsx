@@ -43,7 +43,10 @@ import {
   useUserGetAccessRule,
   useUserGetAccessRuleApprovers,
 } from "../../../utils/backend-client/end-user/end-user";
-import { CreateRequestRequestBody } from "../../../utils/backend-client/types";
+import {
+  AccessRuleRequestField,
+  CreateRequestRequestBody,
+} from "../../../utils/backend-client/types";
 import { durationString } from "../../../utils/durationString";
 export type When = "asap" | "scheduled";
 
@@ -137,6 +140,7 @@ const Home = () => {
       },
       reason: data.reason,
       with: data.with,
+      fields: data.fields,
     };
     if (data.when === "scheduled") {
       r.timing.startTime = new Date(data.startDateTime).toISOString();
@@ -366,6 +370,60 @@ const Home = () => {
                   />
                 </FormControl>
 
+                {rule?.requestFields?.map((f) => {
+                  const error = errors.fields?.[f.id];
+                  return (
+                    <FormControl
+                      key={"field-" + f.id}
+                      id={"fields." + f.id}
+                      isInvalid={error !== undefined}
+                    >
+                      <FormLabel textStyle="Body/Medium" fontWeight="normal">
+                        {f.label}
+                      </FormLabel>
+                      {f.type === "SELECT" ? (
+                        <Controller
+                          name={`fields.${f.id}`}
+                          control={control}
+                          rules={{
+                            required: f.required && `${f.label} is required`,
+                          }}
+                          render={({
+                            field: { value, onChange, ...rest },
+                          }) => (
+                            <Select
+                              isMulti={false}
+                              isClearable={!f.required}
+                              options={f.options?.map((o) => ({
+                                value: o,
+                                label: o,
+                              }))}
+                              value={value ? { value, label: value } : null}
+                              onChange={(val) => {
+                                onChange(val?.value);
+                              }}
+                              {...rest}
+                            />
+                          )}
+                        />
+                      ) : (
+                        <Input
+                          bg="white"
+                          inputMode={f.type === "URL" ? "url" : "text"}
+                          {...register(`fields.${f.id}`, {
+                            required: f.required && `${f.label} is required`,
+                            pattern: fieldPattern(f),
+                          })}
+                        />
+                      )}
+                      {f.description && (
+                        <FormHelperText>{f.description}</FormHelperText>
+                      )}
+                      <FormErrorMessage>{error?.message}</FormErrorMessage>
+                    </FormControl>
+                  );
+                })}
+
                 {/* Don't show approval section if approvers are still loading */}
                 <Approvers approvers={approvers?.users} />
                 <Box>
@@ -382,6 +440,29 @@ const Home = () => {
   );
 };
 
+// fieldPattern returns the validation rule for the value of a TEXT or URL
+// request field, matching the checks the API makes when the request is created.
+// Patterns which JavaScript can't compile are left for the API to check.
+const fieldPattern = (f: AccessRuleRequestField) => {
+  if (f.type === "URL") {
+    return {
+      value: /^https?:\/\/[^\s/]+/,
+      message: `${f.label} must be an http or https link`,
+    };
+  }
+  if (f.type !== "TEXT" || !f.pattern) {
+    return undefined;
+  }
+  try {
+    return {
+      value: new RegExp("^(?:" + f.pattern + ")$"),
+      message: `${f.label} must match the pattern ${f.pattern}`,
+    };
+  } catch {
+    return undefined;
+  }
+};
+
 export const WhenRadioGroup: React.FC<UseRadioGroupProps> = (props) => {
   const { getRootProps, getRadioProps } = useRadioGroup(props);
   const group = getRootProps();
@@ -7,6 +7,7 @@
  */
 import type { AccessRuleTarget } from './accessRuleTarget';
 import type { TimeConstraints } from './timeConstraints';
+import type { AccessRuleRequestField } from './accessRuleRequestField';
 
 /**
  * Access Rule contains information for an end user to make a request for access.
@@ -22,4 +23,6 @@ When a rule is updated, it's ID remains consistent.
   target: AccessRuleTarget;
   timeConstraints: TimeConstraints;
   isCurrent: boolean;
+  /** The fields which requesters fill in when making a request. */
+  requestFields?: AccessRuleRequestField[];
 }
@@ -10,6 +10,7 @@ import type { ApproverConfig } from './approverConfig';
 import type { AccessRuleMetadata } from './accessRuleMetadata';
 import type { AccessRuleTarget } from './accessRuleTarget';
 import type { TimeConstraints } from './timeConstraints';
+import type { AccessRuleRequestField } from './accessRuleRequestField';
 
 /**
  * AccessRuleDetail contains detailed information about a rule and is used in administrative apis.
@@ -30,4 +31,6 @@ When a rule is updated, it's ID remains consistent.
   target: AccessRuleTarget;
   timeConstraints: TimeConstraints;
   isCurrent: boolean;
+  /** The fields which requesters fill in when making a request. */
+  requestFields?: AccessRuleRequestField[];
 }
@@ -0,0 +1,25 @@
+/**
+ * Generated by orval v6.9.6 🍺
+ * Do not edit manually.
+ * Approvals
+ * Granted Approvals API
+ * OpenAPI spec version: 1.0
+ */
+import type { AccessRuleRequestFieldType } from './accessRuleRequestFieldType';
+
+/**
+ * A field which requesters fill in when making a request, such as a ticket ID or a change category.
+ */
+export interface AccessRuleRequestField {
+  /** The ID of the field, which the values are keyed by on requests. */
+  id: string;
+  label: string;
+  description?: string;
+  /** TEXT fields accept any text which matches the pattern, URL fields accept http and https links, and SELECT fields accept one of the options. */
+  type: AccessRuleRequestFieldType;
+  required: boolean;
+  /** A regular expression which the whole value of a TEXT field must match. */
+  pattern?: string;
+  /** The options for a SELECT field. */
+  options?: string[];
+}
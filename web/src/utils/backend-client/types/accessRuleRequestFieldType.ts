@@ -0,0 +1,20 @@
+/**
+ * Generated by orval v6.9.6 🍺
+ * Do not edit manually.
+ * Approvals
+ * Granted Approvals API
+ * OpenAPI spec version: 1.0
+ */
+
+/**
+ * TEXT fields accept any text which matches the pattern, URL fields accept http and https links, and SELECT fields accept one of the options.
+ */
+export type AccessRuleRequestFieldType = typeof AccessRuleRequestFieldType[keyof typeof AccessRuleRequestFieldType];
+
+
+// eslint-disable-next-line @typescript-eslint/no-redeclare
+export const AccessRuleRequestFieldType = {
+  TEXT: 'TEXT',
+  URL: 'URL',
+  SELECT: 'SELECT',
+} as const;
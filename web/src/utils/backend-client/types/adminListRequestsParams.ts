@@ -7,4 +7,4 @@
  */
 import type { AdminListRequestsStatus } from './adminListRequestsStatus';
 
-export type AdminListRequestsParams = { status?: AdminListRequestsStatus; nextToken?: string };
+export type AdminListRequestsParams = { status?: AdminListRequestsStatus; nextToken?: string; fieldId?: string; fieldValue?: string };
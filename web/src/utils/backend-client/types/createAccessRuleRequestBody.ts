@@ -8,6 +8,7 @@
 import type { ApproverConfig } from './approverConfig';
 import type { CreateAccessRuleTarget } from './createAccessRuleTarget';
 import type { TimeConstraints } from './timeConstraints';
+import type { AccessRuleRequestField } from './accessRuleRequestField';
 
 export type CreateAccessRuleRequestBody = {
   /** The group IDs that the access rule applies to. */
@@ -17,4 +18,6 @@ export type CreateAccessRuleRequestBody = {
   description: string;
   target: CreateAccessRuleTarget;
   timeConstraints: TimeConstraints;
+  /** The fields which requesters fill in when making a request. */
+  requestFields?: AccessRuleRequestField[];
 };
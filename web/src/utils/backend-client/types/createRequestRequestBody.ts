@@ -7,10 +7,13 @@
  */
 import type { RequestTiming } from './requestTiming';
 import type { CreateRequestWith } from './createRequestWith';
+import type { CreateRequestRequestBodyFields } from './createRequestRequestBodyFields';
 
 export type CreateRequestRequestBody = {
   accessRuleId: string;
   reason?: string;
   timing: RequestTiming;
   with?: CreateRequestWith;
+  /** The values of the request fields of the Access Rule, keyed by the field ID. */
+  fields?: CreateRequestRequestBodyFields;
 };
@@ -154,3 +154,8 @@ export * from "./accessToken";
 export * from './identityConfigurationResponseResponse';
 export * from './createGroupRequestBody';
 export * from './updateUserBody';
+export * from "./accessRuleRequestField";
+export * from "./accessRuleRequestFieldType";
+export * from "./requestFields";
+export * from "./requestDetailFields";
+export * from "./createRequestRequestBodyFields";
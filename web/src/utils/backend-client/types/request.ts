@@ -11,6 +11,7 @@ import type { RequestAccessRule } from './requestAccessRule';
 import type { Grant } from './grant';
 import type { ApprovalMethod } from './approvalMethod';
 import type { RequestSelectedWith } from './requestSelectedWith';
+import type { RequestFields } from './requestFields';
 
 /**
  * A request to access something made by an end user in Granted.
@@ -27,4 +28,6 @@ export interface Request {
   grant?: Grant;
   approvalMethod?: ApprovalMethod;
   selectedWith: RequestSelectedWith;
+  /** The values of the request fields of the Access Rule, keyed by the field ID. */
+  fields?: RequestFields;
 }
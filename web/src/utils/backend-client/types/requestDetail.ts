@@ -11,6 +11,7 @@ import type { AccessRule } from './accessRule';
 import type { Grant } from './grant';
 import type { ApprovalMethod } from './approvalMethod';
 import type { RequestDetailSelectedWith } from './requestDetailSelectedWith';
+import type { RequestDetailFields } from './requestDetailFields';
 
 /**
  * A request to access something made by an end user in Granted.
@@ -29,4 +30,6 @@ export interface RequestDetail {
   canReview: boolean;
   approvalMethod?: ApprovalMethod;
   selectedWith?: RequestDetailSelectedWith;
+  /** The values of the request fields of the Access Rule, keyed by the field ID. */
+  fields?: RequestDetailFields;
 }
@@ -7,6 +7,7 @@
  */
 import type { TimeConstraints } from './timeConstraints';
 import type { ApproverConfig } from './approverConfig';
+import type { AccessRuleRequestField } from './accessRuleRequestField';
 
 export type UpdateAccessRuleRequestBody = {
   timeConstraints: TimeConstraints;
@@ -15,4 +16,6 @@ export type UpdateAccessRuleRequestBody = {
   name: string;
   description: string;
   updateMessage?: string;
+  /** The fields which requesters fill in when making a request. */
+  requestFields?: AccessRuleRequestField[];
 };